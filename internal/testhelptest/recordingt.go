@@ -0,0 +1,85 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testhelptest holds the test doubles shared by the tests of the packages in this repository, so that each
+// package (and each submodule) doesn't need its own copy.  It's internal, so it isn't part of the public API.
+package testhelptest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// RecordingT is a testhelp.TestingTB mock that records messages instead of failing the test, and saves cleanup
+// functions so that tests can run them at a point of their choosing.  Each RecordingT has its own records, so tests
+// using it don't need to reset any globals.
+//
+// Fatalf records its message and returns, rather than stopping the goroutine, so the code under test carries on after
+// it; the helpers in this repository return straight after calling Fatalf for that reason.
+//
+// A RecordingT is safe for concurrent use.  Its fields should only be read once the code under test has finished.
+type RecordingT struct {
+	mu       sync.Mutex
+	Errors   []string
+	Fatals   []string
+	Logs     []string
+	cleanups []func()
+}
+
+func (r *RecordingT) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *RecordingT) Fatalf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Fatals = append(r.Fatals, fmt.Sprintf(format, args...))
+}
+
+func (r *RecordingT) Logf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Logs = append(r.Logs, fmt.Sprintf(format, args...))
+}
+
+func (*RecordingT) Helper() {}
+
+func (r *RecordingT) Cleanup(f func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cleanups = append(r.cleanups, f)
+}
+
+// RunCleanups runs the saved cleanup functions in last-added-first-called order, as *testing.T does.
+func (r *RecordingT) RunCleanups() {
+	r.mu.Lock()
+	cleanups := r.cleanups
+	r.cleanups = nil
+	r.mu.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// Failures returns all recorded errors and fatals, errors first.  It never returns nil, so that its result can be
+// compared directly with an empty expected list.
+func (r *RecordingT) Failures() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append(append([]string{}, r.Errors...), r.Fatals...)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelptest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecordingT(t *testing.T) {
+	rt := &RecordingT{}
+	if got := rt.Failures(); got == nil || len(got) != 0 {
+		t.Errorf("Failures(): Incorrect failures with none recorded: expected\n%#+v\ngot\n%#+v", []string{}, got)
+	}
+
+	var order []int
+	rt.Cleanup(func() { order = append(order, 1) })
+	rt.Cleanup(func() { order = append(order, 2) })
+	rt.Fatalf("fatal %d", 1)
+	rt.Errorf("error %d", 2)
+	rt.Logf("log %d", 3)
+	rt.RunCleanups()
+	rt.RunCleanups() // already run, so nothing happens
+
+	if want := []string{"error 2", "fatal 1"}; !reflect.DeepEqual(rt.Failures(), want) {
+		t.Errorf("Failures(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, rt.Failures())
+	}
+	if want := []string{"log 3"}; !reflect.DeepEqual(rt.Logs, want) {
+		t.Errorf("Logf(): Incorrect logs: expected\n%#+v\ngot\n%#+v", want, rt.Logs)
+	}
+	if want := []int{2, 1}; !reflect.DeepEqual(order, want) {
+		t.Errorf("RunCleanups(): Incorrect order: expected\n%#+v\ngot\n%#+v", want, order)
+	}
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package assertcompat provides an adapter with the method names and argument orders of testify's assert package,
+// implemented on top of testhelp, so that projects migrating from testify can switch to testhelp's failure messages
+// without rewriting all of their assertions at once.  Typically, only the import and the constructor change:
+//
+//	func TestThing(t *testing.T) {
+//		assert := assertcompat.New(t)
+//		assert.Equal(3, Count(items), "counting %d items", len(items))
+//		assert.Contains(Names(items), "widget")
+//		assert.Panics(func() { Count(nil) })
+//	}
+//
+// As in testify, each assertion reports a failure with t.Errorf and returns false if it fails, and the optional
+// msgAndArgs are either a single message or a format string followed by its arguments.  Assertions with no
+// counterpart here can be replaced with the equivalent testhelp function.
+package assertcompat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// Assertions provides testify-style assertions that report failures to a test.  Use New to create one.
+type Assertions struct {
+	t testhelp.TestingTB
+}
+
+// New returns an Assertions that reports failures to t, like testify's assert.New.
+func New(t testhelp.TestingTB) *Assertions {
+	return &Assertions{t: t}
+}
+
+// fail reports a failure, with the user's message (if any) added.
+func (a *Assertions) fail(failure string, msgAndArgs []interface{}) bool {
+	a.t.Helper()
+	if msg := message(msgAndArgs); msg != "" {
+		failure += "\nmessage: " + msg
+	}
+	a.t.Errorf("%s", failure)
+	return false
+}
+
+// message formats the optional message arguments the way testify does: a single value is used as is, and a string
+// followed by other values is treated as a format string.
+func message(msgAndArgs []interface{}) string {
+	switch {
+	case len(msgAndArgs) == 0:
+		return ""
+	case len(msgAndArgs) == 1:
+		if s, ok := msgAndArgs[0].(string); ok {
+			return s
+		}
+		return fmt.Sprintf("%+v", msgAndArgs[0])
+	}
+	if format, ok := msgAndArgs[0].(string); ok {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return fmt.Sprint(msgAndArgs...)
+}
+
+// objectsAreEqual compares values the way testify does: byte slices by their contents (so that nil and empty ones are
+// equal), and everything else with reflect.DeepEqual.
+func objectsAreEqual(expected, actual interface{}) bool {
+	expBytes, expOK := expected.([]byte)
+	actBytes, actOK := actual.([]byte)
+	if expOK && actOK {
+		return bytes.Equal(expBytes, actBytes)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// Equal checks that expected and actual are equal, showing the differences between them (see testhelp.Diff) if not.
+func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if objectsAreEqual(expected, actual) {
+		return true
+	}
+	return a.fail("Values are not equal:\n"+testhelp.Diff(expected, actual), msgAndArgs)
+}
+
+// NotEqual checks that expected and actual are not equal.
+func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if !objectsAreEqual(expected, actual) {
+		return true
+	}
+	return a.fail("Values are equal, but expected them not to be:\n"+testhelp.Format(actual), msgAndArgs)
+}
+
+// Contains checks that s contains contains: as a substring if s is a string, as an element if s is a slice or array,
+// or as a key if s is a map.
+func (a *Assertions) Contains(s, contains interface{}, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	found, problem := includes(s, contains)
+	switch {
+	case problem != "":
+		return a.fail(problem, msgAndArgs)
+	case !found:
+		return a.fail(fmt.Sprintf("Value does not contain\n%s\nvalue:\n%s", testhelp.Format(contains),
+			testhelp.Format(s)), msgAndArgs)
+	}
+	return true
+}
+
+// NotContains is the opposite of Contains.
+func (a *Assertions) NotContains(s, contains interface{}, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	found, problem := includes(s, contains)
+	switch {
+	case problem != "":
+		return a.fail(problem, msgAndArgs)
+	case found:
+		return a.fail(fmt.Sprintf("Value contains\n%s\nbut expected it not to\nvalue:\n%s", testhelp.Format(contains),
+			testhelp.Format(s)), msgAndArgs)
+	}
+	return true
+}
+
+// includes returns true if s contains elem, as described for Contains, or a description of the problem if s can't
+// contain elem.
+func includes(s, elem interface{}) (found bool, problem string) {
+	v := reflect.ValueOf(s)
+	switch {
+	case !v.IsValid():
+		return false, "Can't check the contents of a nil value"
+	case v.Kind() == reflect.String:
+		str, ok := elem.(string)
+		if !ok {
+			return false, fmt.Sprintf("Can't check a string for a %T; expected a string", elem)
+		}
+		return strings.Contains(v.String(), str), ""
+	case v.Kind() == reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if objectsAreEqual(iter.Key().Interface(), elem) {
+				return true, ""
+			}
+		}
+		return false, ""
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if objectsAreEqual(v.Index(i).Interface(), elem) {
+				return true, ""
+			}
+		}
+		return false, ""
+	}
+	return false, fmt.Sprintf("Can't check the contents of a %T; expected a string, slice, array, or map", s)
+}
+
+// True checks that value is true.
+func (a *Assertions) True(value bool, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if value {
+		return true
+	}
+	return a.fail("Expected true, got false", msgAndArgs)
+}
+
+// False checks that value is false.
+func (a *Assertions) False(value bool, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if !value {
+		return true
+	}
+	return a.fail("Expected false, got true", msgAndArgs)
+}
+
+// isNil returns true if object is nil, or is a nil value of a type that can be nil (as in testify).
+func isNil(object interface{}) bool {
+	if object == nil {
+		return true
+	}
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Nil checks that object is nil (including a nil pointer, slice, map, etc. in an interface).
+func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if isNil(object) {
+		return true
+	}
+	return a.fail("Expected nil, got\n"+testhelp.Format(object), msgAndArgs)
+}
+
+// NotNil is the opposite of Nil.
+func (a *Assertions) NotNil(object interface{}, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if !isNil(object) {
+		return true
+	}
+	return a.fail("Expected a non-nil value, got "+testhelp.Format(object), msgAndArgs)
+}
+
+// NoError checks that err is nil.
+func (a *Assertions) NoError(err error, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if err == nil {
+		return true
+	}
+	return a.fail(fmt.Sprintf("Unexpected error: %s", err), msgAndArgs)
+}
+
+// Error checks that err is not nil.
+func (a *Assertions) Error(err error, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if err != nil {
+		return true
+	}
+	return a.fail("Expected an error, got nil", msgAndArgs)
+}
+
+// ErrorIs checks that errors.Is(err, target) is true.
+func (a *Assertions) ErrorIs(err, target error, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if errors.Is(err, target) {
+		return true
+	}
+	return a.fail(fmt.Sprintf("Incorrect error: expected an error matching\n%v\ngot\n%v", target, err), msgAndArgs)
+}
+
+// Len checks that object (a string, slice, array, map, or channel) has the given length.
+func (a *Assertions) Len(object interface{}, length int, msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+	default:
+		return a.fail(fmt.Sprintf("Can't get the length of a %T", object), msgAndArgs)
+	}
+	if v.Len() == length {
+		return true
+	}
+	return a.fail(fmt.Sprintf("Incorrect length: expected %d, got %d\nvalue:\n%s", length, v.Len(),
+		testhelp.Format(object)), msgAndArgs)
+}
+
+// Panics checks that f panics.
+func (a *Assertions) Panics(f func(), msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if testhelp.Panics(f) {
+		return true
+	}
+	return a.fail("Expected a panic", msgAndArgs)
+}
+
+// NotPanics checks that f does not panic.
+func (a *Assertions) NotPanics(f func(), msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	if didNotPanic, pVal := testhelp.NotPanicsGet(f); !didNotPanic {
+		return a.fail("Unexpected panic: "+testhelp.PanicMessage(pVal), msgAndArgs)
+	}
+	return true
+}
+
+// PanicsWithValue checks that f panics with a value equal to expected.
+func (a *Assertions) PanicsWithValue(expected interface{}, f func(), msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	didPanic, pVal := testhelp.PanicsGet(f)
+	switch {
+	case !didPanic:
+		return a.fail("Expected a panic", msgAndArgs)
+	case !objectsAreEqual(expected, pVal):
+		return a.fail(fmt.Sprintf("Incorrect panic value: expected\n%s\ngot\n%s", testhelp.Format(expected),
+			testhelp.Format(pVal)), msgAndArgs)
+	}
+	return true
+}
+
+// PanicsWithError checks that f panics with an error whose message is errString.
+func (a *Assertions) PanicsWithError(errString string, f func(), msgAndArgs ...interface{}) bool {
+	a.t.Helper()
+	didPanic, pVal := testhelp.PanicsGet(f)
+	if !didPanic {
+		return a.fail("Expected a panic", msgAndArgs)
+	}
+	if err, ok := pVal.(error); !ok || err.Error() != errString {
+		return a.fail(fmt.Sprintf("Incorrect panic value: expected an error with the message\n%q\ngot\n%s", errString,
+			testhelp.Format(pVal)), msgAndArgs)
+	}
+	return true
+}
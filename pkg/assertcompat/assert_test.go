@@ -0,0 +1,172 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assertcompat
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestAssertions(t *testing.T) {
+	var nilPtr *int
+	tests := []struct {
+		name     string
+		check    func(a *Assertions) bool
+		expected string // "" for success
+	}{
+		{name: "Equal", check: func(a *Assertions) bool { return a.Equal([]int{1, 2}, []int{1, 2}) }},
+		{name: "Equal bytes", check: func(a *Assertions) bool { return a.Equal([]byte(nil), []byte{}) }},
+		{
+			name:     "Equal fails",
+			check:    func(a *Assertions) bool { return a.Equal([]int{1, 2}, []int{1, 3}) },
+			expected: "Values are not equal:\n[1]: expected 2, got 3",
+		},
+		{
+			name:     "Equal fails with message",
+			check:    func(a *Assertions) bool { return a.Equal(1, 2, "counting %d items", 3) },
+			expected: "Values are not equal:\n(top level): expected 1, got 2\nmessage: counting 3 items",
+		},
+		{
+			name:     "Equal fails with single message",
+			check:    func(a *Assertions) bool { return a.Equal(1, 2, "100%") },
+			expected: "Values are not equal:\n(top level): expected 1, got 2\nmessage: 100%",
+		},
+		{name: "NotEqual", check: func(a *Assertions) bool { return a.NotEqual(1, 2) }},
+		{
+			name:     "NotEqual fails",
+			check:    func(a *Assertions) bool { return a.NotEqual("a", "a") },
+			expected: "Values are equal, but expected them not to be:\n\"a\"",
+		},
+		{name: "Contains string", check: func(a *Assertions) bool { return a.Contains("widget", "dge") }},
+		{name: "Contains slice", check: func(a *Assertions) bool { return a.Contains([]string{"a", "b"}, "b") }},
+		{name: "Contains map", check: func(a *Assertions) bool { return a.Contains(map[string]int{"a": 1}, "a") }},
+		{
+			name:     "Contains fails",
+			check:    func(a *Assertions) bool { return a.Contains([]int{1, 2}, 3) },
+			expected: "Value does not contain\n3\nvalue:\n[]int{1, 2}",
+		},
+		{
+			name:     "Contains wrong type",
+			check:    func(a *Assertions) bool { return a.Contains(5, 3) },
+			expected: "Can't check the contents of a int; expected a string, slice, array, or map",
+		},
+		{
+			name:     "Contains string for non-string",
+			check:    func(a *Assertions) bool { return a.Contains("abc", 3) },
+			expected: "Can't check a string for a int; expected a string",
+		},
+		{name: "NotContains", check: func(a *Assertions) bool { return a.NotContains("widget", "x") }},
+		{
+			name:     "NotContains fails",
+			check:    func(a *Assertions) bool { return a.NotContains("widget", "dge") },
+			expected: "Value contains\n\"dge\"\nbut expected it not to\nvalue:\n\"widget\"",
+		},
+		{name: "True", check: func(a *Assertions) bool { return a.True(true) }},
+		{name: "True fails", check: func(a *Assertions) bool { return a.True(false) }, expected: "Expected true, got false"},
+		{name: "False", check: func(a *Assertions) bool { return a.False(false) }},
+		{name: "False fails", check: func(a *Assertions) bool { return a.False(true) }, expected: "Expected false, got true"},
+		{name: "Nil", check: func(a *Assertions) bool { return a.Nil(nil) }},
+		{name: "Nil typed", check: func(a *Assertions) bool { return a.Nil(nilPtr) }},
+		{name: "Nil fails", check: func(a *Assertions) bool { return a.Nil(5) }, expected: "Expected nil, got\n5"},
+		{name: "NotNil", check: func(a *Assertions) bool { return a.NotNil(5) }},
+		{
+			name:     "NotNil fails",
+			check:    func(a *Assertions) bool { return a.NotNil(nilPtr) },
+			expected: "Expected a non-nil value, got (*int)(nil)",
+		},
+		{name: "NoError", check: func(a *Assertions) bool { return a.NoError(nil) }},
+		{
+			name:     "NoError fails",
+			check:    func(a *Assertions) bool { return a.NoError(io.EOF) },
+			expected: "Unexpected error: EOF",
+		},
+		{name: "Error", check: func(a *Assertions) bool { return a.Error(io.EOF) }},
+		{
+			name:     "Error fails",
+			check:    func(a *Assertions) bool { return a.Error(nil) },
+			expected: "Expected an error, got nil",
+		},
+		{
+			name:  "ErrorIs",
+			check: func(a *Assertions) bool { return a.ErrorIs(fmt.Errorf("reading: %w", io.EOF), io.EOF) },
+		},
+		{
+			name:     "ErrorIs fails",
+			check:    func(a *Assertions) bool { return a.ErrorIs(errors.New("other"), io.EOF) },
+			expected: "Incorrect error: expected an error matching\nEOF\ngot\nother",
+		},
+		{name: "Len", check: func(a *Assertions) bool { return a.Len([]int{1, 2}, 2) }},
+		{
+			name:     "Len fails",
+			check:    func(a *Assertions) bool { return a.Len("abc", 2) },
+			expected: "Incorrect length: expected 2, got 3\nvalue:\n\"abc\"",
+		},
+		{
+			name:     "Len wrong type",
+			check:    func(a *Assertions) bool { return a.Len(5, 2) },
+			expected: "Can't get the length of a int",
+		},
+		{name: "Panics", check: func(a *Assertions) bool { return a.Panics(func() { panic("boom") }) }},
+		{name: "Panics fails", check: func(a *Assertions) bool { return a.Panics(func() {}) }, expected: "Expected a panic"},
+		{name: "NotPanics", check: func(a *Assertions) bool { return a.NotPanics(func() {}) }},
+		{
+			name:     "NotPanics fails",
+			check:    func(a *Assertions) bool { return a.NotPanics(func() { panic("boom") }) },
+			expected: "Unexpected panic: boom",
+		},
+		{
+			name:  "PanicsWithValue",
+			check: func(a *Assertions) bool { return a.PanicsWithValue("boom", func() { panic("boom") }) },
+		},
+		{
+			name:     "PanicsWithValue wrong value",
+			check:    func(a *Assertions) bool { return a.PanicsWithValue("boom", func() { panic(5) }) },
+			expected: "Incorrect panic value: expected\n\"boom\"\ngot\n5",
+		},
+		{
+			name:     "PanicsWithValue no panic",
+			check:    func(a *Assertions) bool { return a.PanicsWithValue("boom", func() {}) },
+			expected: "Expected a panic",
+		},
+		{
+			name:  "PanicsWithError",
+			check: func(a *Assertions) bool { return a.PanicsWithError("EOF", func() { panic(io.EOF) }) },
+		},
+		{
+			name:     "PanicsWithError not an error",
+			check:    func(a *Assertions) bool { return a.PanicsWithError("EOF", func() { panic("EOF") }) },
+			expected: "Incorrect panic value: expected an error with the message\n\"EOF\"\ngot\n\"EOF\"",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := test.check(New(rt))
+		want := []string{}
+		if test.expected != "" {
+			want = []string{test.expected}
+		}
+		if ok != (test.expected == "") || !reflect.DeepEqual(rt.Failures(), want) {
+			t.Errorf("Assertions: Incorrect result: expected\n%v, %#+v\ngot\n%v, %#+v\nin test '%s'",
+				test.expected == "", want, ok, rt.Failures(), test.name)
+		}
+	}
+}
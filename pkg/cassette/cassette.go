@@ -0,0 +1,324 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// A Mode says whether a Recorder records interactions or replays them.
+type Mode int
+
+// Recorder modes
+const (
+	// ModeAuto records if the cassette file doesn't exist, or if the environment variable named by
+	// testhelp.UpdateGoldenEnvVar is set, and replays otherwise.
+	ModeAuto Mode = iota
+	// ModeRecord always records, replacing any existing cassette.
+	ModeRecord
+	// ModeReplay always replays, failing the test if the cassette doesn't exist.  This is useful in CI, to make sure
+	// that a missing cassette doesn't cause a test to reach the network.
+	ModeReplay
+)
+
+// Options holds options for New.  The zero value (or a nil *Options) means ModeAuto, http.DefaultTransport,
+// DefaultMatchers, and only the default redaction.
+type Options struct {
+	Mode Mode
+	// Transport makes the real requests while recording.
+	Transport http.RoundTripper
+	// Matchers decide which recorded interaction answers a request; all of them must match.
+	Matchers []Matcher
+	// RedactHeaders lists headers whose values are redacted, in addition to DefaultRedactHeaders.
+	RedactHeaders []string
+	// Redact, if not nil, is called with each interaction before it's saved (after the headers are redacted), and
+	// with the Request form of each live request before it's matched, so that it can remove other secrets, such as
+	// tokens in URLs or bodies.
+	Redact func(*Interaction)
+}
+
+// An Interaction is a request and its response, as stored in a cassette.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// A Request is the recorded form of an HTTP request.  If the body isn't valid UTF-8, it's base64-encoded, and
+// BodyEncoding is "base64".
+type Request struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// A Response is the recorded form of an HTTP response, with the body encoded as for Request.
+type Response struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// A Recorder is an http.RoundTripper that records or replays interactions; see the package documentation.  It's safe
+// for concurrent use, but concurrent requests are recorded in the order they complete, so replaying them is only
+// deterministic if the Matchers can tell them apart.
+type Recorder struct {
+	t         testhelp.TestingTB
+	path      string
+	recording bool
+	opts      Options
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New returns a Recorder that uses the cassette testdata/cassettes/<name>.json.  In record mode, the cassette is
+// written when the test finishes; in replay mode, it's read immediately, and t.Fatalf is called if it can't be.
+func New(t testhelp.TestingTB, name string, opts *Options) *Recorder {
+	t.Helper()
+	return newAt(t, filepath.Join("testdata", "cassettes", filepath.FromSlash(name)+".json"), opts)
+}
+
+func newAt(t testhelp.TestingTB, path string, opts *Options) *Recorder {
+	t.Helper()
+	r := &Recorder{t: t, path: path}
+	if opts != nil {
+		r.opts = *opts
+	}
+	if r.opts.Transport == nil {
+		r.opts.Transport = http.DefaultTransport
+	}
+	if r.opts.Matchers == nil {
+		r.opts.Matchers = DefaultMatchers
+	}
+
+	switch r.opts.Mode {
+	case ModeRecord:
+		r.recording = true
+	case ModeAuto:
+		if os.Getenv(testhelp.UpdateGoldenEnvVar) != "" {
+			r.recording = true
+		} else if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			r.recording = true
+		}
+	}
+
+	if r.recording {
+		t.Cleanup(r.save)
+		return r
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Can't read cassette: %s", err)
+		return r // for mocks whose Fatalf doesn't stop the test
+	}
+	if err := json.Unmarshal(data, &r.interactions); err != nil {
+		t.Fatalf("Can't parse cassette %s: %s", path, err)
+		return r // for mocks whose Fatalf doesn't stop the test
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r
+}
+
+// Recording returns true if r is recording interactions, and false if it's replaying them.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Client returns an *http.Client that uses r as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.  While replaying, it answers each request with the first unused recorded
+// interaction that matches it, calling t.Errorf and returning an error if there isn't one.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	recReq := r.recordRequest(req, reqBody)
+	if r.recording {
+		return r.record(req, reqBody, recReq)
+	}
+	return r.replay(req, recReq)
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte, recReq Request) (*http.Response, error) {
+	// The original body has been consumed, so the real transport gets a copy of the request with a new one
+	realReq := req.Clone(req.Context())
+	if len(reqBody) > 0 {
+		realReq.Body = io.NopCloser(bytes.NewReader(reqBody))
+	} else if req.Body != nil {
+		realReq.Body = http.NoBody
+	}
+	resp, err := r.opts.Transport.RoundTrip(realReq)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.Request = req
+	in := Interaction{Request: recReq, Response: Response{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}}
+	in.Response.Body, in.Response.BodyEncoding = encodeBody(respBody)
+	redactHeaders(in.Response.Header, DefaultRedactHeaders)
+	redactHeaders(in.Response.Header, r.opts.RedactHeaders)
+	if r.opts.Redact != nil {
+		r.opts.Redact(&in)
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recReq Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.interactions {
+		if r.used[i] || !r.matches(recReq, in.Request) {
+			continue
+		}
+		r.used[i] = true
+		body, err := decodeBody(in.Response.Body, in.Response.BodyEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("bad response body in cassette %s: %w", r.path, err)
+		}
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	r.t.Errorf("No unused interaction in cassette %s matches the request %s %s (run the tests with %s=1 to "+
+		"re-record it)", r.path, recReq.Method, recReq.URL, testhelp.UpdateGoldenEnvVar)
+	return nil, fmt.Errorf("no recorded interaction matches %s %s", recReq.Method, recReq.URL)
+}
+
+func (r *Recorder) matches(live, recorded Request) bool {
+	for _, m := range r.opts.Matchers {
+		if !m(live, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordRequest converts a live request to its recorded (and redacted) form.
+func (r *Recorder) recordRequest(req *http.Request, body []byte) Request {
+	rec := Request{Method: req.Method, URL: req.URL.String(), Header: req.Header.Clone()}
+	if rec.Method == "" {
+		rec.Method = http.MethodGet
+	}
+	rec.Body, rec.BodyEncoding = encodeBody(body)
+	if rec.Header == nil {
+		rec.Header = http.Header{}
+	}
+	redactHeaders(rec.Header, DefaultRedactHeaders)
+	redactHeaders(rec.Header, r.opts.RedactHeaders)
+	if len(rec.Header) == 0 {
+		rec.Header = nil
+	}
+	if r.opts.Redact != nil {
+		in := Interaction{Request: rec}
+		r.opts.Redact(&in)
+		rec = in.Request
+	}
+	return rec
+}
+
+// save writes the recorded interactions to the cassette.
+func (r *Recorder) save() {
+	r.t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interactions := r.interactions
+	if interactions == nil {
+		interactions = []Interaction{}
+	}
+	data, err := json.MarshalIndent(interactions, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(r.path, append(data, '\n'), 0o644)
+	}
+	if err != nil {
+		r.t.Errorf("Can't write cassette: %s", err)
+		return
+	}
+	r.t.Logf("Recorded %d interaction(s) in cassette %s", len(interactions), r.path)
+}
+
+// readBody reads and closes body, if it isn't nil.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// encodeBody returns the recorded form of a body, and its encoding.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// decodeBody reverses encodeBody.
+func decodeBody(body, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unknown body encoding '%s'", encoding)
+	}
+}
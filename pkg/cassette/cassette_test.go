@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassette
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// newTestServer returns a server that echoes the method, path, and body of each request, and whether it had the
+// right Authorization header, and counts the requests it gets.
+func newTestServer(t *testing.T, count *int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*count++
+		body, _ := io.ReadAll(req.Body)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Count", fmt.Sprint(*count))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s %q auth=%t", req.Method, req.URL.RequestURI(), body,
+			req.Header.Get("Authorization") == "Bearer token123")
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// do makes a request through client, returning the status, the X-Count header, and the body.
+func do(t *testing.T, client *http.Client, method, url, body string) (int, string, string, error) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Can't create request: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer token123")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Can't read response: %s", err)
+	}
+	return resp.StatusCode, resp.Header.Get("X-Count"), string(respBody), nil
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	count := 0
+	srv := newTestServer(t, &count)
+	path := filepath.Join(t.TempDir(), "cassettes", "example.json")
+	type result struct {
+		status      int
+		xCount      string
+		body        string
+		description string
+	}
+	requests := []struct{ method, path, body string }{
+		{"GET", "/a?x=1", ""},
+		{"POST", "/b", "\xff\x00"},
+		{"GET", "/a?x=1", ""},
+	}
+
+	rt := &testhelptest.RecordingT{}
+	rec := newAt(rt, path, nil)
+	if !rec.Recording() {
+		t.Fatalf("newAt(): Expected to record with no cassette")
+	}
+	var recorded []result
+	for _, r := range requests {
+		status, xCount, body, err := do(t, rec.Client(), r.method, srv.URL+r.path, r.body)
+		if err != nil {
+			t.Fatalf("Recorder.RoundTrip(): Unexpected error while recording: %s", err)
+		}
+		recorded = append(recorded, result{status, xCount, body, r.method + " " + r.path})
+	}
+	if recorded[0].body != `GET /a?x=1 "" auth=true` || recorded[1].body != `POST /b "\xff\x00" auth=true` {
+		t.Errorf("Recorder.RoundTrip(): Incorrect responses while recording:\n%#+v", recorded)
+	}
+	rt.RunCleanups()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Recorder: Cassette not written: %s", err)
+	}
+	if strings.Contains(string(data), "token123") || strings.Contains(string(data), "session=secret") ||
+		!strings.Contains(string(data), Redacted) || !strings.Contains(string(data), `"body_encoding": "base64"`) {
+		t.Errorf("Recorder: Incorrect cassette contents:\n%s", data)
+	}
+
+	srv.Close()
+	rt = &testhelptest.RecordingT{}
+	rec = newAt(rt, path, nil)
+	if rec.Recording() {
+		t.Fatalf("newAt(): Expected to replay with an existing cassette")
+	}
+	for i, r := range requests {
+		status, xCount, body, err := do(t, rec.Client(), r.method, srv.URL+r.path, r.body)
+		got := result{status, xCount, body, r.method + " " + r.path}
+		if err != nil || got != recorded[i] {
+			t.Errorf("Recorder.RoundTrip(): Incorrect replay: expected\n%#+v\ngot\n%#+v\nerror: %v", recorded[i], got, err)
+		}
+	}
+	if _, _, _, err := do(t, rec.Client(), "GET", srv.URL+"/a?x=1", ""); err == nil {
+		t.Errorf("Recorder.RoundTrip(): Expected an error when all matching interactions have been used")
+	}
+	want := "No unused interaction in cassette " + path + " matches the request GET " + srv.URL + "/a?x=1 (run " +
+		"the tests with " + testhelp.UpdateGoldenEnvVar + "=1 to re-record it)"
+	if len(rt.Errors) != 1 || rt.Errors[0] != want {
+		t.Errorf("Recorder.RoundTrip(): Incorrect failures: expected\n%s\ngot\n%#+v", want, rt.Errors)
+	}
+	if count != 3 {
+		t.Errorf("Recorder: Expected 3 real requests, got %d", count)
+	}
+}
+
+func TestModes(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.json")
+	if err := os.WriteFile(existing, []byte("[]\n"), 0o644); err != nil {
+		t.Fatalf("Can't write cassette: %s", err)
+	}
+
+	rt := &testhelptest.RecordingT{}
+	newAt(rt, filepath.Join(dir, "missing.json"), &Options{Mode: ModeReplay})
+	if len(rt.Fatals) != 1 || !strings.HasPrefix(rt.Fatals[0], "Can't read cassette: ") {
+		t.Errorf("newAt(): Incorrect failures in replay mode with no cassette:\n%#+v", rt.Fatals)
+	}
+	if newAt(&testhelptest.RecordingT{}, existing, &Options{Mode: ModeRecord}).Recording() != true {
+		t.Errorf("newAt(): Expected to record in record mode")
+	}
+	if newAt(&testhelptest.RecordingT{}, existing, nil).Recording() != false {
+		t.Errorf("newAt(): Expected to replay in auto mode with a cassette")
+	}
+	t.Setenv(testhelp.UpdateGoldenEnvVar, "1")
+	if newAt(&testhelptest.RecordingT{}, existing, nil).Recording() != true {
+		t.Errorf("newAt(): Expected to record in auto mode when updating")
+	}
+	t.Setenv(testhelp.UpdateGoldenEnvVar, "")
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("{"), 0o644); err != nil {
+		t.Fatalf("Can't write cassette: %s", err)
+	}
+	rt = &testhelptest.RecordingT{}
+	newAt(rt, bad, nil)
+	if len(rt.Fatals) != 1 || !strings.HasPrefix(rt.Fatals[0], "Can't parse cassette "+bad+": ") {
+		t.Errorf("newAt(): Incorrect failures with a bad cassette:\n%#+v", rt.Fatals)
+	}
+}
+
+func TestMatchersAndRedact(t *testing.T) {
+	count := 0
+	srv := newTestServer(t, &count)
+	path := filepath.Join(t.TempDir(), "redact.json")
+	opts := &Options{
+		Matchers:      []Matcher{MatchMethod, MatchURL, MatchBody},
+		RedactHeaders: []string{"X-Count"},
+		Redact: func(in *Interaction) {
+			in.Request.URL = strings.ReplaceAll(in.Request.URL, "key=abc", "key=KEY")
+			in.Response.Body = strings.ReplaceAll(in.Response.Body, "key=abc", "key=KEY")
+		},
+	}
+	rt := &testhelptest.RecordingT{}
+	rec := newAt(rt, path, opts)
+	for _, body := range []string{"one", "two"} {
+		if _, _, _, err := do(t, rec.Client(), "PUT", srv.URL+"/k?key=abc", body); err != nil {
+			t.Fatalf("Recorder.RoundTrip(): Unexpected error while recording: %s", err)
+		}
+	}
+	rt.RunCleanups()
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "key=abc") || strings.Count(string(data), Redacted) != 6 {
+		t.Errorf("Recorder: Incorrect cassette contents:\n%s", data)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	rec = newAt(rt, path, opts)
+	// Replayed out of order, matched by body, with the URL redacted the same way as when it was recorded
+	for _, body := range []string{"two", "one"} {
+		_, xCount, respBody, err := do(t, rec.Client(), "PUT", srv.URL+"/k?key=abc", body)
+		want := fmt.Sprintf("PUT /k?key=KEY %q auth=true", body)
+		if err != nil || xCount != Redacted || respBody != want {
+			t.Errorf("Recorder.RoundTrip(): Incorrect replay: expected %s, got %s, %s (error: %v)", want, xCount,
+				respBody, err)
+		}
+	}
+	if len(rt.Errors) != 0 {
+		t.Errorf("Recorder.RoundTrip(): Unexpected failures:\n%#+v", rt.Errors)
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package cassette records the HTTP interactions made by code under test and replays them in later runs, so that tests
+of clients for third-party APIs can be hermetic and deterministic without hand-written fakes.
+
+A Recorder is an http.RoundTripper.  The first time a test runs (or whenever the environment variable named by
+testhelp.UpdateGoldenEnvVar is set), it passes requests to a real transport and saves each request and response to
+a cassette file under testdata/cassettes; afterwards, it answers requests from the cassette without touching the
+network.  Recorded requests are matched to live ones with Matchers (by default, on method and URL), and credentials
+are redacted from the cassette before it's written.  For example:
+
+	func TestClient(t *testing.T) {
+		rec := cassette.New(t, "list_widgets", nil)
+		client := api.NewClient(rec.Client(), os.Getenv("API_TOKEN"))
+		widgets, err := client.ListWidgets()
+		// ...
+	}
+*/
+package cassette
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassette
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// A Matcher reports whether a recorded request can be used to answer a live one.  The live request has been
+// converted to a Request (and redacted) in the same way as the recorded one, so that, for example, an Authorization
+// header can be compared even though its value isn't in the cassette.
+type Matcher func(live, recorded Request) bool
+
+// MatchMethod matches requests with the same method.
+func MatchMethod(live, recorded Request) bool {
+	return live.Method == recorded.Method
+}
+
+// MatchURL matches requests with the same URL, including the query string.
+func MatchURL(live, recorded Request) bool {
+	return live.URL == recorded.URL
+}
+
+// MatchBody matches requests with the same body.
+func MatchBody(live, recorded Request) bool {
+	return live.Body == recorded.Body && live.BodyEncoding == recorded.BodyEncoding
+}
+
+// MatchHeaders returns a Matcher that matches requests with the same values for each of the given headers (including
+// both requests not having the header).
+func MatchHeaders(names ...string) Matcher {
+	return func(live, recorded Request) bool {
+		for _, name := range names {
+			if !reflect.DeepEqual(live.Header.Values(name), recorded.Header.Values(name)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DefaultMatchers is used when Options.Matchers is nil.
+var DefaultMatchers = []Matcher{MatchMethod, MatchURL}
+
+// DefaultRedactHeaders lists the headers whose values are always redacted, in both requests and responses.
+var DefaultRedactHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// Redacted replaces the values of redacted headers.
+const Redacted = "[REDACTED]"
+
+// redactHeaders replaces the values of the given headers in h, which is modified.
+func redactHeaders(h http.Header, names []string) {
+	for _, name := range names {
+		if vals := h.Values(name); len(vals) > 0 {
+			redacted := make([]string, len(vals))
+			for i := range redacted {
+				redacted[i] = Redacted
+			}
+			h[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+}
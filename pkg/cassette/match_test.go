@@ -0,0 +1,61 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassette
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	base := Request{
+		Method: "POST", URL: "https://example.com/a?x=1", Header: http.Header{"Accept": {"text/plain"}}, Body: "hi",
+	}
+	tests := []struct {
+		name     string
+		matcher  Matcher
+		recorded Request
+		expected bool
+	}{
+		{name: "method", matcher: MatchMethod, recorded: base, expected: true},
+		{name: "method differs", matcher: MatchMethod, recorded: Request{Method: "GET"}, expected: false},
+		{name: "url", matcher: MatchURL, recorded: base, expected: true},
+		{name: "url differs", matcher: MatchURL, recorded: Request{URL: "https://example.com/a?x=2"}, expected: false},
+		{name: "body", matcher: MatchBody, recorded: base, expected: true},
+		{name: "body differs", matcher: MatchBody, recorded: Request{Body: "hi", BodyEncoding: "base64"}},
+		{name: "headers", matcher: MatchHeaders("accept", "X-Missing"), recorded: base, expected: true},
+		{
+			name: "headers differ", matcher: MatchHeaders("Accept"),
+			recorded: Request{Header: http.Header{"Accept": {"text/html"}}},
+		},
+		{name: "header missing", matcher: MatchHeaders("Accept"), recorded: Request{}},
+	}
+	for _, test := range tests {
+		if got := test.matcher(base, test.recorded); got != test.expected {
+			t.Errorf("Matcher: Incorrect result: expected %t, got %t in test '%s'", test.expected, got, test.name)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{"Authorization": {"Bearer x"}, "Set-Cookie": {"a=1", "b=2"}, "Accept": {"*/*"}}
+	redactHeaders(h, DefaultRedactHeaders)
+	if h.Get("Authorization") != Redacted || len(h.Values("Set-Cookie")) != 2 || h.Values("Set-Cookie")[1] != Redacted ||
+		h.Get("Accept") != "*/*" || len(h) != 3 {
+		t.Errorf("redactHeaders(): Incorrect result: %#+v", h)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmptest provides shims for adopting testhelp in code that already uses go-cmp and gotest.tools/golden: an
+// Equal assertion that takes go-cmp Options, and golden-file assertions that read and write the gotest.tools golden
+// file layout.  It's a separate module so that the main testhelp package doesn't depend on go-cmp.
+package cmptest
+
+import (
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// Equal checks that want and got are equal according to cmp.Equal with the given options (such as
+// cmpopts.IgnoreFields or cmpopts.EquateApprox), calling t.Errorf with the cmp.Diff of the values if not.  It returns
+// true if they are equal.
+//
+// Note that, like cmp.Equal, it panics if the values have unexported fields and no option says how to handle them.
+func Equal(t testhelp.TestingTB, want, got interface{}, opts ...cmp.Option) bool {
+	t.Helper()
+	if cmp.Equal(want, got, opts...) {
+		return true
+	}
+	t.Errorf("Values are not equal (-want +got):\n%s", cmp.Diff(want, got, opts...))
+	return false
+}
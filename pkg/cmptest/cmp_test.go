@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmptest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type widget struct {
+	Name    string
+	Updated int
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got interface{}
+		ignore    bool
+		contains  []string // nil for success; go-cmp's diffs have deliberately unstable whitespace
+	}{
+		{name: "equal", want: widget{"a", 1}, got: widget{"a", 1}},
+		{name: "different", want: widget{"a", 1}, got: widget{"b", 1}, contains: []string{
+			"Values are not equal (-want +got):\n", `-`, `"a"`, `+`, `"b"`,
+		}},
+		{name: "ignored field", want: widget{"a", 1}, got: widget{"a", 2}, ignore: true},
+		{name: "not ignored field", want: widget{"a", 1}, got: widget{"a", 2}, contains: []string{"Updated"}},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		var ok bool
+		if test.ignore {
+			ok = Equal(rt, test.want, test.got, cmpopts.IgnoreFields(widget{}, "Updated"))
+		} else {
+			ok = Equal(rt, test.want, test.got)
+		}
+		wantFailures := 0
+		if test.contains != nil {
+			wantFailures = 1
+		}
+		if ok != (test.contains == nil) || len(rt.Failures()) != wantFailures {
+			t.Errorf("Equal(): Incorrect result: expected %t, got %t with failures\n%#+v\nin test '%s'",
+				test.contains == nil, ok, rt.Failures(), test.name)
+			continue
+		}
+		for _, s := range test.contains {
+			if !strings.Contains(rt.Failures()[0], s) {
+				t.Errorf("Equal(): Incorrect failure: expected it to contain\n%q\ngot\n%q\nin test '%s'", s,
+					rt.Failures()[0], test.name)
+			}
+		}
+	}
+}
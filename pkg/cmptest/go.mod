@@ -0,0 +1,10 @@
+module github.com/ocsw/go-testhelp/pkg/cmptest
+
+go 1.19
+
+replace github.com/ocsw/go-testhelp => ../..
+
+require (
+	github.com/google/go-cmp v0.6.0
+	github.com/ocsw/go-testhelp v0.0.0-00010101000000-000000000000
+)
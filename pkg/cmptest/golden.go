@@ -0,0 +1,110 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmptest
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// GoldenAssert compares actual with the golden file testdata/<filename>, relative to the test's working directory,
+// the way gotest.tools/golden.Assert does, with the same argument order: filename is used as is (with no suffix
+// added), and carriage returns before newlines in the golden file are ignored, so that files checked out with
+// Windows line endings still match.  If they differ, or the golden file doesn't exist, it calls t.Errorf with a
+// line-by-line diff.  It returns true if the check passed.
+//
+// The golden files are written instead of checked if the environment variable named by testhelp.UpdateGoldenEnvVar is
+// set, or if gotest.tools' -update flag is registered (by importing gotest.tools/golden in the test binary) and set,
+// so either way of updating works during a migration.
+func GoldenAssert(t testhelp.TestingTB, actual, filename string) bool {
+	t.Helper()
+	return goldenFile(t, goldenPath(filename), []byte(actual), true)
+}
+
+// GoldenAssertBytes is like GoldenAssert, but for binary data, as with gotest.tools/golden.AssertBytes; the golden
+// file is compared exactly, with no line ending normalization.
+func GoldenAssertBytes(t testhelp.TestingTB, actual []byte, filename string) bool {
+	t.Helper()
+	return goldenFile(t, goldenPath(filename), actual, false)
+}
+
+// goldenPath returns the path of a golden file in the gotest.tools layout.
+func goldenPath(filename string) string {
+	return filepath.Join("testdata", filepath.FromSlash(filename))
+}
+
+// updateGolden returns true if the golden files should be written instead of checked.
+func updateGolden() bool {
+	if os.Getenv(testhelp.UpdateGoldenEnvVar) != "" {
+		return true
+	}
+	for _, name := range []string{"update", "test.update-golden"} { // gotest.tools' current and original flags
+		if f := flag.Lookup(name); f != nil && f.Value.String() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+func goldenFile(t testhelp.TestingTB, path string, actual []byte, text bool) bool {
+	t.Helper()
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("Can't create directory for golden file: %s", err)
+			return false
+		}
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Errorf("Can't write golden file: %s", err)
+			return false
+		}
+		t.Logf("Updated golden file %s", path)
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Golden file %s does not exist; run the tests with %s=1 to create it", path,
+			testhelp.UpdateGoldenEnvVar)
+		return false
+	} else if err != nil {
+		t.Errorf("Can't read golden file: %s", err)
+		return false
+	}
+	if text {
+		want = bytes.ReplaceAll(want, []byte("\r\n"), []byte("\n"))
+	}
+	if bytes.Equal(want, actual) {
+		return true
+	}
+	var diff string
+	if text {
+		diff = cmp.Diff(string(want), string(actual))
+	} else {
+		diff = cmp.Diff(want, actual)
+	}
+	t.Errorf("Output does not match golden file %s (run the tests with %s=1 to update it) (-want +got):\n%s", path,
+		testhelp.UpdateGoldenEnvVar, diff)
+	return false
+}
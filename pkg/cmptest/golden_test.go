@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmptest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+func TestGoldenPath(t *testing.T) {
+	if got, want := goldenPath("sub/output.txt"), filepath.Join("testdata", "sub", "output.txt"); got != want {
+		t.Errorf("goldenPath(): Incorrect path: expected %q, got %q", want, got)
+	}
+}
+
+func TestGoldenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "output.txt")
+
+	rt := &testhelptest.RecordingT{}
+	if goldenFile(rt, path, []byte("a\nb\n"), true) {
+		t.Errorf("goldenFile(): Expected false with a missing golden file")
+	}
+	if want := []string{"Golden file " + path + " does not exist; run the tests with " + testhelp.UpdateGoldenEnvVar +
+		"=1 to create it"}; !reflect.DeepEqual(rt.Failures(), want) {
+		t.Errorf("goldenFile(): Incorrect failures with a missing golden file: expected\n%#+v\ngot\n%#+v", want,
+			rt.Failures())
+	}
+
+	t.Setenv(testhelp.UpdateGoldenEnvVar, "1")
+	rt = &testhelptest.RecordingT{}
+	if !goldenFile(rt, path, []byte("a\nb\n"), true) || len(rt.Failures()) != 0 {
+		t.Errorf("goldenFile(): Unexpected failure while updating:\n%#+v", rt.Failures())
+	}
+	t.Setenv(testhelp.UpdateGoldenEnvVar, "")
+
+	rt = &testhelptest.RecordingT{}
+	if !goldenFile(rt, path, []byte("a\nb\n"), true) || len(rt.Failures()) != 0 {
+		t.Errorf("goldenFile(): Unexpected failure for matching output:\n%#+v", rt.Failures())
+	}
+
+	// Windows line endings in the golden file are ignored
+	if err := os.WriteFile(path, []byte("a\r\nb\r\n"), 0o644); err != nil {
+		t.Fatalf("Can't write golden file: %s", err)
+	}
+	rt = &testhelptest.RecordingT{}
+	if !goldenFile(rt, path, []byte("a\nb\n"), true) || len(rt.Failures()) != 0 {
+		t.Errorf("goldenFile(): Unexpected failure for CRLF golden file:\n%#+v", rt.Failures())
+	}
+
+	// but binary golden files are compared exactly
+	rt = &testhelptest.RecordingT{}
+	if goldenFile(rt, path, []byte("a\nb\n"), false) {
+		t.Errorf("goldenFile(): Expected false for binary output against a CRLF golden file")
+	}
+
+	for _, text := range []bool{true, false} {
+		rt = &testhelptest.RecordingT{}
+		if goldenFile(rt, path, []byte("a\nc\n"), text) {
+			t.Errorf("goldenFile(): Expected false for different output (text %t)", text)
+		}
+		prefix := "Output does not match golden file " + path
+		if len(rt.Failures()) != 1 || !strings.HasPrefix(rt.Failures()[0], prefix) {
+			t.Errorf("goldenFile(): Incorrect failures for different output (text %t): expected one starting with\n"+
+				"%q\ngot\n%#+v", text, prefix, rt.Failures())
+		}
+	}
+}
+
+func TestGoldenFileBinaryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output.bin")
+	data := []byte("\x00\x01\r\n\xff\r\n")
+
+	t.Setenv(testhelp.UpdateGoldenEnvVar, "1")
+	rt := &testhelptest.RecordingT{}
+	if !goldenFile(rt, path, data, false) || len(rt.Failures()) != 0 {
+		t.Errorf("goldenFile(): Unexpected failure while updating:\n%#+v", rt.Failures())
+	}
+	t.Setenv(testhelp.UpdateGoldenEnvVar, "")
+
+	rt = &testhelptest.RecordingT{}
+	if !goldenFile(rt, path, data, false) || len(rt.Failures()) != 0 {
+		t.Errorf("goldenFile(): Unexpected failure for binary data with CRLF after updating:\n%#+v", rt.Failures())
+	}
+}
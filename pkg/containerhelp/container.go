@@ -0,0 +1,211 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package containerhelp starts Docker containers for integration tests, such as a database or cache for the code under
+// test to talk to, by running the docker CLI.  Containers are removed automatically when the test (or, for shared
+// containers, the test binary's run) finishes.
+package containerhelp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// defaultReadyTimeout is used when ContainerOptions.ReadyTimeout is 0.
+const defaultReadyTimeout = 60 * time.Second
+
+// readyInterval is how often readiness is checked.
+const readyInterval = 250 * time.Millisecond
+
+// ContainerOptions holds the settings for StartContainer and SharedContainer.  Only Port is required.
+type ContainerOptions struct {
+	// Port is the container port to publish and return the address of, e.g. "5432/tcp" (or just "5432")
+	Port string
+	// Env holds environment variables for the container, e.g. POSTGRES_PASSWORD
+	Env map[string]string
+	// Args holds arguments to pass to the image's entrypoint, after the image name
+	Args []string
+	// Ready checks whether the service in the container is ready, given its host:port address; the default checks
+	// that a TCP connection can be made (which, for some images, happens before the service is fully ready)
+	Ready func(addr string) error
+	// ReadyTimeout is how long to wait for Ready to succeed; the default is 60 seconds
+	ReadyTimeout time.Duration
+	// Docker is the docker CLI command to run; the default is "docker" (found in the PATH)
+	Docker string
+}
+
+// A Container describes a running container started by StartContainer or SharedContainer.
+type Container struct {
+	ID   string
+	Host string
+	Port string
+}
+
+// Addr returns the container's published address, as host:port.
+func (c Container) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// DockerAvailable returns true if the docker CLI can be found in the PATH, for tests that want to skip themselves
+// when it can't.
+func DockerAvailable() bool {
+	_, err := exec.LookPath("docker")
+	return err == nil
+}
+
+// StartContainer starts a container from image, with opts.Port published on a random host port, waits until
+// opts.Ready succeeds (polling with testhelp.WaitFor), and registers a cleanup function with t which removes the
+// container.  For example:
+//
+//	pg := containerhelp.StartContainer(t, "postgres:16", containerhelp.ContainerOptions{
+//		Port: "5432/tcp",
+//		Env:  map[string]string{"POSTGRES_PASSWORD": "test"},
+//	})
+//	db, err := sql.Open("pgx", "postgres://postgres:test@"+pg.Addr()+"/postgres")
+//
+// If the container can't be started, or doesn't become ready in time, StartContainer calls t.Fatalf, including the
+// container's logs if it started.
+func StartContainer(t testhelp.TestingTB, image string, opts ContainerOptions) Container {
+	t.Helper()
+	c, err := startContainer(image, opts)
+	if c.ID != "" {
+		t.Cleanup(func() {
+			t.Helper()
+			if err := removeContainer(opts, c.ID); err != nil {
+				t.Errorf("Container cleanup failed: %s", err)
+			}
+		})
+	}
+	if err != nil {
+		t.Fatalf("Container setup failed: %s", err)
+	}
+	return c
+}
+
+// SharedContainer is like StartContainer, but shares the container between every test in the test binary's run that
+// requests the same key, using testhelp.OncePer.  The container is removed by testhelp.TeardownFixtures (which
+// RunMain calls), so the test binary should use TestMain; without it, the container is left running.
+func SharedContainer(t testhelp.TestingTB, key, image string, opts ContainerOptions) Container {
+	t.Helper()
+	return testhelp.OncePerT(t, "containerhelp:"+key, func() (Container, func()) {
+		c, err := startContainer(image, opts)
+		if err != nil {
+			if c.ID != "" {
+				_ = removeContainer(opts, c.ID)
+			}
+			panic(err)
+		}
+		return c, func() {
+			if err := removeContainer(opts, c.ID); err != nil {
+				panic(err)
+			}
+		}
+	})
+}
+
+// startContainer does the work for StartContainer and SharedContainer.  If the container was started, the returned
+// Container has its ID, even if there's an error.
+func startContainer(image string, opts ContainerOptions) (Container, error) {
+	if opts.Port == "" {
+		return Container{}, errors.New("no port was given for the container (ContainerOptions.Port)")
+	}
+	args := []string{"run", "--detach", "--publish", "127.0.0.1::" + opts.Port}
+	envNames := make([]string, 0, len(opts.Env))
+	for name := range opts.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		args = append(args, "--env", name+"="+opts.Env[name])
+	}
+	args = append(append(args, image), opts.Args...)
+	out, err := docker(opts, args...)
+	if err != nil {
+		return Container{}, fmt.Errorf("could not start a container from image '%s': %w", image, err)
+	}
+	c := Container{ID: strings.TrimSpace(out)}
+
+	out, err = docker(opts, "port", c.ID, opts.Port)
+	if err != nil {
+		return c, fmt.Errorf("could not find the published port of container %s (%s): %w", c.ID, image, err)
+	}
+	// docker port prints one address per line, e.g. "127.0.0.1:49153"
+	host, port, err := net.SplitHostPort(strings.TrimSpace(strings.SplitN(out, "\n", 2)[0]))
+	if err != nil {
+		return c, fmt.Errorf("could not parse the published port of container %s (%s): %w", c.ID, image, err)
+	}
+	c.Host, c.Port = host, port
+
+	ready := opts.Ready
+	if ready == nil {
+		ready = dialReady
+	}
+	timeout := opts.ReadyTimeout
+	if timeout == 0 {
+		timeout = defaultReadyTimeout
+	}
+	if err := testhelp.WaitFor(timeout, readyInterval, func() error { return ready(c.Addr()) }); err != nil {
+		logs, logErr := docker(opts, "logs", c.ID)
+		if logErr != nil {
+			logs = fmt.Sprintf("(could not get logs: %s)", logErr)
+		}
+		return c, fmt.Errorf("container %s (%s) did not become ready: %w\nlogs:\n%s", c.ID, image, err, logs)
+	}
+	return c, nil
+}
+
+// removeContainer stops and removes a container.
+func removeContainer(opts ContainerOptions, id string) error {
+	if _, err := docker(opts, "rm", "--force", "--volumes", id); err != nil {
+		return fmt.Errorf("could not remove container %s: %w", id, err)
+	}
+	return nil
+}
+
+// dialReady is the default readiness check.
+func dialReady(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// docker runs the docker CLI and returns its standard output; errors include its standard error.
+func docker(opts ContainerOptions, args ...string) (string, error) {
+	command := opts.Docker
+	if command == "" {
+		command = "docker"
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%s: %s", err, msg)
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
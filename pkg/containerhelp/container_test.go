@@ -0,0 +1,184 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerhelp
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+const fakeDocker = `#!/bin/sh
+echo "$@" >> "$FAKE_DOCKER_LOG"
+if [ "$1" = "$FAKE_DOCKER_FAIL" ]; then
+	echo "$1 went wrong" >&2
+	exit 1
+fi
+case "$1" in
+run) echo abc123 ;;
+port) echo "$FAKE_DOCKER_ADDR"; echo "[::1]:1" ;;
+logs) echo "server starting" ;;
+esac
+`
+
+// setupFakeDocker writes a fake docker CLI script, which logs its arguments and answers as for a container whose
+// port is published at addr, and returns its path and a function that reads its log.
+func setupFakeDocker(t *testing.T, addr, fail string) (string, func() []string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake docker CLI is a shell script")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "docker")
+	if err := os.WriteFile(script, []byte(fakeDocker), 0o755); err != nil {
+		t.Fatalf("Could not write the fake docker CLI: %s", err)
+	}
+	logFile := filepath.Join(dir, "log")
+	t.Setenv("FAKE_DOCKER_LOG", logFile)
+	t.Setenv("FAKE_DOCKER_ADDR", addr)
+	t.Setenv("FAKE_DOCKER_FAIL", fail)
+	return script, func() []string {
+		data, err := os.ReadFile(logFile)
+		if err != nil {
+			return nil // never called
+		}
+		return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+}
+
+func TestStartContainer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not listen: %s", err)
+	}
+	defer ln.Close()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	tests := []struct {
+		name          string
+		fail          string
+		opts          ContainerOptions
+		expectC       Container
+		expectCalls   []string
+		expectErrorRE string
+	}{
+		{
+			name:    "ready",
+			opts:    ContainerOptions{Port: "5432/tcp", Env: map[string]string{"B": "2", "A": "1"}, Args: []string{"-v"}},
+			expectC: Container{ID: "abc123", Host: "127.0.0.1", Port: port},
+			expectCalls: []string{
+				"run --detach --publish 127.0.0.1::5432/tcp --env A=1 --env B=2 img -v",
+				"port abc123 5432/tcp",
+				"rm --force --volumes abc123",
+			},
+		},
+		{
+			name: "not ready",
+			opts: ContainerOptions{Port: "80", ReadyTimeout: 1,
+				Ready: func(addr string) error { return errors.New("no answer from " + addr) }},
+			expectC: Container{ID: "abc123", Host: "127.0.0.1", Port: port},
+			expectCalls: []string{
+				"run --detach --publish 127.0.0.1::80 img", "port abc123 80", "logs abc123",
+				"rm --force --volumes abc123",
+			},
+			expectErrorRE: `^Container setup failed: container abc123 \(img\) did not become ready: ` +
+				`condition not met within 1ns \(1 attempt\(s\)\); last error: ` +
+				`no answer from 127\.0\.0\.1:\d+\nlogs:\nserver starting\n$`,
+		},
+		{
+			name: "run fails", fail: "run", opts: ContainerOptions{Port: "80"},
+			expectCalls: []string{"run --detach --publish 127.0.0.1::80 img"},
+			expectErrorRE: `^Container setup failed: could not start a container from image 'img': exit status 1: ` +
+				`run went wrong$`,
+		},
+		{
+			name: "port fails", fail: "port", opts: ContainerOptions{Port: "80"},
+			expectC: Container{ID: "abc123"},
+			expectCalls: []string{
+				"run --detach --publish 127.0.0.1::80 img", "port abc123 80", "rm --force --volumes abc123",
+			},
+			expectErrorRE: `^Container setup failed: could not find the published port of container abc123 \(img\): ` +
+				`exit status 1: port went wrong$`,
+		},
+		{
+			name: "rm fails", fail: "rm", opts: ContainerOptions{Port: "80"},
+			expectC: Container{ID: "abc123", Host: "127.0.0.1", Port: port},
+			expectCalls: []string{
+				"run --detach --publish 127.0.0.1::80 img", "port abc123 80", "rm --force --volumes abc123",
+			},
+			expectErrorRE: `^Container cleanup failed: could not remove container abc123: exit status 1: rm went wrong$`,
+		},
+		{
+			name:          "no port",
+			expectErrorRE: `^Container setup failed: no port was given for the container \(ContainerOptions\.Port\)$`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script, calls := setupFakeDocker(t, ln.Addr().String(), test.fail)
+			test.opts.Docker = script
+			rt := &testhelptest.RecordingT{}
+			c := StartContainer(rt, "img", test.opts)
+			rt.RunCleanups()
+
+			if c != test.expectC {
+				t.Errorf("StartContainer(): Incorrect container: expected\n%#+v\ngot\n%#+v", test.expectC, c)
+			}
+			if got := calls(); !reflect.DeepEqual(got, test.expectCalls) {
+				t.Errorf("StartContainer(): Incorrect docker calls: expected\n%#+v\ngot\n%#+v", test.expectCalls, got)
+			}
+			if test.expectErrorRE == "" {
+				if len(rt.Failures()) != 0 {
+					t.Errorf("StartContainer(): Unexpected failures:\n%#+v", rt.Failures())
+				}
+			} else if len(rt.Failures()) != 1 || !regexp.MustCompile(test.expectErrorRE).MatchString(rt.Failures()[0]) {
+				t.Errorf("StartContainer(): Incorrect failures: expected a match for\n%s\ngot\n%#+v",
+					test.expectErrorRE, rt.Failures())
+			}
+		})
+	}
+}
+
+func TestSharedContainer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not listen: %s", err)
+	}
+	defer ln.Close()
+	script, calls := setupFakeDocker(t, ln.Addr().String(), "")
+	opts := ContainerOptions{Port: "6379", Docker: script}
+
+	rt := &testhelptest.RecordingT{}
+	c1 := SharedContainer(rt, "redis", "redis:7", opts)
+	c2 := SharedContainer(rt, "redis", "redis:7", opts)
+	if c1 != c2 || c1.ID != "abc123" || len(rt.Failures()) != 0 {
+		t.Errorf("SharedContainer(): Expected the same container twice, got\n%#+v\n%#+v\n%#+v", c1, c2, rt.Failures())
+	}
+	testhelp.TeardownFixtures()
+	want := []string{"run --detach --publish 127.0.0.1::6379 redis:7", "port abc123 6379", "rm --force --volumes abc123"}
+	if got := calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SharedContainer(): Incorrect docker calls: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package dbhelp contains test doubles and fixtures for code that uses database/sql.
+
+Mock is a fake database driver that checks the calls made through a *sql.DB against a script of expected queries,
+returning canned rows, results, or errors, with failure messages in the same style as package testhelp's.
+WithTxRollback is a fixture for integration tests against a real database, which undoes each test's changes by
+running it in a transaction that is always rolled back.
+*/
+package dbhelp
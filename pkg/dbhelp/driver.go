@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbhelp
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// DriverName is the name under which Mock's driver is registered with database/sql.
+const DriverName = "testhelp-dbhelp"
+
+// mockRegistry maps DSNs to Mocks, for sql.Open.
+var mockRegistry = struct {
+	mu     sync.Mutex
+	mocks  map[string]*Mock
+	nextID int
+}{mocks: map[string]*Mock{}}
+
+func init() {
+	sql.Register(DriverName, mockDriver{})
+}
+
+// registerMock adds m to the registry and returns its DSN.
+func registerMock(m *Mock) string {
+	mockRegistry.mu.Lock()
+	defer mockRegistry.mu.Unlock()
+	mockRegistry.nextID++
+	dsn := fmt.Sprintf("mock-%d", mockRegistry.nextID)
+	mockRegistry.mocks[dsn] = m
+	return dsn
+}
+
+func unregisterMock(dsn string) {
+	mockRegistry.mu.Lock()
+	defer mockRegistry.mu.Unlock()
+	delete(mockRegistry.mocks, dsn)
+}
+
+type mockDriver struct{}
+
+func (mockDriver) Open(dsn string) (driver.Conn, error) {
+	mockRegistry.mu.Lock()
+	defer mockRegistry.mu.Unlock()
+	m, ok := mockRegistry.mocks[dsn]
+	if !ok {
+		return nil, fmt.Errorf("dbhelp: no Mock with DSN %q (it may belong to a test that has finished)", dsn)
+	}
+	return &conn{m}, nil
+}
+
+// connector lets NewMock open a DB without going through the registry.
+type connector struct {
+	m *Mock
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{c.m}, nil
+}
+
+func (connector) Driver() driver.Driver {
+	return mockDriver{}
+}
+
+// conn passes every call on to the Mock.  Connections are interchangeable, since the Mock holds all of the state.
+type conn struct {
+	m *Mock
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{c, query}, nil
+}
+
+func (*conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	if _, err := c.m.call(callBegin, "", nil); err != nil {
+		return nil, err
+	}
+	return tx{c.m}, nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	e, err := c.m.call(callQuery, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{columns: e.columns, rows: e.rows}, nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, err := c.m.call(callExec, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if e.result == nil {
+		return result{}, nil
+	}
+	return e.result, nil
+}
+
+type stmt struct {
+	c     *conn
+	query string
+}
+
+func (*stmt) Close() error {
+	return nil
+}
+
+func (*stmt) NumInput() int {
+	return -1 // any number; the Mock checks them
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.c.ExecContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.c.QueryContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.c.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.c.QueryContext(ctx, s.query, args)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+type tx struct {
+	m *Mock
+}
+
+func (t tx) Commit() error {
+	_, err := t.m.call(callCommit, "", nil)
+	return err
+}
+
+func (t tx) Rollback() error {
+	_, err := t.m.call(callRollback, "", nil)
+	return err
+}
+
+type result struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r result) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+type rows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (*rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+// Compile-time checks of the optional driver interfaces
+var (
+	_ driver.ConnBeginTx      = (*conn)(nil)
+	_ driver.QueryerContext   = (*conn)(nil)
+	_ driver.ExecerContext    = (*conn)(nil)
+	_ driver.StmtExecContext  = (*stmt)(nil)
+	_ driver.StmtQueryContext = (*stmt)(nil)
+)
@@ -0,0 +1,317 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbhelp
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// A Mock checks the calls made through its *sql.DB against a script of expected calls, declared with the Expect
+// methods, which must happen in order.  Each call made through the DB is compared with the next unmet expectation; if
+// it matches, the expectation is met and the call returns the expectation's canned rows, result, or error.  If it
+// doesn't match, or there are no expectations left, Mock calls t.Errorf describing the problem, and the call returns
+// an error (so the code under test sees a failure, too).  Expectations that are still unmet at the end of the test
+// are reported by a cleanup function.
+//
+// For example:
+//
+//	db, mock := dbhelp.NewMock(t)
+//	mock.ExpectQueryRE(`^SELECT name FROM users WHERE id = \?$`).WithArgs(42).
+//		WillReturnRows([]string{"name"}, []interface{}{"Ada"})
+//	mock.ExpectExec("UPDATE users SET seen = ?").WithArgs(dbhelp.AnyArg()).WillReturnResult(0, 1)
+//	mypkg.Visit(db, 42)
+//
+// A Mock is safe for concurrent use, but since expectations must be met in order, calls made from different
+// goroutines need to be synchronized by the code under test.
+type Mock struct {
+	t   testhelp.TestingTB
+	dsn string
+
+	mu           sync.Mutex
+	expectations []*Expectation
+	next         int // index of the first unmet expectation
+}
+
+// callKind is the kind of database call an Expectation is for.
+type callKind int
+
+const (
+	callQuery callKind = iota
+	callExec
+	callBegin
+	callCommit
+	callRollback
+)
+
+// String returns the kind as it appears in failure messages.
+func (k callKind) String() string {
+	switch k {
+	case callQuery:
+		return "query"
+	case callExec:
+		return "exec"
+	case callBegin:
+		return "begin"
+	case callCommit:
+		return "commit"
+	}
+	return "rollback"
+}
+
+// An Expectation is one expected call in a Mock's script.  Its methods configure it, and return it for chaining; they
+// should only be called before the code under test runs.
+type Expectation struct {
+	kind    callKind
+	query   testhelp.PanicMatcher // nil for begin, commit, and rollback
+	args    []testhelp.PanicMatcher
+	hasArgs bool // false means any arguments are acceptable
+
+	columns []string
+	rows    [][]driver.Value
+	result  driver.Result
+	err     error
+}
+
+// NewMock returns a *sql.DB backed by a new Mock, and the Mock, and registers a cleanup function with t which calls
+// Verify and closes the DB.  The DB can also be opened separately, with sql.Open(DriverName, mock.DSN()), e.g. for
+// code under test that opens its own connection from a configured DSN.
+func NewMock(t testhelp.TestingTB) (*sql.DB, *Mock) {
+	t.Helper()
+	m := &Mock{t: t}
+	m.dsn = registerMock(m)
+	db := sql.OpenDB(connector{m})
+	t.Cleanup(func() {
+		t.Helper()
+		_ = db.Close()
+		unregisterMock(m.dsn)
+		m.Verify(t)
+	})
+	return db, m
+}
+
+// DSN returns the data source name to pass to sql.Open, along with DriverName, to get a DB backed by the Mock.  It is
+// only valid until the end of the test.
+func (m *Mock) DSN() string {
+	return m.dsn
+}
+
+func (m *Mock) expect(e *Expectation) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// ExpectQuery adds an expectation for a query (e.g. from DB.Query or DB.QueryRow) whose text is exactly query.  By
+// default it returns no rows and no columns.
+func (m *Mock) ExpectQuery(query string) *Expectation {
+	return m.expect(&Expectation{kind: callQuery, query: testhelp.EqualsVal(query)})
+}
+
+// ExpectQueryRE adds an expectation for a query whose text matches the regular expression queryRE.  The regexp is
+// unanchored, as with testhelp.Regexp; it panics if it can't be compiled.  By default the query returns no rows and
+// no columns.
+func (m *Mock) ExpectQueryRE(queryRE string) *Expectation {
+	return m.expect(&Expectation{kind: callQuery, query: testhelp.Regexp(queryRE)})
+}
+
+// ExpectExec adds an expectation for a statement run with Exec (e.g. DB.Exec) whose text is exactly query.  By
+// default it returns a result with 0 for both the last insert ID and the number of rows affected.
+func (m *Mock) ExpectExec(query string) *Expectation {
+	return m.expect(&Expectation{kind: callExec, query: testhelp.EqualsVal(query)})
+}
+
+// ExpectExecRE adds an expectation for a statement run with Exec whose text matches the regular expression queryRE,
+// as for ExpectQueryRE.
+func (m *Mock) ExpectExecRE(queryRE string) *Expectation {
+	return m.expect(&Expectation{kind: callExec, query: testhelp.Regexp(queryRE)})
+}
+
+// ExpectBegin adds an expectation for the start of a transaction.
+func (m *Mock) ExpectBegin() *Expectation {
+	return m.expect(&Expectation{kind: callBegin})
+}
+
+// ExpectCommit adds an expectation for a transaction commit.
+func (m *Mock) ExpectCommit() *Expectation {
+	return m.expect(&Expectation{kind: callCommit})
+}
+
+// ExpectRollback adds an expectation for a transaction rollback.
+func (m *Mock) ExpectRollback() *Expectation {
+	return m.expect(&Expectation{kind: callRollback})
+}
+
+// WithArgs sets the arguments that the call must have.  Each argument is either a testhelp.PanicMatcher (such as
+// testhelp.Regexp or AnyArg), which must match the argument's driver value, or a plain value, which must be equal to
+// it after conversion to a driver value (so, e.g., an int matches the int64 that database/sql passes to drivers).
+// Without WithArgs, any arguments are accepted; WithArgs with no arguments requires the call to have none.
+func (e *Expectation) WithArgs(args ...interface{}) *Expectation {
+	e.hasArgs = true
+	e.args = make([]testhelp.PanicMatcher, len(args))
+	for i, arg := range args {
+		if matcher, ok := arg.(testhelp.PanicMatcher); ok {
+			e.args[i] = matcher
+			continue
+		}
+		v, err := driver.DefaultParameterConverter.ConvertValue(arg)
+		if err != nil {
+			panic(fmt.Sprintf("WithArgs: argument %d can't be converted to a driver value: %s", i, err))
+		}
+		e.args[i] = equalsArg{v}
+	}
+	return e
+}
+
+// WillReturnRows sets the rows that a query returns, with the given column names.  Each row must have one value per
+// column; values are converted to driver values as for WithArgs, and WillReturnRows panics if one can't be.
+func (e *Expectation) WillReturnRows(columns []string, rows ...[]interface{}) *Expectation {
+	e.columns = append([]string{}, columns...)
+	e.rows = make([][]driver.Value, len(rows))
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			panic(fmt.Sprintf("WillReturnRows: row %d has %d value(s), but there are %d column(s)", i, len(row),
+				len(columns)))
+		}
+		e.rows[i] = make([]driver.Value, len(row))
+		for j, val := range row {
+			v, err := driver.DefaultParameterConverter.ConvertValue(val)
+			if err != nil {
+				panic(fmt.Sprintf("WillReturnRows: row %d, column %d can't be converted to a driver value: %s", i,
+					j, err))
+			}
+			e.rows[i][j] = v
+		}
+	}
+	return e
+}
+
+// WillReturnResult sets the result that an exec returns.
+func (e *Expectation) WillReturnResult(lastInsertID, rowsAffected int64) *Expectation {
+	e.result = result{lastInsertID, rowsAffected}
+	return e
+}
+
+// WillReturnError makes the call return err (after its query and arguments have been checked); canned rows or results
+// are ignored.
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+// String describes the expectation, for failure messages, e.g. `query that equals "SELECT 1"`.
+func (e *Expectation) String() string {
+	if e.query == nil {
+		return e.kind.String()
+	}
+	desc := fmt.Sprintf("%s that %s", e.kind, e.query.Describe())
+	if e.hasArgs {
+		argDescs := make([]string, len(e.args))
+		for i, arg := range e.args {
+			argDescs[i] = arg.Describe()
+		}
+		desc += fmt.Sprintf(", with args [%s]", strings.Join(argDescs, "; "))
+	}
+	return desc
+}
+
+// AnyArg returns a matcher for WithArgs that accepts any argument value.
+func AnyArg() testhelp.PanicMatcher {
+	return testhelp.AllOf[interface{}]()
+}
+
+// equalsArg is the matcher WithArgs uses for plain values.  Unlike testhelp.EqualsVal, it uses reflect.DeepEqual, since
+// []byte is a valid driver value.
+type equalsArg struct {
+	want driver.Value
+}
+
+func (m equalsArg) Match(v interface{}) bool {
+	return reflect.DeepEqual(v, m.want)
+}
+
+func (m equalsArg) Describe() string {
+	return "equals " + testhelp.Format(m.want)
+}
+
+// Verify calls t.Errorf, listing the unmet expectations, if there are any, and returns true if there are none.  It is
+// called automatically at the end of the test, but can also be called directly.
+func (m *Mock) Verify(t testhelp.TestingT) bool {
+	m.mu.Lock()
+	unmet := m.expectations[m.next:]
+	m.mu.Unlock()
+	if len(unmet) == 0 {
+		return true
+	}
+	descs := make([]string, len(unmet))
+	for i, e := range unmet {
+		descs[i] = e.String()
+	}
+	t.Errorf("Unmet database expectations:\n%s", strings.Join(descs, "\n"))
+	return false
+}
+
+// call checks a call made through the driver against the next expectation, and returns the expectation if it
+// matched.  If it didn't, it calls t.Errorf and returns an error.
+func (m *Mock) call(kind callKind, query string, args []driver.NamedValue) (*Expectation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	callDesc := kind.String()
+	if kind == callQuery || kind == callExec {
+		callDesc = fmt.Sprintf("%s %q", kind, query)
+	}
+	if m.next >= len(m.expectations) {
+		return nil, m.fail("Unexpected database call: %s\n(no more calls were expected)", callDesc)
+	}
+	e := m.expectations[m.next]
+	switch {
+	case e.kind != kind:
+		return nil, m.fail("Unexpected database call: %s\nexpected: %s", callDesc, e)
+	case e.query != nil && !e.query.Match(query):
+		return nil, m.fail("Incorrect database %s: expected a query that %s\ngot\n%q", kind, e.query.Describe(), query)
+	case e.hasArgs && len(args) != len(e.args):
+		return nil, m.fail("Incorrect number of arguments for %s: expected %d, got %d", callDesc, len(e.args),
+			len(args))
+	}
+	if e.hasArgs {
+		for i, arg := range args {
+			if !e.args[i].Match(arg.Value) {
+				return nil, m.fail("Incorrect argument %d for %s: expected a value that %s\ngot\n%s", i+1, callDesc,
+					e.args[i].Describe(), testhelp.Format(arg.Value))
+			}
+		}
+	}
+	m.next++
+	return e, e.err
+}
+
+// fail reports a failure to the test, and returns it as an error for the code under test.  It must be called with
+// m.mu held.
+func (m *Mock) fail(format string, args ...interface{}) error {
+	m.t.Helper()
+	msg := fmt.Sprintf(format, args...)
+	m.t.Errorf("%s", msg)
+	return fmt.Errorf("dbhelp: %s", msg)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbhelp
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+func TestMockScript(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	db, mock := NewMock(rt)
+	mock.ExpectQueryRE(`^SELECT name FROM users WHERE id = \?$`).WithArgs(42).
+		WillReturnRows([]string{"name"}, []interface{}{"Ada"})
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET seen = ? WHERE id = ?").WithArgs(AnyArg(), 42).WillReturnResult(0, 1)
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT COUNT(*) FROM users").
+		WillReturnRows([]string{"n"}, []interface{}{3}).WillReturnError(errors.New("gone"))
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM users WHERE id = ?", 42).Scan(&name); err != nil || name != "Ada" {
+		t.Errorf("Mock: Incorrect query result: got %q, %v", name, err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Mock: Unexpected error from Begin: %s", err)
+	}
+	res, err := tx.Exec("UPDATE users SET seen = ? WHERE id = ?", "now", 42)
+	if err != nil {
+		t.Fatalf("Mock: Unexpected error from Exec: %s", err)
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("Mock: Incorrect rows affected: expected 1, got %d", n)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("Mock: Unexpected error from Commit: %s", err)
+	}
+	if _, err := db.Query("SELECT COUNT(*) FROM users"); err == nil || err.Error() != "gone" {
+		t.Errorf("Mock: Expected the canned error, got %v", err)
+	}
+
+	rt.RunCleanups()
+	if len(rt.Failures()) != 0 {
+		t.Errorf("Mock: Unexpected failures:\n%#+v", rt.Failures())
+	}
+}
+
+func TestMockSQLOpen(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	_, mock := NewMock(rt)
+	mock.ExpectExec("DELETE FROM sessions")
+	db, err := sql.Open(DriverName, mock.DSN())
+	if err != nil {
+		t.Fatalf("sql.Open(): Unexpected error: %s", err)
+	}
+	if _, err := db.Exec("DELETE FROM sessions"); err != nil {
+		t.Errorf("Mock: Unexpected error from Exec: %s", err)
+	}
+	_ = db.Close()
+	rt.RunCleanups()
+	if len(rt.Failures()) != 0 {
+		t.Errorf("Mock: Unexpected failures:\n%#+v", rt.Failures())
+	}
+
+	db, _ = sql.Open(DriverName, mock.DSN())
+	if err := db.Ping(); err == nil {
+		t.Errorf("sql.Open(): Expected an error for a finished test's DSN")
+	}
+}
+
+func TestMockFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		expect       func(mock *Mock)
+		run          func(db *sql.DB) error
+		expectErrors []string
+	}{
+		{
+			name:   "unexpected call",
+			expect: func(mock *Mock) {},
+			run: func(db *sql.DB) error {
+				_, err := db.Exec("DELETE FROM users")
+				return err
+			},
+			expectErrors: []string{"Unexpected database call: exec \"DELETE FROM users\"\n(no more calls were expected)"},
+		},
+		{
+			name:   "wrong kind",
+			expect: func(mock *Mock) { mock.ExpectBegin() },
+			run: func(db *sql.DB) error {
+				_, err := db.Query("SELECT 1")
+				return err
+			},
+			expectErrors: []string{
+				"Unexpected database call: query \"SELECT 1\"\nexpected: begin",
+				"Unmet database expectations:\nbegin",
+			},
+		},
+		{
+			name:   "wrong query",
+			expect: func(mock *Mock) { mock.ExpectQuery("SELECT 1") },
+			run: func(db *sql.DB) error {
+				_, err := db.Query("SELECT 2")
+				return err
+			},
+			expectErrors: []string{
+				"Incorrect database query: expected a query that equals \"SELECT 1\"\ngot\n\"SELECT 2\"",
+				"Unmet database expectations:\nquery that equals \"SELECT 1\"",
+			},
+		},
+		{
+			name:   "wrong number of args",
+			expect: func(mock *Mock) { mock.ExpectExecRE("^INSERT").WithArgs() },
+			run: func(db *sql.DB) error {
+				_, err := db.Exec("INSERT INTO t VALUES (?)", 1)
+				return err
+			},
+			expectErrors: []string{
+				"Incorrect number of arguments for exec \"INSERT INTO t VALUES (?)\": expected 0, got 1",
+				"Unmet database expectations:\nexec that matches regexp \"^INSERT\", with args []",
+			},
+		},
+		{
+			name: "wrong arg",
+			expect: func(mock *Mock) {
+				mock.ExpectExec("INSERT INTO t VALUES (?, ?)").WithArgs([]byte("a"), testhelp.Regexp("^b"))
+			},
+			run: func(db *sql.DB) error {
+				_, err := db.Exec("INSERT INTO t VALUES (?, ?)", []byte("a"), "c")
+				return err
+			},
+			expectErrors: []string{
+				"Incorrect argument 2 for exec \"INSERT INTO t VALUES (?, ?)\": expected a value that matches " +
+					"regexp \"^b\"\ngot\n\"c\"",
+				"Unmet database expectations:\nexec that equals \"INSERT INTO t VALUES (?, ?)\", with args " +
+					"[equals []byte(\"a\"); matches regexp \"^b\"]",
+			},
+		},
+		{
+			name:   "unmet",
+			expect: func(mock *Mock) { mock.ExpectBegin(); mock.ExpectRollback() },
+			run: func(db *sql.DB) error {
+				_, err := db.Begin()
+				return err
+			},
+			expectErrors: []string{"Unmet database expectations:\nrollback"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		db, mock := NewMock(rt)
+		test.expect(mock)
+		err := test.run(db)
+		rt.RunCleanups()
+		if !reflect.DeepEqual(rt.Failures(), append([]string{}, test.expectErrors...)) {
+			t.Errorf("Mock: Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expectErrors,
+				rt.Failures(), test.name)
+		}
+		if len(test.expectErrors) > 0 && test.name != "unmet" && err == nil {
+			t.Errorf("Mock: Expected the call to return an error in test '%s'", test.name)
+		}
+	}
+}
+
+func TestExpectationPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      func()
+		wantRE string
+	}{
+		{
+			name:   "bad arg",
+			f:      func() { new(Expectation).WithArgs(struct{}{}) },
+			wantRE: "^WithArgs: argument 0 can't be converted",
+		},
+		{
+			name:   "short row",
+			f:      func() { new(Expectation).WillReturnRows([]string{"a", "b"}, []interface{}{1}) },
+			wantRE: `^WillReturnRows: row 0 has 1 value\(s\), but there are 2 column\(s\)$`,
+		},
+		{
+			name:   "bad value",
+			f:      func() { new(Expectation).WillReturnRows([]string{"a"}, []interface{}{struct{}{}}) },
+			wantRE: "^WillReturnRows: row 0, column 0 can't be converted",
+		},
+	}
+	for _, test := range tests {
+		if didPanic, matches, pVal := testhelp.PanicsRE(test.f, test.wantRE); !didPanic || !matches {
+			t.Errorf("Expectation: Expected a panic matching %q, got %t, %#+v in test '%s'", test.wantRE, didPanic,
+				pVal, test.name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbhelp
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// WithTxRollback begins a transaction on db, calls f with it, and registers a cleanup function with t which rolls the
+// transaction back, so that an integration test against a shared database leaves nothing behind.  f should do all of
+// its work through tx, and must not commit it.
+//
+// If f panics, the panic is recovered and reported with t.Fatalf (with the stack), and the transaction is still rolled
+// back at cleanup.  Failing to begin the transaction calls t.Fatalf without calling f; failing to roll it back calls
+// t.Errorf, as does finding at cleanup that f already committed or rolled back the transaction (since a commit means
+// there may be residue).
+func WithTxRollback(t testhelp.TestingTB, db *sql.DB, f func(tx *sql.Tx)) {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Could not begin the test transaction: %s", err)
+		return // for mocks whose Fatalf doesn't stop the test
+	}
+	t.Cleanup(func() {
+		t.Helper()
+		err := tx.Rollback()
+		switch {
+		case errors.Is(err, sql.ErrTxDone):
+			t.Errorf("The test transaction was already committed or rolled back before cleanup; changes may not " +
+				"have been undone")
+		case err != nil:
+			t.Errorf("Could not roll back the test transaction: %s", err)
+		}
+	})
+
+	if c := testhelp.Capture(func() { f(tx) }); c.DidPanic {
+		t.Fatalf("Panic in the test transaction (%T):\n%s\nstack:\n%s", c.PVal, testhelp.PanicMessage(c.PVal),
+			c.Stack)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dbhelp
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestWithTxRollback(t *testing.T) {
+	tests := []struct {
+		name          string
+		expect        func(mock *Mock)
+		f             func(tx *sql.Tx)
+		expectCalled  bool
+		expectErrorRE []string
+	}{
+		{
+			name: "rolled back",
+			expect: func(mock *Mock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO t VALUES (1)")
+				mock.ExpectRollback()
+			},
+			f:            func(tx *sql.Tx) { _, _ = tx.Exec("INSERT INTO t VALUES (1)") },
+			expectCalled: true,
+		},
+		{
+			name: "panic",
+			expect: func(mock *Mock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			f:             func(tx *sql.Tx) { panic("oops") },
+			expectCalled:  true,
+			expectErrorRE: []string{`^Panic in the test transaction \(string\):\noops\nstack:\n.*WithTxRollback`},
+		},
+		{
+			name: "committed",
+			expect: func(mock *Mock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			f:             func(tx *sql.Tx) { _ = tx.Commit() },
+			expectCalled:  true,
+			expectErrorRE: []string{`^The test transaction was already committed or rolled back before cleanup`},
+		},
+		{
+			name: "rollback error",
+			expect: func(mock *Mock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback().WillReturnError(errors.New("connection lost"))
+			},
+			f:             func(tx *sql.Tx) {},
+			expectCalled:  true,
+			expectErrorRE: []string{`^Could not roll back the test transaction: connection lost$`},
+		},
+		{
+			name:          "begin error",
+			expect:        func(mock *Mock) { mock.ExpectBegin().WillReturnError(errors.New("too many connections")) },
+			f:             func(tx *sql.Tx) {},
+			expectErrorRE: []string{`^Could not begin the test transaction: too many connections$`},
+		},
+	}
+	for _, test := range tests {
+		mockT := &testhelptest.RecordingT{}
+		db, mock := NewMock(mockT)
+		test.expect(mock)
+
+		rt := &testhelptest.RecordingT{}
+		called := false
+		WithTxRollback(rt, db, func(tx *sql.Tx) {
+			called = true
+			test.f(tx)
+		})
+		rt.RunCleanups()
+		mockT.RunCleanups()
+
+		if called != test.expectCalled {
+			t.Errorf("WithTxRollback(): Incorrect call of f: expected %t, got %t in test '%s'", test.expectCalled,
+				called, test.name)
+		}
+		ok := len(rt.Failures()) == len(test.expectErrorRE)
+		for i := 0; ok && i < len(rt.Failures()); i++ {
+			ok = regexp.MustCompile("(?s)" + test.expectErrorRE[i]).MatchString(rt.Failures()[i])
+		}
+		if !ok {
+			t.Errorf("WithTxRollback(): Incorrect failures: expected matches for\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectErrorRE, rt.Failures(), test.name)
+		}
+		if len(mockT.Failures()) != 0 {
+			t.Errorf("WithTxRollback(): Unexpected database calls:\n%#+v\nin test '%s'", mockT.Failures(), test.name)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logtest provides adapters that feed the entries logged through third-party logging libraries (logr and zap)
+// into a testhelp.LogStore, so that tests can make the same assertions whichever library the code under test uses.
+// It's a separate module so that the main testhelp package doesn't depend on the libraries.
+package logtest
@@ -0,0 +1,13 @@
+module github.com/ocsw/go-testhelp/pkg/logtest
+
+go 1.19
+
+replace github.com/ocsw/go-testhelp => ../..
+
+require (
+	github.com/go-logr/logr v1.4.4
+	github.com/ocsw/go-testhelp v0.0.0-00010101000000-000000000000
+	go.uber.org/zap v1.28.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logtest
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// NewLogr returns a logr.Logger that records every entry in store, whatever its verbosity.  V(0) messages are recorded
+// at testhelp.LogInfo, and higher verbosities at testhelp.LogDebug; Error messages are recorded at testhelp.LogError,
+// with the error (if not nil) in the "error" field.  Names added with WithName are joined with "/", as with most logr
+// implementations.
+func NewLogr(store *testhelp.LogStore) logr.Logger {
+	return logr.New(&logrSink{store: store})
+}
+
+type logrSink struct {
+	store  *testhelp.LogStore
+	name   string
+	values []interface{}
+}
+
+func (*logrSink) Init(logr.RuntimeInfo) {}
+
+func (*logrSink) Enabled(int) bool {
+	return true
+}
+
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	entryLevel := testhelp.LogInfo
+	if level > 0 {
+		entryLevel = testhelp.LogDebug
+	}
+	s.add(entryLevel, msg, nil, keysAndValues)
+}
+
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.add(testhelp.LogError, msg, err, keysAndValues)
+}
+
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	s2 := *s
+	s2.values = append(append([]interface{}{}, s.values...), keysAndValues...)
+	return &s2
+}
+
+func (s *logrSink) WithName(name string) logr.LogSink {
+	s2 := *s
+	if s.name != "" {
+		name = s.name + "/" + name
+	}
+	s2.name = name
+	return &s2
+}
+
+func (s *logrSink) add(level testhelp.LogLevel, msg string, err error, keysAndValues []interface{}) {
+	var fields map[string]interface{}
+	for _, kv := range [][]interface{}{s.values, keysAndValues} {
+		for i := 0; i < len(kv); i += 2 {
+			if fields == nil {
+				fields = map[string]interface{}{}
+			}
+			var v interface{} = "(MISSING)"
+			if i+1 < len(kv) {
+				v = kv[i+1]
+			}
+			fields[fmt.Sprint(kv[i])] = v
+		}
+	}
+	if err != nil {
+		if fields == nil {
+			fields = map[string]interface{}{}
+		}
+		fields["error"] = err
+	}
+	s.store.Add(testhelp.LogEntry{Level: level, Logger: s.name, Message: msg, Fields: fields})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logtest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+func TestNewLogr(t *testing.T) {
+	var store testhelp.LogStore
+	logger := NewLogr(&store).WithName("app").WithValues("req", 12)
+	logger.Info("handled")
+	logger.V(2).Info("detail", "odd")
+	logger.WithName("db").Error(errors.New("connection refused"), "query failed", "table", "users")
+
+	want := []testhelp.LogEntry{
+		{Level: testhelp.LogInfo, Logger: "app", Message: "handled", Fields: map[string]interface{}{"req": 12}},
+		{Level: testhelp.LogDebug, Logger: "app", Message: "detail",
+			Fields: map[string]interface{}{"req": 12, "odd": "(MISSING)"}},
+		{Level: testhelp.LogError, Logger: "app/db", Message: "query failed",
+			Fields: map[string]interface{}{"req": 12, "table": "users", "error": errors.New("connection refused")}},
+	}
+	if got := store.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewLogr(): Incorrect entries: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+	store.AssertLogged(t, testhelp.LogError, "connection refused")
+}
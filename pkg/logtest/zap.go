@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logtest
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// NewZap returns a *zap.Logger that records every entry in store, whatever its level.  zap's Debug, Info, Warn, and
+// Error levels map onto the testhelp levels of the same names, and the levels above Error (DPanic, Panic, and Fatal)
+// map onto testhelp.LogError.  (zap still panics or exits for the Panic and Fatal levels, after recording the entry.)
+// Fields are encoded as by zapcore.MapObjectEncoder, so, e.g., zap.Error(err) becomes an "error" field holding the
+// error's message.
+func NewZap(store *testhelp.LogStore) *zap.Logger {
+	return zap.New(NewZapCore(store))
+}
+
+// NewZapCore returns the zapcore.Core behind NewZap, for combining with other cores (e.g. with zapcore.NewTee) or
+// wrapping an existing logger (e.g. with zap.WrapCore).
+func NewZapCore(store *testhelp.LogStore) zapcore.Core {
+	return &zapCore{store: store}
+}
+
+type zapCore struct {
+	store  *testhelp.LogStore
+	fields []zapcore.Field
+}
+
+func (*zapCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *zapCore) With(fields []zapcore.Field) zapcore.Core {
+	c2 := *c
+	c2.fields = append(append([]zapcore.Field{}, c.fields...), fields...)
+	return &c2
+}
+
+func (c *zapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(entry, c)
+}
+
+func (c *zapCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	entryFields := enc.Fields
+	if len(entryFields) == 0 {
+		entryFields = nil
+	}
+	c.store.Add(testhelp.LogEntry{
+		Level: zapLevel(entry.Level), Logger: entry.LoggerName, Message: entry.Message, Fields: entryFields,
+	})
+	return nil
+}
+
+func (*zapCore) Sync() error {
+	return nil
+}
+
+// zapLevel maps a zap level onto a testhelp.LogLevel.
+func zapLevel(l zapcore.Level) testhelp.LogLevel {
+	switch {
+	case l >= zapcore.ErrorLevel:
+		return testhelp.LogError
+	case l == zapcore.WarnLevel:
+		return testhelp.LogWarn
+	case l == zapcore.InfoLevel:
+		return testhelp.LogInfo
+	}
+	return testhelp.LogDebug
+}
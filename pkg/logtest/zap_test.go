@@ -0,0 +1,52 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logtest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+func TestNewZap(t *testing.T) {
+	var store testhelp.LogStore
+	logger := NewZap(&store).Named("app").With(zap.Int("req", 12))
+	logger.Debug("dbg")
+	logger.Info("handled")
+	logger.Warn("slow", zap.Duration("took", 0))
+	logger.Error("query failed", zap.Error(errors.New("connection refused")))
+	logger.DPanic("odd")
+
+	want := []testhelp.LogEntry{
+		{Level: testhelp.LogDebug, Logger: "app", Message: "dbg", Fields: map[string]interface{}{"req": int64(12)}},
+		{Level: testhelp.LogInfo, Logger: "app", Message: "handled", Fields: map[string]interface{}{"req": int64(12)}},
+		{Level: testhelp.LogWarn, Logger: "app", Message: "slow",
+			Fields: map[string]interface{}{"req": int64(12), "took": time.Duration(0)}},
+		{Level: testhelp.LogError, Logger: "app", Message: "query failed",
+			Fields: map[string]interface{}{"req": int64(12), "error": "connection refused"}},
+		{Level: testhelp.LogError, Logger: "app", Message: "odd", Fields: map[string]interface{}{"req": int64(12)}},
+	}
+	if got := store.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewZap(): Incorrect entries: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+	store.AssertLogged(t, testhelp.LogError, "connection refused")
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nethelp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Resolver is the part of *net.Resolver's method set that StubResolver implements.  Code under test that does lookups
+// through an interface like this one (declared in its own package, to avoid depending on this one) can be given a
+// *net.Resolver in production and a StubResolver in tests.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// A StubResolver answers lookups from a table of hostnames set up by the test, so that code that resolves names can be
+// tested offline and deterministically, including its handling of lookup failures.  Lookups of hosts that aren't in
+// the table fail as if the host doesn't exist.  It can also stand in for a dialer, with DialContext.
+//
+// The zero value is an empty resolver, ready to use, and a StubResolver is safe for concurrent use.  Hostnames are
+// matched case-insensitively, ignoring any trailing dot.
+type StubResolver struct {
+	mu      sync.Mutex
+	hosts   map[string]stubHost
+	lookups []string
+}
+
+type stubHost struct {
+	addrs []net.IP
+	err   error
+}
+
+// Map sets the addresses that host resolves to, replacing any earlier setting; each address must be an IPv4 or IPv6
+// address, or Map panics.  Mapping a host to no addresses makes lookups succeed with an empty result, which some code
+// treats differently from an error.  Map returns r, for chaining.
+func (r *StubResolver) Map(host string, addrs ...string) *StubResolver {
+	ips := make([]net.IP, len(addrs))
+	for i, addr := range addrs {
+		if ips[i] = net.ParseIP(addr); ips[i] == nil {
+			panic(fmt.Sprintf("Map: '%s' is not an IP address", addr))
+		}
+	}
+	r.set(host, stubHost{addrs: ips})
+	return r
+}
+
+// Fail makes lookups of host fail with err, replacing any earlier setting; NotFoundError and TimeoutError make
+// errors of the kinds that net.Resolver returns.  Fail returns r, for chaining.
+func (r *StubResolver) Fail(host string, err error) *StubResolver {
+	r.set(host, stubHost{err: err})
+	return r
+}
+
+func (r *StubResolver) set(host string, entry stubHost) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hosts == nil {
+		r.hosts = map[string]stubHost{}
+	}
+	r.hosts[normalizeHost(host)] = entry
+}
+
+// NotFoundError returns an error like the one net.Resolver returns for a host that doesn't exist (NXDOMAIN).
+func NotFoundError(host string) *net.DNSError {
+	return &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// TimeoutError returns an error like the one net.Resolver returns when the DNS server doesn't answer in time.
+func TimeoutError(host string) *net.DNSError {
+	return &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true, IsTemporary: true}
+}
+
+// Lookups returns the hosts looked up so far (including through DialContext), in order, as they were given.
+// Literal IP addresses, which don't need a lookup, aren't included.
+func (r *StubResolver) Lookups() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.lookups...)
+}
+
+// lookup does the work for the Lookup methods.
+func (r *StubResolver) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	r.mu.Lock()
+	r.lookups = append(r.lookups, host)
+	entry, ok := r.hosts[normalizeHost(host)]
+	r.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: host, IsTimeout: errors.Is(err, context.DeadlineExceeded)}
+	}
+	if !ok {
+		return nil, NotFoundError(host)
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return append([]net.IP{}, entry.addrs...), nil
+}
+
+// LookupHost returns the addresses of host, as strings, as net.Resolver.LookupHost does.
+func (r *StubResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	ips, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = ip.String()
+	}
+	return addrs, nil
+}
+
+// LookupIPAddr returns the addresses of host, as net.Resolver.LookupIPAddr does.
+func (r *StubResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]net.IPAddr, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.IPAddr{IP: ip}
+	}
+	return addrs, nil
+}
+
+// LookupIP returns the addresses of host for the given network ("ip", "ip4", or "ip6"), as net.Resolver.LookupIP
+// does; if the host has addresses, but none for the network, the error is a NotFoundError.
+func (r *StubResolver) LookupIP(ctx context.Context, network, host string) ([]net.IP, error) {
+	if network != "ip" && network != "ip4" && network != "ip6" {
+		return nil, net.UnknownNetworkError(network)
+	}
+	ips, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []net.IP
+	for _, ip := range ips {
+		is4 := ip.To4() != nil
+		if network == "ip" || (network == "ip4") == is4 {
+			filtered = append(filtered, ip)
+		}
+	}
+	if len(filtered) == 0 && len(ips) > 0 {
+		return nil, NotFoundError(host)
+	}
+	return filtered, nil
+}
+
+// DialContext resolves the host in address with the stub, and dials the first of its addresses that accepts a
+// connection, with a zero net.Dialer.  It has the signature of net.Dialer.DialContext, so it can be used as, e.g.,
+// http.Transport.DialContext, to point the hostnames used by the code under test at local test servers.
+func (r *StubResolver) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	ips, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	if len(ips) == 0 {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: NotFoundError(host)}
+	}
+	var dialer net.Dialer
+	for _, ip := range ips {
+		var conn net.Conn
+		if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port)); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// normalizeHost makes hostnames comparable, as DNS does.
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// Compile-time checks that the interface matches net.Resolver, and that StubResolver implements it
+var (
+	_ Resolver = (*net.Resolver)(nil)
+	_ Resolver = (*StubResolver)(nil)
+)
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nethelp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestStubResolverLookups(t *testing.T) {
+	timeoutErr := TimeoutError("slow.test")
+	r := new(StubResolver).
+		Map("db.test", "10.0.0.1", "fd00::1").
+		Map("v6.test", "fd00::2").
+		Map("empty.test").
+		Fail("slow.test", timeoutErr)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		network   string
+		host      string
+		expectIPs []string
+		expectErr error
+	}{
+		{name: "mapped", network: "ip", host: "db.test", expectIPs: []string{"10.0.0.1", "fd00::1"}},
+		{name: "case and dot", network: "ip", host: "DB.Test.", expectIPs: []string{"10.0.0.1", "fd00::1"}},
+		{name: "ip4", network: "ip4", host: "db.test", expectIPs: []string{"10.0.0.1"}},
+		{name: "ip6", network: "ip6", host: "db.test", expectIPs: []string{"fd00::1"}},
+		{name: "no addresses for network", network: "ip4", host: "v6.test", expectErr: NotFoundError("v6.test")},
+		{name: "empty", network: "ip", host: "empty.test", expectIPs: []string{}},
+		{name: "not mapped", network: "ip", host: "other.test", expectErr: NotFoundError("other.test")},
+		{name: "failure", network: "ip", host: "slow.test", expectErr: timeoutErr},
+		{name: "literal", network: "ip", host: "192.0.2.1", expectIPs: []string{"192.0.2.1"}},
+		{
+			name: "canceled", ctx: canceled, network: "ip", host: "db.test",
+			expectErr: &net.DNSError{Err: "context canceled", Name: "db.test"},
+		},
+		{name: "bad network", network: "tcp", host: "db.test", expectErr: net.UnknownNetworkError("tcp")},
+	}
+	for _, test := range tests {
+		ctx := test.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ips, err := r.LookupIP(ctx, test.network, test.host)
+		got := []string{}
+		for _, ip := range ips {
+			got = append(got, ip.String())
+		}
+		if test.expectErr != nil {
+			if !reflect.DeepEqual(err, test.expectErr) {
+				t.Errorf("StubResolver.LookupIP(): Incorrect error: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+					test.expectErr, err, test.name)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, test.expectIPs) {
+			t.Errorf("StubResolver.LookupIP(): Incorrect result: expected\n%#+v\ngot\n%#+v, %v\nin test '%s'",
+				test.expectIPs, got, err, test.name)
+		}
+	}
+
+	var dnsErr *net.DNSError
+	if _, err := r.LookupHost(context.Background(), "nx.test"); !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("StubResolver.LookupHost(): Expected a not-found DNSError, got %#+v", err)
+	}
+	if _, err := r.LookupIPAddr(context.Background(), "slow.test"); !errors.As(err, &dnsErr) || !dnsErr.Timeout() {
+		t.Errorf("StubResolver.LookupIPAddr(): Expected a timeout DNSError, got %#+v", err)
+	}
+	hosts, err := r.LookupHost(context.Background(), "db.test")
+	if want := []string{"10.0.0.1", "fd00::1"}; err != nil || !reflect.DeepEqual(hosts, want) {
+		t.Errorf("StubResolver.LookupHost(): Incorrect result: expected\n%#+v\ngot\n%#+v, %v", want, hosts, err)
+	}
+	addrs, err := r.LookupIPAddr(context.Background(), "v6.test")
+	if want := []net.IPAddr{{IP: net.ParseIP("fd00::2")}}; err != nil || !reflect.DeepEqual(addrs, want) {
+		t.Errorf("StubResolver.LookupIPAddr(): Incorrect result: expected\n%#+v\ngot\n%#+v, %v", want, addrs, err)
+	}
+
+	wantLookups := []string{
+		"db.test", "DB.Test.", "db.test", "db.test", "v6.test", "empty.test", "other.test", "slow.test", "db.test",
+		"nx.test", "slow.test", "db.test", "v6.test",
+	}
+	if got := r.Lookups(); !reflect.DeepEqual(got, wantLookups) {
+		t.Errorf("StubResolver.Lookups(): Incorrect result: expected\n%#+v\ngot\n%#+v", wantLookups, got)
+	}
+
+	if didPanic := func() (p bool) {
+		defer func() { p = recover() != nil }()
+		r.Map("x.test", "not-an-ip")
+		return
+	}(); !didPanic {
+		t.Errorf("StubResolver.Map(): Expected a panic for an invalid address")
+	}
+}
+
+func TestStubResolverDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Host)
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	r := new(StubResolver).Map("api.test", "127.0.0.1").Map("empty.test")
+	client := &http.Client{Transport: &http.Transport{DialContext: r.DialContext}}
+	resp, err := client.Get("http://api.test:" + port + "/")
+	if err != nil {
+		t.Fatalf("StubResolver.DialContext(): Unexpected error: %s", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "api.test:"+port {
+		t.Errorf("StubResolver.DialContext(): Incorrect Host header: got %q", body)
+	}
+
+	var dnsErr *net.DNSError
+	for _, host := range []string{"nx.test", "empty.test"} {
+		_, err := r.DialContext(context.Background(), "tcp", host+":80")
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			t.Errorf("StubResolver.DialContext(): Expected a not-found DNSError for %s, got %#+v", host, err)
+		}
+	}
+	if _, err := r.DialContext(context.Background(), "tcp", "no-port"); err == nil {
+		t.Errorf("StubResolver.DialContext(): Expected an error for an address without a port")
+	}
+}
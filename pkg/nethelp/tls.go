@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nethelp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// certValidity is how long generated certificates are valid for, starting an hour in the past to allow for clock skew
+// between the test and anything it runs.
+const certValidity = 24 * time.Hour
+
+// defaultHosts are the hosts a certificate is issued for if none are given.
+var defaultHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// A TestCA is a certificate authority generated in memory by GenerateTestCA, for issuing certificates with IssueCert.
+// Since a new CA is generated for each test run, its certificates can't expire between runs, and nothing outside the
+// test trusts them.
+type TestCA struct {
+	Cert *x509.Certificate
+	// CertPEM is the CA certificate in PEM format, for code under test that loads its roots from a file
+	CertPEM []byte
+	// Pool is a cert pool containing only the CA certificate
+	Pool *x509.CertPool
+
+	key *ecdsa.PrivateKey
+}
+
+// GenerateTestCA generates a new TestCA, calling t.Fatalf if it can't.
+func GenerateTestCA(t testhelp.TestingTB) *TestCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate a key for the test CA: %s", err)
+		return nil // for mocks whose Fatalf doesn't stop the test
+	}
+	template := certTemplate()
+	template.Subject = pkix.Name{Organization: []string{"go-testhelp"}, CommonName: "go-testhelp test CA"}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Could not create the test CA certificate: %s", err)
+		return nil // for mocks whose Fatalf doesn't stop the test
+	}
+	cert, _ := x509.ParseCertificate(der) // can't fail, since x509.CreateCertificate produced it
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &TestCA{
+		Cert:    cert,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Pool:    pool,
+		key:     key,
+	}
+}
+
+// IssueCert issues a certificate signed by ca for the given hosts, each of which is a DNS name or an IP address,
+// calling t.Fatalf if it can't.  With no hosts, the certificate is for localhost, 127.0.0.1, and ::1, which covers
+// httptest servers.  The certificate can be used by both servers and clients (for mutual TLS), and includes ca's
+// certificate in its chain.
+func IssueCert(t testhelp.TestingTB, ca *TestCA, hosts ...string) tls.Certificate {
+	t.Helper()
+	if len(hosts) == 0 {
+		hosts = defaultHosts
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate a key for a test certificate: %s", err)
+		return tls.Certificate{} // for mocks whose Fatalf doesn't stop the test
+	}
+	template := certTemplate()
+	template.Subject = pkix.Name{Organization: []string{"go-testhelp"}, CommonName: hosts[0]}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("Could not create a test certificate: %s", err)
+		return tls.Certificate{} // for mocks whose Fatalf doesn't stop the test
+	}
+	leaf, _ := x509.ParseCertificate(der) // can't fail, since x509.CreateCertificate produced it
+	return tls.Certificate{Certificate: [][]byte{der, ca.Cert.Raw}, PrivateKey: key, Leaf: leaf}
+}
+
+// ClientConfig returns a TLS config for clients that trusts only ca.
+func (ca *TestCA) ClientConfig() *tls.Config {
+	return &tls.Config{RootCAs: ca.Pool, MinVersion: tls.VersionTLS12}
+}
+
+// certTemplate returns the parts of a certificate template common to CAs and leaf certificates.
+func certTemplate() *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+	}
+}
+
+// StartTLSServer starts an httptest.Server serving handler over TLS with the given certificates, and registers a
+// cleanup function with t which closes it.  With no certificates, it uses one issued for the default hosts (see
+// IssueCert) by a new TestCA.  The server's Client method returns a client that trusts the server's certificate; for
+// other clients, use the issuing CA's ClientConfig.
+func StartTLSServer(t testhelp.TestingTB, handler http.Handler, certs ...tls.Certificate) *httptest.Server {
+	t.Helper()
+	if len(certs) == 0 {
+		certs = []tls.Certificate{IssueCert(t, GenerateTestCA(t))}
+	}
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{Certificates: certs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
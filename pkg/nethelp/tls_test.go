@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nethelp
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestIssueCert(t *testing.T) {
+	ca := GenerateTestCA(t)
+	if block, _ := pem.Decode(ca.CertPEM); block == nil || string(block.Bytes) != string(ca.Cert.Raw) {
+		t.Errorf("GenerateTestCA(): CertPEM doesn't hold the CA certificate")
+	}
+
+	tests := []struct {
+		name         string
+		hosts        []string
+		verifyHost   string
+		expectVerify bool
+	}{
+		{name: "default localhost", verifyHost: "localhost", expectVerify: true},
+		{name: "default IPv6", verifyHost: "::1", expectVerify: true},
+		{name: "named", hosts: []string{"db.test", "10.0.0.1"}, verifyHost: "db.test", expectVerify: true},
+		{name: "named IP", hosts: []string{"db.test", "10.0.0.1"}, verifyHost: "10.0.0.1", expectVerify: true},
+		{name: "wrong host", hosts: []string{"db.test"}, verifyHost: "localhost"},
+	}
+	for _, test := range tests {
+		cert := IssueCert(t, ca, test.hosts...)
+		_, err := cert.Leaf.Verify(x509.VerifyOptions{
+			DNSName:   test.verifyHost,
+			Roots:     ca.Pool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		if (err == nil) != test.expectVerify {
+			t.Errorf("IssueCert(): Incorrect verification: expected %t, got error %v in test '%s'",
+				test.expectVerify, err, test.name)
+		}
+		if !cert.Leaf.NotAfter.After(time.Now().Add(time.Hour)) || cert.Leaf.NotBefore.After(time.Now()) {
+			t.Errorf("IssueCert(): Certificate isn't currently valid: %s to %s in test '%s'", cert.Leaf.NotBefore,
+				cert.Leaf.NotAfter, test.name)
+		}
+	}
+
+	otherCA := GenerateTestCA(t)
+	if _, err := IssueCert(t, otherCA).Leaf.Verify(x509.VerifyOptions{Roots: ca.Pool}); err == nil {
+		t.Errorf("IssueCert(): Expected a certificate from another CA not to verify")
+	}
+}
+
+func TestStartTLSServer(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "secure") })
+	get := func(client *http.Client, url string) (string, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	rt := &testhelptest.RecordingT{}
+	server := StartTLSServer(rt, handler)
+	if body, err := get(server.Client(), server.URL); err != nil || body != "secure" {
+		t.Errorf("StartTLSServer(): Incorrect response with the default certificate: %q, %v", body, err)
+	}
+	rt.RunCleanups()
+	if _, err := get(server.Client(), server.URL); err == nil {
+		t.Errorf("StartTLSServer(): Expected the server to be closed at cleanup")
+	}
+
+	ca := GenerateTestCA(t)
+	server = StartTLSServer(t, handler, IssueCert(t, ca))
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: ca.ClientConfig()}}
+	if body, err := get(client, server.URL); err != nil || body != "secure" {
+		t.Errorf("StartTLSServer(): Incorrect response with the CA's client config: %q, %v", body, err)
+	}
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: GenerateTestCA(t).ClientConfig()}}
+	if _, err := get(client, server.URL); err == nil {
+		t.Errorf("StartTLSServer(): Expected a client trusting another CA to fail")
+	}
+	if len(rt.Failures()) != 0 {
+		t.Errorf("StartTLSServer(): Unexpected failures:\n%#+v", rt.Failures())
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package normtest provides NormalizeForMatch, a normalizer for checking messages that include user data, for use
+// with testhelp's normalizing checks (such as PanicsStrNorm and ContainsNorm), plus shortcuts for those checks.
+// It's a separate module so that the main testhelp package doesn't depend on golang.org/x/text.
+package normtest
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// NormalizeForMatch returns s with case folded, converted to Unicode NFC, and with each run of whitespace collapsed
+// to a single space (trimming leading and trailing whitespace), so that messages that differ only in those ways
+// compare as equal.  For example, "Café" written with a precomposed é and "CAFÉ" (an E followed by a combining
+// accent) both normalize to "café".
+//
+// Case folding is done before converting to NFC, since folding can produce decomposed sequences, and whitespace is
+// as defined by unicode.IsSpace.
+func NormalizeForMatch(s string) string {
+	s = norm.NFC.String(cases.Fold().String(s))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// PanicsStr is like testhelp.PanicsStr, but compares the panic string and wantStr after passing them through
+// NormalizeForMatch.
+func PanicsStr(f func(), wantStr string) (didPanic bool, pContainsStr bool, pVal interface{}) {
+	return testhelp.PanicsStrNorm(f, wantStr, NormalizeForMatch)
+}
+
+// Contains returns a testhelp.PanicMatcher like testhelp.Contains, but that compares the panic string and wantStr
+// after passing them through NormalizeForMatch.
+func Contains(wantStr string) testhelp.PanicMatcher {
+	return testhelp.ContainsNorm(wantStr, NormalizeForMatch)
+}
+
+// ErrorContains returns a testhelp.Matcher for errors like Contains, matching non-nil errors whose messages contain
+// substr after both are passed through NormalizeForMatch.
+func ErrorContains(substr string) testhelp.Matcher[error] {
+	return testhelp.MatcherFor[error](Contains(substr))
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package normtest
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeForMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "abc", "abc"},
+		{"case", "Hello World", "hello world"},
+		{"precomposed", "Caf\u00e9", "caf\u00e9"},
+		{"decomposed", "CAFE\u0301", "caf\u00e9"},
+		{"fold beyond lower", "Straße", "strasse"},
+		{"whitespace", "  a \t\n b  c  ", "a b c"},
+		{"empty", "", ""},
+	}
+	for _, test := range tests {
+		if got := NormalizeForMatch(test.input); got != test.want {
+			t.Errorf("NormalizeForMatch(): Incorrect result: expected %q, got %q in test '%s'", test.want, got,
+				test.name)
+		}
+	}
+}
+
+func TestShortcuts(t *testing.T) {
+	msg := "Unknown user \"José\":\n  no such   account"
+	didPanic, pContainsStr, pVal := PanicsStr(func() { panic(msg) }, "unknown user \"jose\u0301\": no such account")
+	if !didPanic || !pContainsStr || pVal != msg {
+		t.Errorf("PanicsStr(): Incorrect results: expected true, true, %q, got %t, %t, %#+v", msg, didPanic,
+			pContainsStr, pVal)
+	}
+	if _, pContainsStr, _ = PanicsStr(func() { panic(msg) }, "jose"); pContainsStr {
+		t.Errorf("PanicsStr(): Expected a different letter not to match")
+	}
+
+	if m := Contains("JOSÉ"); !m.Match(errors.New(msg)) {
+		t.Errorf("Contains(): Expected a match for %q", msg)
+	} else if got, want := m.Describe(), "contains \"JOSÉ\" (after normalization)"; got != want {
+		t.Errorf("Contains(): Incorrect description: expected\n%s\ngot\n%s", want, got)
+	}
+
+	if m := ErrorContains("NO SUCH ACCOUNT"); !m.Match(errors.New(msg)) {
+		t.Errorf("ErrorContains(): Expected a match for %q", msg)
+	} else if m.Match(nil) {
+		t.Errorf("ErrorContains(): Expected no match for a nil error")
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/ocsw/go-testhelp/pkg/oteltest
+
+go 1.19
+
+replace github.com/ocsw/go-testhelp => ../..
+
+require (
+	github.com/ocsw/go-testhelp v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.16.0
+	go.opentelemetry.io/otel/sdk v1.16.0
+	go.opentelemetry.io/otel/trace v1.16.0
+)
+
+require (
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.16.0 // indirect
+	golang.org/x/sys v0.8.0 // indirect
+)
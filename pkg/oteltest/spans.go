@@ -0,0 +1,170 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package oteltest provides an OpenTelemetry span exporter that records spans in memory, with assertions on them, so
+// that tracing-instrumented code can be checked in unit tests.  It's a separate module so that the main testhelp
+// package doesn't depend on the OpenTelemetry SDK.
+package oteltest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// A SpanRecorder is a sdktrace.SpanExporter that records the spans exported to it.  To see each span as soon as it
+// ends, register it with sdktrace.WithSyncer rather than with a batching processor:
+//
+//	rec := &oteltest.SpanRecorder{}
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(rec))
+//	mypkg.Process(ctx, tp.Tracer("test"))
+//	rec.SpanStatusIs(t, "Process", codes.Ok)
+//
+// The zero value is an empty recorder, ready to use, and a SpanRecorder is safe for concurrent use.  The assertion
+// methods only see spans that have ended (and been exported).
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+// ExportSpans records spans.  It's part of the sdktrace.SpanExporter interface.
+func (r *SpanRecorder) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+// Shutdown does nothing; the recorded spans remain available.  It's part of the sdktrace.SpanExporter interface.
+func (*SpanRecorder) Shutdown(context.Context) error {
+	return nil
+}
+
+// Spans returns the spans recorded so far, in the order in which they were exported.
+func (r *SpanRecorder) Spans() []sdktrace.ReadOnlySpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]sdktrace.ReadOnlySpan{}, r.spans...)
+}
+
+// Reset discards all of the recorded spans.
+func (r *SpanRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = nil
+}
+
+// Named returns the recorded spans with the given name.
+func (r *SpanRecorder) Named(name string) []sdktrace.ReadOnlySpan {
+	var found []sdktrace.ReadOnlySpan
+	for _, s := range r.Spans() {
+		if s.Name() == name {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// SpanExists calls t.Errorf, listing the names of the recorded spans, if no span with the given name was recorded.
+// It returns true if one was.
+func (r *SpanRecorder) SpanExists(t testhelp.TestingTB, name string) bool {
+	t.Helper()
+	if len(r.Named(name)) > 0 {
+		return true
+	}
+	t.Errorf("Expected a span named '%s', got spans: %s", name, r.spanNames())
+	return false
+}
+
+// SpanHasAttr calls t.Errorf if no recorded span with the given name has an attribute with the given key and value.
+// It returns true if one does.  The value is compared (with reflect.DeepEqual) with the attribute's value as returned
+// by attribute.Value.AsInterface, so, e.g., integer attributes must be compared with int64 values, and
+// attribute.StringSlice attributes with []string values.
+func (r *SpanRecorder) SpanHasAttr(t testhelp.TestingTB, name string, key string, value interface{}) bool {
+	t.Helper()
+	spans := r.Named(name)
+	if len(spans) == 0 {
+		t.Errorf("Expected a span named '%s', got spans: %s", name, r.spanNames())
+		return false
+	}
+	var got []string
+	for _, s := range spans {
+		for _, kv := range s.Attributes() {
+			if string(kv.Key) != key {
+				continue
+			}
+			if reflect.DeepEqual(kv.Value.AsInterface(), value) {
+				return true
+			}
+			got = append(got, fmt.Sprintf("%#+v (%T)", kv.Value.AsInterface(), kv.Value.AsInterface()))
+		}
+	}
+	if len(got) == 0 {
+		t.Errorf("Expected span '%s' to have attribute '%s', but it has no such attribute", name, key)
+		return false
+	}
+	t.Errorf("Incorrect value for attribute '%s' of span '%s': expected\n%#+v (%T)\ngot\n%s", key, name, value,
+		value, strings.Join(got, "\n"))
+	return false
+}
+
+// SpanStatusIs calls t.Errorf if no recorded span with the given name has the given status code.  It returns true if
+// one does.
+//
+// This is the way to check that instrumented code marks its span as failed when the traced function panics: run the
+// code with testhelp.Panics (or one of its relatives) and then check for codes.Error.  (The SDK's span.End records a
+// panic passing through it as an "exception" event, but it doesn't set the span's status; the instrumentation has to
+// do that itself.)
+func (r *SpanRecorder) SpanStatusIs(t testhelp.TestingTB, name string, code codes.Code) bool {
+	t.Helper()
+	spans := r.Named(name)
+	if len(spans) == 0 {
+		t.Errorf("Expected a span named '%s', got spans: %s", name, r.spanNames())
+		return false
+	}
+	var got []string
+	for _, s := range spans {
+		if s.Status().Code == code {
+			return true
+		}
+		got = append(got, fmt.Sprintf("%s (%q)", s.Status().Code, s.Status().Description))
+	}
+	t.Errorf("Incorrect status for span '%s': expected %s, got %s", name, code, strings.Join(got, ", "))
+	return false
+}
+
+// spanNames lists the names of the recorded spans for failure messages.
+func (r *SpanRecorder) spanNames() string {
+	spans := r.Spans()
+	if len(spans) == 0 {
+		return "(none)"
+	}
+	names := make([]string, len(spans))
+	for i, s := range spans {
+		names[i] = "'" + s.Name() + "'"
+	}
+	return strings.Join(names, ", ")
+}
+
+// Compile-time check that SpanRecorder is an exporter
+var _ sdktrace.SpanExporter = (*SpanRecorder)(nil)
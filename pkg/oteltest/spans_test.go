@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oteltest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// process is instrumented code of the usual shape, which marks its span as failed if it panics.
+func process(tracer trace.Tracer, name string, fail bool) {
+	_, span := tracer.Start(context.Background(), name, trace.WithAttributes(attribute.Int("items", 3),
+		attribute.StringSlice("tags", []string{"a", "b"})))
+	defer span.End()
+	defer func() {
+		if pVal := recover(); pVal != nil {
+			span.SetStatus(codes.Error, fmt.Sprint(pVal))
+			panic(pVal)
+		}
+	}()
+	if fail {
+		panic("bad item")
+	}
+	span.SetStatus(codes.Ok, "")
+}
+
+func TestSpanRecorder(t *testing.T) {
+	rec := &SpanRecorder{}
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(rec)).Tracer("test")
+	process(tracer, "process", false)
+	if !testhelp.Panics(func() { process(tracer, "failing", true) }) {
+		t.Fatalf("process(): Expected a panic")
+	}
+
+	tests := []struct {
+		name         string
+		check        func(rt testhelp.TestingTB) bool
+		expectResult bool
+		expectErrors []string
+	}{
+		{
+			name: "exists", expectResult: true,
+			check: func(rt testhelp.TestingTB) bool { return rec.SpanExists(rt, "process") },
+		},
+		{
+			name:         "doesn't exist",
+			check:        func(rt testhelp.TestingTB) bool { return rec.SpanExists(rt, "other") },
+			expectErrors: []string{"Expected a span named 'other', got spans: 'process', 'failing'"},
+		},
+		{
+			name: "has attr", expectResult: true,
+			check: func(rt testhelp.TestingTB) bool { return rec.SpanHasAttr(rt, "process", "items", int64(3)) },
+		},
+		{
+			name:         "wrong attr value",
+			check:        func(rt testhelp.TestingTB) bool { return rec.SpanHasAttr(rt, "process", "items", 3) },
+			expectErrors: []string{"Incorrect value for attribute 'items' of span 'process': expected\n3 (int)\ngot\n3 (int64)"},
+		},
+		{
+			name: "has slice attr", expectResult: true,
+			check: func(rt testhelp.TestingTB) bool {
+				return rec.SpanHasAttr(rt, "process", "tags", []string{"a", "b"})
+			},
+		},
+		{
+			name: "wrong slice attr value",
+			check: func(rt testhelp.TestingTB) bool {
+				return rec.SpanHasAttr(rt, "process", "tags", []string{"a"})
+			},
+			expectErrors: []string{"Incorrect value for attribute 'tags' of span 'process': expected\n" +
+				"[]string{\"a\"} ([]string)\ngot\n[]string{\"a\", \"b\"} ([]string)"},
+		},
+		{
+			name:  "missing attr",
+			check: func(rt testhelp.TestingTB) bool { return rec.SpanHasAttr(rt, "process", "user", "x") },
+			expectErrors: []string{
+				"Expected span 'process' to have attribute 'user', but it has no such attribute",
+			},
+		},
+		{
+			name: "status", expectResult: true,
+			check: func(rt testhelp.TestingTB) bool { return rec.SpanStatusIs(rt, "process", codes.Ok) },
+		},
+		{
+			name:         "wrong status",
+			check:        func(rt testhelp.TestingTB) bool { return rec.SpanStatusIs(rt, "process", codes.Error) },
+			expectErrors: []string{"Incorrect status for span 'process': expected Error, got Ok (\"\")"},
+		},
+		{
+			name: "status after panic", expectResult: true,
+			check: func(rt testhelp.TestingTB) bool { return rec.SpanStatusIs(rt, "failing", codes.Error) },
+		},
+		{
+			name:         "wrong status after panic",
+			check:        func(rt testhelp.TestingTB) bool { return rec.SpanStatusIs(rt, "failing", codes.Ok) },
+			expectErrors: []string{"Incorrect status for span 'failing': expected Ok, got Error (\"bad item\")"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if got := test.check(rt); got != test.expectResult {
+			t.Errorf("SpanRecorder: Incorrect result: expected %t, got %t in test '%s'", test.expectResult, got,
+				test.name)
+		}
+		if !reflect.DeepEqual(rt.Failures(), append([]string{}, test.expectErrors...)) {
+			t.Errorf("SpanRecorder: Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expectErrors,
+				rt.Failures(), test.name)
+		}
+	}
+
+	rec.Reset()
+	rt := &testhelptest.RecordingT{}
+	rec.SpanExists(rt, "process")
+	want := []string{"Expected a span named 'process', got spans: (none)"}
+	if !reflect.DeepEqual(rt.Failures(), want) {
+		t.Errorf("SpanRecorder.Reset(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, rt.Failures())
+	}
+}
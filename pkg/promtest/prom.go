@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package promtest provides assertions on Prometheus metrics, for checking the metrics side effects of instrumented
+// code without scraping and parsing the text exposition format.  It's a separate module so that the main testhelp
+// package doesn't depend on the Prometheus client library.
+package promtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// MetricValue gathers the metrics from g and returns the current value of the series of the metric called name that
+// has the given labels, calling t.Fatalf if they can't be gathered, if there is no such series, or if more than one
+// series matches.  A series matches if it has all of the given labels with the given values; it may have other labels
+// as well, so a nil or empty labels map matches a metric without labels (or the only series of a metric with them).
+//
+// For counters, gauges, and untyped metrics, the value is the metric's own value; for histograms and summaries, it's
+// the number of observations.
+func MetricValue(t testhelp.TestingTB, g prometheus.Gatherer, name string, labels map[string]string) float64 {
+	t.Helper()
+	val, found, err := metricValue(g, name, labels)
+	if err != nil {
+		t.Fatalf("Metric lookup failed: %s", err)
+		return 0 // for mocks whose Fatalf doesn't stop the test
+	}
+	if !found {
+		t.Fatalf("No series of metric '%s' has labels %s", name, formatLabels(labels))
+		return 0 // for mocks whose Fatalf doesn't stop the test
+	}
+	return val
+}
+
+// AssertCounterDelta gathers the metrics from g before and after calling f, and calls t.Errorf if the value of the
+// series of the metric called name that has the given labels didn't change by want.  It returns true if it did.  The
+// series is matched as it is by MetricValue, except that a missing series counts as 0, since the series of a vector
+// (such as a prometheus.CounterVec) usually don't exist until they're first used.  Failing to gather the metrics or
+// finding more than one matching series calls t.Fatalf.
+//
+// Despite the name, this works with any of the metric types that MetricValue supports (e.g. for a gauge that should
+// go up by a known amount, or a histogram that should get one more observation).
+//
+// The change doesn't have to be exact: since it's the difference of two floating-point values, which may each have
+// accumulated rounding errors (e.g. from adding 0.1 to a counter several times), it only has to be within a relative
+// tolerance of 1e-9 (of the largest of want, before, and after) of want.
+func AssertCounterDelta(t testhelp.TestingTB, g prometheus.Gatherer, name string, labels map[string]string,
+	want float64, f func()) bool {
+	t.Helper()
+	before, _, err := metricValue(g, name, labels)
+	if err != nil {
+		t.Fatalf("Metric lookup failed: %s", err)
+		return false // for mocks whose Fatalf doesn't stop the test
+	}
+	f()
+	after, _, err := metricValue(g, name, labels)
+	if err != nil {
+		t.Fatalf("Metric lookup failed: %s", err)
+		return false // for mocks whose Fatalf doesn't stop the test
+	}
+	if got := after - before; !deltaEqual(got, want, before, after) {
+		t.Errorf("Incorrect change in metric '%s' with labels %s: expected %g, got %g (from %g to %g)", name,
+			formatLabels(labels), want, got, before, after)
+		return false
+	}
+	return true
+}
+
+// deltaTolerance is the relative tolerance for AssertCounterDelta.
+const deltaTolerance = 1e-9
+
+// deltaEqual returns true if the change got is within deltaTolerance of want, relative to the largest of want and the
+// values that got was computed from.
+func deltaEqual(got, want, before, after float64) bool {
+	scale := math.Max(math.Max(math.Abs(want), math.Abs(before)), math.Abs(after))
+	return math.Abs(got-want) <= deltaTolerance*scale
+}
+
+// metricValue does the work for MetricValue and AssertCounterDelta.  Not finding the series isn't an error; found
+// is false instead.
+func metricValue(g prometheus.Gatherer, name string, labels map[string]string) (val float64, found bool, err error) {
+	families, err := g.Gather()
+	if err != nil {
+		return 0, false, fmt.Errorf("could not gather metrics: %w", err)
+	}
+	var matches []*dto.Metric
+	var mType dto.MetricType
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		mType = family.GetType()
+		for _, m := range family.GetMetric() {
+			if hasLabels(m, labels) {
+				matches = append(matches, m)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return 0, false, nil
+	case 1:
+	default:
+		return 0, false, fmt.Errorf("%d series of metric '%s' have labels %s; more labels are needed to choose one",
+			len(matches), name, formatLabels(labels))
+	}
+
+	m := matches[0]
+	switch mType {
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue(), true, nil
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue(), true, nil
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		return float64(m.GetHistogram().GetSampleCount()), true, nil
+	case dto.MetricType_SUMMARY:
+		return float64(m.GetSummary().GetSampleCount()), true, nil
+	}
+	return m.GetUntyped().GetValue(), true, nil
+}
+
+// hasLabels returns true if m has all of the given labels with the given values.
+func hasLabels(m *dto.Metric, labels map[string]string) bool {
+	have := map[string]string{}
+	for _, lp := range m.GetLabel() {
+		have[lp.GetName()] = lp.GetValue()
+	}
+	for k, v := range labels {
+		if hv, ok := have[k]; !ok || hv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// formatLabels formats labels as in the Prometheus exposition format, e.g. {code="200",method="GET"}, sorted by name.
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, k := range names {
+		pairs[i] = fmt.Sprintf("%s=%q", k, labels[k])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
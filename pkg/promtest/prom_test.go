@@ -0,0 +1,147 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promtest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type failingGatherer struct{}
+
+func (failingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	return nil, errors.New("boom")
+}
+
+func newTestRegistry() (*prometheus.Registry, *prometheus.CounterVec, prometheus.Gauge, prometheus.Histogram) {
+	reg := prometheus.NewRegistry()
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests_total"}, []string{"method", "code"})
+	inFlight := prometheus.NewGauge(prometheus.GaugeOpts{Name: "in_flight"})
+	latency := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "latency_seconds"})
+	reg.MustRegister(requests, inFlight, latency)
+	return reg, requests, inFlight, latency
+}
+
+func TestMetricValue(t *testing.T) {
+	reg, requests, inFlight, latency := newTestRegistry()
+	requests.WithLabelValues("GET", "200").Add(3)
+	requests.WithLabelValues("GET", "500").Inc()
+	inFlight.Set(2.5)
+	latency.Observe(0.1)
+	latency.Observe(0.2)
+
+	tests := []struct {
+		name         string
+		gatherer     prometheus.Gatherer
+		metric       string
+		labels       map[string]string
+		expected     float64
+		expectFatals []string
+	}{
+		{name: "counter", gatherer: reg, metric: "requests_total",
+			labels: map[string]string{"method": "GET", "code": "500"}, expected: 1},
+		{name: "gauge", gatherer: reg, metric: "in_flight", expected: 2.5},
+		{name: "histogram", gatherer: reg, metric: "latency_seconds", expected: 2},
+		{
+			name: "ambiguous", gatherer: reg, metric: "requests_total", labels: map[string]string{"method": "GET"},
+			expectFatals: []string{
+				"Metric lookup failed: 2 series of metric 'requests_total' have labels {method=\"GET\"}; more labels " +
+					"are needed to choose one",
+			},
+		},
+		{
+			name: "missing", gatherer: reg, metric: "requests_total", labels: map[string]string{"method": "PUT"},
+			expectFatals: []string{"No series of metric 'requests_total' has labels {method=\"PUT\"}"},
+		},
+		{
+			name: "gather error", gatherer: failingGatherer{}, metric: "x",
+			expectFatals: []string{"Metric lookup failed: could not gather metrics: boom"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		got := MetricValue(rt, test.gatherer, test.metric, test.labels)
+		if got != test.expected {
+			t.Errorf("MetricValue(): Incorrect value: expected %g, got %g in test '%s'", test.expected, got,
+				test.name)
+		}
+		if !reflect.DeepEqual(rt.Fatals, test.expectFatals) || len(rt.Errors) != 0 {
+			t.Errorf("MetricValue(): Incorrect failures: expected\n%#+v\ngot\n%#+v\n%#+v\nin test '%s'",
+				test.expectFatals, rt.Fatals, rt.Errors, test.name)
+		}
+	}
+}
+
+func TestAssertCounterDelta(t *testing.T) {
+	reg, requests, _, _ := newTestRegistry()
+	labels := map[string]string{"method": "POST", "code": "201"}
+	tests := []struct {
+		name         string
+		want         float64
+		f            func()
+		expectResult bool
+		expectErrors []string
+	}{
+		{
+			name: "series created by f", want: 1, expectResult: true,
+			f: func() { requests.WithLabelValues("POST", "201").Inc() },
+		},
+		{
+			name: "wrong delta", want: 1,
+			f: func() { requests.WithLabelValues("POST", "201").Add(2) },
+			expectErrors: []string{"Incorrect change in metric 'requests_total' with labels " +
+				"{code=\"201\",method=\"POST\"}: expected 1, got 2 (from 1 to 3)"},
+		},
+		{
+			name: "rounding", want: 0.3, expectResult: true,
+			f: func() {
+				for i := 0; i < 3; i++ {
+					requests.WithLabelValues("POST", "201").Add(0.1)
+				}
+			},
+		},
+		{
+			name: "other series", want: 0, expectResult: true,
+			f: func() { requests.WithLabelValues("POST", "400").Inc() },
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if got := AssertCounterDelta(rt, reg, "requests_total", labels, test.want, test.f); got != test.expectResult {
+			t.Errorf("AssertCounterDelta(): Incorrect result: expected %t, got %t in test '%s'", test.expectResult,
+				got, test.name)
+		}
+		if !reflect.DeepEqual(rt.Errors, test.expectErrors) || len(rt.Fatals) != 0 {
+			t.Errorf("AssertCounterDelta(): Incorrect failures: expected\n%#+v\ngot\n%#+v\n%#+v\nin test '%s'",
+				test.expectErrors, rt.Errors, rt.Fatals, test.name)
+		}
+	}
+
+	rt := &testhelptest.RecordingT{}
+	called := false
+	AssertCounterDelta(rt, failingGatherer{}, "x", nil, 1, func() { called = true })
+	wantFatals := []string{"Metric lookup failed: could not gather metrics: boom"}
+	if called || !reflect.DeepEqual(rt.Fatals, wantFatals) {
+		t.Errorf("AssertCounterDelta(): Expected a fatal error without calling f; got %t, %#+v", called, rt.Fatals)
+	}
+}
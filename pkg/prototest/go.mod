@@ -0,0 +1,10 @@
+module github.com/ocsw/go-testhelp/pkg/prototest
+
+go 1.19
+
+replace github.com/ocsw/go-testhelp => ../..
+
+require (
+	github.com/ocsw/go-testhelp v0.0.0-00010101000000-000000000000
+	google.golang.org/protobuf v1.31.0
+)
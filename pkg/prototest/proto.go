@@ -0,0 +1,274 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prototest provides an equality assertion for protobuf messages that compares them the way the protobuf
+// library does (rather than with reflect.DeepEqual, which sees internal state) and reports differences by field path.
+// It's a separate module so that the main testhelp package doesn't depend on the protobuf library.
+package prototest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// ProtoOptions holds options for ProtoEqual.
+type ProtoOptions struct {
+	// If IgnoreUnknown is true, unknown fields (ones that were in the wire data but aren't in the message's
+	// definition) are ignored.
+	IgnoreUnknown bool
+}
+
+// ProtoEqual checks that want and got are equal according to proto.Equal, calling t.Errorf with the path and values
+// of each differing field (e.g. "items[2].name: expected \"a\", got \"b\"") if not.  Unknown fields are compared as
+// raw bytes.  It returns true if the messages are equal.
+func ProtoEqual(t testhelp.TestingTB, want, got proto.Message) bool {
+	t.Helper()
+	return new(ProtoOptions).ProtoEqual(t, want, got)
+}
+
+// ProtoEqual is like the package-level ProtoEqual, but with the options in o.
+func (o *ProtoOptions) ProtoEqual(t testhelp.TestingTB, want, got proto.Message) bool {
+	t.Helper()
+	wantValid := want != nil && want.ProtoReflect().IsValid()
+	gotValid := got != nil && got.ProtoReflect().IsValid()
+	switch {
+	case !wantValid && !gotValid:
+		return true
+	case !wantValid:
+		t.Errorf("Protobuf messages differ: expected a nil message, got a %s", got.ProtoReflect().Descriptor().FullName())
+		return false
+	case !gotValid:
+		t.Errorf("Protobuf messages differ: expected a %s, got a nil message", want.ProtoReflect().Descriptor().FullName())
+		return false
+	}
+
+	if o.IgnoreUnknown {
+		want, got = proto.Clone(want), proto.Clone(got)
+		clearUnknown(want.ProtoReflect())
+		clearUnknown(got.ProtoReflect())
+	}
+	if proto.Equal(want, got) {
+		return true
+	}
+
+	wantName := want.ProtoReflect().Descriptor().FullName()
+	gotName := got.ProtoReflect().Descriptor().FullName()
+	if wantName != gotName {
+		t.Errorf("Protobuf messages differ: expected a %s, got a %s", wantName, gotName)
+		return false
+	}
+	var diffs []string
+	diffMessage("", want.ProtoReflect(), got.ProtoReflect(), &diffs)
+	if len(diffs) == 0 { // shouldn't happen, but the failure still needs to say something
+		diffs = append(diffs, "(no differing fields found)")
+	}
+	t.Errorf("Protobuf messages differ:\n%s", strings.Join(diffs, "\n"))
+	return false
+}
+
+// clearUnknown removes the unknown fields from m and all of the messages it contains.
+func clearUnknown(m protoreflect.Message) {
+	m.SetUnknown(nil)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i, l := 0, v.List(); i < l.Len(); i++ {
+				clearUnknown(l.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				clearUnknown(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			clearUnknown(v.Message())
+		}
+		return true
+	})
+}
+
+// diffMessage appends a description of each difference between two messages of the same type to diffs, with field
+// paths starting with prefix.
+func diffMessage(prefix string, want, got protoreflect.Message, diffs *[]string) {
+	// Known fields in declaration order, followed by any extensions in name order
+	var fields []protoreflect.FieldDescriptor
+	fds := want.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fields = append(fields, fds.Get(i))
+	}
+	extensions := map[protoreflect.FullName]protoreflect.FieldDescriptor{}
+	for _, m := range []protoreflect.Message{want, got} {
+		m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if fd.IsExtension() {
+				extensions[fd.FullName()] = fd
+			}
+			return true
+		})
+	}
+	extNames := make([]string, 0, len(extensions))
+	for name := range extensions {
+		extNames = append(extNames, string(name))
+	}
+	sort.Strings(extNames)
+	for _, name := range extNames {
+		fields = append(fields, extensions[protoreflect.FullName(name)])
+	}
+
+	for _, fd := range fields {
+		path := fieldPath(prefix, fd)
+		wantHas, gotHas := want.Has(fd), got.Has(fd)
+		switch {
+		case !wantHas && !gotHas:
+		case fd.IsList():
+			diffList(path, fd, want.Get(fd).List(), got.Get(fd).List(), diffs)
+		case fd.IsMap():
+			diffMap(path, fd, want.Get(fd).Map(), got.Get(fd).Map(), diffs)
+		case !gotHas:
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got (unset)", path, formatValue(fd, want.Get(fd))))
+		case !wantHas:
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected (unset), got %s", path, formatValue(fd, got.Get(fd))))
+		default:
+			diffValue(path, fd, want.Get(fd), got.Get(fd), diffs)
+		}
+	}
+
+	if !bytes.Equal(want.GetUnknown(), got.GetUnknown()) {
+		path := prefix
+		if path == "" {
+			path = "(top level)"
+		}
+		*diffs = append(*diffs, fmt.Sprintf("%s: unknown fields differ: expected %x, got %x", path, want.GetUnknown(),
+			got.GetUnknown()))
+	}
+}
+
+// diffList appends the differences between two lists to diffs, index by index.
+func diffList(path string, fd protoreflect.FieldDescriptor, want, got protoreflect.List, diffs *[]string) {
+	if want.Len() != got.Len() {
+		*diffs = append(*diffs, fmt.Sprintf("%s: expected %d element(s), got %d", path, want.Len(), got.Len()))
+	}
+	for i := 0; i < want.Len() || i < got.Len(); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= got.Len():
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got (missing)", elemPath, formatValue(fd, want.Get(i))))
+		case i >= want.Len():
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected (missing), got %s", elemPath, formatValue(fd, got.Get(i))))
+		default:
+			diffValue(elemPath, fd, want.Get(i), got.Get(i), diffs)
+		}
+	}
+}
+
+// diffMap appends the differences between two maps to diffs, key by key in sorted order.
+func diffMap(path string, fd protoreflect.FieldDescriptor, want, got protoreflect.Map, diffs *[]string) {
+	keys := map[string]protoreflect.MapKey{}
+	for _, m := range []protoreflect.Map{want, got} {
+		m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys[formatScalar(fd.MapKey(), k.Value())] = k
+			return true
+		})
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	valFD := fd.MapValue()
+	for _, name := range names {
+		k := keys[name]
+		elemPath := fmt.Sprintf("%s[%s]", path, name)
+		switch {
+		case !got.Has(k):
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got (missing)", elemPath, formatValue(valFD, want.Get(k))))
+		case !want.Has(k):
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected (missing), got %s", elemPath, formatValue(valFD, got.Get(k))))
+		default:
+			diffValue(elemPath, valFD, want.Get(k), got.Get(k), diffs)
+		}
+	}
+}
+
+// diffValue appends the differences between two singular values (or list or map elements) to diffs.
+func diffValue(path string, fd protoreflect.FieldDescriptor, want, got protoreflect.Value, diffs *[]string) {
+	if fd.Message() != nil {
+		diffMessage(path, want.Message(), got.Message(), diffs)
+		return
+	}
+	if !scalarsEqual(fd, want, got) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, formatScalar(fd, want),
+			formatScalar(fd, got)))
+	}
+}
+
+// scalarsEqual compares two non-message values, treating NaNs as equal to each other, as proto.Equal does.
+func scalarsEqual(fd protoreflect.FieldDescriptor, want, got protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.BytesKind:
+		return bytes.Equal(want.Bytes(), got.Bytes())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		w, g := want.Float(), got.Float()
+		return w == g || (math.IsNaN(w) && math.IsNaN(g))
+	default:
+		return want.Interface() == got.Interface()
+	}
+}
+
+// fieldPath returns the path of a field within the message at prefix.
+func fieldPath(prefix string, fd protoreflect.FieldDescriptor) string {
+	name := string(fd.Name())
+	if fd.IsExtension() {
+		name = "(" + string(fd.FullName()) + ")"
+	}
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// formatValue formats a value that is only on one side; messages are described by type, since their text format is
+// deliberately unstable.
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.Message() != nil {
+		return fmt.Sprintf("a %s", fd.Message().FullName())
+	}
+	return formatScalar(fd, v)
+}
+
+// formatScalar formats a non-message value, using names for enum values.
+func formatScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("%q", v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprintf("%d", v.Enum())
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+}
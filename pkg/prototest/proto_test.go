@@ -0,0 +1,185 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prototest
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func testFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("widget.proto"),
+		Package:    proto.String("widget"),
+		Dependency: []string{"a.proto", "b.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Widget"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:   proto.String("id"),
+						Number: proto.Int32(1),
+						Type:   descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func withUnknown(m proto.Message, num protowire.Number, v uint64) proto.Message {
+	b := protowire.AppendTag(nil, num, protowire.VarintType)
+	m.ProtoReflect().SetUnknown(protowire.AppendVarint(b, v))
+	return m
+}
+
+func TestProtoEqual(t *testing.T) {
+	tests := []struct {
+		name          string
+		want, got     proto.Message
+		ignoreUnknown bool
+		expected      []string
+	}{
+		{name: "same", want: testFile(), got: testFile(), expected: []string{}},
+		{name: "nil", want: nil, got: (*descriptorpb.FileDescriptorProto)(nil), expected: []string{}},
+		{
+			name: "nil want", want: nil, got: testFile(),
+			expected: []string{
+				"Protobuf messages differ: expected a nil message, got a google.protobuf.FileDescriptorProto",
+			},
+		},
+		{
+			name: "nil got", want: testFile(), got: nil,
+			expected: []string{
+				"Protobuf messages differ: expected a google.protobuf.FileDescriptorProto, got a nil message",
+			},
+		},
+		{
+			name: "types", want: testFile(), got: &descriptorpb.DescriptorProto{},
+			expected: []string{
+				"Protobuf messages differ: expected a google.protobuf.FileDescriptorProto, got a " +
+					"google.protobuf.DescriptorProto",
+			},
+		},
+		{
+			name: "fields",
+			want: testFile(),
+			got: func() proto.Message {
+				f := testFile()
+				f.Package = nil
+				f.Syntax = proto.String("proto3")
+				f.Dependency = []string{"a.proto", "c.proto", "d.proto"}
+				f.MessageType[0].Field[0].Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+				f.MessageType[0].Field[0].JsonName = proto.String("id")
+				f.Options = &descriptorpb.FileOptions{}
+				return f
+			}(),
+			expected: []string{
+				"Protobuf messages differ:\n" +
+					"package: expected \"widget\", got (unset)\n" +
+					"dependency: expected 2 element(s), got 3\n" +
+					"dependency[1]: expected \"b.proto\", got \"c.proto\"\n" +
+					"dependency[2]: expected (missing), got \"d.proto\"\n" +
+					"message_type[0].field[0].type: expected TYPE_INT64, got TYPE_STRING\n" +
+					"message_type[0].field[0].json_name: expected (unset), got \"id\"\n" +
+					"options: expected (unset), got a google.protobuf.FileOptions\n" +
+					"syntax: expected (unset), got \"proto3\"",
+			},
+		},
+		{
+			name: "map",
+			want: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"a": structpb.NewNumberValue(1), "b": structpb.NewStringValue("x"), "c": structpb.NewBoolValue(true),
+			}},
+			got: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"a": structpb.NewNumberValue(2), "b": structpb.NewStringValue("x"), "d": structpb.NewNullValue(),
+			}},
+			expected: []string{
+				"Protobuf messages differ:\n" +
+					"fields[\"a\"].number_value: expected 1, got 2\n" +
+					"fields[\"c\"]: expected a google.protobuf.Value, got (missing)\n" +
+					"fields[\"d\"]: expected (missing), got a google.protobuf.Value",
+			},
+		},
+		{
+			name: "bytes", want: wrapperspb.Bytes([]byte("a\x00")), got: wrapperspb.Bytes([]byte("a\x01")),
+			expected: []string{"Protobuf messages differ:\nvalue: expected \"a\\x00\", got \"a\\x01\""},
+		},
+		{
+			name: "NaN", want: wrapperspb.Double(math.NaN()), got: wrapperspb.Double(math.NaN()),
+			expected: []string{},
+		},
+		{
+			name: "unknown", want: testFile(), got: withUnknown(testFile(), 99, 1),
+			expected: []string{"Protobuf messages differ:\n(top level): unknown fields differ: expected , got 980601"},
+		},
+		{
+			name: "nested unknown",
+			want: testFile(),
+			got: func() proto.Message {
+				f := testFile()
+				withUnknown(f.MessageType[0], 99, 1)
+				return f
+			}(),
+			expected: []string{
+				"Protobuf messages differ:\nmessage_type[0]: unknown fields differ: expected , got 980601",
+			},
+		},
+		{
+			name: "ignore unknown", want: withUnknown(testFile(), 98, 2), ignoreUnknown: true,
+			got: func() proto.Message {
+				f := testFile()
+				withUnknown(f.MessageType[0].Field[0], 99, 1)
+				return f
+			}(),
+			expected: []string{},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{Errors: []string{}}
+		ok := (&ProtoOptions{IgnoreUnknown: test.ignoreUnknown}).ProtoEqual(rt, test.want, test.got)
+		if ok != (len(test.expected) == 0) || !reflect.DeepEqual(rt.Failures(), append([]string{}, test.expected...)) {
+			t.Errorf("ProtoEqual(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected,
+				rt.Failures(), test.name)
+		}
+	}
+}
+
+func TestProtoEqualDefaults(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	want := testFile()
+	got := withUnknown(testFile(), 99, 1)
+	if ProtoEqual(rt, want, got) {
+		t.Errorf("ProtoEqual(): Expected unknown fields to be compared by default")
+	}
+	if got.ProtoReflect().GetUnknown() == nil {
+		t.Errorf("ProtoEqual(): The message was modified")
+	}
+	rt = &testhelptest.RecordingT{}
+	if !(&ProtoOptions{IgnoreUnknown: true}).ProtoEqual(rt, want, got) || len(got.ProtoReflect().GetUnknown()) == 0 {
+		t.Errorf("ProtoOptions.ProtoEqual(): Expected a match without modifying the message: %#+v", rt.Failures())
+	}
+}
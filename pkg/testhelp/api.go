@@ -0,0 +1,320 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CheckAPICompat checks that the exported API of the package with the given import path (or directory, such as ".",
+// relative to the test's working directory) is compatible with the one recorded in the golden file
+// testdata/<goldenAPI>.golden, relative to the test's working directory (which is normally the package's directory).
+// The package is type-checked from source with go/types, and its API is listed one declaration per line: each exported
+// constant, variable, function, and type, each exported field of an exported struct type, and each exported method of
+// an exported type (including promoted methods, and with the receiver given as T or *T).  The methods of an exported
+// interface type are listed as "interface method" lines, and an interface with unexported methods (which can't be
+// implemented outside its package) is marked as "(sealed)".  Signatures leave out the names of parameters, since
+// renaming a parameter doesn't break callers.
+//
+// If a line of the golden file is missing from the current API, meaning that something was removed or its signature or
+// type changed, or if a method was added to an interface in the golden file (which breaks every implementation of it
+// outside the package, as does adding the first unexported method, sealing it), CheckAPICompat calls t.Errorf with
+// the removed and added lines, and returns false.  Other additions are compatible, so it just logs them and returns
+// true (but the golden file should be updated to record them, so that removing them later is caught).  This lets
+// library authors catch accidental breaking changes in their tests:
+//
+//	func TestAPICompat(t *testing.T) {
+//		testhelp.CheckAPICompat(t, ".", "api")
+//	}
+//
+// As with PanicsGolden, running the tests with the environment variable named by UpdateGoldenEnvVar set writes the
+// current API to the golden file instead of checking it.
+func CheckAPICompat(t TestingTB, pkgPath string, goldenAPI string) bool {
+	t.Helper()
+	return checkAPICompatFile(t, pkgPath, filepath.Join("testdata", filepath.FromSlash(goldenAPI)+".golden"))
+}
+
+func checkAPICompatFile(t TestingTB, pkgPath, path string) bool {
+	t.Helper()
+	pkg, err := importSource(pkgPath)
+	if err != nil {
+		t.Errorf("Can't load package %s: %s", pkgPath, err)
+		return false
+	}
+	got := apiLines(pkg)
+	if os.Getenv(UpdateGoldenEnvVar) != "" {
+		return textGoldenFile(t, path, "API", strings.Join(got, "\n")+"\n")
+	}
+
+	wantBytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Golden file %s does not exist; run the tests with %s=1 to create it", path, UpdateGoldenEnvVar)
+		return false
+	} else if err != nil {
+		t.Errorf("Can't read golden file: %s", err)
+		return false
+	}
+	var want []string
+	for _, line := range strings.Split(string(wantBytes), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			want = append(want, line)
+		}
+	}
+	removed, added := lineSetDiff(want, got)
+	switch {
+	case len(removed) > 0 || addsInterfaceMethods(want, added):
+		t.Errorf("API of %s is incompatible with golden file %s (run the tests with %s=1 to accept the changes):\n%s",
+			pkgPath, path, UpdateGoldenEnvVar, apiDiff(removed, added))
+		return false
+	case len(added) > 0:
+		t.Logf("API of %s has additions that aren't in golden file %s (run the tests with %s=1 to record them):\n%s",
+			pkgPath, path, UpdateGoldenEnvVar, apiDiff(nil, added))
+	}
+	return true
+}
+
+// sourceImporter is shared by the calls to importSource, since type-checking from source is slow (including for the
+// standard library packages that most packages import), and it caches the packages it has already loaded.  It isn't
+// safe for concurrent use, so it's guarded by sourceImporterMu.
+var (
+	sourceImporterMu sync.Mutex
+	sourceImporter   types.Importer
+)
+
+// importSource type-checks the package with the given import path (or directory) from source.
+func importSource(pkgPath string) (*types.Package, error) {
+	sourceImporterMu.Lock()
+	defer sourceImporterMu.Unlock()
+	if sourceImporter == nil {
+		sourceImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+	}
+	return sourceImporter.Import(pkgPath)
+}
+
+// lineSetDiff returns the lines of want that aren't in got, and the lines of got that aren't in want, in order.
+func lineSetDiff(want, got []string) (removed, added []string) {
+	inWant, inGot := map[string]bool{}, map[string]bool{}
+	for _, line := range want {
+		inWant[line] = true
+	}
+	for _, line := range got {
+		inGot[line] = true
+	}
+	for _, line := range want {
+		if !inGot[line] {
+			removed = append(removed, line)
+		}
+	}
+	for _, line := range got {
+		if !inWant[line] {
+			added = append(added, line)
+		}
+	}
+	return removed, added
+}
+
+// addsInterfaceMethods returns true if any of the added API lines is a method of an interface type that's in want.
+func addsInterfaceMethods(want, added []string) bool {
+	interfaces := map[string]bool{}
+	for _, line := range want {
+		if strings.HasPrefix(line, "type ") &&
+			(strings.HasSuffix(line, " interface") || strings.HasSuffix(line, " interface (sealed)")) {
+			name := strings.Fields(line)[1]
+			if i := strings.IndexByte(name, '['); i >= 0 {
+				name = name[:i]
+			}
+			interfaces[name] = true
+		}
+	}
+	for _, line := range added {
+		if rest := strings.TrimPrefix(line, "interface method ("); rest != line {
+			if i := strings.IndexByte(rest, ')'); i >= 0 && interfaces[rest[:i]] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apiDiff formats removed and added API lines, marked with "-" and "+", sorted together so that a changed declaration
+// has its old and new lines next to each other.
+func apiDiff(removed, added []string) string {
+	type diffLine struct {
+		marker, line string
+	}
+	var lines []diffLine
+	for _, line := range removed {
+		lines = append(lines, diffLine{"-", line})
+	}
+	for _, line := range added {
+		lines = append(lines, diffLine{"+", line})
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].line < lines[j].line })
+	var b strings.Builder
+	for _, dl := range lines {
+		fmt.Fprintf(&b, "%s %s\n", dl.marker, dl.line)
+	}
+	return b.String()
+}
+
+// apiLines returns the lines describing the exported API of pkg, sorted.
+func apiLines(pkg *types.Package) []string {
+	qf := types.RelativeTo(pkg)
+	var lines []string
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		switch obj := obj.(type) {
+		case *types.Const:
+			lines = append(lines, fmt.Sprintf("const %s %s", name, types.TypeString(obj.Type(), qf)))
+		case *types.Var:
+			lines = append(lines, fmt.Sprintf("var %s %s", name, types.TypeString(obj.Type(), qf)))
+		case *types.Func:
+			lines = append(lines, "func "+name+signatureString(obj.Type().(*types.Signature), qf))
+		case *types.TypeName:
+			lines = append(lines, typeAPILines(obj, qf)...)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// typeAPILines returns the lines describing an exported type, its exported fields, and its exported methods (and, for
+// an interface, whether it has unexported ones).
+func typeAPILines(obj *types.TypeName, qf types.Qualifier) []string {
+	name := obj.Name()
+	if obj.IsAlias() {
+		typ := obj.Type()
+		if alias, ok := typ.(interface{ Rhs() types.Type }); ok { // a *types.Alias, on newer versions of Go
+			typ = alias.Rhs()
+		}
+		return []string{fmt.Sprintf("type %s = %s", name, types.TypeString(typ, qf))}
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+	decl := "type " + name + typeParamsString(named.TypeParams(), qf)
+	var lines []string
+	switch u := named.Underlying().(type) {
+	case *types.Struct:
+		lines = append(lines, decl+" struct")
+		for i := 0; i < u.NumFields(); i++ {
+			if f := u.Field(i); f.Exported() {
+				lines = append(lines, fmt.Sprintf("field %s.%s %s", name, f.Name(), types.TypeString(f.Type(), qf)))
+			}
+		}
+	case *types.Interface:
+		if !u.IsMethodSet() { // a constraint, whose type set is part of its API
+			return []string{decl + " " + types.TypeString(u, qf)}
+		}
+		sealed := false
+		for i := 0; i < u.NumMethods(); i++ {
+			m := u.Method(i)
+			if !m.Exported() {
+				sealed = true
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("interface method (%s) %s%s", name, m.Name(),
+				signatureString(m.Type().(*types.Signature), qf)))
+		}
+		if sealed {
+			return append(lines, decl+" interface (sealed)")
+		}
+		return append(lines, decl+" interface")
+	default:
+		lines = append(lines, decl+" "+types.TypeString(u, qf))
+	}
+
+	valueMethods := types.NewMethodSet(named)
+	ptrMethods := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < ptrMethods.Len(); i++ {
+		m := ptrMethods.At(i).Obj()
+		if !m.Exported() {
+			continue
+		}
+		recv := "*" + name
+		if valueMethods.Lookup(m.Pkg(), m.Name()) != nil {
+			recv = name
+		}
+		lines = append(lines, fmt.Sprintf("method (%s) %s%s", recv, m.Name(),
+			signatureString(m.Type().(*types.Signature), qf)))
+	}
+	return lines
+}
+
+// signatureString formats a function signature without the parameter names, e.g. "[T any](T, ...int) (T, error)".
+func signatureString(sig *types.Signature, qf types.Qualifier) string {
+	var b strings.Builder
+	b.WriteString(typeParamsString(sig.TypeParams(), qf))
+	b.WriteString("(")
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if sig.Variadic() && i == params.Len()-1 {
+			b.WriteString("..." + types.TypeString(params.At(i).Type().(*types.Slice).Elem(), qf))
+		} else {
+			b.WriteString(types.TypeString(params.At(i).Type(), qf))
+		}
+	}
+	b.WriteString(")")
+	results := sig.Results()
+	switch results.Len() {
+	case 0:
+	case 1:
+		b.WriteString(" " + types.TypeString(results.At(0).Type(), qf))
+	default:
+		b.WriteString(" (")
+		for i := 0; i < results.Len(); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(types.TypeString(results.At(i).Type(), qf))
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// typeParamsString formats a list of type parameters with their constraints, e.g. "[K comparable, V any]", or "" if
+// there are none.
+func typeParamsString(tparams *types.TypeParamList, qf types.Qualifier) string {
+	if tparams.Len() == 0 {
+		return ""
+	}
+	parts := make([]string, tparams.Len())
+	for i := range parts {
+		tp := tparams.At(i)
+		parts[i] = tp.Obj().Name() + " " + types.TypeString(tp.Constraint(), qf)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+const apiFixture = "./testdata/apicompat"
+
+const apiFixtureAPI = `const Limit int
+const Version untyped string
+field Named.Label string
+field Named.Widget Widget
+field Widget.Name string
+field Widget.Size int
+func Max[T Number](T, T) T
+func New(string) (*Widget, error)
+interface method (Sizer) Close() error
+interface method (Sizer) Size() int
+method (*Named) Resize(int, ...string) error
+method (*Widget) Resize(int, ...string) error
+method (Named) String() string
+method (Widget) String() string
+type Bytes = []byte
+type Kind int
+type Named struct
+type Number interface{~int | ~float64}
+type Sizer interface (sealed)
+type Widget struct
+var Default *Widget
+`
+
+func TestCheckAPICompat(t *testing.T) {
+	// Checked against testdata/apicompat_example.golden
+	if !CheckAPICompat(t, apiFixture, "apicompat_example") {
+		t.Errorf("CheckAPICompat(): Expected the fixture package to match its golden file")
+	}
+}
+
+func TestCheckAPICompatFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.golden")
+
+	rt := &testhelptest.RecordingT{}
+	if checkAPICompatFile(rt, apiFixture, path) {
+		t.Errorf("checkAPICompatFile(): Expected false with a missing golden file")
+	}
+	if failures := rt.Failures(); len(failures) != 1 || !strings.Contains(failures[0], "does not exist") {
+		t.Errorf("checkAPICompatFile(): Incorrect failure(s) with a missing golden file:\n%#+v", failures)
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "1")
+	rt = &testhelptest.RecordingT{}
+	if !checkAPICompatFile(rt, apiFixture, path) {
+		t.Errorf("checkAPICompatFile(): Expected true when updating, got failure(s):\n%#+v", rt.Failures())
+	}
+	if contents, err := os.ReadFile(path); err != nil || string(contents) != apiFixtureAPI {
+		t.Errorf("checkAPICompatFile(): Golden file not written correctly: expected\n%s\ngot\n%s\n(error %v)",
+			apiFixtureAPI, contents, err)
+	}
+	t.Setenv(UpdateGoldenEnvVar, "")
+
+	tests := []struct {
+		name         string
+		golden       string
+		wantOK       bool
+		wantFailures []string
+		wantLogs     []string
+	}{
+		{"same", apiFixtureAPI, true, []string{}, nil},
+		{"reordered, with blank lines", "\nvar Default *Widget\n\n" + strings.Replace(apiFixtureAPI,
+			"var Default *Widget\n", "", 1), true, []string{}, nil},
+		{"addition", strings.Replace(apiFixtureAPI, "type Kind int\n", "", 1), true, []string{},
+			[]string{"API of ./testdata/apicompat has additions that aren't in golden file " + path +
+				" (run the tests with TESTHELP_UPDATE_GOLDEN=1 to record them):\n+ type Kind int\n"}},
+		{"interface method added", strings.Replace(apiFixtureAPI, "interface method (Sizer) Size() int\n", "", 1), false,
+			[]string{"API of ./testdata/apicompat is incompatible with golden file " + path +
+				" (run the tests with TESTHELP_UPDATE_GOLDEN=1 to accept the changes):\n" +
+				"+ interface method (Sizer) Size() int\n"}, nil},
+		{"interface sealed", strings.Replace(apiFixtureAPI, "type Sizer interface (sealed)\n", "type Sizer interface\n", 1),
+			false, []string{"API of ./testdata/apicompat is incompatible with golden file " + path +
+				" (run the tests with TESTHELP_UPDATE_GOLDEN=1 to accept the changes):\n" +
+				"- type Sizer interface\n+ type Sizer interface (sealed)\n"}, nil},
+		{"new interface", strings.Replace(strings.Replace(strings.Replace(apiFixtureAPI,
+			"interface method (Sizer) Close() error\n", "", 1), "interface method (Sizer) Size() int\n", "", 1),
+			"type Sizer interface (sealed)\n", "", 1), true, []string{},
+			[]string{"API of ./testdata/apicompat has additions that aren't in golden file " + path +
+				" (run the tests with TESTHELP_UPDATE_GOLDEN=1 to record them):\n" +
+				"+ interface method (Sizer) Close() error\n+ interface method (Sizer) Size() int\n" +
+				"+ type Sizer interface (sealed)\n"}},
+		{"removal and change", strings.Replace(apiFixtureAPI, "func New(string) (*Widget, error)\n",
+			"func New(string, int) (*Widget, error)\nfunc Old()\n", 1), false, []string{
+			"API of ./testdata/apicompat is incompatible with golden file " + path +
+				" (run the tests with TESTHELP_UPDATE_GOLDEN=1 to accept the changes):\n" +
+				"+ func New(string) (*Widget, error)\n- func New(string, int) (*Widget, error)\n- func Old()\n",
+		}, nil},
+	}
+	for _, test := range tests {
+		if err := os.WriteFile(path, []byte(test.golden), 0o644); err != nil {
+			t.Fatalf("Can't write golden file: %s", err)
+		}
+		rt := &testhelptest.RecordingT{}
+		if ok := checkAPICompatFile(rt, apiFixture, path); ok != test.wantOK {
+			t.Errorf("checkAPICompatFile(): Incorrect result: expected %t, got %t in test '%s'", test.wantOK, ok,
+				test.name)
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, test.wantFailures) {
+			t.Errorf("checkAPICompatFile(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantFailures, got, test.name)
+		}
+		if !reflect.DeepEqual(rt.Logs, test.wantLogs) {
+			t.Errorf("checkAPICompatFile(): Incorrect logs: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantLogs,
+				rt.Logs, test.name)
+		}
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if checkAPICompatFile(rt, "./testdata/no-such-package", path) {
+		t.Errorf("checkAPICompatFile(): Expected false with a missing package")
+	}
+	if failures := rt.Failures(); len(failures) != 1 ||
+		!strings.HasPrefix(failures[0], "Can't load package ./testdata/no-such-package: ") {
+		t.Errorf("checkAPICompatFile(): Incorrect failure(s) with a missing package:\n%#+v", failures)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithinDuration checks that got is no more than delta before or after want, calling t.Errorf with both times and
+// the actual skew between them if not.  It returns true if the check passed.
+func WithinDuration(t TestingTB, want, got time.Time, delta time.Duration) bool {
+	t.Helper()
+	if msg := withinDuration(want, got, delta); msg != "" {
+		t.Errorf("%s", msg)
+		return false
+	}
+	return true
+}
+
+// RecentlyHappened checks that got is no more than window before the current time, and not after it, calling
+// t.Errorf with the time and how long ago (or how far in the future) it was if not.  It returns true if the check
+// passed.  This is for timestamps set by the code under test, such as creation times.
+func RecentlyHappened(t TestingTB, got time.Time, window time.Duration) bool {
+	t.Helper()
+	if msg := recentlyHappened(time.Now(), got, window); msg != "" {
+		t.Errorf("%s", msg)
+		return false
+	}
+	return true
+}
+
+// A WithinDurationTest is a test case for WithinDurationLoop.
+type WithinDurationTest struct {
+	Name      string
+	Want, Got time.Time
+	Delta     time.Duration
+}
+
+// WithinDurationLoop runs WithinDuration for each of the test cases, adding the test name to each failure message.  It
+// returns true if all of the checks passed.
+func WithinDurationLoop(t TestingTB, tests []WithinDurationTest) bool {
+	t.Helper()
+	ok := true
+	for _, test := range tests {
+		if msg := withinDuration(test.Want, test.Got, test.Delta); msg != "" {
+			t.Errorf("%s\nin test '%s'", msg, test.Name)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// A RecentlyHappenedTest is a test case for RecentlyHappenedLoop.
+type RecentlyHappenedTest struct {
+	Name   string
+	Got    time.Time
+	Window time.Duration
+}
+
+// RecentlyHappenedLoop runs RecentlyHappened for each of the test cases, adding the test name to each failure message.
+// All of the cases are checked against the same current time, taken when RecentlyHappenedLoop is called.  It returns
+// true if all of the checks passed.
+func RecentlyHappenedLoop(t TestingTB, tests []RecentlyHappenedTest) bool {
+	t.Helper()
+	now := time.Now()
+	ok := true
+	for _, test := range tests {
+		if msg := recentlyHappened(now, test.Got, test.Window); msg != "" {
+			t.Errorf("%s\nin test '%s'", msg, test.Name)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// withinDuration returns a failure message if got is more than delta away from want, or "" if it isn't.  A negative
+// delta is treated as positive.
+func withinDuration(want, got time.Time, delta time.Duration) string {
+	if delta < 0 {
+		delta = -delta
+	}
+	skew := got.Sub(want)
+	if skew >= -delta && skew <= delta {
+		return ""
+	}
+	return fmt.Sprintf("Time is %s, which is more than the allowed %s: expected\n%s\ngot\n%s", describeSkew(skew,
+		"than expected"), delta, formatTime(want), formatTime(got))
+}
+
+// recentlyHappened returns a failure message if got is more than window before now or after now, or "" if it isn't.
+func recentlyHappened(now, got time.Time, window time.Duration) string {
+	skew := got.Sub(now)
+	switch {
+	case skew > 0:
+		return fmt.Sprintf("Time is %s; expected it within the last %s: got\n%s\nat\n%s", describeSkew(skew,
+			"than the current time"), window, formatTime(got), formatTime(now))
+	case -skew > window:
+		return fmt.Sprintf("Time is %s, which is more than the allowed %s: got\n%s\nat\n%s", describeSkew(skew,
+			"than the current time"), window, formatTime(got), formatTime(now))
+	}
+	return ""
+}
+
+// describeSkew describes a time difference as e.g. "1.5s later than expected".
+func describeSkew(skew time.Duration, than string) string {
+	if skew < 0 {
+		return fmt.Sprintf("%s earlier %s", -skew, than)
+	}
+	return fmt.Sprintf("%s later %s", skew, than)
+}
+
+// formatTime formats a time for a failure message, without the monotonic clock reading that String would include.
+func formatTime(tm time.Time) string {
+	return tm.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestWithinDuration(t *testing.T) {
+	base := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		got      time.Time
+		delta    time.Duration
+		expected string // "" for success
+	}{
+		{name: "same", got: base, delta: 0},
+		{name: "later within", got: base.Add(time.Second), delta: time.Second},
+		{name: "earlier within", got: base.Add(-time.Second), delta: time.Second},
+		{name: "negative delta", got: base.Add(time.Second), delta: -time.Second},
+		{
+			name: "later", got: base.Add(1500 * time.Millisecond), delta: time.Second,
+			expected: "Time is 1.5s later than expected, which is more than the allowed 1s: expected\n" +
+				"2020-01-02T03:04:05Z\ngot\n2020-01-02T03:04:06.5Z",
+		},
+		{
+			name: "earlier", got: base.Add(-time.Minute), delta: time.Second,
+			expected: "Time is 1m0s earlier than expected, which is more than the allowed 1s: expected\n" +
+				"2020-01-02T03:04:05Z\ngot\n2020-01-02T03:03:05Z",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := WithinDuration(rt, base, test.got, test.delta)
+		want := []string{}
+		if test.expected != "" {
+			want = []string{test.expected}
+		}
+		if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+			t.Errorf("WithinDuration(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got,
+				test.name)
+		}
+	}
+}
+
+func TestRecentlyHappened(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		got      time.Time
+		expected string // "" for success
+	}{
+		{name: "now", got: now},
+		{name: "recent", got: now.Add(-time.Minute)},
+		{
+			name: "old", got: now.Add(-time.Hour),
+			expected: "Time is 1h0m0s earlier than the current time, which is more than the allowed 1m0s: got\n" +
+				"2020-01-02T02:04:05Z\nat\n2020-01-02T03:04:05Z",
+		},
+		{
+			name: "future", got: now.Add(time.Second),
+			expected: "Time is 1s later than the current time; expected it within the last 1m0s: got\n" +
+				"2020-01-02T03:04:06Z\nat\n2020-01-02T03:04:05Z",
+		},
+	}
+	for _, test := range tests {
+		if got := recentlyHappened(now, test.got, time.Minute); got != test.expected {
+			t.Errorf("recentlyHappened(): Incorrect message: expected\n%s\ngot\n%s\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+
+	rt := &testhelptest.RecordingT{}
+	if !RecentlyHappened(rt, time.Now(), time.Minute) || len(rt.Failures()) != 0 {
+		t.Errorf("RecentlyHappened(): Unexpected failure for the current time:\n%#+v", rt.Failures())
+	}
+	rt = &testhelptest.RecordingT{}
+	if RecentlyHappened(rt, time.Now().Add(-time.Hour), time.Minute) || len(rt.Failures()) != 1 {
+		t.Errorf("RecentlyHappened(): Expected one failure for an old time, got:\n%#+v", rt.Failures())
+	}
+}
+
+func TestTimeLoops(t *testing.T) {
+	base := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rt := &testhelptest.RecordingT{}
+	ok := WithinDurationLoop(rt, []WithinDurationTest{
+		{Name: "close", Want: base, Got: base.Add(time.Millisecond), Delta: time.Second},
+		{Name: "far", Want: base, Got: base.Add(2 * time.Second), Delta: time.Second},
+	})
+	if f := rt.Failures(); ok || len(f) != 1 || !strings.HasSuffix(f[0], "\nin test 'far'") {
+		t.Errorf("WithinDurationLoop(): Incorrect failures:\n%#+v", f)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	now := time.Now()
+	ok = RecentlyHappenedLoop(rt, []RecentlyHappenedTest{
+		{Name: "recent", Got: now, Window: time.Minute},
+		{Name: "old", Got: now.Add(-time.Hour), Window: time.Minute},
+		{Name: "future", Got: now.Add(time.Hour), Window: time.Minute},
+	})
+	f := rt.Failures()
+	if ok || len(f) != 2 || !strings.HasSuffix(f[0], "\nin test 'old'") || !strings.HasSuffix(f[1], "\nin test 'future'") {
+		t.Errorf("RecentlyHappenedLoop(): Incorrect failures:\n%#+v", f)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ZipContains reads a zip archive from r, and checks that it contains each of the files in want (by slash-separated
+// path) with the given contents, calling t.Errorf, listing missing files and giving a diff for each file whose
+// contents differ, if not.  Other files in the archive are ignored.  It returns true if the check passed.
+func ZipContains(t TestingTB, r io.Reader, want map[string]string) bool {
+	t.Helper()
+	got, err := readZip(r, nil)
+	if err != nil {
+		t.Errorf("Can't read zip archive: %s", err)
+		return false
+	}
+	return archiveContains(t, "Zip", got, want)
+}
+
+// TarContains is like ZipContains, but for a tar archive, which may be gzip-compressed.
+func TarContains(t TestingTB, r io.Reader, want map[string]string) bool {
+	t.Helper()
+	got, err := readTar(r, nil)
+	if err != nil {
+		t.Errorf("Can't read tar archive: %s", err)
+		return false
+	}
+	return archiveContains(t, "Tar", got, want)
+}
+
+func archiveContains(t TestingTB, kind string, got map[string]treeFile, want map[string]string) bool {
+	t.Helper()
+	wantFiles := make(map[string]treeFile, len(want))
+	for name, contents := range want {
+		wantFiles[name] = treeFile{contents: []byte(contents)}
+	}
+	missing, diffs := new(GoldenDirOptions).diffFiles(wantFiles, got)
+	if len(missing) == 0 && diffs == "" {
+		return true
+	}
+	var b strings.Builder
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\nMissing files:\n%s", strings.Join(missing, "\n"))
+	}
+	b.WriteString(diffs)
+	t.Errorf("%s archive does not contain the expected files:%s", kind, b.String())
+	return false
+}
+
+// ZipEqualsGolden reads a zip archive from r, and compares the files in it with the golden directory testdata/<name>,
+// as GoldenDir does for a directory on disk (including updating the golden directory when the environment variable
+// named by UpdateGoldenEnvVar is set, and the restrictions on name).
+func ZipEqualsGolden(t TestingTB, name string, r io.Reader) bool {
+	t.Helper()
+	return new(GoldenDirOptions).ZipEqualsGolden(t, name, r)
+}
+
+// ZipEqualsGolden is like the package-level ZipEqualsGolden, but with the options in o.
+func (o *GoldenDirOptions) ZipEqualsGolden(t TestingTB, name string, r io.Reader) bool {
+	t.Helper()
+	goldenDir, err := goldenDirPath(name)
+	if err != nil {
+		t.Errorf("Can't use golden directory: %s", err)
+		return false
+	}
+	got, err := readZip(r, o)
+	if err != nil {
+		t.Errorf("Can't read zip archive: %s", err)
+		return false
+	}
+	return o.compareTree(t, "Zip archive", goldenDir, got)
+}
+
+// TarEqualsGolden is like ZipEqualsGolden, but for a tar archive, which may be gzip-compressed.
+func TarEqualsGolden(t TestingTB, name string, r io.Reader) bool {
+	t.Helper()
+	return new(GoldenDirOptions).TarEqualsGolden(t, name, r)
+}
+
+// TarEqualsGolden is like the package-level TarEqualsGolden, but with the options in o.
+func (o *GoldenDirOptions) TarEqualsGolden(t TestingTB, name string, r io.Reader) bool {
+	t.Helper()
+	goldenDir, err := goldenDirPath(name)
+	if err != nil {
+		t.Errorf("Can't use golden directory: %s", err)
+		return false
+	}
+	got, err := readTar(r, o)
+	if err != nil {
+		t.Errorf("Can't read tar archive: %s", err)
+		return false
+	}
+	return o.compareTree(t, "Tar archive", goldenDir, got)
+}
+
+// readZip reads the regular files in a zip archive, normalizing their contents with o, if it isn't nil.
+func readZip(r io.Reader, o *GoldenDirOptions) (map[string]treeFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]treeFile{}
+	for _, f := range zr.File {
+		mode := f.Mode()
+		if mode.IsDir() {
+			continue
+		}
+		if !mode.IsRegular() {
+			return nil, fmt.Errorf("entry %s is not a regular file or directory", f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		contents, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if err := addArchiveFile(files, f.Name, contents, mode.Perm()&0o111 != 0, o); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// readTar reads the regular files in a tar archive, which may be gzip-compressed, normalizing their contents with o,
+// if it isn't nil.
+func readTar(r io.Reader, o *GoldenDirOptions) (map[string]treeFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		if data, err = gunzip(data); err != nil {
+			return nil, err
+		}
+	}
+	tr := tar.NewReader(bytes.NewReader(data))
+	files := map[string]treeFile{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		} else if err != nil {
+			return nil, err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("entry %s is not a regular file or directory", hdr.Name)
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if err := addArchiveFile(files, hdr.Name, contents, hdr.Mode&0o111 != 0, o); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// addArchiveFile adds a file from an archive to files, cleaning up its name and rejecting names that would escape
+// the golden directory or that appear more than once.
+func addArchiveFile(files map[string]treeFile, name string, contents []byte, executable bool,
+	o *GoldenDirOptions) error {
+	clean := path.Clean(strings.TrimPrefix(name, "/"))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("entry %s is outside the archive's root", name)
+	}
+	if _, ok := files[clean]; ok {
+		return fmt.Errorf("entry %s appears more than once", name)
+	}
+	if o != nil {
+		contents = o.normalize(contents)
+	}
+	files[clean] = treeFile{contents, executable}
+	return nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// makeZip returns a zip archive of files (by slash-separated path), making paths ending in ".sh" executable.
+func makeZip(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range sortedStringKeys(files) {
+		hdr := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		hdr.SetMode(archiveTestMode(name))
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatalf("Can't create zip entry: %s", err)
+		}
+		if _, err := w.Write([]byte(files[name])); err != nil {
+			t.Fatalf("Can't write zip entry: %s", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Can't finish zip archive: %s", err)
+	}
+	return &buf
+}
+
+// makeTar returns a tar archive of files, gzip-compressed if gz is true, with the same modes as makeZip.  Tar
+// entries get a "./" prefix, as they do from "tar -C dir -c .".
+func makeTar(t *testing.T, files map[string]string, gz bool) *bytes.Buffer {
+	var buf bytes.Buffer
+	var tw *tar.Writer
+	var zw *gzip.Writer
+	if gz {
+		zw = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(zw)
+	} else {
+		tw = tar.NewWriter(&buf)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatalf("Can't write tar header: %s", err)
+	}
+	for _, name := range sortedStringKeys(files) {
+		hdr := &tar.Header{
+			Name: "./" + name, Typeflag: tar.TypeReg, Mode: int64(archiveTestMode(name)), Size: int64(len(files[name])),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("Can't write tar header: %s", err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatalf("Can't write tar entry: %s", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Can't finish tar archive: %s", err)
+	}
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			t.Fatalf("Can't finish gzip stream: %s", err)
+		}
+	}
+	return &buf
+}
+
+func archiveTestMode(name string) os.FileMode {
+	if strings.HasSuffix(name, ".sh") {
+		return 0o755
+	}
+	return 0o644
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestArchiveContains(t *testing.T) {
+	archive := map[string]string{
+		"README":     "hello\nworld\n",
+		"bin/run.sh": "#!/bin/sh\n",
+		"data.bin":   "\x00\x01",
+	}
+	tests := []struct {
+		name     string
+		want     map[string]string
+		expected string // the failure after the first line, or "" for none
+	}{
+		{name: "all", want: archive},
+		{name: "subset", want: map[string]string{"bin/run.sh": "#!/bin/sh\n"}},
+		{name: "empty", want: map[string]string{}},
+		{
+			name: "different",
+			want: map[string]string{
+				"README": "hello\nthere\n", "data.bin": "\x00\x02", "missing.txt": "", "bin/other.sh": "",
+			},
+			expected: "Missing files:\nbin/other.sh\nmissing.txt\nContents of README differ:\n" +
+				"  hello\n- there\n+ world\n  \n\nContents of data.bin differ (binary file)",
+		},
+	}
+	for _, test := range tests {
+		for _, kind := range []string{"Zip", "Tar", "Tar (gzip)"} {
+			rt := &testhelptest.RecordingT{}
+			var ok bool
+			switch kind {
+			case "Zip":
+				ok = ZipContains(rt, makeZip(t, archive), test.want)
+			default:
+				ok = TarContains(rt, makeTar(t, archive, kind != "Tar"), test.want)
+			}
+			want := []string{}
+			if test.expected != "" {
+				want = []string{strings.Fields(kind)[0] + " archive does not contain the expected files:\n" +
+					test.expected}
+			}
+			if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+				t.Errorf("%sContains(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+					strings.Fields(kind)[0], want, got, test.name)
+			}
+		}
+	}
+}
+
+func TestArchiveEqualsGolden(t *testing.T) {
+	// Checked against testdata/golden_dir_example, like TestGoldenDir
+	timeRE := regexp.MustCompile(`[0-9]{2}:[0-9]{2}`)
+	files := map[string]string{
+		"widget.toml":  "name = \"widget\"\nversion = 1\n",
+		"cmd/build.sh": "#!/bin/sh\necho \"built at 12:34\"\n",
+	}
+	opts := GoldenDirOptions{
+		ComparePerms: true,
+		Normalizers:  []func(string) string{func(s string) string { return timeRE.ReplaceAllString(s, "TIME") }},
+	}
+	if !opts.ZipEqualsGolden(t, "golden_dir_example", makeZip(t, files)) {
+		t.Errorf("GoldenDirOptions.ZipEqualsGolden(): Expected the example to match its golden directory")
+	}
+	if !opts.TarEqualsGolden(t, "golden_dir_example", makeTar(t, files, true)) {
+		t.Errorf("GoldenDirOptions.TarEqualsGolden(): Expected the example to match its golden directory")
+	}
+
+	files["cmd/build"] = files["cmd/build.sh"]
+	delete(files, "cmd/build.sh")
+	rt := &testhelptest.RecordingT{}
+	if opts.TarEqualsGolden(rt, "golden_dir_example", makeTar(t, files, false)) {
+		t.Errorf("GoldenDirOptions.TarEqualsGolden(): Expected false with a renamed file")
+	}
+	want := []string{"Tar archive does not match golden directory testdata/golden_dir_example (run the tests with " +
+		"TESTHELP_UPDATE_GOLDEN=1 to update it):\nMissing files:\ncmd/build.sh\nUnexpected files:\ncmd/build"}
+	if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GoldenDirOptions.TarEqualsGolden(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
+
+func TestArchiveEqualsGoldenBadNames(t *testing.T) {
+	files := map[string]string{"a.txt": "a\n"}
+	zipData, tarData := makeZip(t, files).Bytes(), makeTar(t, files, false).Bytes()
+	checkBadGoldenDirNames(t, "ZipEqualsGolden", func(rt TestingTB, name string) bool {
+		return ZipEqualsGolden(rt, name, bytes.NewReader(zipData))
+	})
+	checkBadGoldenDirNames(t, "TarEqualsGolden", func(rt TestingTB, name string) bool {
+		return TarEqualsGolden(rt, name, bytes.NewReader(tarData))
+	})
+}
+
+func TestArchiveErrors(t *testing.T) {
+	var symlinkTar bytes.Buffer
+	tw := tar.NewWriter(&symlinkTar)
+	if err := tw.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "x"}); err != nil {
+		t.Fatalf("Can't write tar header: %s", err)
+	}
+	tw.Close()
+
+	var escapingTar bytes.Buffer
+	tw = tar.NewWriter(&escapingTar)
+	if err := tw.WriteHeader(&tar.Header{Name: "../x", Typeflag: tar.TypeReg, Mode: 0o644}); err != nil {
+		t.Fatalf("Can't write tar header: %s", err)
+	}
+	tw.Close()
+
+	tests := []struct {
+		name     string
+		f        func(t TestingTB) bool
+		expected string
+	}{
+		{
+			name:     "not a zip",
+			f:        func(t TestingTB) bool { return ZipContains(t, strings.NewReader("nope"), nil) },
+			expected: "Can't read zip archive: zip: not a valid zip file",
+		},
+		{
+			name:     "bad gzip",
+			f:        func(t TestingTB) bool { return TarContains(t, strings.NewReader("\x1f\x8bnope"), nil) },
+			expected: "Can't read tar archive: unexpected EOF",
+		},
+		{
+			name:     "symlink",
+			f:        func(t TestingTB) bool { return TarEqualsGolden(t, "unused", &symlinkTar) },
+			expected: "Can't read tar archive: entry link is not a regular file or directory",
+		},
+		{
+			name:     "escaping",
+			f:        func(t TestingTB) bool { return TarContains(t, &escapingTar, nil) },
+			expected: "Can't read tar archive: entry ../x is outside the archive's root",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := test.f(rt)
+		want := []string{test.expected}
+		if got := rt.Failures(); ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("Archive error: Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got, test.name)
+		}
+	}
+}
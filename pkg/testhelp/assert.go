@@ -0,0 +1,183 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// A Matcher checks a value of type T against some condition: Match returns true if the value meets the condition,
+// and Describe returns a description of the condition for diagnostic messages, phrased to follow "a value that", e.g.
+// `is between 1 and 10`.  Matchers are used with Assert and AssertLoop, and the matchers for panic values
+// (PanicMatchers) are Matcher[interface{}], so a user-defined matcher written once works at any of these assertion
+// sites.
+//
+// This package provides matchers for ordered values (Eq, LessThan, GreaterThan, Between, Near), strings (HasPrefix),
+// slices (HasLen, HasElem, Each), and errors (NoError, IsError), along with MatcherFunc for one-off conditions.  The
+// combinators AllOf, AnyOf, and Not work on matchers of any type, and MatcherFor adapts the matchers for panic values
+// (such as Contains and Regexp, which match strings and errors) to a specific type.
+type Matcher[T any] interface {
+	Match(got T) bool
+	Describe() string
+}
+
+// Assert checks that got matches m, calling t.Errorf with the matcher's description and the value if not.  It
+// returns true if the value matched.
+func Assert[T any](t TestingTB, got T, m Matcher[T]) bool {
+	t.Helper()
+	if !m.Match(got) {
+		t.Errorf("Incorrect value: expected a value that %s\ngot\n%s", m.Describe(), Format(got))
+		return false
+	}
+	return true
+}
+
+// A MatchTest is a test case for AssertLoop.
+type MatchTest[T any] struct {
+	Name    string
+	Got     T
+	Matcher Matcher[T]
+}
+
+// AssertLoop runs Assert for each of the test cases, adding the test name to each failure message.  It returns true
+// if all of the values matched.
+func AssertLoop[T any](t TestingTB, tests []MatchTest[T]) bool {
+	t.Helper()
+	ok := true
+	for _, test := range tests {
+		if !test.Matcher.Match(test.Got) {
+			t.Errorf("Incorrect value: expected a value that %s\ngot\n%s\nin test '%s'", test.Matcher.Describe(),
+				Format(test.Got), test.Name)
+			ok = false
+		}
+	}
+	return ok
+}
+
+type funcMatcher[T any] struct {
+	desc string
+	f    func(T) bool
+}
+
+// MatcherFunc returns a Matcher that matches values for which f returns true, described by desc (which should follow
+// "a value that", as for Describe).
+func MatcherFunc[T any](desc string, f func(got T) bool) Matcher[T] {
+	return funcMatcher[T]{desc, f}
+}
+
+func (m funcMatcher[T]) Match(got T) bool {
+	return m.f(got)
+}
+
+func (m funcMatcher[T]) Describe() string {
+	return m.desc
+}
+
+// Ordered is a constraint for the types that can be compared with <.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Eq returns a Matcher that matches values equal to want (with ==).
+func Eq[T comparable](want T) Matcher[T] {
+	return MatcherFunc("equals "+Format(want), func(got T) bool { return got == want })
+}
+
+// LessThan returns a Matcher that matches values less than limit.
+func LessThan[T Ordered](limit T) Matcher[T] {
+	return MatcherFunc("is less than "+Format(limit), func(got T) bool { return got < limit })
+}
+
+// GreaterThan returns a Matcher that matches values greater than limit.
+func GreaterThan[T Ordered](limit T) Matcher[T] {
+	return MatcherFunc("is greater than "+Format(limit), func(got T) bool { return got > limit })
+}
+
+// Between returns a Matcher that matches values from lo to hi, inclusive.
+func Between[T Ordered](lo, hi T) Matcher[T] {
+	return MatcherFunc("is between "+Format(lo)+" and "+Format(hi), func(got T) bool {
+		return got >= lo && got <= hi
+	})
+}
+
+// Near returns a Matcher that matches floating-point values within tolerance of want.
+func Near[T ~float32 | ~float64](want, tolerance T) Matcher[T] {
+	return MatcherFunc(fmt.Sprintf("is within %v of %v", tolerance, want), func(got T) bool {
+		return math.Abs(float64(got)-float64(want)) <= float64(tolerance)
+	})
+}
+
+// HasPrefix returns a Matcher that matches strings starting with prefix.
+func HasPrefix(prefix string) Matcher[string] {
+	return MatcherFunc(fmt.Sprintf("starts with %q", prefix), func(got string) bool {
+		return strings.HasPrefix(got, prefix)
+	})
+}
+
+// HasLen returns a Matcher that matches slices of length n.
+func HasLen[E any](n int) Matcher[[]E] {
+	return MatcherFunc(fmt.Sprintf("has length %d", n), func(got []E) bool { return len(got) == n })
+}
+
+// HasElem returns a Matcher that matches slices containing an element equal to want.
+func HasElem[E comparable](want E) Matcher[[]E] {
+	return MatcherFunc("has an element equal to "+Format(want), func(got []E) bool {
+		for _, e := range got {
+			if e == want {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Each returns a Matcher that matches slices whose elements all match m (including empty slices).
+func Each[E any](m Matcher[E]) Matcher[[]E] {
+	return MatcherFunc(fmt.Sprintf("has only elements that %s", m.Describe()), func(got []E) bool {
+		for _, e := range got {
+			if !m.Match(e) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// NoError returns a Matcher that matches nil errors.
+func NoError() Matcher[error] {
+	return MatcherFunc("is a nil error", func(got error) bool { return got == nil })
+}
+
+// IsError returns a Matcher that matches errors for which errors.Is(got, target) is true.
+func IsError(target error) Matcher[error] {
+	return MatcherFunc("is an error matching errors.Is("+Format(target)+")", func(got error) bool {
+		return errors.Is(got, target)
+	})
+}
+
+// MatcherFor returns a Matcher[T] that matches values that m, a matcher for values of any type (such as Contains or
+// Regexp), matches.  This lets the matchers for panic values be used with Assert and combined with the typed ones:
+//
+//	testhelp.Assert(t, name, testhelp.AllOf(testhelp.HasPrefix("user-"),
+//		testhelp.MatcherFor[string](testhelp.Regexp(`^\S+$`))))
+func MatcherFor[T any](m PanicMatcher) Matcher[T] {
+	return MatcherFunc(m.Describe(), func(got T) bool { return m.Match(got) })
+}
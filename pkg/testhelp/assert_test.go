@@ -0,0 +1,127 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// checkMatcher checks a matcher's result for one value, and its description.
+func checkMatcher[T any](t *testing.T, name string, m Matcher[T], got T, wantMatch bool, wantDesc string) {
+	t.Helper()
+	if match := m.Match(got); match != wantMatch {
+		t.Errorf("Matcher.Match(): Incorrect result for %#+v: expected %t, got %t in test '%s'", got, wantMatch, match,
+			name)
+	}
+	if desc := m.Describe(); desc != wantDesc {
+		t.Errorf("Matcher.Describe(): Incorrect description: expected\n%s\ngot\n%s\nin test '%s'", wantDesc, desc, name)
+	}
+}
+
+func TestGenericMatchers(t *testing.T) {
+	errBase := errors.New("base")
+	wrapped := fmt.Errorf("wrapped: %w", errBase)
+
+	checkMatcher(t, "eq", Eq(3), 3, true, "equals 3")
+	checkMatcher(t, "eq mismatch", Eq("a"), "b", false, `equals "a"`)
+	checkMatcher(t, "less", LessThan(3), 2, true, "is less than 3")
+	checkMatcher(t, "less mismatch", LessThan(3), 3, false, "is less than 3")
+	checkMatcher(t, "greater", GreaterThan(uint8(3)), 4, true, "is greater than uint8(3)")
+	checkMatcher(t, "between", Between(1.5, 2.5), 2.5, true, "is between 1.5 and 2.5")
+	checkMatcher(t, "between mismatch", Between("b", "d"), "e", false, `is between "b" and "d"`)
+	checkMatcher(t, "near", Near(1.0, 0.01), 1.005, true, "is within 0.01 of 1")
+	checkMatcher(t, "near mismatch", Near(float32(1), 0.01), 1.1, false, "is within 0.01 of 1")
+	checkMatcher(t, "prefix", HasPrefix("he"), "oh hello", false, `starts with "he"`)
+	checkMatcher(t, "len", HasLen[int](2), []int{1, 2}, true, "has length 2")
+	checkMatcher(t, "len mismatch", HasLen[string](0), []string{"a"}, false, "has length 0")
+	checkMatcher(t, "elem", HasElem("b"), []string{"a", "b"}, true, `has an element equal to "b"`)
+	checkMatcher(t, "elem mismatch", HasElem(3), []int{1, 2}, false, "has an element equal to 3")
+	checkMatcher(t, "each", Each(GreaterThan(0)), []int{1, 2}, true, "has only elements that is greater than 0")
+	checkMatcher(t, "each mismatch", Each(GreaterThan(0)), []int{1, 0}, false, "has only elements that is greater than 0")
+	checkMatcher(t, "each empty", Each(GreaterThan(0)), nil, true, "has only elements that is greater than 0")
+	checkMatcher(t, "no error", NoError(), nil, true, "is a nil error")
+	checkMatcher(t, "no error mismatch", NoError(), errBase, false, "is a nil error")
+	checkMatcher(t, "is error", IsError(errBase), wrapped, true,
+		"is an error matching errors.Is(&errors.errorString{s: \"base\"})")
+	checkMatcher(t, "func", MatcherFunc("is even", func(i int) bool { return i%2 == 0 }), 4, true, "is even")
+	checkMatcher(t, "all", AllOf(GreaterThan(1), LessThan(5)), 3, true, "(is greater than 1 and is less than 5)")
+	checkMatcher(t, "all mismatch", AllOf(GreaterThan(1), LessThan(5)), 5, false,
+		"(is greater than 1 and is less than 5)")
+	checkMatcher(t, "all empty", AllOf[int](), 5, true, "is anything")
+	checkMatcher(t, "any", AnyOf(Eq(1), Eq(2)), 2, true, "(equals 1 or equals 2)")
+	checkMatcher(t, "any empty", AnyOf[int](), 2, false, "is nothing")
+	checkMatcher(t, "not", Not(HasPrefix("x")), "abc", true, `is not a value that starts with "x"`)
+}
+
+func TestMatcherFor(t *testing.T) {
+	errBase := errors.New("base")
+	wrapped := fmt.Errorf("wrapped: %w", errBase)
+
+	checkMatcher(t, "contains", MatcherFor[string](Contains("ell")), "hello", true, `contains "ell"`)
+	checkMatcher(t, "regexp", MatcherFor[string](Regexp(`^h.*o$`)), "hallo", true, `matches regexp "^h.*o$"`)
+	checkMatcher(t, "regexp mismatch", MatcherFor[string](Regexp(`^h.*o$`)), "oh", false, `matches regexp "^h.*o$"`)
+	checkMatcher(t, "error contains", MatcherFor[error](Contains("wrap")), wrapped, true, `contains "wrap"`)
+	checkMatcher(t, "error contains nil", MatcherFor[error](Contains("")), nil, false, `contains ""`)
+	checkMatcher(t, "error is", MatcherFor[error](ErrIs(errBase)), wrapped, true,
+		"is an error matching errors.Is(&errors.errorString{s: \"base\"})")
+	checkMatcher(t, "combined", AllOf(HasPrefix("user-"), MatcherFor[string](Not(Contains(" ")))), "user-1", true,
+		`(starts with "user-" and is not a value that contains " ")`)
+}
+
+func TestAssert(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if !Assert(rt, 3, Between(1, 5)) || Assert(rt, "abc", HasPrefix("b")) {
+		t.Errorf("Assert(): Incorrect results")
+	}
+	// PanicMatchers are Matcher[interface{}], so they work with Assert, and user matchers work with PanicsMatching
+	if Assert[interface{}](rt, "boom", Contains("x")) {
+		t.Errorf("Assert(): Incorrect result with a PanicMatcher")
+	}
+	even := MatcherFunc[interface{}]("is an even int", func(v interface{}) bool {
+		i, ok := v.(int)
+		return ok && i%2 == 0
+	})
+	if _, pMatches, _ := PanicsMatching(func() { panic(4) }, even); !pMatches {
+		t.Errorf("PanicsMatching(): Expected a MatcherFunc to match")
+	}
+	want := []string{
+		"Incorrect value: expected a value that starts with \"b\"\ngot\n\"abc\"",
+		"Incorrect value: expected a value that contains \"x\"\ngot\n\"boom\"",
+	}
+	if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Assert(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
+
+func TestAssertLoop(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	ok := AssertLoop(rt, []MatchTest[error]{
+		{Name: "nil", Got: nil, Matcher: NoError()},
+		{Name: "non-nil", Got: errors.New("oops"), Matcher: NoError()},
+		{Name: "contains", Got: errors.New("oops"), Matcher: MatcherFor[error](Contains("oop"))},
+	})
+	want := []string{"Incorrect value: expected a value that is a nil error\ngot\n&errors.errorString{s: \"oops\"}\n" +
+		"in test 'non-nil'"}
+	if got := rt.Failures(); ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("AssertLoop(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
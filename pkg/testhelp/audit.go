@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+// A NamedCall encapsulates a call to a function in an API, along with the name of the function and a description of
+// the input it's called with, for use in diagnostic messages.  Input is only used for reporting; F should make the
+// call itself.
+type NamedCall struct {
+	Name  string
+	Input interface{}
+	F     func()
+}
+
+// NeverPanicsAPI runs each of the given calls, and for any that panics, calls t.Errorf with the name of the function,
+// the input, the panic value, and the stack at the point of the panic.  It returns true if none of the calls panicked.
+//
+// This is intended to turn "this library never panics on valid input" into a maintained test: list a call to each
+// exported function with a range of representative inputs, and add to the list as the API grows.  For example:
+//
+//	func TestAPINeverPanics(t *testing.T) {
+//		var targets []testhelp.NamedCall
+//		for _, in := range []string{"", "a", "a,b", ",,"} {
+//			in := in
+//			targets = append(targets,
+//				testhelp.NamedCall{"Split", in, func() { mypkg.Split(in) }},
+//				testhelp.NamedCall{"Count", in, func() { mypkg.Count(in) }},
+//			)
+//		}
+//		testhelp.NeverPanicsAPI(t, targets)
+//	}
+func NeverPanicsAPI(t TestingTB, targets []NamedCall) bool {
+	t.Helper()
+	ok := true
+	for _, target := range targets {
+		didPanic, pVal, stack := panicsWithStack(target.F)
+		if didPanic {
+			ok = false
+			t.Errorf("Unexpected panic from API function '%s' with input\n%s\npanic value (%T):\n%s\nstack:\n%s",
+				target.Name, Format(target.Input), pVal, PanicMessage(pVal), stack)
+		}
+	}
+	return ok
+}
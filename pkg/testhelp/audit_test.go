@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func auditIndex(s []int, i int) int {
+	return s[i]
+}
+
+func TestNeverPanicsAPI(t *testing.T) {
+	s := []int{1, 2, 3}
+	var targets []NamedCall
+	for _, i := range []int{0, 2, 3} {
+		i := i
+		targets = append(targets, NamedCall{"auditIndex", i, func() { auditIndex(s, i) }})
+	}
+
+	rt := &testhelptest.RecordingT{}
+	if NeverPanicsAPI(rt, targets) {
+		t.Errorf("NeverPanicsAPI(): Expected false with a panicking call")
+	}
+	failures := rt.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("NeverPanicsAPI(): Wrong number of failures: expected 1, got %d:\n%#+v", len(failures), failures)
+	}
+	for _, wantStr := range []string{
+		"'auditIndex' with input\n3\n",
+		"index out of range",
+		"testhelp.auditIndex", // from the stack
+	} {
+		if !strings.Contains(failures[0], wantStr) {
+			t.Errorf("NeverPanicsAPI(): Incorrect failure: expected a string containing\n\"%s\"\ngot\n\"%s\"",
+				wantStr, failures[0])
+		}
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if !NeverPanicsAPI(rt, targets[:2]) {
+		t.Errorf("NeverPanicsAPI(): Expected true with no panicking calls")
+	}
+	if failures := rt.Failures(); len(failures) != 0 {
+		t.Errorf("NeverPanicsAPI(): Unexpected failure(s):\n%#+v", failures)
+	}
+}
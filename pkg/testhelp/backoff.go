@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A BackoffPolicy returns the delay before the given retry (starting from 1), or false if there should be no more
+// retries.  Most backoff implementations can be adapted to it with a small closure.
+type BackoffPolicy func(retry int) (time.Duration, bool)
+
+// AssertBackoffSchedule checks that policy gives the delays in want, in order, each within tolerance (to allow for
+// jitter), and then stops; it calls t.Errorf with a table of the expected and actual delays if not.  It returns true
+// if the check passed.
+func AssertBackoffSchedule(t TestingTB, policy BackoffPolicy, want []time.Duration, tolerance time.Duration) bool {
+	t.Helper()
+	var got []time.Duration
+	for retry := 1; retry <= len(want)+1; retry++ { // one more than expected, to make sure the policy stops
+		d, ok := policy(retry)
+		if !ok {
+			break
+		}
+		got = append(got, d)
+	}
+	return AssertDelays(t, got, want, tolerance)
+}
+
+// AssertDelays checks that got and want have the same number of delays, and that each delay in got is within
+// tolerance of the corresponding one in want, calling t.Errorf with a table of the expected and actual delays
+// (marking the ones that don't match with "*") if not.  It returns true if the check passed.
+func AssertDelays(t TestingTB, got, want []time.Duration, tolerance time.Duration) bool {
+	t.Helper()
+	var b strings.Builder
+	ok := true
+	for i := 0; i < len(want) || i < len(got); i++ {
+		mark := " "
+		w, g := "(none)", "(none)"
+		if i < len(want) {
+			w = want[i].String()
+		}
+		if i < len(got) {
+			g = got[i].String()
+		}
+		if i >= len(want) || i >= len(got) || got[i] < want[i]-tolerance || got[i] > want[i]+tolerance {
+			mark = "*"
+			ok = false
+		}
+		fmt.Fprintf(&b, "\n%s #%d: expected %s, got %s", mark, i+1, w, g)
+	}
+	if !ok {
+		t.Errorf("Delays do not match the expected schedule (tolerance %s):%s", tolerance, b.String())
+	}
+	return ok
+}
+
+// driveInterval is how often DriveRetries checks whether f is waiting on the clock.
+const driveInterval = time.Millisecond
+
+// DriveRetries runs f, which should wait on clock (with After or Sleep) between attempts, and advances the clock
+// whenever f is waiting, straight to the earliest time it's waiting for, so that f runs without any real waiting.  It
+// returns f's error and the delays that the clock was advanced by, in order, which can be checked with AssertDelays.
+// (If f waits on more than one channel at once, such as a per-attempt timeout and a backoff delay, each advance is
+// recorded separately.)
+//
+// If f doesn't return within timeout (of real time), for example because it's waiting on something other than the
+// clock, DriveRetries calls t.Errorf and returns the delays so far, with a nil error; f is left running.  If f panics,
+// DriveRetries calls t.Errorf with the panic value and stack, and also returns the delays so far with a nil error.
+func DriveRetries(t TestingTB, clock *FakeClock, timeout time.Duration, f func() error) ([]time.Duration, error) {
+	t.Helper()
+	type outcome struct {
+		err error
+		c   CapturedPanic
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		var err error
+		c := Capture(func() { err = f() })
+		done <- outcome{err, c}
+	}()
+
+	var delays []time.Duration
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(driveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case o := <-done:
+			if o.c.DidPanic {
+				t.Errorf("Panic in the function (%T):\n%s\nstack:\n%s", o.c.PVal, PanicMessage(o.c.PVal), o.c.Stack)
+				return delays, nil
+			}
+			return delays, o.err
+		case <-deadline:
+			t.Errorf("Function did not return within %s; delays so far: %v", timeout, delays)
+			return delays, nil
+		case <-ticker.C:
+			if at, ok := clock.nextWaiter(); ok {
+				delays = append(delays, at.Sub(clock.Now()))
+				clock.Set(at)
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// exponential returns a BackoffPolicy that doubles from base, for max retries.
+func exponential(base time.Duration, max int) BackoffPolicy {
+	return func(retry int) (time.Duration, bool) {
+		if retry > max {
+			return 0, false
+		}
+		return base << (retry - 1), true
+	}
+}
+
+func TestAssertBackoffSchedule(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name      string
+		policy    BackoffPolicy
+		want      []time.Duration
+		tolerance time.Duration
+		expected  string // the table, or "" for success
+	}{
+		{name: "exact", policy: exponential(100*ms, 3), want: []time.Duration{100 * ms, 200 * ms, 400 * ms}},
+		{name: "no retries", policy: exponential(100*ms, 0), want: nil},
+		{
+			name: "jitter", policy: exponential(105*ms, 2), want: []time.Duration{100 * ms, 200 * ms},
+			tolerance: 10 * ms,
+		},
+		{
+			name: "too much jitter", policy: exponential(105*ms, 2), want: []time.Duration{100 * ms, 200 * ms},
+			tolerance: 5 * ms,
+			expected:  "  #1: expected 100ms, got 105ms\n* #2: expected 200ms, got 210ms",
+		},
+		{
+			name: "too few", policy: exponential(100*ms, 1), want: []time.Duration{100 * ms, 200 * ms},
+			expected: "  #1: expected 100ms, got 100ms\n* #2: expected 200ms, got (none)",
+		},
+		{
+			name: "doesn't stop", policy: exponential(100*ms, 5), want: []time.Duration{100 * ms},
+			expected: "  #1: expected 100ms, got 100ms\n* #2: expected (none), got 200ms",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := AssertBackoffSchedule(rt, test.policy, test.want, test.tolerance)
+		want := []string{}
+		if test.expected != "" {
+			want = []string{"Delays do not match the expected schedule (tolerance " + test.tolerance.String() + "):\n" +
+				test.expected}
+		}
+		if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+			t.Errorf("AssertBackoffSchedule(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got,
+				test.name)
+		}
+	}
+}
+
+func TestDriveRetries(t *testing.T) {
+	errTemporary := errors.New("temporary")
+
+	// A retry loop that sleeps through its sleep function, giving up after 4 attempts
+	clock := NewFakeClock(time.Time{})
+	attempts := 0
+	delays, err := DriveRetries(t, clock, 5*time.Second, func() error {
+		sleep := clock.Sleep
+		policy := exponential(time.Second, 3)
+		for retry := 1; ; retry++ {
+			attempts++
+			d, ok := policy(retry)
+			if !ok {
+				return errTemporary
+			}
+			sleep(d)
+		}
+	})
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	if !errors.Is(err, errTemporary) || attempts != 4 || !AssertDelays(t, delays, want, 0) {
+		t.Errorf("DriveRetries(): Incorrect result: got delays %v, error %v, %d attempt(s)", delays, err, attempts)
+	}
+	if got := clock.Since(defaultFakeTime); got != 7*time.Second {
+		t.Errorf("DriveRetries(): Incorrect clock advance: expected 7s, got %s", got)
+	}
+
+	// A retry loop that selects on the clock, and succeeds on the third attempt
+	clock = NewFakeClock(time.Time{})
+	attempts = 0
+	delays, err = DriveRetries(t, clock, 5*time.Second, func() error {
+		for {
+			attempts++
+			if attempts == 3 {
+				return nil
+			}
+			<-clock.After(time.Duration(attempts) * time.Minute)
+		}
+	})
+	if err != nil || !reflect.DeepEqual(delays, []time.Duration{time.Minute, 2 * time.Minute}) {
+		t.Errorf("DriveRetries(): Incorrect result with After: got delays %v, error %v", delays, err)
+	}
+
+	// A function that waits on something else
+	rt := &testhelptest.RecordingT{}
+	block := make(chan struct{})
+	defer close(block)
+	delays, err = DriveRetries(rt, NewFakeClock(time.Time{}), 20*time.Millisecond, func() error {
+		<-block
+		return errTemporary
+	})
+	want2 := []string{"Function did not return within 20ms; delays so far: []"}
+	if got := rt.Failures(); err != nil || len(delays) != 0 || !reflect.DeepEqual(got, want2) {
+		t.Errorf("DriveRetries(): Incorrect failures on timeout: expected\n%#+v\ngot\n%#+v", want2, got)
+	}
+
+	// A function that panics after a retry
+	rt = &testhelptest.RecordingT{}
+	clock = NewFakeClock(time.Time{})
+	delays, err = DriveRetries(rt, clock, 5*time.Second, func() error {
+		clock.Sleep(time.Second)
+		panic("retry loop broke")
+	})
+	wantRE := regexp.MustCompile(`^Panic in the function \(string\):\nretry loop broke\nstack:\n(?s:.*)backoff_test\.go`)
+	if got := rt.Failures(); err != nil || !reflect.DeepEqual(delays, []time.Duration{time.Second}) || len(got) != 1 ||
+		!wantRE.MatchString(got[0]) {
+		t.Errorf("DriveRetries(): Incorrect result for a panic: got delays %v, error %v, failures\n%#+v", delays, err,
+			got)
+	}
+}
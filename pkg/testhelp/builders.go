@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Ptr returns a pointer to a copy of v, for filling in pointer fields in table entries without a temporary variable,
+// e.g. Ptr(5) or Ptr("name").
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// SliceOf returns a new slice of the given values.  Unlike a nil slice, the result is never nil, even with no values,
+// which matters for code (such as encoding/json) that treats nil and empty slices differently.
+func SliceOf[T any](values ...T) []T {
+	return append([]T{}, values...)
+}
+
+// MapOf returns a new map with the given keys and values, which alternate, e.g. MapOf[string, int]("a", 1, "b", 2).
+// Each key and value must be assignable to K or V, or a number convertible to it (so that untyped constants such as 5
+// can be used for an int64), as with Call; a nil value can be used for a V of pointer, interface, slice, map, channel,
+// or function type.  The result is never nil, even with no keys.
+//
+// MapOf panics if there's an odd number of arguments, or if one of them doesn't fit its type.
+func MapOf[K comparable, V any](keysAndValues ...interface{}) map[K]V {
+	if len(keysAndValues)%2 != 0 {
+		panic(fmt.Sprintf("MapOf: odd number of arguments (%d); keys and values must alternate", len(keysAndValues)))
+	}
+	keyType, valueType := reflect.TypeOf((*K)(nil)).Elem(), reflect.TypeOf((*V)(nil)).Elem()
+	m := make(map[K]V, len(keysAndValues)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		k, ok := callArg(keysAndValues[i], keyType)
+		if !ok {
+			panic(fmt.Sprintf("MapOf: key %d has type %T, which can't be used as %s", i/2, keysAndValues[i], keyType))
+		}
+		v, ok := callArg(keysAndValues[i+1], valueType)
+		if !ok {
+			panic(fmt.Sprintf("MapOf: value %d has type %T, which can't be used as %s", i/2, keysAndValues[i+1],
+				valueType))
+		}
+		m[k.Interface().(K)] = v.Interface().(V)
+	}
+	return m
+}
+
+// A Builder builds values of type T (normally a struct) by applying overrides to a copy of a base fixture, for table
+// tests whose entries differ from a typical value in only a field or two.  Builders are immutable: With and Set return
+// a new Builder with the override added, so a Builder can be shared as the base for other Builders:
+//
+//	base := testhelp.Build(User{Name: "alice", Age: 30, Email: "alice@example.com"})
+//	tests := []struct {
+//		name string
+//		user User
+//	}{
+//		{"typical", base.Build()},
+//		{"no email", base.Set("Email", "").Build()},
+//		{"minor", base.Set("Age", 12).With(func(u *User) { u.Guardian = &bob }).Build()},
+//	}
+//
+// Note that the base is copied as a Go value is assigned, so the built values share the contents of any maps, slices,
+// or pointers in the base; set such fields to new values (e.g. with SliceOf) instead of modifying them in place.
+type Builder[T any] struct {
+	base      T
+	overrides []func(v *T)
+}
+
+// Build returns a Builder whose values start as copies of base.
+func Build[T any](base T) *Builder[T] {
+	return &Builder[T]{base: base}
+}
+
+// With returns a copy of the Builder with an override that calls f on the value being built.
+func (b *Builder[T]) With(f func(v *T)) *Builder[T] {
+	overrides := make([]func(v *T), len(b.overrides), len(b.overrides)+1)
+	copy(overrides, b.overrides)
+	return &Builder[T]{base: b.base, overrides: append(overrides, f)}
+}
+
+// Set returns a copy of the Builder with an override that sets the given field of the value being built to value.
+// The field can be a path through nested structs, such as "Address.City"; pointers to structs along the path are
+// replaced with pointers to new copies (or to new zero values, if they're nil), so that the base isn't modified.  As
+// with MapOf, value must be assignable to the field, or a number convertible to it, or nil for a field of pointer,
+// interface, slice, map, channel, or function type.
+//
+// Set panics immediately (rather than when the value is built) if T isn't a struct type, or if the field doesn't
+// exist, is unexported, or can't be set to value, so that a mistake in a table is reported at the entry that has it.
+func (b *Builder[T]) Set(field string, value interface{}) *Builder[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	fieldType := builderFieldType(typ, field)
+	v, ok := callArg(value, fieldType)
+	if !ok {
+		panic(fmt.Sprintf("Build: value for field %s of %s has type %T, which can't be used as %s", field, typ, value,
+			fieldType))
+	}
+	return b.With(func(p *T) { builderField(reflect.ValueOf(p).Elem(), field).Set(v) })
+}
+
+// Build returns a new value: a copy of the base, with the overrides applied in the order in which they were added.
+func (b *Builder[T]) Build() T {
+	v := b.base
+	for _, override := range b.overrides {
+		override(&v)
+	}
+	return v
+}
+
+// builderFieldType returns the type of the field of typ with the given path, for Builder.Set.  It panics if the path
+// doesn't lead to an exported field through structs and pointers to structs.
+func builderFieldType(typ reflect.Type, path string) reflect.Type {
+	t := typ
+	for i, name := range strings.Split(path, ".") {
+		if i > 0 && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("Build: can't set field %s of %s: %s isn't a struct type", path, typ, t))
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			panic(fmt.Sprintf("Build: no field %s in %s", path, typ))
+		} else if !f.IsExported() {
+			panic(fmt.Sprintf("Build: field %s of %s is unexported", path, typ))
+		}
+		t = f.Type
+	}
+	return t
+}
+
+// builderField returns the field of v with the given path, replacing pointers to structs along the way with pointers
+// to copies, for Builder.Set.  The path has already been checked by builderFieldType.
+func builderField(v reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Ptr {
+			p := reflect.New(v.Type().Elem())
+			if !v.IsNil() {
+				p.Elem().Set(v.Elem())
+			}
+			v.Set(p)
+			v = p.Elem()
+		}
+		v = v.FieldByName(name)
+	}
+	return v
+}
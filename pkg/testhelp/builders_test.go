@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"testing"
+)
+
+type builderAddress struct {
+	City string
+	Zip  string
+}
+
+type builderUser struct {
+	Name    string
+	Age     int64
+	Tags    []string
+	Home    builderAddress
+	Work    *builderAddress
+	Manager *builderUser
+	notes   string
+}
+
+func TestPtrSliceOfMapOf(t *testing.T) {
+	if p := Ptr(5); *p != 5 {
+		t.Errorf("Ptr(): Incorrect value: expected 5, got %d", *p)
+	}
+	if s := SliceOf[int](); s == nil || len(s) != 0 {
+		t.Errorf("SliceOf(): Expected an empty, non-nil slice, got %#+v", s)
+	}
+	if s, want := SliceOf("a", "b"), []string{"a", "b"}; !reflect.DeepEqual(s, want) {
+		t.Errorf("SliceOf(): Incorrect slice: expected %#+v, got %#+v", want, s)
+	}
+	if m := MapOf[string, int](); m == nil || len(m) != 0 {
+		t.Errorf("MapOf(): Expected an empty, non-nil map, got %#+v", m)
+	}
+	m, want := MapOf[string, int64]("a", 1, "b", int64(2)), map[string]int64{"a": 1, "b": 2}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("MapOf(): Incorrect map: expected %#+v, got %#+v", want, m)
+	}
+	if m, want := MapOf[int, []int](1, nil), map[int][]int{1: nil}; !reflect.DeepEqual(m, want) {
+		t.Errorf("MapOf(): Incorrect map with a nil value: expected %#+v, got %#+v", want, m)
+	}
+
+	panicTests := []struct {
+		name    string
+		f       func()
+		wantStr string
+	}{
+		{"odd", func() { MapOf[string, int]("a", 1, "b") }, "MapOf: odd number of arguments (3)"},
+		{"bad key", func() { MapOf[string, int](1, 1) }, "MapOf: key 0 has type int, which can't be used as string"},
+		{"bad value", func() { MapOf[string, int]("a", 1, "b", "2") },
+			"MapOf: value 1 has type string, which can't be used as int"},
+	}
+	for _, test := range panicTests {
+		if didPanic, pContainsStr, pVal := PanicsStr(test.f, test.wantStr); !didPanic || !pContainsStr {
+			t.Errorf("Incorrect panic: expected a panic containing\n%q\ngot\n%#+v\nin test '%s'", test.wantStr, pVal,
+				test.name)
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	work := &builderAddress{City: "Boston", Zip: "02110"}
+	base := Build(builderUser{Name: "alice", Age: 30, Home: builderAddress{City: "Cambridge"}, Work: work})
+	older := base.Set("Age", 40)
+
+	tests := []struct {
+		name string
+		got  builderUser
+		want builderUser
+	}{
+		{"base", base.Build(),
+			builderUser{Name: "alice", Age: 30, Home: builderAddress{City: "Cambridge"}, Work: work}},
+		{"set", older.Build(),
+			builderUser{Name: "alice", Age: 40, Home: builderAddress{City: "Cambridge"}, Work: work}},
+		{"set from a shared builder", older.Set("Name", "bob").Build(),
+			builderUser{Name: "bob", Age: 40, Home: builderAddress{City: "Cambridge"}, Work: work}},
+		{"nested", base.Set("Home.Zip", "02139").Set("Work.City", "Salem").Build(),
+			builderUser{Name: "alice", Age: 30, Home: builderAddress{City: "Cambridge", Zip: "02139"},
+				Work: &builderAddress{City: "Salem", Zip: "02110"}}},
+		{"nil pointer", base.Set("Manager.Name", "carol").Build(),
+			builderUser{Name: "alice", Age: 30, Home: builderAddress{City: "Cambridge"}, Work: work,
+				Manager: &builderUser{Name: "carol"}}},
+		{"nil value", base.Set("Work", nil).Set("Tags", SliceOf("x")).Build(),
+			builderUser{Name: "alice", Age: 30, Home: builderAddress{City: "Cambridge"}, Tags: []string{"x"}}},
+		{"with", base.With(func(u *builderUser) { u.Name += "!" }).Set("Age", 1).Build(),
+			builderUser{Name: "alice!", Age: 1, Home: builderAddress{City: "Cambridge"}, Work: work}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("Build(): Incorrect value: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.want, test.got,
+				test.name)
+		}
+	}
+	if *work != (builderAddress{City: "Boston", Zip: "02110"}) {
+		t.Errorf("Build(): The base's pointer field was modified: got %#+v", *work)
+	}
+
+	panicTests := []struct {
+		name    string
+		f       func()
+		wantStr string
+	}{
+		{"no field", func() { base.Set("Email", "x") }, "Build: no field Email in testhelp.builderUser"},
+		{"no nested field", func() { base.Set("Home.Street", "x") }, "Build: no field Home.Street in testhelp.builderUser"},
+		{"unexported", func() { base.Set("notes", "x") }, "Build: field notes of testhelp.builderUser is unexported"},
+		{"not a struct", func() { base.Set("Name.First", "x") },
+			"Build: can't set field Name.First of testhelp.builderUser: string isn't a struct type"},
+		{"not a struct builder", func() { Build(5).Set("X", 1) },
+			"Build: can't set field X of int: int isn't a struct type"},
+		{"wrong type", func() { base.Set("Age", "old") },
+			"Build: value for field Age of testhelp.builderUser has type string, which can't be used as int64"},
+	}
+	for _, test := range panicTests {
+		if didPanic, pContainsStr, pVal := PanicsStr(test.f, test.wantStr); !didPanic || !pContainsStr {
+			t.Errorf("Incorrect panic: expected a panic containing\n%q\ngot\n%#+v\nin test '%s'", test.wantStr, pVal,
+				test.name)
+		}
+	}
+}
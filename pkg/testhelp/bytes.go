@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// BytesContain checks that haystack, such as the output of a binary encoder, contains needle as a contiguous
+// sequence, calling t.Errorf if not.  The failure gives needle in hex, and a hex dump of the rows of haystack around
+// the longest prefix of needle that it does contain (or its first rows, if it doesn't contain even the first byte),
+// with the rows of that partial match marked with "*".  Unlike checks on string(haystack), it works the same on
+// bytes that aren't valid UTF-8.  It returns true if the check passed.
+//
+// As with bytes.Contains, every haystack contains an empty needle.
+func BytesContain(t TestingTB, haystack, needle []byte) bool {
+	t.Helper()
+	if bytes.Contains(haystack, needle) {
+		return true
+	}
+	t.Errorf("Bytes do not contain the expected sequence: expected\n%s\nin %d bytes:\n%s", hexBytes(needle),
+		len(haystack), partialMatchDump(haystack, needle))
+	return false
+}
+
+// RuneCount checks that got, which can be a string or a byte slice, has want runes (as counted by
+// utf8.RuneCountInString or utf8.RuneCount, in which each byte of an invalid UTF-8 sequence counts as one rune),
+// calling t.Errorf if not.  If got isn't valid UTF-8, the failure also gives the offset of the first invalid byte,
+// since that's a common reason for an unexpected count.  It returns true if the check passed.
+func RuneCount[S ~string | ~[]byte](t TestingTB, got S, want int) bool {
+	t.Helper()
+	b := []byte(got)
+	if n := utf8.RuneCount(b); n != want {
+		t.Errorf("Incorrect rune count: expected %d, got %d (in %d bytes%s) for\n%q", want, n, len(b),
+			invalidUTF8Note(b), b)
+		return false
+	}
+	return true
+}
+
+// ValidUTF8 checks that got, which can be a string or a byte slice, is valid UTF-8, calling t.Errorf with the offset
+// of the first invalid byte and a hex dump of the rows around it if not.  It returns true if the check passed.
+func ValidUTF8[S ~string | ~[]byte](t TestingTB, got S) bool {
+	t.Helper()
+	b := []byte(got)
+	offset := firstInvalidUTF8(b)
+	if offset < 0 {
+		return true
+	}
+	t.Errorf("Invalid UTF-8 at offset %d (0x%x) of %d bytes:\n%s", offset, offset, len(b),
+		hexContext(b, offset, offset+1))
+	return false
+}
+
+// firstInvalidUTF8 returns the offset of the first byte of b that isn't part of a valid UTF-8 sequence, or -1 if b is
+// valid UTF-8.
+func firstInvalidUTF8(b []byte) int {
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size == 1 {
+			return i
+		}
+		i += size
+	}
+	return -1
+}
+
+// invalidUTF8Note returns a note about the first invalid byte of b, to add to a failure message, or "" if b is valid
+// UTF-8.
+func invalidUTF8Note(b []byte) string {
+	offset := firstInvalidUTF8(b)
+	if offset < 0 {
+		return ""
+	}
+	return fmt.Sprintf(", not valid UTF-8 from offset %d (0x%x)", offset, offset)
+}
+
+// hexBytes formats b as space-separated hex bytes, or "(empty)" if there are none.
+func hexBytes(b []byte) string {
+	if len(b) == 0 {
+		return "(empty)"
+	}
+	return strings.TrimRight(fmt.Sprintf("% x", b), " ")
+}
+
+// partialMatchDump returns a hex dump of the rows of haystack around the longest prefix of needle that haystack
+// contains, for BytesContain.
+func partialMatchDump(haystack, needle []byte) string {
+	start, n := 0, 0
+	for n < len(needle) {
+		i := bytes.Index(haystack, needle[:n+1])
+		if i < 0 {
+			break
+		}
+		start, n = i, n+1
+	}
+	if n == 0 {
+		return "(no part of the expected sequence was found)\n" + hexContext(haystack, 0, 0)
+	}
+	return fmt.Sprintf("(the longest partial match is the first %d byte(s), at offset %d (0x%x))\n%s", n, start,
+		start, hexContext(haystack, start, start+n))
+}
+
+// hexContext returns a hex dump of the rows of data around the bytes from start to end, with the rows that overlap
+// those bytes marked with "*".  If start == end, no rows are marked.
+func hexContext(data []byte, start, end int) string {
+	if len(data) == 0 {
+		return "  (no bytes)"
+	}
+	firstRow := start/hexDumpWidth - hexDumpContext
+	if firstRow < 0 {
+		firstRow = 0
+	}
+	lastRow := (end-1)/hexDumpWidth + hexDumpContext
+	if end <= start {
+		lastRow = start/hexDumpWidth + hexDumpContext
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %-8s  %s", "offset", "bytes")
+	for row := firstRow; row <= lastRow && row*hexDumpWidth < len(data); row++ {
+		rowStart := row * hexDumpWidth
+		marker := " "
+		if start < end && rowStart < end && start < rowStart+hexDumpWidth {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "\n%s %08x  %s", marker, rowStart, strings.TrimRight(hexRow(data, rowStart), " "))
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestBytesContain(t *testing.T) {
+	haystack := make([]byte, 96)
+	for i := range haystack {
+		haystack[i] = byte(i)
+	}
+	haystack[50] = 0xff // not valid UTF-8, and not part of any match
+
+	tests := []struct {
+		name         string
+		haystack     []byte
+		needle       []byte
+		wantOK       bool
+		wantFailures []string
+	}{
+		{"found", haystack, []byte{0x30, 0x31, 0xff, 0x33}, true, []string{}},
+		{"empty needle", nil, nil, true, []string{}},
+		{"partial match", haystack, []byte{0x40, 0x41, 0x42, 0x00}, false, []string{
+			"Bytes do not contain the expected sequence: expected\n40 41 42 00\nin 96 bytes:\n" +
+				"(the longest partial match is the first 3 byte(s), at offset 64 (0x40))\n" +
+				"  offset    bytes\n" +
+				"  00000020  20 21 22 23 24 25 26 27  28 29 2a 2b 2c 2d 2e 2f\n" +
+				"  00000030  30 31 ff 33 34 35 36 37  38 39 3a 3b 3c 3d 3e 3f\n" +
+				"* 00000040  40 41 42 43 44 45 46 47  48 49 4a 4b 4c 4d 4e 4f\n" +
+				"  00000050  50 51 52 53 54 55 56 57  58 59 5a 5b 5c 5d 5e 5f",
+		}},
+		{"partial match across rows", haystack, []byte{0x0e, 0x0f, 0x10, 0x12}, false, []string{
+			"Bytes do not contain the expected sequence: expected\n0e 0f 10 12\nin 96 bytes:\n" +
+				"(the longest partial match is the first 3 byte(s), at offset 14 (0xe))\n" +
+				"  offset    bytes\n" +
+				"* 00000000  00 01 02 03 04 05 06 07  08 09 0a 0b 0c 0d 0e 0f\n" +
+				"* 00000010  10 11 12 13 14 15 16 17  18 19 1a 1b 1c 1d 1e 1f\n" +
+				"  00000020  20 21 22 23 24 25 26 27  28 29 2a 2b 2c 2d 2e 2f\n" +
+				"  00000030  30 31 ff 33 34 35 36 37  38 39 3a 3b 3c 3d 3e 3f",
+		}},
+		{"no partial match", []byte{0xc3, 0x28}, []byte{0xaa}, false, []string{
+			"Bytes do not contain the expected sequence: expected\naa\nin 2 bytes:\n" +
+				"(no part of the expected sequence was found)\n" +
+				"  offset    bytes\n" +
+				"  00000000  c3 28",
+		}},
+		{"empty haystack", nil, []byte{0x01}, false, []string{
+			"Bytes do not contain the expected sequence: expected\n01\nin 0 bytes:\n" +
+				"(no part of the expected sequence was found)\n  (no bytes)",
+		}},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if ok := BytesContain(rt, test.haystack, test.needle); ok != test.wantOK {
+			t.Errorf("BytesContain(): Incorrect result: expected %t, got %t in test '%s'", test.wantOK, ok, test.name)
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, test.wantFailures) {
+			t.Errorf("BytesContain(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantFailures,
+				got, test.name)
+		}
+	}
+}
+
+func TestRuneCount(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	results := []bool{
+		RuneCount(rt, "héllo", 5),
+		RuneCount(rt, []byte("héllo"), 5),
+		RuneCount(rt, "héllo", 6),
+		RuneCount(rt, []byte{'a', 0xc3, 0x28}, 2),
+	}
+	wantResults := []bool{true, true, false, false}
+	if !reflect.DeepEqual(results, wantResults) {
+		t.Errorf("RuneCount(): Incorrect results: expected\n%#+v\ngot\n%#+v", wantResults, results)
+	}
+	wantFailures := []string{
+		"Incorrect rune count: expected 6, got 5 (in 6 bytes) for\n\"héllo\"",
+		"Incorrect rune count: expected 2, got 3 (in 3 bytes, not valid UTF-8 from offset 1 (0x1)) for\n\"a\\xc3(\"",
+	}
+	if got := rt.Failures(); !reflect.DeepEqual(got, wantFailures) {
+		t.Errorf("RuneCount(): Incorrect failures: expected\n%#+v\ngot\n%#+v", wantFailures, got)
+	}
+}
+
+func TestValidUTF8(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	results := []bool{
+		ValidUTF8(rt, "héllo"),
+		ValidUTF8(rt, []byte{}),
+		ValidUTF8(rt, "ok\xe2\x82"),
+	}
+	wantResults := []bool{true, true, false}
+	if !reflect.DeepEqual(results, wantResults) {
+		t.Errorf("ValidUTF8(): Incorrect results: expected\n%#+v\ngot\n%#+v", wantResults, results)
+	}
+	wantFailures := []string{
+		"Invalid UTF-8 at offset 2 (0x2) of 4 bytes:\n  offset    bytes\n* 00000000  6f 6b e2 82",
+	}
+	if got := rt.Failures(); !reflect.DeepEqual(got, wantFailures) {
+		t.Errorf("ValidUTF8(): Incorrect failures: expected\n%#+v\ngot\n%#+v", wantFailures, got)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Call returns a function that calls the method of recv with the given name, with the given arguments, discarding any
+// results.  This is for building panic tests over many methods or argument sets programmatically, instead of writing a
+// func() literal for each one; see also CallTests.
+//
+// The method must be exported, and recv must have it in its method set (so for a method with a pointer receiver,
+// recv must be a pointer).  Each argument must be assignable to the corresponding parameter, or a number whose value
+// the parameter's type can represent exactly (so that untyped constants such as 5 can be passed to an int64 parameter,
+// but -1 can't be passed to a uint16 one, or 3.7 to an int one; a float64 passed to a float32 parameter only has to be
+// in range); a nil argument can be passed for a parameter of pointer, interface, slice, map, channel, or function
+// type.  Variadic methods take their variadic
+// arguments individually, as in a normal call.
+//
+// Call panics immediately (rather than when the returned function is run) if the method doesn't exist or the
+// arguments don't fit it, so that a mistake in the table isn't mistaken for a panic from the code under test.
+func Call(recv interface{}, method string, args ...interface{}) func() {
+	m, in := callArgs(recv, method, args)
+	return func() { m.Call(in) }
+}
+
+// CallPanics tests if calling the method of recv with the given name and arguments panics, as with Call and Panics.
+func CallPanics(recv interface{}, method string, args ...interface{}) (didPanic bool) {
+	return Panics(Call(recv, method, args...))
+}
+
+// CallPanicsGet tests if calling the method of recv with the given name and arguments panics, as with Call and
+// PanicsGet, and also returns the panic value.
+func CallPanicsGet(recv interface{}, method string, args ...interface{}) (didPanic bool, pVal interface{}) {
+	return PanicsGet(Call(recv, method, args...))
+}
+
+// CallTests returns a PanicTest for each of the argument sets, calling the method of recv with the given name, as
+// with Call.  The tests are named after the calls, e.g. `Parse("", 3)`.  The result can be passed to PanicsLoop or
+// RunPanicTests directly, or used as the basis of a table with more checks:
+//
+//	for _, test := range testhelp.CallTests(p, "Parse", [][]interface{}{{"", 3}, {"x", -1}}) {
+//		tests = append(tests, testhelp.PanicStrTest{Name: test.Name, F: test.F, WantStr: "invalid"})
+//	}
+//
+// CallTests panics if any of the argument sets doesn't fit the method.
+func CallTests(recv interface{}, method string, argSets [][]interface{}) []PanicTest {
+	tests := make([]PanicTest, 0, len(argSets))
+	for _, args := range argSets {
+		tests = append(tests, PanicTest{Name: callName(method, args), F: Call(recv, method, args...)})
+	}
+	return tests
+}
+
+// callName returns the name of a call for CallTests.
+func callName(method string, args []interface{}) string {
+	strs := make([]string, 0, len(args))
+	for _, arg := range args {
+		strs = append(strs, Format(arg))
+	}
+	return fmt.Sprintf("%s(%s)", method, strings.Join(strs, ", "))
+}
+
+// callArgs looks up the method for Call, and converts the arguments to the types of its parameters.  It panics if the
+// method doesn't exist or the arguments don't fit.
+func callArgs(recv interface{}, method string, args []interface{}) (reflect.Value, []reflect.Value) {
+	if recv == nil {
+		panic(fmt.Sprintf("Call: no method %q on nil receiver", method))
+	}
+	m := reflect.ValueOf(recv).MethodByName(method)
+	if !m.IsValid() {
+		hint := ""
+		if _, ok := reflect.PtrTo(reflect.TypeOf(recv)).MethodByName(method); ok {
+			hint = " (it has a pointer receiver, so pass a pointer)"
+		}
+		panic(fmt.Sprintf("Call: no method %q on %T%s", method, recv, hint))
+	}
+
+	mt := m.Type()
+	fixed := mt.NumIn()
+	if mt.IsVariadic() {
+		fixed--
+	}
+	if mt.IsVariadic() && len(args) < fixed {
+		panic(fmt.Sprintf("Call: wrong number of arguments for %T.%s: expected at least %d, got %d", recv, method,
+			fixed, len(args)))
+	} else if !mt.IsVariadic() && len(args) != fixed {
+		panic(fmt.Sprintf("Call: wrong number of arguments for %T.%s: expected %d, got %d", recv, method, fixed,
+			len(args)))
+	}
+	in := make([]reflect.Value, 0, len(args))
+	for i, arg := range args {
+		var paramType reflect.Type
+		if i < fixed {
+			paramType = mt.In(i)
+		} else {
+			paramType = mt.In(fixed).Elem()
+		}
+		v, ok := callArg(arg, paramType)
+		if !ok && arg != nil && isNumber(reflect.TypeOf(arg).Kind()) && isNumber(paramType.Kind()) {
+			panic(fmt.Sprintf("Call: argument %d to %T.%s is %v (%T), which can't be represented exactly as %s", i+1,
+				recv, method, arg, arg, paramType))
+		} else if !ok {
+			panic(fmt.Sprintf("Call: argument %d to %T.%s has type %T, which can't be used as %s", i+1, recv, method,
+				arg, paramType))
+		}
+		in = append(in, v)
+	}
+	return m, in
+}
+
+// callArg converts an argument for Call to the given parameter type, returning false if it can't be.
+func callArg(arg interface{}, paramType reflect.Type) (reflect.Value, bool) {
+	if arg == nil {
+		switch paramType.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(paramType), true
+		}
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(arg)
+	if v.Type().AssignableTo(paramType) {
+		return v, true
+	}
+	if isNumber(v.Kind()) && isNumber(paramType.Kind()) && representable(v, paramType) {
+		return v.Convert(paramType), true
+	}
+	return reflect.Value{}, false
+}
+
+// representable returns true if the number v can be converted to the number type t without changing its value (except
+// for rounding when converting between floating-point types).
+func representable(v reflect.Value, t reflect.Type) bool {
+	z := reflect.Zero(t)
+	switch {
+	case isSigned(t.Kind()):
+		switch {
+		case isSigned(v.Kind()):
+			return !z.OverflowInt(v.Int())
+		case isFloat(v.Kind()):
+			f := v.Float()
+			return f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !z.OverflowInt(int64(f))
+		default:
+			return v.Uint() <= math.MaxInt64 && !z.OverflowInt(int64(v.Uint()))
+		}
+	case isFloat(t.Kind()):
+		if isFloat(v.Kind()) {
+			return !z.OverflowFloat(v.Float())
+		}
+		// Integers are representable if they survive the round trip
+		return v.Convert(t).Convert(v.Type()).Interface() == v.Interface()
+	default:
+		switch {
+		case isSigned(v.Kind()):
+			return v.Int() >= 0 && !z.OverflowUint(uint64(v.Int()))
+		case isFloat(v.Kind()):
+			f := v.Float()
+			return f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !z.OverflowUint(uint64(f))
+		default:
+			return !z.OverflowUint(v.Uint())
+		}
+	}
+}
+
+// isNumber returns true for the kinds of integers and floating-point numbers.
+func isNumber(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uintptr) || isFloat(k)
+}
+
+// isSigned returns true for the kinds of signed integers.
+func isSigned(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+// isFloat returns true for the kinds of floating-point numbers.
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
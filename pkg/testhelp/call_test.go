@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type callTestRecv struct {
+	calls []string
+}
+
+func (r *callTestRecv) Div(a, b int64) int64 {
+	r.calls = append(r.calls, fmt.Sprintf("Div(%d, %d)", a, b))
+	return a / b
+}
+
+func (r *callTestRecv) Join(sep string, parts ...string) {
+	r.calls = append(r.calls, fmt.Sprintf("Join(%q, %q)", sep, parts))
+}
+
+func (r *callTestRecv) Deref(p *int) {
+	r.calls = append(r.calls, "Deref")
+	_ = *p
+}
+
+func (r *callTestRecv) Listen(port uint16, ratio float32, count int) {
+	r.calls = append(r.calls, fmt.Sprintf("Listen(%d, %g, %d)", port, ratio, count))
+}
+
+func (r callTestRecv) Value() {
+	panic("value receiver")
+}
+
+func TestCall(t *testing.T) {
+	recv := &callTestRecv{}
+	tests := []struct {
+		name       string
+		recv       interface{}
+		method     string
+		args       []interface{}
+		wantPanics bool
+		wantCalls  []string
+	}{
+		{"converted args", recv, "Div", []interface{}{6, int64(3)}, false, []string{"Div(6, 3)"}},
+		{"panics", recv, "Div", []interface{}{int8(1), 0}, true, []string{"Div(1, 0)"}},
+		{"exact conversions", recv, "Listen", []interface{}{8080, 0.1, 3.0}, false,
+			[]string{"Listen(8080, 0.1, 3)"}},
+		{"variadic, none", recv, "Join", []interface{}{","}, false, []string{`Join(",", [])`}},
+		{"variadic, some", recv, "Join", []interface{}{",", "a", "b"}, false, []string{`Join(",", ["a" "b"])`}},
+		{"nil pointer", recv, "Deref", []interface{}{nil}, true, []string{"Deref"}},
+		{"value receiver", callTestRecv{}, "Value", nil, true, nil},
+		{"value receiver via pointer", recv, "Value", nil, true, nil},
+	}
+	for _, test := range tests {
+		recv.calls = nil
+		didPanic := CallPanics(test.recv, test.method, test.args...)
+		if didPanic != test.wantPanics {
+			t.Errorf("CallPanics(): Incorrect result: expected %t, got %t in test '%s'", test.wantPanics, didPanic,
+				test.name)
+		}
+		if !reflect.DeepEqual(recv.calls, test.wantCalls) {
+			t.Errorf("CallPanics(): Incorrect calls: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantCalls,
+				recv.calls, test.name)
+		}
+	}
+
+	didPanic, pVal := CallPanicsGet(callTestRecv{}, "Value")
+	if !didPanic || pVal != "value receiver" {
+		t.Errorf("CallPanicsGet(): Incorrect results: expected true, \"value receiver\", got %t, %#+v", didPanic, pVal)
+	}
+}
+
+func TestCallPanicsOnBadCalls(t *testing.T) {
+	recv := &callTestRecv{}
+	tests := []struct {
+		name    string
+		recv    interface{}
+		method  string
+		args    []interface{}
+		wantStr string
+	}{
+		{"nil receiver", nil, "Div", nil, `Call: no method "Div" on nil receiver`},
+		{"no method", recv, "Mul", nil, `Call: no method "Mul" on *testhelp.callTestRecv`},
+		{"unexported", recv, "calls", nil, `Call: no method "calls" on *testhelp.callTestRecv`},
+		{"pointer receiver", callTestRecv{}, "Div", []interface{}{1, 2},
+			`Call: no method "Div" on testhelp.callTestRecv (it has a pointer receiver, so pass a pointer)`},
+		{"too few", recv, "Div", []interface{}{1},
+			"Call: wrong number of arguments for *testhelp.callTestRecv.Div: expected 2, got 1"},
+		{"too many", recv, "Div", []interface{}{1, 2, 3},
+			"Call: wrong number of arguments for *testhelp.callTestRecv.Div: expected 2, got 3"},
+		{"too few variadic", recv, "Join", nil,
+			"Call: wrong number of arguments for *testhelp.callTestRecv.Join: expected at least 1, got 0"},
+		{"wrong type", recv, "Div", []interface{}{1, "2"},
+			"Call: argument 2 to *testhelp.callTestRecv.Div has type string, which can't be used as int64"},
+		{"wrong variadic type", recv, "Join", []interface{}{",", "a", 1},
+			"Call: argument 3 to *testhelp.callTestRecv.Join has type int, which can't be used as string"},
+		{"negative for unsigned", recv, "Listen", []interface{}{-1, 0.5, 1},
+			"Call: argument 1 to *testhelp.callTestRecv.Listen is -1 (int), which can't be represented exactly as uint16"},
+		{"too big for unsigned", recv, "Listen", []interface{}{70000, 0.5, 1},
+			"Call: argument 1 to *testhelp.callTestRecv.Listen is 70000 (int), which can't be represented exactly as " +
+				"uint16"},
+		{"out of range float", recv, "Listen", []interface{}{1, 1e300, 1},
+			"Call: argument 2 to *testhelp.callTestRecv.Listen is 1e+300 (float64), which can't be represented exactly " +
+				"as float32"},
+		{"inexact integer for float", recv, "Listen", []interface{}{1, 1<<24 + 1, 1},
+			"Call: argument 2 to *testhelp.callTestRecv.Listen is 16777217 (int), which can't be represented exactly " +
+				"as float32"},
+		{"fraction for integer", recv, "Listen", []interface{}{1, 0.5, 3.7},
+			"Call: argument 3 to *testhelp.callTestRecv.Listen is 3.7 (float64), which can't be represented exactly " +
+				"as int"},
+		{"huge unsigned for signed", recv, "Div", []interface{}{uint64(1 << 63), 1},
+			"Call: argument 1 to *testhelp.callTestRecv.Div is 9223372036854775808 (uint64), which can't be " +
+				"represented exactly as int64"},
+		{"nil for non-nilable", recv, "Div", []interface{}{nil, 1},
+			"Call: argument 1 to *testhelp.callTestRecv.Div has type <nil>, which can't be used as int64"},
+	}
+	for _, test := range tests {
+		didPanic, pVal := PanicsGet(func() { Call(test.recv, test.method, test.args...) })
+		if !didPanic || pVal != test.wantStr {
+			t.Errorf("Call(): Incorrect panic: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantStr, pVal, test.name)
+		}
+	}
+}
+
+func TestCallTests(t *testing.T) {
+	recv := &callTestRecv{}
+	tests := CallTests(recv, "Div", [][]interface{}{{6, 3}, {1, 0}})
+	var names []string
+	for _, test := range tests {
+		names = append(names, test.Name)
+	}
+	if want := []string{"Div(6, 3)", "Div(1, 0)"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("CallTests(): Incorrect names: expected\n%#+v\ngot\n%#+v", want, names)
+	}
+
+	var notPanicked []string
+	PanicsLoop(tests, func(testName string) { notPanicked = append(notPanicked, testName) })
+	if want := []string{"Div(6, 3)"}; !reflect.DeepEqual(notPanicked, want) {
+		t.Errorf("PanicsLoop(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, notPanicked)
+	}
+}
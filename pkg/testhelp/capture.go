@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+// A CapturedPanic holds the result of running a function once with Capture, so that any number of checks can be made
+// on the panic without running the function again (which would break functions that aren't idempotent).  Stack is the
+// stack of the goroutine at the point of the panic, or "" if the function did not panic.  Name is the name of the
+// test, when captured by CaptureLoop.
+//
+// The check methods all return false if the function did not panic, so they can be combined directly:
+//
+//	c := testhelp.Capture(func() { mypkg.Consume(queue) })
+//	if !c.ContainsStr("empty") || !c.MatchesRE(`^consume: `) {
+//		t.Errorf("Incorrect panic from Consume: %s\n%s", testhelp.PanicMessage(c.PVal), c.Stack)
+//	}
+type CapturedPanic struct {
+	Name     string
+	DidPanic bool
+	PVal     interface{}
+	Stack    string
+}
+
+// Capture runs f once, and returns the result.
+func Capture(f func()) CapturedPanic {
+	didPanic, pVal, stack := panicsWithStack(f)
+	if !didPanic {
+		stack = ""
+	}
+	return CapturedPanic{DidPanic: didPanic, PVal: pVal, Stack: stack}
+}
+
+// ContainsStr returns true if the function panicked and the panic value can be cast to a string or error containing
+// wantStr, as in PanicsStr.
+func (c CapturedPanic) ContainsStr(wantStr string) bool {
+	return c.Matches(Contains(wantStr))
+}
+
+// MatchesRE returns true if the function panicked and the panic value can be cast to a string or error matching the
+// regular expression given by wantRE, as in PanicsRE.
+//
+// MatchesRE panics if wantRE does not represent a valid regular expression.
+func (c CapturedPanic) MatchesRE(wantRE string) bool {
+	return c.Matches(Regexp(wantRE))
+}
+
+// EqualsVal returns true if the function panicked and the panic value equals wantVal, as in PanicsVal.
+//
+// EqualsVal panics if the panic value and wantVal are of the same type, but it's not a type that Go can compare with
+// ==.
+func (c CapturedPanic) EqualsVal(wantVal interface{}) bool {
+	return c.Matches(EqualsVal(wantVal))
+}
+
+// IsType returns true if the function panicked and the panic value has the same dynamic type as example.
+//
+// IsType panics if example is nil.
+func (c CapturedPanic) IsType(example interface{}) bool {
+	return c.Matches(IsType(example))
+}
+
+// Matches returns true if the function panicked and the panic value matches all of the matchers, as in
+// PanicsMatching.
+func (c CapturedPanic) Matches(matchers ...PanicMatcher) bool {
+	return c.DidPanic && firstMismatch(c.PVal, matchers) == nil
+}
+
+// CaptureLoop runs through a slice of panic tests, running each test function once, and returns the results in the
+// same order, for analysis after the fact.  This allows any number of checks, or statistics over the whole table,
+// without running the functions again.  For example:
+//
+//	for _, c := range testhelp.CaptureLoop(tests) {
+//		if msg := testhelp.PanicMessage(c.PVal); len(msg) > 200 {
+//			t.Errorf("Panic message too long in test '%s': %d characters", c.Name, len(msg))
+//		}
+//	}
+func CaptureLoop(tests []PanicTest) []CapturedPanic {
+	return new(LoopRunner).CaptureLoop(tests)
+}
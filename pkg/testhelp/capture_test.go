@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	calls := 0
+	c := Capture(func() {
+		calls++
+		panic("consume: queue is empty")
+	})
+	if !c.DidPanic || c.PVal != "consume: queue is empty" {
+		t.Errorf("Capture(): Incorrect result: got\n%#+v", c)
+	}
+	if !strings.Contains(c.Stack, "capture_test.go") {
+		t.Errorf("Capture(): Stack doesn't include the panicking function:\n%s", c.Stack)
+	}
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"ContainsStr", c.ContainsStr("empty"), true},
+		{"ContainsStr, no match", c.ContainsStr("full"), false},
+		{"MatchesRE", c.MatchesRE("^consume: "), true},
+		{"MatchesRE, no match", c.MatchesRE("^produce: "), false},
+		{"EqualsVal", c.EqualsVal("consume: queue is empty"), true},
+		{"EqualsVal, no match", c.EqualsVal(5), false},
+		{"IsType", c.IsType(""), true},
+		{"IsType, no match", c.IsType(5), false},
+		{"Matches", c.Matches(Contains("queue"), Not(Contains("full"))), true},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("CapturedPanic: Incorrect result: expected %t, got %t in test '%s'", test.want, test.got,
+				test.name)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Capture(): Function called %d times, expected 1", calls)
+	}
+}
+
+func TestCaptureNoPanic(t *testing.T) {
+	c := Capture(func() {})
+	if c.DidPanic || c.PVal != nil || c.Stack != "" {
+		t.Errorf("Capture(): Incorrect result with no panic: got\n%#+v", c)
+	}
+	if c.ContainsStr("") || c.MatchesRE("") || c.EqualsVal(nil) || c.Matches() {
+		t.Errorf("CapturedPanic: Expected checks to fail when there was no panic")
+	}
+}
+
+func TestCaptureLoop(t *testing.T) {
+	calls := map[string]int{}
+	tests := []PanicTest{
+		{Name: "np", F: func() { calls["np"]++ }},
+		{Name: "p1", F: func() { calls["p1"]++; panic("index 1 out of range") }},
+		{Name: "p2", F: func() { calls["p2"]++; panic("index 2 out of range") }},
+	}
+	results := CaptureLoop(tests)
+	if len(results) != len(tests) {
+		t.Fatalf("CaptureLoop(): Wrong number of results: expected %d, got %d", len(tests), len(results))
+	}
+	for i, c := range results {
+		if c.Name != tests[i].Name {
+			t.Errorf("CaptureLoop(): Wrong name for result %d: expected '%s', got '%s'", i, tests[i].Name, c.Name)
+		}
+		if wantPanic := i > 0; c.DidPanic != wantPanic {
+			t.Errorf("CaptureLoop(): Wrong DidPanic in test '%s': expected %t, got %t", c.Name, wantPanic, c.DidPanic)
+		}
+		if c.DidPanic && (!c.MatchesRE(`^index \d`) || !c.ContainsStr("out of range")) {
+			t.Errorf("CaptureLoop(): Incorrect panic value in test '%s': got\n%#+v", c.Name, c.PVal)
+		}
+		if calls[c.Name] != 1 {
+			t.Errorf("CaptureLoop(): Function called %d times in test '%s', expected 1", calls[c.Name], c.Name)
+		}
+	}
+}
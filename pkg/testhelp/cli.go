@@ -0,0 +1,157 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// panicExitCode is the exit code RunCLI reports when the CLI panics, the same one the Go runtime uses.
+const panicExitCode = 2
+
+// A CLIResult holds the result of a RunCLI call, with methods that check it, reporting failures to the test that ran
+// the CLI.
+type CLIResult struct {
+	Args     []string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+
+	t TestingTB
+}
+
+// RunCLI runs a command-line application in-process, for end-to-end tests of flag- or cobra-based CLIs without
+// building a binary.  main is the application's entry point, taking the arguments (without the program name) and
+// returning the exit code; a typical main package is split so that its main function is just
+//
+//	func main() { os.Exit(run(os.Args[1:])) }
+//
+// While main runs, os.Args is set to the program name followed by args (for code that reads it directly, such as
+// cobra commands without SetArgs), os.Stdin reads from stdin, and os.Stdout and os.Stderr are captured as with
+// CaptureOutput.  The user's home, configuration, and cache directories are sandboxed with SandboxUserDirs for the
+// rest of the test, so the CLI can't read or change the real user's settings.  If main panics, t.Errorf is called
+// with the panic, and the exit code is 2, as for a real program.  For example:
+//
+//	res := testhelp.RunCLI(t, run, []string{"convert", "--format=json"}, "a,b\n1,2\n")
+//	res.ExpectCode(0)
+//	res.ExpectStdout(`[{"a":"1","b":"2"}]`)
+//
+// Note that a call to os.Exit in main (or in code it calls, such as flag.Parse with flag.ExitOnError) ends the test
+// binary, so the CLI must return its exit code instead.  As with t.Setenv and CaptureOutput, RunCLI can't be used in
+// parallel tests.  If stdin or the output capture can't be set up, the test fails with t.Fatalf.
+func RunCLI(t EnvT, main func(args []string) int, args []string, stdin string) *CLIResult {
+	t.Helper()
+	res := &CLIResult{Args: args, t: t}
+	SandboxUserDirs(t)
+
+	in, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err == nil {
+		if _, err = in.WriteString(stdin); err == nil {
+			_, err = in.Seek(0, 0)
+		}
+	}
+	if err != nil {
+		t.Fatalf("Can't set up stdin for the CLI: %s", err)
+		return res // for mocks whose Fatalf doesn't stop the test
+	}
+	defer in.Close()
+
+	origArgs, origStdin := os.Args, os.Stdin
+	os.Args = append([]string{origArgs[0]}, args...)
+	os.Stdin = in
+	defer func() { os.Args, os.Stdin = origArgs, origStdin }()
+
+	var didPanic bool
+	var pVal interface{}
+	var stack string
+	res.Stdout, res.Stderr, err = CaptureOutput(func() {
+		didPanic, pVal, stack = panicsWithStack(func() { res.ExitCode = main(args) })
+	})
+	if err != nil {
+		t.Fatalf("Can't capture output: %s", err)
+		return res
+	}
+	if didPanic {
+		res.ExitCode = panicExitCode
+		t.Errorf("Panic in CLI %s (%T):\n%s\nstack:\n%s", res.command(), pVal, PanicMessage(pVal), stack)
+	}
+	return res
+}
+
+// command describes the command that was run, for failure messages.
+func (res *CLIResult) command() string {
+	return fmt.Sprintf("%q", strings.Join(res.Args, " "))
+}
+
+// ExpectCode checks that the CLI exited with the given code, showing its standard error if not.  It returns true if
+// the check passed.
+func (res *CLIResult) ExpectCode(want int) bool {
+	res.t.Helper()
+	if res.ExitCode == want {
+		return true
+	}
+	res.t.Errorf("Incorrect exit code from CLI %s: expected %d, got %d\nstderr:\n%s", res.command(), want,
+		res.ExitCode, res.Stderr)
+	return false
+}
+
+// ExpectStdout checks that the CLI's standard output is want, ignoring leading and trailing space, giving a
+// line-by-line diff if not.  It returns true if the check passed.
+func (res *CLIResult) ExpectStdout(want string) bool {
+	res.t.Helper()
+	return res.expectOutput("standard output", res.Stdout, want)
+}
+
+// ExpectStderr is like ExpectStdout, but for standard error.
+func (res *CLIResult) ExpectStderr(want string) bool {
+	res.t.Helper()
+	return res.expectOutput("standard error", res.Stderr, want)
+}
+
+// ExpectStdoutContains checks that the CLI's standard output contains substr.  It returns true if the check passed.
+func (res *CLIResult) ExpectStdoutContains(substr string) bool {
+	res.t.Helper()
+	return res.expectContains("standard output", res.Stdout, substr)
+}
+
+// ExpectStderrContains is like ExpectStdoutContains, but for standard error.
+func (res *CLIResult) ExpectStderrContains(substr string) bool {
+	res.t.Helper()
+	return res.expectContains("standard error", res.Stderr, substr)
+}
+
+func (res *CLIResult) expectOutput(stream, got, want string) bool {
+	res.t.Helper()
+	got, want = strings.TrimSpace(got), strings.TrimSpace(want)
+	if got == want {
+		return true
+	}
+	res.t.Errorf("Incorrect %s from CLI %s: expected\n%s\ngot\n%s\ndiff:\n%s", stream, res.command(), want, got,
+		strings.TrimSuffix(lineDiff(want, got), "\n"))
+	return false
+}
+
+func (res *CLIResult) expectContains(stream, got, substr string) bool {
+	res.t.Helper()
+	if strings.Contains(got, substr) {
+		return true
+	}
+	res.t.Errorf("Incorrect %s from CLI %s: expected it to contain\n%q\ngot\n%s", stream, res.command(), substr, got)
+	return false
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// testCLI is a small flag-based CLI: it upper-cases its standard input (or its arguments, with -args), reports the
+// sandboxed home directory with -home, and panics with -panic.
+func testCLI(args []string) int {
+	fs := flag.NewFlagSet("testcli", flag.ContinueOnError)
+	fromArgs := fs.Bool("args", false, "use the arguments instead of stdin")
+	home := fs.Bool("home", false, "print the home directory")
+	doPanic := fs.Bool("panic", false, "panic")
+	if err := fs.Parse(args); err != nil {
+		return 2 // the flag package has already printed the error to os.Stderr
+	}
+	switch {
+	case *doPanic:
+		panic("boom")
+	case *home:
+		fmt.Println(os.Getenv("HOME"))
+	case *fromArgs:
+		fmt.Println(strings.ToUpper(strings.Join(fs.Args(), " ")))
+	default:
+		in, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Print(strings.ToUpper(string(in)))
+	}
+	return 0
+}
+
+// cliTMock is an envTMock with a real temporary directory (but still no environment changes).
+type cliTMock struct {
+	envTMock
+	dir string
+}
+
+func (c *cliTMock) TempDir() string {
+	return c.dir
+}
+
+func TestRunCLI(t *testing.T) {
+	origArgs, origStdin := os.Args, os.Stdin
+	origHome := os.Getenv("HOME")
+
+	t.Run("stdin", func(t *testing.T) {
+		res := RunCLI(t, testCLI, nil, "hello\nworld\n")
+		if res.ExitCode != 0 || res.Stdout != "HELLO\nWORLD\n" || res.Stderr != "" {
+			t.Errorf("RunCLI(): Incorrect result: got\n%#+v", *res)
+		}
+	})
+	t.Run("args", func(t *testing.T) {
+		res := RunCLI(t, func(args []string) int {
+			if !reflect.DeepEqual(os.Args[1:], args) {
+				t.Errorf("RunCLI(): Incorrect os.Args: expected\n%#+v\ngot\n%#+v", args, os.Args[1:])
+			}
+			return testCLI(args)
+		}, []string{"-args", "a", "b"}, "")
+		if res.ExitCode != 0 || res.Stdout != "A B\n" {
+			t.Errorf("RunCLI(): Incorrect result: got\n%#+v", *res)
+		}
+	})
+	t.Run("sandboxed", func(t *testing.T) {
+		res := RunCLI(t, testCLI, []string{"-home"}, "")
+		if got := strings.TrimSpace(res.Stdout); got == origHome || got != os.Getenv("HOME") {
+			t.Errorf("RunCLI(): Home directory not sandboxed: got \"%s\"", got)
+		}
+	})
+	t.Run("bad flag", func(t *testing.T) {
+		res := RunCLI(t, testCLI, []string{"-nope"}, "")
+		if res.ExitCode != 2 || !strings.Contains(res.Stderr, "flag provided but not defined: -nope") {
+			t.Errorf("RunCLI(): Incorrect result: got\n%#+v", *res)
+		}
+	})
+
+	rt := &cliTMock{dir: t.TempDir()}
+	res := RunCLI(rt, testCLI, []string{"-panic"}, "")
+	failures := rt.Failures()
+	if res.ExitCode != panicExitCode || len(failures) != 1 ||
+		!strings.HasPrefix(failures[0], "Panic in CLI \"-panic\" (string):\nboom\nstack:\n") {
+		t.Errorf("RunCLI(): Incorrect result for a panic: got %d with failures\n%#+v", res.ExitCode, failures)
+	}
+
+	if !reflect.DeepEqual(os.Args, origArgs) || os.Stdin != origStdin {
+		t.Errorf("RunCLI(): os.Args or os.Stdin not restored")
+	}
+}
+
+func TestCLIResult(t *testing.T) {
+	res := CLIResult{Args: []string{"convert", "-v"}, ExitCode: 1, Stdout: "a\nb\n", Stderr: "bad input\n"}
+	tests := []struct {
+		name     string
+		check    func(res *CLIResult) bool
+		expected []string
+	}{
+		{name: "code", check: func(res *CLIResult) bool { return res.ExpectCode(1) }, expected: []string{}},
+		{
+			name:     "wrong code",
+			check:    func(res *CLIResult) bool { return res.ExpectCode(0) },
+			expected: []string{"Incorrect exit code from CLI \"convert -v\": expected 0, got 1\nstderr:\nbad input\n"},
+		},
+		{name: "stdout", check: func(res *CLIResult) bool { return res.ExpectStdout("a\nb") }, expected: []string{}},
+		{
+			name:  "wrong stdout",
+			check: func(res *CLIResult) bool { return res.ExpectStdout("a\nc") },
+			expected: []string{"Incorrect standard output from CLI \"convert -v\": expected\na\nc\ngot\na\nb\n" +
+				"diff:\n  a\n- c\n+ b"},
+		},
+		{name: "stderr", check: func(res *CLIResult) bool { return res.ExpectStderr("bad input") }, expected: []string{}},
+		{
+			name:     "stdout contains",
+			check:    func(res *CLIResult) bool { return res.ExpectStdoutContains("b\n") },
+			expected: []string{},
+		},
+		{
+			name:  "stderr doesn't contain",
+			check: func(res *CLIResult) bool { return res.ExpectStderrContains("usage") },
+			expected: []string{"Incorrect standard error from CLI \"convert -v\": expected it to contain\n\"usage\"\n" +
+				"got\nbad input\n"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		res.t = rt
+		ok := test.check(&res)
+		if got := rt.Failures(); ok != (len(test.expected) == 0) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("CLIResult: Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultFakeTime is the starting time of a FakeClock made with a zero time.
+var defaultFakeTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// A FakeClock is a clock that only moves when the test says so, for testing code that depends on the passage of time
+// (timeouts, expiry, rate limits) without sleeping.  The code under test has to get the time from the clock instead of
+// from the time package, usually through a field or option that defaults to the real time:
+//
+//	type Cache struct {
+//		now func() time.Time // time.Now, or a FakeClock's Now in tests
+//		// ...
+//	}
+//
+// A FakeClock is safe for concurrent use; the time never goes backwards unless Set is called with an earlier time.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFakeClock returns a FakeClock set to start, or to midnight UTC on 1 January 2000 if start is the zero time.
+func NewFakeClock(start time.Time) *FakeClock {
+	if start.IsZero() {
+		start = defaultFakeTime
+	}
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since returns the time elapsed on the clock since tm.
+func (c *FakeClock) Since(tm time.Time) time.Duration {
+	return c.Now().Sub(tm)
+}
+
+// Advance moves the clock forward by d (which must not be negative), firing any channels from After whose time has
+// come, and returns the new time.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	if d < 0 {
+		panic("Advance: negative duration " + d.String())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.now.Add(d))
+	return c.now
+}
+
+// Set sets the clock to tm, firing any channels from After whose time has come.
+func (c *FakeClock) Set(tm time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(tm)
+}
+
+func (c *FakeClock) setLocked(tm time.Time) {
+	c.now = tm
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(tm) {
+			remaining = append(remaining, w)
+			continue
+		}
+		w.ch <- tm // buffered, and only sent to once
+	}
+	c.waiters = remaining
+}
+
+// After returns a channel that receives the clock's time once it has been advanced by at least d, as time.After does
+// for the real clock.  If d is zero or negative, the channel receives immediately.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{c.now.Add(d), ch})
+	sort.SliceStable(c.waiters, func(i, j int) bool { return c.waiters[i].at.Before(c.waiters[j].at) })
+	return ch
+}
+
+// Sleep blocks until the clock has been advanced by at least d, as time.Sleep does for the real clock.  It can be
+// passed to code under test that takes a sleep function; DriveRetries advances the clock for it automatically.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Waiters returns the number of channels from After that haven't fired yet.  Tests can use it to wait until the code
+// under test has started waiting, before advancing the clock.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// nextWaiter returns the time of the earliest channel from After that hasn't fired yet, if there is one.
+func (c *FakeClock) nextWaiter() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.waiters) == 0 {
+		return time.Time{}, false
+	}
+	return c.waiters[0].at, true
+}
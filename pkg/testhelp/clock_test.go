@@ -0,0 +1,110 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	c := NewFakeClock(time.Time{})
+	if got := c.Now(); !got.Equal(defaultFakeTime) {
+		t.Errorf("NewFakeClock(): Incorrect default time: expected %s, got %s", defaultFakeTime, got)
+	}
+	start := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	c = NewFakeClock(start)
+
+	immediate := c.After(0)
+	late := c.After(2 * time.Hour)
+	early := c.After(time.Hour)
+	select {
+	case got := <-immediate:
+		if !got.Equal(start) {
+			t.Errorf("FakeClock.After(): Incorrect time for a zero duration: expected %s, got %s", start, got)
+		}
+	default:
+		t.Errorf("FakeClock.After(): Expected a zero duration to fire immediately")
+	}
+	if n := c.Waiters(); n != 2 {
+		t.Errorf("FakeClock.Waiters(): Incorrect count: expected 2, got %d", n)
+	}
+
+	if got := c.Advance(90 * time.Minute); !got.Equal(start.Add(90 * time.Minute)) {
+		t.Errorf("FakeClock.Advance(): Incorrect time: got %s", got)
+	}
+	select {
+	case got := <-early:
+		if !got.Equal(start.Add(90 * time.Minute)) {
+			t.Errorf("FakeClock.After(): Incorrect time received: got %s", got)
+		}
+	default:
+		t.Errorf("FakeClock.After(): Expected the 1h channel to fire after 90m")
+	}
+	select {
+	case <-late:
+		t.Errorf("FakeClock.After(): Expected the 2h channel not to fire after 90m")
+	default:
+	}
+	if got := c.Since(start); got != 90*time.Minute {
+		t.Errorf("FakeClock.Since(): Incorrect duration: expected 1h30m, got %s", got)
+	}
+
+	c.Set(start.Add(3 * time.Hour))
+	select {
+	case <-late:
+	default:
+		t.Errorf("FakeClock.Set(): Expected the 2h channel to fire")
+	}
+	if n := c.Waiters(); n != 0 {
+		t.Errorf("FakeClock.Waiters(): Incorrect count: expected 0, got %d", n)
+	}
+
+	if didPanic := Panics(func() { c.Advance(-time.Second) }); !didPanic {
+		t.Errorf("FakeClock.Advance(): Expected a panic for a negative duration")
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	c := NewFakeClock(time.Time{})
+	if _, ok := c.nextWaiter(); ok {
+		t.Errorf("FakeClock.nextWaiter(): Expected no waiters on a new clock")
+	}
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Minute)
+		close(done)
+	}()
+	for c.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if at, ok := c.nextWaiter(); !ok || !at.Equal(defaultFakeTime.Add(time.Minute)) {
+		t.Errorf("FakeClock.nextWaiter(): Incorrect result: got %s, %t", at, ok)
+	}
+	c.Advance(30 * time.Second)
+	select {
+	case <-done:
+		t.Errorf("FakeClock.Sleep(): Expected Sleep not to return after half the duration")
+	case <-time.After(10 * time.Millisecond):
+	}
+	c.Advance(30 * time.Second)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("FakeClock.Sleep(): Expected Sleep to return after the full duration")
+	}
+}
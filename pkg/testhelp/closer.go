@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// A CloseTracker wraps an io.Closer and records each call to its Close method, along with the stack it was called
+// from.  When the test finishes, it checks that Close was called the expected number of times (by default, exactly
+// once), and reports an error otherwise.  Double closes and leaks are easy to miss when they happen on error-handling
+// or panic-recovery paths; the recorded stacks show where the extra closes came from, or where the tracker was created
+// for a close that never happened.
+//
+// A CloseTracker is safe for concurrent use.
+type CloseTracker struct {
+	closer io.Closer
+
+	mu           sync.Mutex
+	wantCloses   int
+	closeStacks  []string
+	createdStack string
+}
+
+// A ReadWriteCloseTracker is a CloseTracker for an io.ReadWriteCloser.  Reads and writes are passed straight through to
+// the wrapped value, so the tracker can be used anywhere the original could be.
+type ReadWriteCloseTracker struct {
+	io.ReadWriter
+	*CloseTracker
+}
+
+// TrackClose returns a CloseTracker wrapping c, and registers a cleanup function with t which checks the number of
+// times Close was called on the tracker.  Code under test should be given the tracker instead of c.
+func TrackClose(t TestingTB, c io.Closer) *CloseTracker {
+	t.Helper()
+	ct := &CloseTracker{
+		closer:       c,
+		wantCloses:   1,
+		createdStack: callerStack(1),
+	}
+	t.Cleanup(func() {
+		t.Helper()
+		ct.check(t)
+	})
+	return ct
+}
+
+// TrackReadWriteClose is like TrackClose, but for an io.ReadWriteCloser; see ReadWriteCloseTracker.
+func TrackReadWriteClose(t TestingTB, rwc io.ReadWriteCloser) *ReadWriteCloseTracker {
+	t.Helper()
+	ct := TrackClose(t, rwc)
+	ct.createdStack = callerStack(1) // record our caller, not ourselves
+	return &ReadWriteCloseTracker{rwc, ct}
+}
+
+// Close records the call and its stack, then calls Close on the wrapped value and returns its result.  Extra calls are
+// still passed through, so that the wrapped value's own behavior on a double close is preserved.
+func (ct *CloseTracker) Close() error {
+	ct.mu.Lock()
+	ct.closeStacks = append(ct.closeStacks, callerStack(1))
+	ct.mu.Unlock()
+	return ct.closer.Close()
+}
+
+// Closes returns the number of times Close has been called so far.
+func (ct *CloseTracker) Closes() int {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return len(ct.closeStacks)
+}
+
+// SetWantCloses sets the number of calls to Close that the end-of-test check expects, in place of the default of 1.
+// Setting it to 0 checks that the value is never closed by the code under test (e.g., because ownership stays with the
+// caller).
+//
+// SetWantCloses panics if n is negative.
+func (ct *CloseTracker) SetWantCloses(n int) {
+	if n < 0 {
+		panic(fmt.Sprintf("Invalid number of closes: %d", n))
+	}
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.wantCloses = n
+}
+
+// check reports an error through t if the number of closes doesn't match the expected number.
+func (ct *CloseTracker) check(t TestingT) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	got := len(ct.closeStacks)
+	switch {
+	case got < ct.wantCloses:
+		t.Errorf("Close called too few times: expected %d, got %d\nTracker created at:\n%s",
+			ct.wantCloses, got, ct.createdStack)
+	case got > ct.wantCloses:
+		var b strings.Builder
+		for i, stack := range ct.closeStacks {
+			fmt.Fprintf(&b, "Close #%d:\n%s", i+1, stack)
+		}
+		t.Errorf("Close called too many times: expected %d, got %d\n%s", ct.wantCloses, got, b.String())
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type closerMock struct {
+	closes int
+	err    error
+}
+
+func (c *closerMock) Close() error {
+	c.closes++
+	return c.err
+}
+
+type rwcMock struct {
+	bytes.Buffer
+	closerMock
+}
+
+func TestTrackClose(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantCloses int
+		closes     int
+		// want from the end-of-test check
+		wantFailure string
+	}{
+		{"closed once", 1, 1, ""},
+		{"never closed", 1, 0, "Close called too few times: expected 1, got 0"},
+		{"closed twice", 1, 2, "Close called too many times: expected 1, got 2"},
+		{"want 0, never closed", 0, 0, ""},
+		{"want 0, closed", 0, 1, "Close called too many times: expected 0, got 1"},
+		{"want 2, closed twice", 2, 2, ""},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		inner := &closerMock{}
+		ct := TrackClose(rt, inner)
+		ct.SetWantCloses(test.wantCloses)
+		for i := 0; i < test.closes; i++ {
+			ct.Close()
+		}
+		if ct.Closes() != test.closes {
+			t.Errorf("Closes(): Wrong number of closes: expected %d, got %d in test '%s'",
+				test.closes, ct.Closes(), test.name)
+		}
+		if inner.closes != test.closes {
+			t.Errorf("Close(): Wrong number of closes passed through: expected %d, got %d in test '%s'",
+				test.closes, inner.closes, test.name)
+		}
+
+		rt.RunCleanups()
+		failures := rt.Failures()
+		if test.wantFailure == "" {
+			if len(failures) != 0 {
+				t.Errorf("TrackClose(): Unexpected failure(s) in test '%s':\n%#+v", test.name, failures)
+			}
+			continue
+		}
+		if len(failures) != 1 {
+			t.Errorf("TrackClose(): Wrong number of failures: expected 1, got %d in test '%s':\n%#+v",
+				len(failures), test.name, failures)
+		} else if !strings.HasPrefix(failures[0], test.wantFailure) {
+			t.Errorf("TrackClose(): Incorrect failure: expected a string starting with\n\"%s\"\ngot\n\"%s\"\n"+
+				"in test '%s'", test.wantFailure, failures[0], test.name)
+		} else if !strings.Contains(failures[0], "closer_test.go") {
+			// Either the creation stack or the close stacks should point back here
+			t.Errorf("TrackClose(): Failure doesn't include a stack from the test in test '%s':\n%s",
+				test.name, failures[0])
+		}
+	}
+}
+
+func TestTrackCloseReturnsError(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	wantErr := errors.New("close failed")
+	ct := TrackClose(rt, &closerMock{err: wantErr})
+	if err := ct.Close(); err != wantErr {
+		t.Errorf("Close(): Incorrect error: expected\n%#+v\ngot\n%#+v", wantErr, err)
+	}
+}
+
+func TestTrackCloseSetWantClosesPanicsWithNegative(t *testing.T) {
+	ct := TrackClose(&testhelptest.RecordingT{}, &closerMock{})
+	wantStr := "Invalid number of closes"
+	didPanic, pContainsStr, pVal := PanicsStr(func() { ct.SetWantCloses(-1) }, wantStr)
+	if !didPanic {
+		t.Fatalf("Expected SetWantCloses() to panic with a negative number")
+	} else if !pContainsStr {
+		t.Fatalf("Incorrect panic value: expected a string containing\n\"%s\"\ngot\n%#+v", wantStr, pVal)
+	}
+}
+
+func TestTrackReadWriteClose(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	inner := &rwcMock{}
+	var rwc io.ReadWriteCloser = TrackReadWriteClose(rt, inner)
+
+	if _, err := rwc.Write([]byte("abc")); err != nil {
+		t.Fatalf("Write(): Unexpected error: %s", err)
+	}
+	got, err := io.ReadAll(rwc)
+	if err != nil {
+		t.Fatalf("Read(): Unexpected error: %s", err)
+	}
+	if string(got) != "abc" {
+		t.Errorf("Read(): Incorrect data: expected \"abc\", got \"%s\"", got)
+	}
+
+	rt.RunCleanups()
+	failures := rt.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("TrackReadWriteClose(): Wrong number of failures: expected 1, got %d:\n%#+v", len(failures), failures)
+	}
+	if !strings.Contains(failures[0], "TestTrackReadWriteClose") {
+		t.Errorf("TrackReadWriteClose(): Creation stack doesn't start at the caller:\n%s", failures[0])
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// A Collector gathers values produced by the code under test, possibly from other goroutines, so that they can be
+// waited for and checked.  The zero value is an empty Collector, ready to use.
+//
+// A Collector's Add method can be passed directly as a loop callback with a single argument, such as the notPanicFunc
+// of PanicsStrLoop (with a Collector[string]); see LoopFailureFunc and LoopValFailureFunc for the other callbacks.
+type Collector[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	changed chan struct{} // closed and replaced on every Add
+}
+
+// A LoopFailure holds the arguments to a loop callback for a test whose panic value didn't meet expectations, such as
+// the notContainsFunc of PanicsStrLoop.
+type LoopFailure struct {
+	TestName string
+	Want     interface{}
+	PVal     interface{}
+}
+
+// LoopFailureFunc returns a function suitable for passing to PanicsStrLoop as a notContainsFunc, or to PanicsRELoop as
+// a notMatchesFunc, which adds each failure to c.
+func LoopFailureFunc(c *Collector[LoopFailure]) func(testName string, want string, pVal interface{}) {
+	return func(testName string, want string, pVal interface{}) {
+		c.Add(LoopFailure{testName, want, pVal})
+	}
+}
+
+// LoopValFailureFunc returns a function suitable for passing to PanicsValLoop as a notEqualsFunc, which adds each
+// failure to c.
+func LoopValFailureFunc(c *Collector[LoopFailure]) func(testName string, wantVal interface{}, pVal interface{}) {
+	return func(testName string, wantVal interface{}, pVal interface{}) {
+		c.Add(LoopFailure{testName, wantVal, pVal})
+	}
+}
+
+// Add adds an item to the collector.  It is safe to call from any goroutine.
+func (c *Collector[T]) Add(item T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = append(c.items, item)
+	if c.changed != nil {
+		close(c.changed)
+		c.changed = nil
+	}
+}
+
+// Items returns a copy of the items collected so far, in the order they were added.
+func (c *Collector[T]) Items() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]T{}, c.items...)
+}
+
+// Len returns the number of items collected so far.
+func (c *Collector[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Reset removes all collected items.
+func (c *Collector[T]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = nil
+}
+
+// Wait waits until at least n items have been collected, or until timeout has passed, whichever comes first.  It
+// returns the items collected so far, and a boolean that is true if there are at least n of them.
+func (c *Collector[T]) Wait(n int, timeout time.Duration) ([]T, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		if len(c.items) >= n {
+			items := append([]T{}, c.items...)
+			c.mu.Unlock()
+			return items, true
+		}
+		if c.changed == nil {
+			c.changed = make(chan struct{})
+		}
+		changed := c.changed
+		c.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			items := c.Items()
+			return items, len(items) >= n
+		}
+	}
+}
+
+// ItemsMatch checks that the collected items are the same as want, in any order (as determined by reflect.DeepEqual,
+// and counting duplicates), and calls t.Errorf with an informative message if not.  It returns true if the check
+// passed.
+func (c *Collector[T]) ItemsMatch(t TestingT, want []T) bool {
+	got := c.Items()
+	used := make([]bool, len(got))
+	var missing []T
+	for _, w := range want {
+		found := false
+		for i, g := range got {
+			if !used[i] && reflect.DeepEqual(w, g) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, w)
+		}
+	}
+	var extra []T
+	for i, g := range got {
+		if !used[i] {
+			extra = append(extra, g)
+		}
+	}
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return true
+	}
+	t.Errorf("Collected items don't match: expected (in any order)\n%s\ngot\n%s\nmissing:\n%s\nextra:\n%s",
+		Format(want), Format(got), Format(missing), Format(extra))
+	return false
+}
+
+// InOrder checks that the collected items are the same as want, in the same order (as determined by
+// reflect.DeepEqual), and calls t.Errorf with an informative message if not.  It returns true if the check passed.
+func (c *Collector[T]) InOrder(t TestingT, want []T) bool {
+	got := c.Items()
+	if len(got) != len(want) {
+		t.Errorf("Wrong number of collected items: expected %d, got %d\nExpected:\n%s\nGot:\n%s",
+			len(want), len(got), Format(want), Format(got))
+		return false
+	}
+	for i := range got {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("Wrong collected item at index %d: expected\n%s\ngot\n%s\nExpected:\n%s\nGot:\n%s",
+				i, Format(want[i]), Format(got[i]), Format(want), Format(got))
+			return false
+		}
+	}
+	return true
+}
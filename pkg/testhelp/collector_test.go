@@ -0,0 +1,140 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCollectorWait(t *testing.T) {
+	var c Collector[int]
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Add(i)
+		}(i)
+	}
+
+	items, ok := c.Wait(5, 5*time.Second)
+	if !ok || len(items) != 5 {
+		t.Errorf("Wait(): Expected 5 items, got %d (ok=%t)", len(items), ok)
+	}
+	wg.Wait()
+
+	items, ok = c.Wait(6, 10*time.Millisecond)
+	if ok || len(items) != 5 {
+		t.Errorf("Wait(): Expected a timeout with 5 items, got %d (ok=%t)", len(items), ok)
+	}
+
+	c.Reset()
+	if c.Len() != 0 {
+		t.Errorf("Reset(): Expected no items, got %d", c.Len())
+	}
+}
+
+func TestCollectorAssertions(t *testing.T) {
+	tests := []struct {
+		name       string
+		items      []string
+		f          func(c *Collector[string], rt *testhelptest.RecordingT) bool
+		wantOK     bool
+		wantErrStr string
+	}{
+		{
+			"ItemsMatch, same order", []string{"a", "b", "b"},
+			func(c *Collector[string], rt *testhelptest.RecordingT) bool {
+				return c.ItemsMatch(rt, []string{"a", "b", "b"})
+			},
+			true, "",
+		},
+		{
+			"ItemsMatch, other order", []string{"b", "a", "b"},
+			func(c *Collector[string], rt *testhelptest.RecordingT) bool {
+				return c.ItemsMatch(rt, []string{"a", "b", "b"})
+			},
+			true, "",
+		},
+		{
+			"ItemsMatch, wrong counts", []string{"a", "a", "b"},
+			func(c *Collector[string], rt *testhelptest.RecordingT) bool {
+				return c.ItemsMatch(rt, []string{"a", "b", "b"})
+			},
+			false, "missing:\n[]string{\"b\"}\nextra:\n[]string{\"a\"}",
+		},
+		{
+			"InOrder, correct", []string{"a", "b"},
+			func(c *Collector[string], rt *testhelptest.RecordingT) bool { return c.InOrder(rt, []string{"a", "b"}) },
+			true, "",
+		},
+		{
+			"InOrder, wrong order", []string{"b", "a"},
+			func(c *Collector[string], rt *testhelptest.RecordingT) bool { return c.InOrder(rt, []string{"a", "b"}) },
+			false, "Wrong collected item at index 0",
+		},
+		{
+			"InOrder, wrong length", []string{"a"},
+			func(c *Collector[string], rt *testhelptest.RecordingT) bool { return c.InOrder(rt, []string{"a", "b"}) },
+			false, "expected 2, got 1",
+		},
+	}
+	for _, test := range tests {
+		var c Collector[string]
+		for _, item := range test.items {
+			c.Add(item)
+		}
+		rt := &testhelptest.RecordingT{}
+		ok := test.f(&c, rt)
+		if ok != test.wantOK {
+			t.Errorf("Wrong result: expected %t, got %t in test '%s'", test.wantOK, ok, test.name)
+		}
+		failures := rt.Failures()
+		if test.wantErrStr == "" {
+			if len(failures) != 0 {
+				t.Errorf("Unexpected failure(s) in test '%s':\n%#+v", test.name, failures)
+			}
+		} else if len(failures) != 1 || !strings.Contains(failures[0], test.wantErrStr) {
+			t.Errorf("Incorrect failure(s): expected one containing\n\"%s\"\ngot\n%#+v\nin test '%s'",
+				test.wantErrStr, failures, test.name)
+		}
+	}
+}
+
+// Tests feeding loop callbacks into collectors
+func TestCollectorLoopCallbacks(t *testing.T) {
+	var noPanic Collector[string]
+	var noContains, noEquals Collector[LoopFailure]
+
+	PanicsStrLoop([]PanicStrTest{
+		{Name: "good", F: func() { panic("ppp") }, WantStr: "ppp"},
+		{Name: "bad", F: func() { panic("rrr") }, WantStr: "ppp"},
+		{Name: "np", F: func() {}, WantStr: "ppp"},
+	}, nil, noPanic.Add, LoopFailureFunc(&noContains))
+	PanicsValLoop([]PanicValTest{
+		{Name: "bad", F: func() { panic(1) }, WantVal: 2},
+	}, nil, noPanic.Add, LoopValFailureFunc(&noEquals))
+
+	noPanic.InOrder(t, []string{"np"})
+	noContains.InOrder(t, []LoopFailure{{"bad", "ppp", "rrr"}})
+	noEquals.InOrder(t, []LoopFailure{{"bad", 2, 1}})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// AssertMaxConcurrency checks that concurrency-limiting code (such as a semaphore, a worker pool, or a rate limiter
+// with a burst size) actually limits: no more than limit calls to op should ever be in flight at once.  drive should
+// trigger many overlapping invocations through the code under test, calling run (which wraps op with an atomic
+// in-flight counter) for each one, and return when they've all finished.  For example:
+//
+//	sem := mypkg.NewSemaphore(3)
+//	testhelp.AssertMaxConcurrency(t, 3, func() { time.Sleep(time.Millisecond) }, func(run func()) {
+//		var wg sync.WaitGroup
+//		for i := 0; i < 50; i++ {
+//			wg.Add(1)
+//			go func() {
+//				defer wg.Done()
+//				sem.Do(run)
+//			}()
+//		}
+//		wg.Wait()
+//	})
+//
+// op should take long enough (such as by sleeping briefly) for the calls to overlap.  If the peak number of calls in
+// flight was more than limit, AssertMaxConcurrency calls t.Errorf with the observed peak; if drive never called run,
+// it calls t.Errorf to say so.  If the peak was below limit, it logs a note, since drive may not be putting enough
+// pressure on the code under test for the check to mean much.  It returns true if the limit was respected.
+//
+// AssertMaxConcurrency panics if limit is less than 1.
+func AssertMaxConcurrency(t TestingTB, limit int, op func(), drive func(run func())) bool {
+	t.Helper()
+	if limit < 1 {
+		panic(fmt.Sprintf("Invalid concurrency limit: %d", limit))
+	}
+
+	var inFlight, peak, calls int64
+	run := func() {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		atomic.AddInt64(&calls, 1)
+		for {
+			p := atomic.LoadInt64(&peak)
+			if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+				break
+			}
+		}
+		op()
+	}
+	drive(run)
+
+	calls, peak = atomic.LoadInt64(&calls), atomic.LoadInt64(&peak)
+	switch {
+	case calls == 0:
+		t.Errorf("Concurrency limit couldn't be checked: drive never called run")
+		return false
+	case peak > int64(limit):
+		t.Errorf("Concurrency limit exceeded: observed a peak of %d calls in flight (limit %d, %d calls in total)",
+			peak, limit, calls)
+		return false
+	case peak < int64(limit):
+		t.Logf("Concurrency peak of %d calls in flight is below the limit of %d (%d calls in total); drive may not be "+
+			"exercising the limit", peak, limit, calls)
+	}
+	return true
+}
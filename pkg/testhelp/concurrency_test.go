@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// driveWithLimit returns a drive function for AssertMaxConcurrency that calls run n times from separate goroutines,
+// with at most limit of them running at once (or no limit, if limit is 0).  Each call waits on a barrier, so that the
+// peak is predictable: up to limit calls are held until that many are in flight (or all n have started).
+func driveWithLimit(n, limit int) func(run func()) {
+	return func(run func()) {
+		width := limit
+		if width == 0 || width > n {
+			width = n
+		}
+		sem := make(chan struct{}, width)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				run()
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestAssertMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name             string
+		limit            int
+		n                int
+		driveLimit       int
+		expectedOK       bool
+		expectedFailures []string
+		expectedLogs     []string
+	}{
+		{
+			name:             "at the limit",
+			limit:            3,
+			n:                3,
+			driveLimit:       3,
+			expectedOK:       true,
+			expectedFailures: []string{},
+			expectedLogs:     []string{},
+		},
+		{
+			name:       "over the limit",
+			limit:      2,
+			n:          4,
+			driveLimit: 0,
+			expectedOK: false,
+			expectedFailures: []string{
+				"Concurrency limit exceeded: observed a peak of 4 calls in flight (limit 2, 4 calls in total)",
+			},
+			expectedLogs: []string{},
+		},
+		{
+			name:             "below the limit",
+			limit:            5,
+			n:                2,
+			driveLimit:       0,
+			expectedOK:       true,
+			expectedFailures: []string{},
+			expectedLogs: []string{
+				"Concurrency peak of 2 calls in flight is below the limit of 5 (2 calls in total); drive may not be " +
+					"exercising the limit",
+			},
+		},
+		{
+			name:             "never called",
+			limit:            1,
+			n:                0,
+			driveLimit:       0,
+			expectedOK:       false,
+			expectedFailures: []string{"Concurrency limit couldn't be checked: drive never called run"},
+			expectedLogs:     []string{},
+		},
+	}
+
+	for _, test := range tests {
+		// Each call blocks until as many calls as the drive function allows are in flight, so the peak is exact
+		width := test.driveLimit
+		if width == 0 || width > test.n {
+			width = test.n
+		}
+		var barrier sync.WaitGroup
+		barrier.Add(width)
+		op := func() {
+			barrier.Done()
+			barrier.Wait()
+		}
+
+		mockT := &testhelptest.RecordingT{Logs: []string{}}
+		ok := AssertMaxConcurrency(mockT, test.limit, op, driveWithLimit(test.n, test.driveLimit))
+		if ok != test.expectedOK {
+			t.Errorf("AssertMaxConcurrency(): Incorrect return value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedOK, ok, test.name)
+		}
+		if fmt.Sprint(mockT.Failures()) != fmt.Sprint(test.expectedFailures) {
+			t.Errorf("AssertMaxConcurrency(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedFailures, mockT.Failures(), test.name)
+		}
+		if fmt.Sprint(mockT.Logs) != fmt.Sprint(test.expectedLogs) {
+			t.Errorf("AssertMaxConcurrency(): Incorrect logs: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedLogs, mockT.Logs, test.name)
+		}
+	}
+
+	if _, ok, _ := PanicsStr(func() { AssertMaxConcurrency(&testhelptest.RecordingT{}, 0, func() {}, func(func()) {}) },
+		"Invalid concurrency limit: 0"); !ok {
+		t.Errorf("AssertMaxConcurrency(): Incorrect panic: expected one for a limit of 0")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// CrashDirEnvVar is the environment variable that names the directory where a LoopRunner with CrashT set writes crash
+// artifacts.  If it isn't set, no artifacts are written, so that local runs don't leave files behind; CI jobs can set
+// it to a directory that they save when the tests fail.
+const CrashDirEnvVar = "TESTHELP_CRASH_DIR"
+
+// A CrashArtifact records an unexpected panic from a NotPanics-style loop, so that a failure in CI (especially in a
+// randomly generated table) can be reproduced locally.  Name is the name of the table entry, as passed to the failure
+// callbacks.  Input is the value returned by the entry's Setup function, formatted with Format, for the setup loops
+// (such as NotPanicsSetupLoop); it's empty for the other loops.  PanicType and Panic describe the panic value (as with
+// PanicMessage), and Stack is the stack of the goroutine at the point of the panic.  Seed is LoopRunner.Seed, if it was
+// set.
+//
+// Artifacts are stored as JSON, and can be read back with ReadCrashArtifact.
+type CrashArtifact struct {
+	Name      string `json:"name"`
+	Input     string `json:"input,omitempty"`
+	PanicType string `json:"panic_type"`
+	Panic     string `json:"panic"`
+	Stack     string `json:"stack"`
+	Seed      *int64 `json:"seed,omitempty"`
+}
+
+// crashFileUnsafe matches the characters of a test name that aren't used in the name of its artifact file.
+var crashFileUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// WriteCrashArtifact writes an artifact to a new file in dir (which is created if needed), named after the table
+// entry, and returns the path of the file.
+func WriteCrashArtifact(dir string, a CrashArtifact) (path string, err error) {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	pattern := crashFileUnsafe.ReplaceAllString(a.Name, "_")
+	if len(pattern) > 64 {
+		pattern = pattern[:64]
+	}
+	f, err := os.CreateTemp(dir, "crash-"+pattern+"-*.json")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// ReadCrashArtifact reads an artifact written by WriteCrashArtifact.
+func ReadCrashArtifact(path string) (CrashArtifact, error) {
+	var a CrashArtifact
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return a, err
+	}
+	if err := json.Unmarshal(data, &a); err != nil {
+		return a, fmt.Errorf("can't parse crash artifact %s: %w", path, err)
+	}
+	return a, nil
+}
+
+// newCrashArtifact returns the artifact for an unexpected panic from a loop.
+func (lr *LoopRunner) newCrashArtifact(name string, pVal interface{}, stack string) CrashArtifact {
+	return CrashArtifact{Name: name, PanicType: fmt.Sprintf("%T", pVal), Panic: PanicMessage(pVal), Stack: stack,
+		Seed: lr.Seed}
+}
+
+// saveCrash writes an artifact, if CrashT is set and the environment variable named by CrashDirEnvVar is set, logging
+// the path of the file with CrashT.Logf, or reporting an error with CrashT.Errorf.
+func (lr *LoopRunner) saveCrash(a CrashArtifact) {
+	if lr.CrashT == nil {
+		return
+	}
+	dir := os.Getenv(CrashDirEnvVar)
+	if dir == "" {
+		return
+	}
+	lr.CrashT.Helper()
+	path, err := WriteCrashArtifact(dir, a)
+	if err != nil {
+		lr.CrashT.Errorf("Can't write crash artifact for test '%s': %s", a.Name, err)
+		return
+	}
+	lr.CrashT.Logf("Wrote crash artifact for test '%s' to %s", a.Name, path)
+}
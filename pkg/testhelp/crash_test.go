@@ -0,0 +1,152 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCrashArtifactRoundTrip(t *testing.T) {
+	seed := int64(42)
+	a := CrashArtifact{Name: "a/b c (at x_test.go:3)", Input: "[]int{1, 2}", PanicType: "string", Panic: "boom",
+		Stack: "main.f()\n", Seed: &seed}
+	dir := filepath.Join(t.TempDir(), "crashes")
+	path, err := WriteCrashArtifact(dir, a)
+	if err != nil {
+		t.Fatalf("WriteCrashArtifact(): Unexpected error: %s", err)
+	}
+	base := filepath.Base(path)
+	if filepath.Dir(path) != dir || !strings.HasPrefix(base, "crash-a_b_c_at_x_test.go_3_-") ||
+		!strings.HasSuffix(base, ".json") {
+		t.Errorf("WriteCrashArtifact(): Incorrect path: %s", path)
+	}
+	got, err := ReadCrashArtifact(path)
+	if err != nil {
+		t.Fatalf("ReadCrashArtifact(): Unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(got, a) {
+		t.Errorf("ReadCrashArtifact(): Incorrect artifact: expected\n%#+v\ngot\n%#+v", a, got)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Can't write test file: %s", err)
+	}
+	if _, err := ReadCrashArtifact(path); err == nil || !strings.HasPrefix(err.Error(), "can't parse crash artifact") {
+		t.Errorf("ReadCrashArtifact(): Incorrect error for invalid JSON: %v", err)
+	}
+}
+
+// readCrashDir reads all of the artifacts in dir, without their stacks, which vary.
+func readCrashDir(t *testing.T, dir string) []CrashArtifact {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("Can't list crash artifacts: %s", err)
+	}
+	var artifacts []CrashArtifact
+	for _, path := range paths {
+		a, err := ReadCrashArtifact(path)
+		if err != nil {
+			t.Fatalf("ReadCrashArtifact(): Unexpected error: %s", err)
+		}
+		if !strings.Contains(a.Stack, "panicsWithStack") {
+			t.Errorf("Crash artifact for '%s' is missing the stack: %q", a.Name, a.Stack)
+		}
+		a.Stack = ""
+		artifacts = append(artifacts, a)
+	}
+	return artifacts
+}
+
+func TestLoopRunnerCrashArtifacts(t *testing.T) {
+	seed := int64(7)
+	panicky := []PanicTest{{Name: "p", F: func() { panic("boom") }}, {Name: "np", F: func() {}}}
+	setupTests := []PanicSetupTest{
+		{Name: "setup p", Setup: func() interface{} { return []int{1, 2} }, F: func(interface{}) { panic(3) }},
+		{Name: "setup panics", Setup: func() interface{} { panic("s") }, F: func(interface{}) {}},
+	}
+	tests := []struct {
+		name string
+		run  func(lr *LoopRunner)
+		want []CrashArtifact
+	}{
+		{"NotPanicsLoop", func(lr *LoopRunner) { lr.NotPanicsLoop(panicky, func(string) {}) },
+			[]CrashArtifact{{Name: "p", PanicType: "string", Panic: "boom", Seed: &seed}}},
+		{"NotPanicsGetLoop", func(lr *LoopRunner) { lr.NotPanicsGetLoop(panicky, func(string, interface{}) {}) },
+			[]CrashArtifact{{Name: "p", PanicType: "string", Panic: "boom", Seed: &seed}}},
+		{"NotPanicsReportLoop", func(lr *LoopRunner) { lr.NotPanicsReportLoop(panicky, func(PanicReport) {}) },
+			[]CrashArtifact{{Name: "p", PanicType: "string", Panic: "boom", Seed: &seed}}},
+		{"NotPanicsSetupLoop", func(lr *LoopRunner) {
+			lr.NotPanicsSetupLoop(setupTests, func(string, interface{}) {}, func(string, interface{}) {})
+		}, []CrashArtifact{{Name: "setup p", Input: "[]int{1, 2}", PanicType: "int", Panic: "3", Seed: &seed}}},
+		{"NotPanicsSetupReportLoop", func(lr *LoopRunner) {
+			lr.NotPanicsSetupReportLoop(setupTests, func(PanicReport) {})
+		}, []CrashArtifact{{Name: "setup p", Input: "[]int{1, 2}", PanicType: "int", Panic: "3", Seed: &seed}}},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		t.Setenv(CrashDirEnvVar, dir)
+		rt := &testhelptest.RecordingT{}
+		test.run(&LoopRunner{CrashT: rt, Seed: &seed})
+		if got := readCrashDir(t, dir); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Incorrect crash artifacts: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.want, got, test.name)
+		}
+		if len(rt.Logs) != 1 || !strings.HasPrefix(rt.Logs[0], "Wrote crash artifact for test '") ||
+			len(rt.Failures()) != 0 {
+			t.Errorf("Incorrect messages: logs\n%#+v\nfailures\n%#+v\nin test '%s'", rt.Logs, rt.Failures(), test.name)
+		}
+	}
+}
+
+func TestLoopRunnerCrashArtifactsDisabled(t *testing.T) {
+	panicky := []PanicTest{{Name: "p", F: func() { panic("boom") }}}
+
+	// Without the environment variable
+	dir := t.TempDir()
+	t.Setenv(CrashDirEnvVar, "")
+	rt := &testhelptest.RecordingT{}
+	(&LoopRunner{CrashT: rt}).NotPanicsLoop(panicky, func(string) {})
+	if len(rt.Logs) != 0 || len(rt.Failures()) != 0 {
+		t.Errorf("NotPanicsLoop(): Unexpected messages without %s: %#+v, %#+v", CrashDirEnvVar, rt.Logs, rt.Failures())
+	}
+
+	// Without CrashT
+	t.Setenv(CrashDirEnvVar, dir)
+	new(LoopRunner).NotPanicsLoop(panicky, func(string) {})
+	if got := readCrashDir(t, dir); len(got) != 0 {
+		t.Errorf("NotPanicsLoop(): Unexpected artifacts without CrashT: %#+v", got)
+	}
+}
+
+func TestLoopRunnerCrashArtifactWriteError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatalf("Can't write test file: %s", err)
+	}
+	t.Setenv(CrashDirEnvVar, file)
+	rt := &testhelptest.RecordingT{}
+	(&LoopRunner{CrashT: rt}).NotPanicsLoop([]PanicTest{{Name: "p", F: func() { panic(1) }}}, func(string) {})
+	if f := rt.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], "Can't write crash artifact for test 'p': ") {
+		t.Errorf("NotPanicsLoop(): Incorrect failures: %#+v", f)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCSVMaxDiffs is the number of cell differences CSVEqual reports if CSVOptions.MaxDiffs is 0.
+const DefaultCSVMaxDiffs = 20
+
+// CSVOptions holds options for CSVEqual.
+type CSVOptions struct {
+	// Comma is the field delimiter; the default (0) is ','.  Use '\t' for TSV.
+	Comma rune
+	// If ByHeader is true, the first row of each side is a header, and columns are matched by name rather than by
+	// position, so the columns can be in a different order.  Missing and unexpected columns are reported separately.
+	ByHeader bool
+	// If FloatTolerance is positive, cells that both parse as floating-point numbers are considered equal if they
+	// differ by no more than FloatTolerance.
+	FloatTolerance float64
+	// MaxDiffs is the maximum number of cell differences to report; the default (0) is DefaultCSVMaxDiffs, and a
+	// negative value means no limit.
+	MaxDiffs int
+}
+
+// CSVEqual parses want and got as CSV (or TSV, etc., depending on opts) and compares them cell by cell, calling
+// t.Errorf with a list of the differing cells (by row and column), if they don't match.  Rows are numbered from 1, in
+// the order they appear in the input, including the header row if there is one.  It returns true if they match.
+//
+// Rows may have different numbers of fields; a cell that is only on one side is reported as missing or unexpected.
+func CSVEqual(t TestingTB, want, got string, opts CSVOptions) bool {
+	t.Helper()
+	wantRows, err := opts.parse(want)
+	if err != nil {
+		t.Errorf("Can't parse expected CSV: %s", err)
+		return false
+	}
+	gotRows, err := opts.parse(got)
+	if err != nil {
+		t.Errorf("Can't parse CSV: %s", err)
+		return false
+	}
+
+	var problems []string
+	columns := func(row []string) []csvColumn { return positionalColumns(len(row)) }
+	firstRow := 0
+	if opts.ByHeader && len(wantRows) > 0 && len(gotRows) > 0 {
+		var shared []csvColumn
+		shared, problems = headerColumns(wantRows[0], gotRows[0])
+		columns = func([]string) []csvColumn { return shared }
+		firstRow = 1
+	}
+
+	var cellDiffs []string
+	for i := firstRow; i < len(wantRows) || i < len(gotRows); i++ {
+		switch {
+		case i >= len(gotRows):
+			cellDiffs = append(cellDiffs, fmt.Sprintf("Row %d: missing %s", i+1, formatCSVRow(wantRows[i])))
+			continue
+		case i >= len(wantRows):
+			cellDiffs = append(cellDiffs, fmt.Sprintf("Row %d: unexpected %s", i+1, formatCSVRow(gotRows[i])))
+			continue
+		}
+		wantRow, gotRow := wantRows[i], gotRows[i]
+		cols := columns(wantRow)
+		if !opts.ByHeader && len(gotRow) > len(wantRow) {
+			cols = columns(gotRow)
+		}
+		for _, c := range cols {
+			w, wOK := csvCell(wantRow, c.want)
+			g, gOK := csvCell(gotRow, c.got)
+			switch {
+			case !wOK && !gOK:
+			case !gOK:
+				cellDiffs = append(cellDiffs, fmt.Sprintf("Row %d, column %s: missing (expected %q)", i+1, c.name, w))
+			case !wOK:
+				cellDiffs = append(cellDiffs, fmt.Sprintf("Row %d, column %s: unexpected %q", i+1, c.name, g))
+			case !opts.cellsEqual(w, g):
+				cellDiffs = append(cellDiffs, fmt.Sprintf("Row %d, column %s: expected %q, got %q", i+1, c.name, w, g))
+			}
+		}
+	}
+
+	if len(problems) == 0 && len(cellDiffs) == 0 {
+		return true
+	}
+	maxDiffs := opts.MaxDiffs
+	if maxDiffs == 0 {
+		maxDiffs = DefaultCSVMaxDiffs
+	}
+	if maxDiffs > 0 && len(cellDiffs) > maxDiffs {
+		more := len(cellDiffs) - maxDiffs
+		cellDiffs = append(cellDiffs[:maxDiffs:maxDiffs], fmt.Sprintf("(and %d more difference(s))", more))
+	}
+	t.Errorf("CSV does not match the expected CSV:\n%s", strings.Join(append(problems, cellDiffs...), "\n"))
+	return false
+}
+
+// parse parses a CSV string, allowing rows to have different numbers of fields.
+func (o CSVOptions) parse(s string) ([][]string, error) {
+	r := csv.NewReader(strings.NewReader(s))
+	if o.Comma != 0 {
+		r.Comma = o.Comma
+	}
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+// cellsEqual compares two cells, exactly or with the float tolerance.
+func (o CSVOptions) cellsEqual(want, got string) bool {
+	if want == got {
+		return true
+	}
+	if o.FloatTolerance <= 0 {
+		return false
+	}
+	w, err := strconv.ParseFloat(strings.TrimSpace(want), 64)
+	if err != nil {
+		return false
+	}
+	g, err := strconv.ParseFloat(strings.TrimSpace(got), 64)
+	if err != nil {
+		return false
+	}
+	return math.Abs(w-g) <= o.FloatTolerance
+}
+
+// A csvColumn says where to find a column on each side, and how to describe it.
+type csvColumn struct {
+	name      string
+	want, got int
+}
+
+// positionalColumns returns n columns matched by position, named by 1-based number.
+func positionalColumns(n int) []csvColumn {
+	cols := make([]csvColumn, n)
+	for i := range cols {
+		cols[i] = csvColumn{name: strconv.Itoa(i + 1), want: i, got: i}
+	}
+	return cols
+}
+
+// headerColumns matches columns by header name, in the order of the wanted header, and returns descriptions of any
+// columns that are only on one side, or whose names are duplicated (which makes matching by name ambiguous; only the
+// first is compared).
+func headerColumns(wantHeader, gotHeader []string) ([]csvColumn, []string) {
+	var problems []string
+	gotIndex := map[string]int{}
+	for i, name := range gotHeader {
+		if _, ok := gotIndex[name]; ok {
+			problems = append(problems, fmt.Sprintf("Duplicate column %q", name))
+			continue
+		}
+		gotIndex[name] = i
+	}
+	var cols []csvColumn
+	var missing []string
+	seen := map[string]bool{}
+	for i, name := range wantHeader {
+		if seen[name] {
+			problems = append(problems, fmt.Sprintf("Duplicate expected column %q", name))
+			continue
+		}
+		seen[name] = true
+		if j, ok := gotIndex[name]; ok {
+			cols = append(cols, csvColumn{name: strconv.Quote(name), want: i, got: j})
+		} else {
+			missing = append(missing, strconv.Quote(name))
+		}
+	}
+	var unexpected []string
+	for _, name := range gotHeader {
+		if !seen[name] {
+			unexpected = append(unexpected, strconv.Quote(name))
+			seen[name] = true
+		}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, "Missing columns: "+strings.Join(missing, ", "))
+	}
+	if len(unexpected) > 0 {
+		problems = append(problems, "Unexpected columns: "+strings.Join(unexpected, ", "))
+	}
+	return cols, problems
+}
+
+// csvCell returns the cell at index i of row, and whether there is one.
+func csvCell(row []string, i int) (string, bool) {
+	if i >= len(row) {
+		return "", false
+	}
+	return row[i], true
+}
+
+// formatCSVRow formats a whole row for a failure message.
+func formatCSVRow(row []string) string {
+	quoted := make([]string, len(row))
+	for i, cell := range row {
+		quoted[i] = strconv.Quote(cell)
+	}
+	return "row [" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCSVEqual(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		opts      CSVOptions
+		expected  string // the failure after the first line, or "" for none
+	}{
+		{name: "same", want: "a,b\n1,2\n", got: "a,b\n1,2\n"},
+		{name: "quoting", want: "a,\"b,c\"\n", got: "\"a\",\"b,c\""},
+		{
+			name: "cell", want: "a,b\n1,2\n3,4\n", got: "a,b\n1,2\n3,5\n",
+			expected: "Row 3, column 2: expected \"4\", got \"5\"",
+		},
+		{
+			name: "ragged", want: "a,b,c\n1\n", got: "a,b\n1,2\n",
+			expected: "Row 1, column 3: missing (expected \"c\")\nRow 2, column 2: unexpected \"2\"",
+		},
+		{
+			name: "rows", want: "a\nb\n", got: "a\n", expected: "Row 2: missing row [\"b\"]",
+		},
+		{
+			name: "extra rows", want: "a\n", got: "a\nb,c\n", expected: "Row 2: unexpected row [\"b\", \"c\"]",
+		},
+		{name: "tsv", want: "a\tb c\n", got: "a\tb c\n", opts: CSVOptions{Comma: '\t'}},
+		{
+			name: "float", want: "x,1.0,y\n", got: "x,1.0004,y \n", opts: CSVOptions{FloatTolerance: 0.001},
+			expected: "Row 1, column 3: expected \"y\", got \"y \"",
+		},
+		{
+			name: "float exceeded", want: "1.0\n", got: "1.01\n", opts: CSVOptions{FloatTolerance: 0.001},
+			expected: "Row 1, column 1: expected \"1.0\", got \"1.01\"",
+		},
+		{name: "no tolerance", want: "1.0\n", got: "1\n", expected: "Row 1, column 1: expected \"1.0\", got \"1\""},
+		{
+			name: "header", want: "id,name,size\n1,a,10\n2,b,20\n", got: "size,id,name\n10,1,a\n21,2,b\n",
+			opts:     CSVOptions{ByHeader: true},
+			expected: "Row 3, column \"size\": expected \"20\", got \"21\"",
+		},
+		{
+			name: "header columns", want: "id,name\n1,a\n", got: "id,extra,id\n2,x,3\n", opts: CSVOptions{ByHeader: true},
+			expected: "Duplicate column \"id\"\nMissing columns: \"name\"\nUnexpected columns: \"extra\"\n" +
+				"Row 2, column \"id\": expected \"1\", got \"2\"",
+		},
+		{
+			name: "max diffs", want: "1,2,3\n", got: "4,5,6\n", opts: CSVOptions{MaxDiffs: 2},
+			expected: "Row 1, column 1: expected \"1\", got \"4\"\nRow 1, column 2: expected \"2\", got \"5\"\n" +
+				"(and 1 more difference(s))",
+		},
+		{
+			name: "unlimited diffs", want: "1,2\n", got: "3,4\n", opts: CSVOptions{MaxDiffs: -1},
+			expected: "Row 1, column 1: expected \"1\", got \"3\"\nRow 1, column 2: expected \"2\", got \"4\"",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := CSVEqual(rt, test.want, test.got, test.opts)
+		want := []string{}
+		if test.expected != "" {
+			want = []string{"CSV does not match the expected CSV:\n" + test.expected}
+		}
+		if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+			t.Errorf("CSVEqual(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got, test.name)
+		}
+	}
+}
+
+func TestCSVEqualDefaultMaxDiffs(t *testing.T) {
+	want := strings.Repeat("a\n", DefaultCSVMaxDiffs+5)
+	got := strings.Repeat("b\n", DefaultCSVMaxDiffs+5)
+	rt := &testhelptest.RecordingT{}
+	CSVEqual(rt, want, got, CSVOptions{})
+	f := rt.Failures()
+	if len(f) != 1 || strings.Count(f[0], "\n") != DefaultCSVMaxDiffs+1 ||
+		!strings.HasSuffix(f[0], "(and 5 more difference(s))") {
+		t.Errorf("CSVEqual(): Incorrect failure(s) with many differences:\n%#+v", f)
+	}
+}
+
+func TestCSVEqualParseErrors(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if CSVEqual(rt, "a,\"b\n", "a\n", CSVOptions{}) {
+		t.Errorf("CSVEqual(): Expected false with unparsable expected CSV")
+	}
+	if f := rt.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], "Can't parse expected CSV: ") {
+		t.Errorf("CSVEqual(): Incorrect failure(s) with unparsable expected CSV:\n%#+v", f)
+	}
+	rt = &testhelptest.RecordingT{}
+	CSVEqual(rt, "a\n", "a,\"b\n", CSVOptions{})
+	if f := rt.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], "Can't parse CSV: ") {
+		t.Errorf("CSVEqual(): Incorrect failure(s) with unparsable CSV:\n%#+v", f)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// goroutineHeaderRE matches the first line of a goroutine's stack in a runtime.Stack dump, capturing the goroutine's
+// ID and its wait reason (e.g. "chan receive", without the wait time that sometimes follows the reason).
+var goroutineHeaderRE = regexp.MustCompile(`^goroutine (\d+) \[([^,\]]+)`)
+
+// WithDeadlockDetection runs f, calling t.Errorf and returning false if it doesn't return within timeout (or if it
+// panics).  On a timeout, the failure message lists the goroutines that are blocked on a mutex, channel, WaitGroup, or
+// condition variable, followed by the stacks of all goroutines, with the blocked ones marked, which usually shows
+// where the deadlock is.  It returns true if f returned in time.
+//
+// f is run in a new goroutine, so it must not call t.FailNow (or t.Fatalf, etc.).  If it doesn't return in time, it's
+// left running (since there's no way to stop it), so the rest of the test may be affected; it's often best to end the
+// test in that case.
+func WithDeadlockDetection(t TestingTB, timeout time.Duration, f func()) bool {
+	t.Helper()
+	type result struct {
+		didPanic bool
+		pVal     interface{}
+		stack    string
+	}
+	done := make(chan result, 1)
+	go func() {
+		didPanic, pVal, stack := panicsWithStack(f)
+		done <- result{didPanic, pVal, stack}
+	}()
+
+	select {
+	case r := <-done:
+		if r.didPanic {
+			t.Errorf("Panic in the function run with deadlock detection (%T):\n%s\nstack:\n%s", r.pVal,
+				PanicMessage(r.pVal), r.stack)
+			return false
+		}
+		return true
+	case <-time.After(timeout):
+	}
+
+	blocked, dump := annotateBlocked(allStacks())
+	summary := "no goroutines are blocked on a mutex, channel, WaitGroup, or condition variable"
+	if len(blocked) > 0 {
+		summary = fmt.Sprintf("blocked goroutines: %s", strings.Join(blocked, ", "))
+	}
+	t.Errorf("Function did not return within %s (possible deadlock); %s\nall goroutines:\n%s", timeout, summary, dump)
+	return false
+}
+
+// annotateBlocked finds the blocked goroutines in a runtime.Stack dump, returning a description of each (its ID and
+// what it's blocked on) and the dump with a marker added to the header line of each.
+func annotateBlocked(dump string) ([]string, string) {
+	var blocked []string
+	goroutines := strings.Split(strings.TrimRight(dump, "\n"), "\n\n")
+	for i, g := range goroutines {
+		m := goroutineHeaderRE.FindStringSubmatch(g)
+		if m == nil {
+			continue
+		}
+		header, rest, _ := strings.Cut(g, "\n")
+		kind := blockKind(m[2])
+		if kind == "" || strings.HasPrefix(rest, "testing.") { // the test framework waiting for tests is normal
+			continue
+		}
+		blocked = append(blocked, fmt.Sprintf("%s (%s)", m[1], kind))
+		goroutines[i] = header + "   <-- BLOCKED on a " + kind + "\n" + rest
+	}
+	return blocked, strings.Join(goroutines, "\n\n") + "\n"
+}
+
+// blockKind returns the kind of thing that a goroutine with the given wait reason is blocked on, or "" if it isn't
+// blocked on a synchronization primitive.
+func blockKind(reason string) string {
+	switch {
+	case strings.Contains(reason, "Mutex") || strings.HasPrefix(reason, "semacquire"):
+		return "mutex"
+	case strings.HasPrefix(reason, "chan ") || strings.HasPrefix(reason, "select"):
+		return "channel"
+	case strings.Contains(reason, "WaitGroup"):
+		return "WaitGroup"
+	case strings.Contains(reason, "Cond"):
+		return "condition variable"
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestWithDeadlockDetection(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if !WithDeadlockDetection(rt, time.Second, func() {}) || len(rt.Failures()) != 0 {
+		t.Errorf("WithDeadlockDetection(): Unexpected failure for a function that returns:\n%#+v", rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if WithDeadlockDetection(rt, time.Second, func() { panic("boom") }) {
+		t.Errorf("WithDeadlockDetection(): Expected false for a function that panics")
+	}
+	if f := rt.Failures(); len(f) != 1 ||
+		!strings.HasPrefix(f[0], "Panic in the function run with deadlock detection (string):\nboom\nstack:\n") {
+		t.Errorf("WithDeadlockDetection(): Incorrect failures for a panic:\n%#+v", f)
+	}
+
+	// A lock-order deadlock between two goroutines, plus one waiting on a channel
+	var a, b sync.Mutex
+	release := make(chan struct{})
+	rt = &testhelptest.RecordingT{}
+	ok := WithDeadlockDetection(rt, 50*time.Millisecond, func() {
+		a.Lock()
+		locked := make(chan struct{})
+		go func() {
+			b.Lock()
+			close(locked)
+			a.Lock() // blocks until the end of the test
+			a.Unlock()
+			b.Unlock()
+		}()
+		<-locked
+		go func() { <-release }()
+		b.Lock() // blocks until the end of the test
+		b.Unlock()
+	})
+	// Let everything finish, so other tests aren't affected: unlocking a (for the function, which is stuck holding
+	// it) lets the inner goroutine finish and unlock b, which lets the function finish
+	close(release)
+	a.Unlock()
+
+	f := rt.Failures()
+	if ok || len(f) != 1 {
+		t.Fatalf("WithDeadlockDetection(): Expected one failure for a deadlock, got:\n%#+v", f)
+	}
+	summaryRE := regexp.MustCompile(`^Function did not return within 50ms \(possible deadlock\); blocked goroutines: ` +
+		`.*\d+ \(mutex\).*\n`)
+	if !summaryRE.MatchString(f[0]) || strings.Count(f[0], "<-- BLOCKED on a mutex") < 2 ||
+		!strings.Contains(f[0], "<-- BLOCKED on a channel") ||
+		!strings.Contains(f[0], "TestWithDeadlockDetection") {
+		t.Errorf("WithDeadlockDetection(): Incorrect failure for a deadlock:\n%s", f[0])
+	}
+}
+
+func TestAnnotateBlocked(t *testing.T) {
+	dump := "goroutine 1 [running]:\nmain.main()\n\n" +
+		"goroutine 2 [chan receive, 2 minutes]:\nmain.worker()\n\n" +
+		"goroutine 3 [sync.Mutex.Lock]:\nsync.(*Mutex).Lock()\n\n" +
+		"goroutine 4 [semacquire]:\nmain.old()\n\n" +
+		"goroutine 5 [sync.WaitGroup.Wait]:\nmain.wait()\n\n" +
+		"goroutine 6 [sync.Cond.Wait]:\nmain.cond()\n\n" +
+		"goroutine 7 [chan receive]:\ntesting.(*T).Run()\n\n" +
+		"goroutine 8 [sleep]:\ntime.Sleep()\n"
+	blocked, annotated := annotateBlocked(dump)
+	wantBlocked := "2 (channel), 3 (mutex), 4 (mutex), 5 (WaitGroup), 6 (condition variable)"
+	if got := strings.Join(blocked, ", "); got != wantBlocked {
+		t.Errorf("annotateBlocked(): Incorrect blocked goroutines: expected\n%s\ngot\n%s", wantBlocked, got)
+	}
+	wantDump := strings.Replace(dump, "2 minutes]:", "2 minutes]:   <-- BLOCKED on a channel", 1)
+	wantDump = strings.Replace(wantDump, "[sync.Mutex.Lock]:", "[sync.Mutex.Lock]:   <-- BLOCKED on a mutex", 1)
+	wantDump = strings.Replace(wantDump, "[semacquire]:", "[semacquire]:   <-- BLOCKED on a mutex", 1)
+	wantDump = strings.Replace(wantDump, "Wait]:", "Wait]:   <-- BLOCKED on a WaitGroup", 1)
+	wantDump = strings.Replace(wantDump, "[sync.Cond.Wait]:", "[sync.Cond.Wait]:   <-- BLOCKED on a condition variable",
+		1)
+	if annotated != wantDump {
+		t.Errorf("annotateBlocked(): Incorrect dump: expected\n%s\ngot\n%s", wantDump, annotated)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"sync"
+)
+
+// A DeferTracker records panics that come from deferred functions, so that PanicsInDefer can tell whether a panic
+// happened in the main body of a function or in its deferred cleanup.  Go doesn't distinguish the two, so the deferred
+// functions have to be instrumented by wrapping them with WrapDefer.
+//
+// For code under test whose deferred functions can't be wrapped directly, a common pattern is to route them through
+// a package-level hook that the test replaces:
+//
+//	// In the code under test
+//	var wrapDefer = func(f func()) func() { return f }
+//
+//	func Process(r *Resource) {
+//		defer wrapDefer(r.Release)()
+//		// ...
+//	}
+//
+//	// In the test
+//	dt := &testhelp.DeferTracker{}
+//	wrapDefer = dt.WrapDefer
+//	didPanic, inDefer, pVal := testhelp.PanicsInDefer(func() { Process(r) }, dt)
+//
+// A DeferTracker is safe for concurrent use, but it can't tell which goroutine a panic came from, so it should only be
+// used for one call to PanicsInDefer at a time.
+type DeferTracker struct {
+	mu          sync.Mutex
+	deferPanics []interface{}
+}
+
+// WrapDefer returns a function that calls f, recording any panic from f before passing it on.  The returned function
+// is intended to be deferred in place of f.  If f exits through runtime.Goexit (as t.FailNow and t.Fatal do), nothing
+// is recorded, and the Goexit carries on as normal.
+//
+// Since f is called by the returned function, rather than directly by the deferred call, a recover call in f doesn't
+// stop a panic (it returns nil), so deferred functions that recover from panics shouldn't be wrapped.
+func (dt *DeferTracker) WrapDefer(f func()) func() {
+	return func() {
+		panicked := true
+		defer func() {
+			if !panicked {
+				return // don't interfere with a panic from the main body that is passing through
+			}
+			pVal := recover()
+			if pVal == nil {
+				return // runtime.Goexit, not a panic
+			}
+			dt.mu.Lock()
+			dt.deferPanics = append(dt.deferPanics, pVal)
+			dt.mu.Unlock()
+			panic(pVal)
+		}()
+		f()
+		panicked = false
+	}
+}
+
+// PanicsInDefer tests if the given function panics, and returns a boolean that is true if it does.  If it does panic,
+// inDefer is true if the panic came from (or was replaced by a panic from) a deferred function wrapped with
+// dt.WrapDefer, and false if it came from anywhere else (such as the function's main body).  The panic value itself is
+// also returned.  (Specifically, this is the return value from recover, which is nil if the function did not panic.)
+//
+// Any records in dt from previous calls are discarded.
+func PanicsInDefer(f func(), dt *DeferTracker) (didPanic bool, inDefer bool, pVal interface{}) {
+	dt.mu.Lock()
+	dt.deferPanics = nil
+	dt.mu.Unlock()
+
+	didPanic, pVal = PanicsGet(f)
+	if !didPanic {
+		return false, false, nil
+	}
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	// The panic that escaped is from a deferred function if it's the last one that a wrapped function passed on
+	if n := len(dt.deferPanics); n > 0 && sameValue(dt.deferPanics[n-1], pVal) {
+		inDefer = true
+	}
+	return didPanic, inDefer, pVal
+}
+
+// sameValue returns true if a and b are equal, using == if they can be compared with it, and reflect.DeepEqual if not,
+// so that it never panics.
+func sameValue(a, b interface{}) (same bool) {
+	defer func() {
+		if recover() != nil { // uncomparable types
+			same = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
+
+// A PanicRecord describes a single panic seen by RecordedPanics.  Recovered is true if the panic was recovered by the
+// code under test (and reported through PanicRecorder.Recover), and false if it escaped.  Stack is the stack of the
+// goroutine at the point where the panic was recovered, which includes the frames that led to the panic.
+type PanicRecord struct {
+	PVal      interface{}
+	Recovered bool
+	Stack     string
+}
+
+// A PanicRecorder records panics that the code under test recovers from, so that RecordedPanics can report on layered
+// recovery: for example, code that recovers from a panic, and then panics again with a different value, masking the
+// original.  The code under test has to cooperate by passing the result of each recover call through Recover; as with
+// DeferTracker, a package-level hook that the test replaces is a common pattern:
+//
+//	// In the code under test
+//	var recordRecover = func(pVal interface{}) interface{} { return pVal }
+//
+//	func Serve() {
+//		defer func() {
+//			if pVal := recordRecover(recover()); pVal != nil {
+//				panic(fmt.Sprintf("serve failed: %v", pVal))
+//			}
+//		}()
+//		// ...
+//	}
+//
+//	// In the test
+//	pr := &testhelp.PanicRecorder{}
+//	recordRecover = pr.Recover
+//	records := testhelp.RecordedPanics(Serve, pr)
+//
+// A PanicRecorder is safe for concurrent use, but should only be used for one call to RecordedPanics at a time.
+type PanicRecorder struct {
+	mu      sync.Mutex
+	records []PanicRecord
+}
+
+// Recover records pVal as a recovered panic, unless it is nil, and returns it.  It is intended to wrap recover, as in
+// pr.Recover(recover()).  (recover itself must be called directly by the deferred function, so it can't be called
+// here.)
+func (pr *PanicRecorder) Recover(pVal interface{}) interface{} {
+	if pVal != nil {
+		pr.mu.Lock()
+		pr.records = append(pr.records, PanicRecord{PVal: pVal, Recovered: true, Stack: callerStack(1)})
+		pr.mu.Unlock()
+	}
+	return pVal
+}
+
+// RecordedPanics runs f, and returns a record of each panic recovered by f and passed through pr.Recover, in order,
+// followed by a record of the panic that escaped from f, if there was one.  More than one record means that f panicked
+// more than once, e.g. because it recovered from a panic and then panicked again.
+//
+// Any records in pr from previous calls are discarded.
+func RecordedPanics(f func(), pr *PanicRecorder) []PanicRecord {
+	pr.mu.Lock()
+	pr.records = nil
+	pr.mu.Unlock()
+
+	didPanic, pVal, stack := panicsWithStack(f)
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	records := append([]PanicRecord{}, pr.records...)
+	if didPanic {
+		records = append(records, PanicRecord{PVal: pVal, Recovered: false, Stack: stack})
+	}
+	return records
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPanicsInDefer(t *testing.T) {
+	dt := &DeferTracker{}
+	cleanupPanics := func() { panic("cleanup") }
+	cleanupOK := func() {}
+
+	tests := []struct {
+		name string
+		f    func()
+		// want from PanicsInDefer
+		wantPanics  bool
+		wantInDefer bool
+		wantPVal    interface{}
+	}{
+		{"np", func() { defer dt.WrapDefer(cleanupOK)() }, false, false, nil},
+		{"body", func() {
+			defer dt.WrapDefer(cleanupOK)()
+			panic("body")
+		}, true, false, "body"},
+		{"defer", func() { defer dt.WrapDefer(cleanupPanics)() }, true, true, "cleanup"},
+		{"body, then defer", func() {
+			defer dt.WrapDefer(cleanupPanics)()
+			panic("body")
+		}, true, true, "cleanup"},
+		{"defer, then recovered, then body", func() {
+			func() {
+				defer func() { recover() }()
+				defer dt.WrapDefer(cleanupPanics)()
+			}()
+			panic("body")
+		}, true, false, "body"},
+		{"uncomparable", func() {
+			defer dt.WrapDefer(func() { panic([]int{1}) })()
+		}, true, true, []int{1}},
+	}
+	for _, test := range tests {
+		didPanic, inDefer, pVal := PanicsInDefer(test.f, dt)
+		if didPanic != test.wantPanics {
+			t.Errorf("PanicsInDefer(): Expected didPanic=%t, got %t in test '%s'", test.wantPanics, didPanic, test.name)
+		}
+		if inDefer != test.wantInDefer {
+			t.Errorf("PanicsInDefer(): Expected inDefer=%t, got %t in test '%s'", test.wantInDefer, inDefer, test.name)
+		}
+		if !sameValue(pVal, test.wantPVal) {
+			t.Errorf("PanicsInDefer(): Incorrect panic value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantPVal, pVal, test.name)
+		}
+	}
+}
+
+func TestWrapDeferGoexit(t *testing.T) {
+	dt := &DeferTracker{}
+	finished := false
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer dt.WrapDefer(runtime.Goexit)()
+		finished = true
+	}()
+	<-done // with a re-panic, the test binary would have crashed by now
+	if !finished {
+		t.Errorf("WrapDefer(): The function's main body did not run")
+	}
+	if len(dt.deferPanics) != 0 {
+		t.Errorf("WrapDefer(): Incorrect records for runtime.Goexit: expected none, got\n%#+v", dt.deferPanics)
+	}
+}
+
+func TestRecordedPanics(t *testing.T) {
+	pr := &PanicRecorder{}
+	layered := func(inner func()) func() {
+		return func() {
+			defer func() {
+				if pVal := pr.Recover(recover()); pVal != nil {
+					panic(fmt.Sprintf("wrapped: %v", pVal))
+				}
+			}()
+			inner()
+		}
+	}
+	swallowed := func() {
+		defer func() { pr.Recover(recover()) }()
+		panic("swallowed")
+	}
+
+	tests := []struct {
+		name          string
+		f             func()
+		wantPVals     []interface{}
+		wantRecovered []bool
+	}{
+		{"np", func() {}, nil, nil},
+		{"plain panic", func() { panic("ppp") }, []interface{}{"ppp"}, []bool{false}},
+		{"np, layered", layered(func() {}), nil, nil},
+		{
+			"double panic", layered(func() { panic("inner") }),
+			[]interface{}{"inner", "wrapped: inner"}, []bool{true, false},
+		},
+		{
+			"triple panic", layered(layered(func() { panic("inner") })),
+			[]interface{}{"inner", "wrapped: inner", "wrapped: wrapped: inner"}, []bool{true, true, false},
+		},
+		{"swallowed", swallowed, []interface{}{"swallowed"}, []bool{true}},
+	}
+	for _, test := range tests {
+		records := RecordedPanics(test.f, pr)
+		if len(records) != len(test.wantPVals) {
+			t.Errorf("RecordedPanics(): Wrong number of records: expected %d, got %d in test '%s':\n%#+v",
+				len(test.wantPVals), len(records), test.name, records)
+			continue
+		}
+		for i, record := range records {
+			if record.PVal != test.wantPVals[i] || record.Recovered != test.wantRecovered[i] {
+				t.Errorf("RecordedPanics(): Wrong record %d: expected value %#+v, recovered %t; got %#+v, %t in test "+
+					"'%s'", i, test.wantPVals[i], test.wantRecovered[i], record.PVal, record.Recovered, test.name)
+			}
+			if !strings.Contains(record.Stack, "deferpanic_test.go") {
+				t.Errorf("RecordedPanics(): Record %d has no useful stack in test '%s':\n%s", i, test.name, record.Stack)
+			}
+		}
+	}
+}
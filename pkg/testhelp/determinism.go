@@ -0,0 +1,60 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckOrderIndependent calls f n times, and calls t.Errorf if its results aren't all the same, with a line-by-line
+// diff between the first result and each of the others.  It returns true if the results were all the same.
+//
+// This is intended for catching output that accidentally depends on map iteration order (which Go randomizes) or other
+// nondeterminism, such as in serialization code:
+//
+//	testhelp.CheckOrderIndependent(t, 20, func() string { return mypkg.Encode(config) })
+//
+// A larger n makes it more likely that a nondeterministic result will be caught.  CheckOrderIndependent panics if n is
+// less than 2.
+func CheckOrderIndependent(t TestingTB, n int, f func() string) bool {
+	t.Helper()
+	if n < 2 {
+		panic(fmt.Sprintf("Invalid number of runs: %d", n))
+	}
+
+	var variants []string
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		out := f()
+		if counts[out] == 0 {
+			variants = append(variants, out)
+		}
+		counts[out]++
+	}
+	if len(variants) == 1 {
+		return true
+	}
+
+	var b strings.Builder
+	for i, variant := range variants[1:] {
+		fmt.Fprintf(&b, "\nVariant %d (%d run(s)) compared with variant 1 (%d run(s)):\n%s", i+2, counts[variant],
+			counts[variants[0]], lineDiff(variants[0], variant))
+	}
+	t.Errorf("Output varied between runs: %d different results in %d runs%s", len(variants), n, b.String())
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCheckOrderIndependent(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8}
+	sorted := func() string {
+		var lines []string
+		for k, v := range m {
+			lines = append(lines, fmt.Sprintf("%s=%d", k, v))
+		}
+		sort.Strings(lines)
+		return strings.Join(lines, "\n")
+	}
+	rt := &testhelptest.RecordingT{}
+	if !CheckOrderIndependent(rt, 10, sorted) || len(rt.Failures()) != 0 {
+		t.Errorf("CheckOrderIndependent(): Unexpected failure with deterministic output:\n%#+v", rt.Failures())
+	}
+
+	calls := 0
+	alternating := func() string {
+		calls++
+		if calls%2 == 0 {
+			return "a\nc"
+		}
+		return "a\nb"
+	}
+	rt = &testhelptest.RecordingT{}
+	if CheckOrderIndependent(rt, 4, alternating) {
+		t.Errorf("CheckOrderIndependent(): Expected false with nondeterministic output")
+	}
+	want := "Output varied between runs: 2 different results in 4 runs\n" +
+		"Variant 2 (2 run(s)) compared with variant 1 (2 run(s)):\n  a\n- b\n+ c\n"
+	if failures := rt.Failures(); len(failures) != 1 || failures[0] != want {
+		t.Errorf("CheckOrderIndependent(): Incorrect failure(s): expected\n%#+v\ngot\n%#+v", want, failures)
+	}
+
+	if !Panics(func() { CheckOrderIndependent(rt, 1, sorted) }) {
+		t.Errorf("CheckOrderIndependent(): Expected a panic with n = 1")
+	}
+}
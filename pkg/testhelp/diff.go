@@ -0,0 +1,86 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lineDiff returns a line-by-line diff between want and got, with each line prefixed by "- " if it is only in want,
+// "+ " if it is only in got, or "  " if it is in both.  If the texts are too large and too different to diff in full
+// (see maxLCSCells), it reports only the first differing line of each instead.
+func lineDiff(want, got string) string {
+	return diffLines(want, got, false)
+}
+
+// numberedLineDiff is like lineDiff, but after each prefix are the line's numbers in want and in got (blank if it's
+// not in one of them), followed by "| ", so that differences can be found in the original text.
+func numberedLineDiff(want, got string) string {
+	return diffLines(want, got, true)
+}
+
+func diffLines(want, got string, numbered bool) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var b strings.Builder
+	write := func(prefix string, wantNum, gotNum int, line string) {
+		b.WriteString(prefix)
+		if numbered {
+			fmt.Fprintf(&b, "%4s %4s | ", lineNum(wantNum), lineNum(gotNum))
+		}
+		b.WriteString(line + "\n")
+	}
+	if prefix, suffix := commonAffixes(wantLines, gotLines); !lcsFits(len(wantLines)-prefix-suffix,
+		len(gotLines)-prefix-suffix) {
+		fmt.Fprintf(&b, "(too many differing lines to diff; showing only the first difference)\n")
+		if prefix < len(wantLines) {
+			write("- ", prefix+1, 0, wantLines[prefix])
+		}
+		if prefix < len(gotLines) {
+			write("+ ", 0, prefix+1, gotLines[prefix])
+		}
+		return b.String()
+	}
+
+	wantMatched, gotMatched := lcsMatch(wantLines, gotLines)
+	for i, j := 0, 0; i < len(wantLines) || j < len(gotLines); {
+		switch {
+		case i < len(wantLines) && !wantMatched[i]:
+			write("- ", i+1, 0, wantLines[i])
+			i++
+		case j < len(gotLines) && !gotMatched[j]:
+			write("+ ", 0, j+1, gotLines[j])
+			j++
+		default: // both matched, so they're the same line
+			write("  ", i+1, j+1, wantLines[i])
+			i++
+			j++
+		}
+	}
+	return b.String()
+}
+
+// lineNum formats a 1-based line number for numberedLineDiff, or returns "" for 0 (no line).
+func lineNum(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
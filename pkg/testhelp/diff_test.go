@@ -0,0 +1,101 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{"same", "a\nb", "a\nb", "  a\n  b\n"},
+		{"changed", "a\nb\nc", "a\nx\nc", "  a\n- b\n+ x\n  c\n"},
+		{"added", "a", "a\nb", "  a\n+ b\n"},
+		{"removed", "a\nb", "b", "- a\n  b\n"},
+		{"empty want", "", "a", "- \n+ a\n"},
+	}
+	for _, test := range tests {
+		if diff := lineDiff(test.want, test.got); diff != test.diff {
+			t.Errorf("lineDiff(): Incorrect diff: expected\n%q\ngot\n%q\nin test '%s'", test.diff, diff, test.name)
+		}
+	}
+}
+
+func TestNumberedLineDiff(t *testing.T) {
+	want := "a\nb\nc"
+	got := "a\nx\nc\nd"
+	diff := "     1    1 | a\n-    2      | b\n+         2 | x\n     3    3 | c\n+         4 | d\n"
+	if got := numberedLineDiff(want, got); got != diff {
+		t.Errorf("numberedLineDiff(): Incorrect diff: expected\n%q\ngot\n%q", diff, got)
+	}
+}
+
+func TestLineDiffTooLarge(t *testing.T) {
+	// 3000 lines that all differ between want and got, plus a common prefix and suffix that don't count toward the
+	// limit; a full table would be 3001*3001 entries, over maxLCSCells
+	var wantLines, gotLines []string
+	for i := 0; i < 3000; i++ {
+		wantLines = append(wantLines, fmt.Sprintf("want %d", i))
+		gotLines = append(gotLines, fmt.Sprintf("got %d", i))
+	}
+	want := "start\n" + strings.Join(wantLines, "\n") + "\nend"
+	got := "start\n" + strings.Join(gotLines, "\n") + "\nend"
+
+	expected := "(too many differing lines to diff; showing only the first difference)\n- want 0\n+ got 0\n"
+	if diff := lineDiff(want, got); diff != expected {
+		t.Errorf("lineDiff(): Incorrect diff: expected\n%q\ngot\n%q", expected, diff)
+	}
+	expected = "(too many differing lines to diff; showing only the first difference)\n" +
+		"-    2      | want 0\n+         2 | got 0\n"
+	if diff := numberedLineDiff(want, got); diff != expected {
+		t.Errorf("numberedLineDiff(): Incorrect diff: expected\n%q\ngot\n%q", expected, diff)
+	}
+
+	// a large common prefix and suffix don't prevent a full diff
+	want = strings.Join(wantLines, "\n") + "\nb\n" + strings.Join(wantLines, "\n")
+	got = strings.Join(wantLines, "\n") + "\nx\n" + strings.Join(wantLines, "\n")
+	if diff := lineDiff(want, got); !strings.Contains(diff, "\n- b\n+ x\n") {
+		t.Errorf("lineDiff(): Incorrect diff: expected it to contain\n%q\ngot\n%q", "\n- b\n+ x\n", diff)
+	}
+}
+
+func TestLCSMatchTooLarge(t *testing.T) {
+	a := []string{"x"}
+	b := []string{"x"}
+	for i := 0; i < 3000; i++ {
+		a = append(a, fmt.Sprintf("a %d", i))
+		b = append(b, fmt.Sprintf("b %d", i))
+	}
+	a = append(a, "y")
+	b = append(b, "y")
+
+	aMatched, bMatched := lcsMatch(a, b)
+	for i := range a {
+		expected := i == 0 || i == len(a)-1
+		if aMatched[i] != expected || bMatched[i] != expected {
+			t.Errorf("lcsMatch(): Incorrect match for element %d: expected %t, got %t and %t", i, expected,
+				aMatched[i], bMatched[i])
+		}
+	}
+}
@@ -15,8 +15,42 @@ limitations under the License.
 */
 
 /*
-Package testhelp contains functions and associated types intended to make testing various types of code easier.
+Package testhelp contains functions and associated types intended to make testing various types of code easier.  Most
+of the helpers take a TestingT or TestingTB (satisfied by *testing.T), report problems with t.Errorf, and return true
+if their checks passed.
 
-Currently, this includes code that should (or should not) panic.
+Panics: Panics, PanicsStr, PanicsRE, PanicsVal, and related functions check whether code panics, and with what value;
+Capture records a panic's value and stack for custom checks.  The *Test and *Loop variants (e.g. PanicStrTest with
+PanicsStrLoop) run table-driven tests, and the Run*Tests functions run each case as a subtest.  PanicMatcher values
+(Contains, Regexp, ErrIs, IsType, and combinations such as AllOf) describe the expected panic for PanicsMatching.
+Other helpers check panics in deferred functions, goroutine groups, HTTP handlers, and nil arguments
+(PanicsOnNilSweep, CheckMethodsNoPanic).
+
+Assertions and matchers: Matcher values (Eq, Between, HasPrefix, Each, NoError, and others, combined with Not, AllOf,
+and AnyOf) are checked with Assert, and Equal and Diff compare values with readable output.  There are also
+comparisons for times, URLs, CSV, byte strings, string formats, and error trees.
+
+Golden files: PanicsGolden, GoldenBytes, GoldenDir, ResponseGolden, RenderGolden, and ZipEqualsGolden compare output
+with files under testdata, which are rewritten when the environment variable named by UpdateGoldenEnvVar is set;
+normalizers such as NormalizeAddresses and NormalizeTimestamps remove details that change from run to run.
+
+Fixtures and environment: OncePer shares expensive fixtures between tests, Builder and With construct test values,
+and helpers such as SandboxUserDirs, WithTimezone, and WithFlagSet change the test's environment, restoring it during
+cleanup.
+
+Fakes and test doubles: FakeClock controls time, FakeSource and Faker generate reproducible random data, Spy records
+calls, CloseTracker checks how the code under test closes what it's given, and LogStore, MessageSink, and Recorder
+collect logs, messages, and events for later checks.
+
+Concurrency: Stress, AssertMaxConcurrency, HappensBefore, WaitFor, Eventually, and WithDeadlockDetection exercise and
+check concurrent code.
+
+API and exhaustiveness checks: CheckAPICompat compares a package's exported API with a golden file, CheckExhaustive
+and EnumValues check that every value of an enum-like type is handled, and Implements and CheckStructTags check types
+against interfaces and tag rules.
+
+Related packages alongside this one cover more specific areas, such as database/sql (dbhelp), networking (nethelp),
+and recorded HTTP interactions (cassette); those that depend on other modules, such as go-cmp or OpenTelemetry, are
+separate modules (e.g. cmptest and oteltest).
 */
 package testhelp
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// UserDirs holds the directories set up by SandboxUserDirs.
+type UserDirs struct {
+	Home       string
+	ConfigHome string
+	CacheHome  string
+	DataHome   string
+	StateHome  string
+	AppData    string
+	LocalData  string
+}
+
+// SandboxUserDirs points the environment variables that identify the user's home, configuration, cache, and data
+// directories at fresh, empty temporary directories for the rest of the test, and returns the directories.  The
+// variables are restored, and the directories removed, when the test finishes.  This keeps code that reads or writes
+// user configuration (through os.UserHomeDir, os.UserConfigDir, os.UserCacheDir, or the variables themselves) from
+// being affected by, or changing, the files on the machine running the tests.
+//
+// The variables set are HOME and USERPROFILE (set to Home); XDG_CONFIG_HOME, XDG_CACHE_HOME, XDG_DATA_HOME, and
+// XDG_STATE_HOME; and APPDATA and LOCALAPPDATA (for Windows).  The directories are all created, within Home.
+//
+// As with t.Setenv, SandboxUserDirs can't be used in parallel tests.
+func SandboxUserDirs(t EnvT) UserDirs {
+	t.Helper()
+	home := t.TempDir()
+	dirs := UserDirs{
+		Home:       home,
+		ConfigHome: filepath.Join(home, ".config"),
+		CacheHome:  filepath.Join(home, ".cache"),
+		DataHome:   filepath.Join(home, ".local", "share"),
+		StateHome:  filepath.Join(home, ".local", "state"),
+		AppData:    filepath.Join(home, "AppData", "Roaming"),
+		LocalData:  filepath.Join(home, "AppData", "Local"),
+	}
+	for _, dir := range []string{dirs.ConfigHome, dirs.CacheHome, dirs.DataHome, dirs.StateHome, dirs.AppData,
+		dirs.LocalData} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatalf("Can't create sandbox user directory: %s", err)
+		}
+	}
+
+	for key, val := range map[string]string{
+		"HOME":            dirs.Home,
+		"USERPROFILE":     dirs.Home,
+		"XDG_CONFIG_HOME": dirs.ConfigHome,
+		"XDG_CACHE_HOME":  dirs.CacheHome,
+		"XDG_DATA_HOME":   dirs.DataHome,
+		"XDG_STATE_HOME":  dirs.StateHome,
+		"APPDATA":         dirs.AppData,
+		"LOCALAPPDATA":    dirs.LocalData,
+	} {
+		t.Setenv(key, val)
+	}
+	return dirs
+}
+
+// WithTimezone sets the local time zone to the named location (such as "UTC" or "America/New_York") for the rest of
+// the test, by setting both the TZ environment variable (for subprocesses) and time.Local (which Go only reads from TZ
+// at startup).  Both are restored when the test finishes.  This keeps tests that format or parse local times from
+// depending on the time zone of the machine running them.  If the location can't be loaded, the test fails with
+// t.Fatalf.
+//
+// time.Local can't be changed atomically, so WithTimezone calls t.Setenv before anything else, which panics if the
+// test (or one of its ancestors) has called t.Parallel; this keeps other tests from reading time.Local while it's
+// being changed.  Goroutines that the test starts must not use local time (such as with time.Now().Local() or
+// time.Local itself) if they may still be running when WithTimezone is called, or when the test finishes.
+func WithTimezone(t EnvT, name string) {
+	t.Helper()
+	t.Setenv("TZ", name) // first, as the guard against parallel tests
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Can't load time zone '%s': %s", name, err)
+		return // for mocks whose Fatalf doesn't stop the test
+	}
+	origLocal := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = origLocal })
+}
+
+// WithLocale sets the locale environment variables (LANG, LC_ALL, and LC_MESSAGES) to the given locale (such as
+// "C" or "en_US.UTF-8") for the rest of the test, and restores them when the test finishes.  Go itself doesn't use
+// the locale, but subprocesses and C libraries do, so this keeps tests of their output from depending on the settings
+// of the machine running them.
+//
+// As with t.Setenv, WithLocale can't be used in parallel tests.
+func WithLocale(t EnvT, locale string) {
+	t.Helper()
+	for _, key := range []string{"LANG", "LC_ALL", "LC_MESSAGES"} {
+		t.Setenv(key, locale)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestSandboxUserDirs(t *testing.T) {
+	origHome := os.Getenv("HOME")
+	t.Run("sandboxed", func(t *testing.T) {
+		dirs := SandboxUserDirs(t)
+		for key, want := range map[string]string{
+			"HOME":            dirs.Home,
+			"XDG_CONFIG_HOME": dirs.ConfigHome,
+			"XDG_CACHE_HOME":  dirs.CacheHome,
+			"APPDATA":         dirs.AppData,
+		} {
+			if got := os.Getenv(key); got != want {
+				t.Errorf("SandboxUserDirs(): Incorrect %s: expected \"%s\", got \"%s\"", key, want, got)
+			}
+		}
+		if home, err := os.UserHomeDir(); err != nil || home != dirs.Home {
+			t.Errorf("SandboxUserDirs(): os.UserHomeDir() not sandboxed: got \"%s\", %v", home, err)
+		}
+		if runtime.GOOS == "linux" {
+			if config, err := os.UserConfigDir(); err != nil || config != dirs.ConfigHome {
+				t.Errorf("SandboxUserDirs(): os.UserConfigDir() not sandboxed: got \"%s\", %v", config, err)
+			}
+		}
+		if entries, err := os.ReadDir(dirs.ConfigHome); err != nil || len(entries) != 0 {
+			t.Errorf("SandboxUserDirs(): Config directory missing or not empty: %v, %v", entries, err)
+		}
+	})
+	if got := os.Getenv("HOME"); got != origHome {
+		t.Errorf("SandboxUserDirs(): HOME not restored: expected \"%s\", got \"%s\"", origHome, got)
+	}
+}
+
+func TestWithTimezone(t *testing.T) {
+	origLocal := time.Local
+	instant := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	t.Run("pinned", func(t *testing.T) {
+		WithTimezone(t, "Asia/Tokyo")
+		if got := os.Getenv("TZ"); got != "Asia/Tokyo" {
+			t.Errorf("WithTimezone(): Incorrect TZ: got \"%s\"", got)
+		}
+		if got, want := instant.Local().Format(time.RFC3339), "2026-01-03T00:04:05+09:00"; got != want {
+			t.Errorf("WithTimezone(): Incorrect local time: expected %s, got %s", want, got)
+		}
+	})
+	if time.Local != origLocal {
+		t.Errorf("WithTimezone(): time.Local not restored")
+	}
+
+	rt := &envTMock{}
+	WithTimezone(rt, "Not/AZone")
+	if failures := rt.Failures(); len(failures) != 1 {
+		t.Errorf("WithTimezone(): Expected a failure with an invalid zone, got\n%#+v", failures)
+	}
+
+	t.Run("parallel", func(t *testing.T) {
+		t.Parallel()
+		if !Panics(func() { WithTimezone(t, "Asia/Tokyo") }) {
+			t.Errorf("WithTimezone(): Expected a panic in a parallel test")
+		}
+		if time.Local != origLocal {
+			t.Errorf("WithTimezone(): time.Local changed in a parallel test")
+		}
+	})
+}
+
+func TestWithLocale(t *testing.T) {
+	WithLocale(t, "C")
+	for _, key := range []string{"LANG", "LC_ALL", "LC_MESSAGES"} {
+		if got := os.Getenv(key); got != "C" {
+			t.Errorf("WithLocale(): Incorrect %s: got \"%s\"", key, got)
+		}
+	}
+}
+
+// envTMock is a testhelptest.RecordingT with the EnvT methods, for checking failures; its Setenv does nothing.
+type envTMock struct {
+	testhelptest.RecordingT
+}
+
+func (e *envTMock) TempDir() string {
+	return os.TempDir()
+}
+
+func (e *envTMock) Setenv(key, value string) {}
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Equal checks that want and got are equal according to reflect.DeepEqual, calling t.Errorf with a description of
+// each difference between them (see Diff) if not.  It returns true if they are equal.
+func Equal(t TestingTB, want, got interface{}) bool {
+	t.Helper()
+	if diff := Diff(want, got); diff != "" {
+		t.Errorf("Values are not equal:\n%s", diff)
+		return false
+	}
+	return true
+}
+
+// Diff returns a description of the differences between want and got, or an empty string if they are equal according
+// to reflect.DeepEqual.  Each difference is on its own line, with the path to the differing part of the values and
+// both versions of that part, formatted with Format; for example:
+//
+//	Items[2].Name: expected "a", got "b"
+//	Tags["env"]: expected (missing), got "prod"
+//
+// Pointers and interfaces are followed, so their paths are the same as if the values they point to or contain were
+// used directly.  Slices and arrays are compared index by index, and maps key by key.
+//
+// Diff is the engine behind Equal, for use in custom failure callbacks, such as the notEqualsFunc of PanicsValLoop:
+//
+//	func(testName string, wantVal interface{}, pVal interface{}) {
+//		t.Errorf("Incorrect panic value in test '%s':\n%s", testName, testhelp.Diff(wantVal, pVal))
+//	}
+func Diff(want, got interface{}) string {
+	if reflect.DeepEqual(want, got) {
+		return ""
+	}
+	d := differ{visited: map[diffVisit]bool{}}
+	d.diff("", reflect.ValueOf(want), reflect.ValueOf(got))
+	if len(d.diffs) == 0 { // shouldn't happen, but the result still needs to say something
+		d.leaf("", reflect.ValueOf(want), reflect.ValueOf(got))
+	}
+	return strings.Join(d.diffs, "\n")
+}
+
+// A diffVisit identifies a pair of pointers, maps, or slices that have already been compared, for cycle detection.
+type diffVisit struct {
+	want, got uintptr
+	typ       reflect.Type
+}
+
+type differ struct {
+	diffs   []string
+	visited map[diffVisit]bool
+}
+
+// seen returns true if want and got (which are pointers, maps, or slices of the same type) have already been
+// compared, and records that they have been if not.
+func (d *differ) seen(want, got reflect.Value) bool {
+	key := diffVisit{want.Pointer(), got.Pointer(), want.Type()}
+	if d.visited[key] {
+		return true
+	}
+	d.visited[key] = true
+	return false
+}
+
+// diff appends a description of each difference between want and got to d.diffs, with paths starting with path.
+func (d *differ) diff(path string, want, got reflect.Value) {
+	if !want.IsValid() || !got.IsValid() || want.Type() != got.Type() {
+		if want.IsValid() || got.IsValid() {
+			d.leaf(path, want, got)
+		}
+		return
+	}
+
+	switch want.Kind() {
+	case reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			if want.IsNil() != got.IsNil() {
+				d.leaf(path, want, got)
+			}
+			return
+		}
+		d.diff(path, want.Elem(), got.Elem())
+	case reflect.Ptr:
+		if want.Pointer() == got.Pointer() {
+			return
+		}
+		if want.IsNil() || got.IsNil() {
+			d.leaf(path, want, got)
+			return
+		}
+		if !d.seen(want, got) {
+			d.diff(path, want.Elem(), got.Elem())
+		}
+	case reflect.Struct:
+		if want.Type() == timeType && want.CanInterface() { // the internal fields aren't useful to compare
+			if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+				d.leaf(path, want, got)
+			}
+			return
+		}
+		for i := 0; i < want.NumField(); i++ {
+			d.diff(joinPath(path, want.Type().Field(i).Name), want.Field(i), got.Field(i))
+		}
+	case reflect.Slice:
+		if want.IsNil() != got.IsNil() || want.Type().Elem().Kind() == reflect.Uint8 {
+			if want.IsNil() != got.IsNil() || !bytesEqual(want, got) {
+				d.leaf(path, want, got)
+			}
+			return
+		}
+		if want.Pointer() == got.Pointer() && want.Len() == got.Len() {
+			return
+		}
+		if !d.seen(want, got) {
+			d.diffElems(path, want, got)
+		}
+	case reflect.Array:
+		d.diffElems(path, want, got)
+	case reflect.Map:
+		if want.IsNil() != got.IsNil() {
+			d.leaf(path, want, got)
+			return
+		}
+		if want.Pointer() == got.Pointer() || d.seen(want, got) {
+			return
+		}
+		d.diffMap(path, want, got)
+	case reflect.Func:
+		// Funcs are only equal if they're both nil
+		if !want.IsNil() || !got.IsNil() {
+			d.leaf(path, want, got)
+		}
+	default:
+		if !scalarEqual(want, got) {
+			d.leaf(path, want, got)
+		}
+	}
+}
+
+// diffElems appends the differences between two slices or arrays to d.diffs, index by index.
+func (d *differ) diffElems(path string, want, got reflect.Value) {
+	if want.Len() != got.Len() {
+		d.diffs = append(d.diffs, fmt.Sprintf("%s: expected %d element(s), got %d", topLevel(path), want.Len(),
+			got.Len()))
+	}
+	for i := 0; i < want.Len() || i < got.Len(); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= got.Len():
+			d.diffs = append(d.diffs, fmt.Sprintf("%s: expected %s, got (missing)", elemPath, diffFormat(want.Index(i))))
+		case i >= want.Len():
+			d.diffs = append(d.diffs, fmt.Sprintf("%s: expected (missing), got %s", elemPath, diffFormat(got.Index(i))))
+		default:
+			d.diff(elemPath, want.Index(i), got.Index(i))
+		}
+	}
+}
+
+// diffMap appends the differences between two maps to d.diffs, key by key in sorted order.
+func (d *differ) diffMap(path string, want, got reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, m := range []reflect.Value{want, got} {
+		iter := m.MapRange()
+		for iter.Next() {
+			keys[diffFormat(iter.Key())] = iter.Key()
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		elemPath := path + "[" + name + "]"
+		wantVal, gotVal := want.MapIndex(keys[name]), got.MapIndex(keys[name])
+		switch {
+		case !gotVal.IsValid():
+			d.diffs = append(d.diffs, fmt.Sprintf("%s: expected %s, got (missing)", elemPath, diffFormat(wantVal)))
+		case !wantVal.IsValid():
+			d.diffs = append(d.diffs, fmt.Sprintf("%s: expected (missing), got %s", elemPath, diffFormat(gotVal)))
+		default:
+			d.diff(elemPath, wantVal, gotVal)
+		}
+	}
+}
+
+// leaf appends a difference between want and got to d.diffs, showing both values in full.
+func (d *differ) leaf(path string, want, got reflect.Value) {
+	d.diffs = append(d.diffs, fmt.Sprintf("%s: expected %s, got %s", topLevel(path), diffFormat(want),
+		diffFormat(got)))
+}
+
+// joinPath adds a struct field name to a path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// topLevel returns path, or a placeholder if it's empty because the difference is in the values as a whole.
+func topLevel(path string) string {
+	if path == "" {
+		return "(top level)"
+	}
+	return path
+}
+
+// diffFormat formats a part of a value for Diff, with its type shown, since the part could be from inside an
+// interface.  (It can't just use Format, because the part could be an unexported field, which can't be converted back
+// to an interface{}.)
+func diffFormat(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return new(FormatOptions).formatter().format(v, 0, true)
+}
+
+// bytesEqual returns true if two byte slices have the same contents.
+func bytesEqual(want, got reflect.Value) bool {
+	if want.Len() != got.Len() {
+		return false
+	}
+	for i := 0; i < want.Len(); i++ {
+		if want.Index(i).Uint() != got.Index(i).Uint() {
+			return false
+		}
+	}
+	return true
+}
+
+// scalarEqual returns true if two values of the same scalar type (including channels and unsafe pointers) are equal,
+// the same way reflect.DeepEqual does it.
+func scalarEqual(want, got reflect.Value) bool {
+	switch want.Kind() {
+	case reflect.Bool:
+		return want.Bool() == got.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return want.Int() == got.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return want.Uint() == got.Uint()
+	case reflect.Float32, reflect.Float64:
+		return want.Float() == got.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return want.Complex() == got.Complex()
+	case reflect.String:
+		return want.String() == got.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return want.Pointer() == got.Pointer()
+	}
+	return false
+}
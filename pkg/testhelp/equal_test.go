@@ -0,0 +1,127 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type diffInner struct {
+	Name string
+	tags map[string]int
+}
+
+type diffOuter struct {
+	ID    int
+	Inner *diffInner
+	Items []diffInner
+	Any   interface{}
+}
+
+type diffNode struct {
+	Val  int
+	Next *diffNode
+}
+
+func TestDiff(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	loopA := &diffNode{Val: 1}
+	loopA.Next = loopA
+	loopB := &diffNode{Val: 1}
+	loopB.Next = loopB
+	loopC := &diffNode{Val: 2}
+	loopC.Next = loopC
+
+	tests := []struct {
+		name     string
+		want     interface{}
+		got      interface{}
+		expected string
+	}{
+		{name: "equal scalars", want: 1, got: 1, expected: ""},
+		{name: "equal structs", want: diffOuter{ID: 1, Inner: &diffInner{Name: "a"}},
+			got: diffOuter{ID: 1, Inner: &diffInner{Name: "a"}}, expected: ""},
+		{name: "both nil", want: nil, got: nil, expected: ""},
+		{name: "scalars", want: 1, got: 2, expected: "(top level): expected 1, got 2"},
+		{name: "types", want: 1, got: int64(1), expected: "(top level): expected 1, got int64(1)"},
+		{name: "nil", want: nil, got: "a", expected: "(top level): expected nil, got \"a\""},
+		{
+			name: "nested fields",
+			want: diffOuter{ID: 1, Inner: &diffInner{Name: "a", tags: map[string]int{"x": 1, "y": 2}}},
+			got:  diffOuter{ID: 2, Inner: &diffInner{Name: "b", tags: map[string]int{"x": 1, "z": 3}}},
+			expected: "ID: expected 1, got 2\nInner.Name: expected \"a\", got \"b\"\n" +
+				"Inner.tags[\"y\"]: expected 2, got (missing)\nInner.tags[\"z\"]: expected (missing), got 3",
+		},
+		{
+			name: "nil pointer",
+			want: diffOuter{Inner: &diffInner{Name: "a"}},
+			got:  diffOuter{},
+			expected: "Inner: expected &testhelp.diffInner{Name: \"a\", tags: map[string]int(nil)}, " +
+				"got (*testhelp.diffInner)(nil)",
+		},
+		{
+			name: "slices",
+			want: diffOuter{Items: []diffInner{{Name: "a"}, {Name: "b"}, {Name: "c"}}},
+			got:  diffOuter{Items: []diffInner{{Name: "a"}, {Name: "x"}}},
+			expected: "Items: expected 3 element(s), got 2\nItems[1].Name: expected \"b\", got \"x\"\n" +
+				"Items[2]: expected testhelp.diffInner{Name: \"c\", tags: map[string]int(nil)}, got (missing)",
+		},
+		{name: "nil slice", want: []int(nil), got: []int{}, expected: "(top level): expected []int(nil), got []int{}"},
+		{name: "top-level slice", want: []int{1, 2}, got: []int{1, 3}, expected: "[1]: expected 2, got 3"},
+		{name: "arrays", want: [2]string{"a", "b"}, got: [2]string{"a", "c"}, expected: "[1]: expected \"b\", got \"c\""},
+		{name: "bytes", want: []byte("ab"), got: []byte("ac"),
+			expected: "(top level): expected []byte(\"ab\"), got []byte(\"ac\")"},
+		{name: "interfaces", want: diffOuter{Any: "a"}, got: diffOuter{Any: 1}, expected: "Any: expected \"a\", got 1"},
+		{name: "nil interface", want: diffOuter{Any: "a"}, got: diffOuter{}, expected: "Any: expected \"a\", got nil"},
+		{name: "times", want: when, got: when.Add(time.Second),
+			expected: "(top level): expected time.Time(2020-01-02T03:04:05Z), got time.Time(2020-01-02T03:04:06Z)"},
+		{name: "errors", want: errors.New("a"), got: errors.New("b"), expected: "s: expected \"a\", got \"b\""},
+		{name: "equal cycles", want: loopA, got: loopB, expected: ""},
+		{name: "different cycles", want: loopA, got: loopC, expected: "Val: expected 1, got 2"},
+	}
+	for _, test := range tests {
+		if got := Diff(test.want, test.got); got != test.expected {
+			t.Errorf("Diff(): Incorrect diff: expected\n%s\ngot\n%s\nin test '%s'", test.expected, got, test.name)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     interface{}
+		got      interface{}
+		expected []string
+	}{
+		{name: "equal", want: []int{1, 2}, got: []int{1, 2}, expected: []string{}},
+		{name: "not equal", want: []int{1, 2}, got: []int{1, 3},
+			expected: []string{"Values are not equal:\n[1]: expected 2, got 3"}},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := Equal(rt, test.want, test.got)
+		if got := rt.Failures(); ok != (len(test.expected) == 0) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Equal(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"time"
+)
+
+// WaitFor calls check, and then again every interval, until it returns nil or timeout has passed since the first call.
+// It returns nil if check succeeded, or an error that includes check's last error if it didn't.  check is always
+// called at least once, and calls are never concurrent.
+func WaitFor(timeout, interval time.Duration, check func() error) error {
+	if attempts, err := waitFor(timeout, interval, check); err != nil {
+		return fmt.Errorf("condition not met within %s (%d attempt(s)); last error: %w", timeout, attempts, err)
+	}
+	return nil
+}
+
+// waitFor does the work for WaitFor and Eventually.  It returns the number of attempts and check's last error, which
+// is nil if check succeeded.
+func waitFor(timeout, interval time.Duration, check func() error) (int, error) {
+	deadline := time.Now().Add(timeout)
+	attempts := 0
+	for {
+		attempts++
+		err := check()
+		if err == nil || !time.Now().Add(interval).Before(deadline) {
+			return attempts, err
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Eventually calls check as WaitFor does, and calls t.Errorf with check's last error if it doesn't succeed within
+// timeout.  It returns true if it succeeds.  This is intended for conditions that the code under test brings about
+// asynchronously, such as a server becoming ready or a background job finishing:
+//
+//	testhelp.Eventually(t, 5*time.Second, 50*time.Millisecond, func() error {
+//		if job.State() != Done {
+//			return fmt.Errorf("job state is %s", job.State())
+//		}
+//		return nil
+//	})
+func Eventually(t TestingTB, timeout, interval time.Duration, check func() error) bool {
+	t.Helper()
+	if attempts, err := waitFor(timeout, interval, check); err != nil {
+		t.Errorf("Condition not met within %s (%d attempt(s)); last error: %s", timeout, attempts, err)
+		return false
+	}
+	return true
+}
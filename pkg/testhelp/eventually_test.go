@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestWaitFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		succeedOn      int // 0 means never
+		timeout        time.Duration
+		expectErr      string
+		expectAttempts int
+	}{
+		{name: "immediately", succeedOn: 1, timeout: time.Second, expectAttempts: 1},
+		{name: "after retries", succeedOn: 3, timeout: time.Second, expectAttempts: 3},
+		{
+			name: "zero timeout", timeout: 0, expectAttempts: 1,
+			expectErr: "condition not met within 0s (1 attempt(s)); last error: attempt 1 failed",
+		},
+	}
+	for _, test := range tests {
+		attempts := 0
+		err := WaitFor(test.timeout, 10*time.Millisecond, func() error {
+			attempts++
+			if attempts == test.succeedOn {
+				return nil
+			}
+			return fmt.Errorf("attempt %d failed", attempts)
+		})
+		gotErr := ""
+		if err != nil {
+			gotErr = err.Error()
+		}
+		if gotErr != test.expectErr || attempts != test.expectAttempts {
+			t.Errorf("WaitFor(): Incorrect result: expected\n%#+v after %d attempt(s)\ngot\n%#+v after %d attempt(s)"+
+				"\nin test '%s'", test.expectErr, test.expectAttempts, gotErr, attempts, test.name)
+		}
+	}
+
+	attempts := 0
+	err := WaitFor(30*time.Millisecond, 10*time.Millisecond, func() error { attempts++; return errors.New("no") })
+	if err == nil || attempts < 2 {
+		t.Errorf("WaitFor(): Expected an error after more than one attempt, got %#+v after %d", err, attempts)
+	}
+
+	sentinel := errors.New("sentinel")
+	if err := WaitFor(0, 0, func() error { return sentinel }); !errors.Is(err, sentinel) {
+		t.Errorf("WaitFor(): Expected the error to wrap check's error, got %#+v", err)
+	}
+}
+
+func TestEventually(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if !Eventually(rt, time.Second, time.Millisecond, func() error { return nil }) || len(rt.Failures()) != 0 {
+		t.Errorf("Eventually(): Unexpected failure:\n%#+v", rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if Eventually(rt, 0, time.Millisecond, func() error { return errors.New("not yet") }) {
+		t.Errorf("Eventually(): Expected false for a condition that's never met")
+	}
+	want := []string{"Condition not met within 0s (1 attempt(s)); last error: not yet"}
+	if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Eventually(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"sort"
+	"strings"
+)
+
+// An ExampleTest is an example for RunExamples: a function that prints to os.Stdout, and the output it should print,
+// as it would be given in an "// Output:" comment.  If Unordered is true, the lines of output can be in any order, as
+// with an "// Unordered output:" comment.
+type ExampleTest struct {
+	Name      string
+	F         func()
+	Output    string
+	Unordered bool
+}
+
+// RunExamples runs each of the examples with its standard output captured (see CaptureOutput), and checks that the
+// output matches the expected output, the way "go test" checks testable Examples: leading and trailing space is
+// ignored, and so is the order of the lines for Unordered examples.  Before the comparison, both the output and the
+// expected output are passed through each of the normalizers in turn, so that details that change from run to run
+// (such as addresses, timestamps, or the order of items from a map) can be replaced; see NormalizeAddresses, for
+// example.  For each example that panics or whose output doesn't match, t.Errorf is called, with a line-by-line diff
+// for mismatched output.  RunExamples returns true if all of the examples passed.
+//
+// This is for functions that can't be real testable Examples, such as ones whose output has to be normalized, or ones
+// generated from a table:
+//
+//	testhelp.RunExamples(t, []testhelp.ExampleTest{
+//		{Name: "point", F: func() { fmt.Println(geo.Point{1, 2}) }, Output: "(1, 2)"},
+//		{Name: "tags", F: func() { geo.PrintTags(tags) }, Output: "a\nb", Unordered: true},
+//	}, testhelp.NormalizeAddresses)
+//
+// As with CaptureOutput, RunExamples shouldn't be used in parallel tests, and if the output can't be captured, the
+// test fails with t.Fatalf.
+func RunExamples(t TestingTB, examples []ExampleTest, normalizers ...func(string) string) bool {
+	t.Helper()
+	ok := true
+	for _, example := range examples {
+		var didPanic bool
+		var pVal interface{}
+		stdout, _, err := CaptureOutput(func() { didPanic, pVal = PanicsGet(example.F) })
+		if err != nil {
+			t.Fatalf("Can't capture output: %s", err)
+			return false // for mocks whose Fatalf doesn't stop the test
+		}
+		if didPanic {
+			t.Errorf("Example '%s' panicked: %s\noutput so far:\n%s", example.Name, PanicMessage(pVal), stdout)
+			ok = false
+			continue
+		}
+		want := normalizeExample(example.Output, example.Unordered, normalizers)
+		got := normalizeExample(stdout, example.Unordered, normalizers)
+		if got != want {
+			t.Errorf("Incorrect output from example '%s': expected\n%s\ngot\n%s\ndiff:\n%s", example.Name, want, got,
+				strings.TrimSuffix(lineDiff(want, got), "\n"))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// normalizeExample applies the normalizers to an example's output, trims space, and sorts the lines if the order
+// doesn't matter.
+func normalizeExample(output string, unordered bool, normalizers []func(string) string) string {
+	for _, normalize := range normalizers {
+		output = normalize(output)
+	}
+	output = strings.TrimSpace(output)
+	if unordered {
+		lines := strings.Split(output, "\n")
+		sort.Strings(lines)
+		output = strings.Join(lines, "\n")
+	}
+	return output
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestRunExamples(t *testing.T) {
+	tests := []struct {
+		name        string
+		example     ExampleTest
+		normalizers []func(string) string
+		expected    []string
+	}{
+		{
+			name:     "matching",
+			example:  ExampleTest{Name: "ex", F: func() { fmt.Print("  hello\n\n") }, Output: "hello"},
+			expected: []string{},
+		},
+		{
+			name:     "mismatch",
+			example:  ExampleTest{Name: "ex", F: func() { fmt.Println("a\nc") }, Output: "a\nb"},
+			expected: []string{"Incorrect output from example 'ex': expected\na\nb\ngot\na\nc\ndiff:\n  a\n- b\n+ c"},
+		},
+		{
+			name: "unordered",
+			example: ExampleTest{Name: "ex", F: func() { fmt.Println("b\na") }, Output: "a\nb",
+				Unordered: true},
+			expected: []string{},
+		},
+		{
+			name:     "ordered",
+			example:  ExampleTest{Name: "ex", F: func() { fmt.Println("b\na") }, Output: "a\nb"},
+			expected: []string{"Incorrect output from example 'ex': expected\na\nb\ngot\nb\na\ndiff:\n- a\n  b\n+ a"},
+		},
+		{
+			name:        "normalized",
+			example:     ExampleTest{Name: "ex", F: func() { fmt.Printf("at %p\n", &struct{ a int }{}) }, Output: "at 0x1"},
+			normalizers: []func(string) string{NormalizeAddresses},
+			expected:    []string{},
+		},
+		{
+			name:     "panic",
+			example:  ExampleTest{Name: "ex", F: func() { fmt.Println("partial"); panic("boom") }, Output: "done"},
+			expected: []string{"Example 'ex' panicked: boom\noutput so far:\npartial\n"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := RunExamples(rt, []ExampleTest{test.example}, test.normalizers...)
+		if got := rt.Failures(); ok != (len(test.expected) == 0) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("RunExamples(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+}
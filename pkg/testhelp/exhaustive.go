@@ -0,0 +1,167 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// CheckExhaustive checks that handled returns true for each of allValues, calling t.Errorf with the values for which
+// it doesn't if there are any.  A panic from handled (such as from the default case of a switch) counts as not
+// handling the value.  It returns true if the check passed.
+//
+// This tests that code that switches on an enum type handles every member, including members added later, especially
+// with EnumValues to list them:
+//
+//	testhelp.CheckExhaustive(t, testhelp.EnumValues[Color](t), func(c Color) bool {
+//		return colorName(c) != "unknown"
+//	})
+func CheckExhaustive[T any](t TestingTB, allValues []T, handled func(T) bool) bool {
+	t.Helper()
+	var unhandled []string
+	for _, v := range allValues {
+		v := v
+		var ok bool
+		if didPanic, pVal := PanicsGet(func() { ok = handled(v) }); didPanic {
+			unhandled = append(unhandled, fmt.Sprintf("  %s (panicked: %s)", enumValueString(v), PanicMessage(pVal)))
+		} else if !ok {
+			unhandled = append(unhandled, "  "+enumValueString(v))
+		}
+	}
+	if len(unhandled) > 0 {
+		t.Errorf("Unhandled values (%d of %d):\n%s", len(unhandled), len(allValues), strings.Join(unhandled, "\n"))
+		return false
+	}
+	return true
+}
+
+// enumValueString formats an enum value for CheckExhaustive, giving both its String form (for types with a String
+// method) and its Go syntax, if they're different.
+func enumValueString(v interface{}) string {
+	s, goSyntax := fmt.Sprintf("%v", v), fmt.Sprintf("%#+v", v)
+	if s == goSyntax {
+		return s
+	}
+	return fmt.Sprintf("%s (%s)", s, goSyntax)
+}
+
+// EnumValues returns the values of the constants of type T declared in T's package, in the order in which they're
+// declared, such as the members of an iota-style constant block:
+//
+//	type Color int
+//
+//	const (
+//		Red Color = iota
+//		Green
+//		Blue
+//	)
+//
+// for which EnumValues[Color](t) returns []Color{Red, Green, Blue}.  The package is type-checked from source with
+// go/types, so the list can't fall out of date when members are added.  Constants with the same value as an earlier
+// one (such as an alias like ColorDefault = Red) are left out, as are constants with any of the names in exclude
+// (such as a sentinel like numColors).
+//
+// T must be a named type whose underlying type is an integer, floating-point, string, or boolean type.  If T's
+// package can't be loaded, or has no constants of type T, EnumValues calls t.Fatalf.
+func EnumValues[T any](t TestingTB, exclude ...string) []T {
+	t.Helper()
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Name() == "" || typ.PkgPath() == "" {
+		t.Fatalf("EnumValues requires a named type, got %s", typ)
+		return nil
+	}
+	pkg, err := importSource(typ.PkgPath())
+	if err != nil {
+		t.Fatalf("Can't load package %s: %s", typ.PkgPath(), err)
+		return nil
+	}
+
+	excluded := map[string]bool{}
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+	var consts []*types.Const
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || excluded[name] {
+			continue
+		}
+		if named, ok := c.Type().(*types.Named); ok && named.Obj().Name() == typ.Name() &&
+			named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == typ.PkgPath() {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		t.Fatalf("No constants of type %s found in package %s", typ, typ.PkgPath())
+		return nil
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	var values []T
+	seen := map[string]bool{}
+	for _, c := range consts {
+		key := c.Val().ExactString()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		var v T
+		if err := setConstant(reflect.ValueOf(&v).Elem(), c.Val()); err != nil {
+			t.Fatalf("Can't convert constant %s: %s", c.Name(), err)
+			return nil
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// setConstant sets rv to the constant value val.
+func setConstant(rv reflect.Value, val constant.Value) error {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, exact := constant.Int64Val(constant.ToInt(val)); exact && !rv.OverflowInt(i) {
+			rv.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u, exact := constant.Uint64Val(constant.ToInt(val)); exact && !rv.OverflowUint(u) {
+			rv.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(val))
+		rv.SetFloat(f)
+		return nil
+	case reflect.String:
+		if val.Kind() == constant.String {
+			rv.SetString(constant.StringVal(val))
+			return nil
+		}
+	case reflect.Bool:
+		if val.Kind() == constant.Bool {
+			rv.SetBool(constant.BoolVal(val))
+			return nil
+		}
+	}
+	return fmt.Errorf("can't set %s from %s", rv.Type(), val.ExactString())
+}
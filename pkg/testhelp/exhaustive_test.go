@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"go/constant"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+	"github.com/ocsw/go-testhelp/pkg/testhelp/testdata/enumfixture"
+)
+
+func TestCheckExhaustive(t *testing.T) {
+	tests := []struct {
+		name         string
+		handled      func(k PanicKind) bool
+		wantOK       bool
+		wantFailures []string
+	}{
+		{"all handled", func(k PanicKind) bool { return true }, true, []string{}},
+		{"some not handled", func(k PanicKind) bool { return k != PanicKindIndex && k != PanicKindSlice }, false,
+			[]string{"Unhandled values (2 of 3):\n  index out of range (4)\n  slice bounds out of range (5)"}},
+		{"panic", func(k PanicKind) bool {
+			if k == PanicKindSlice {
+				panic("unknown kind")
+			}
+			return true
+		}, false, []string{"Unhandled values (1 of 3):\n  slice bounds out of range (5) (panicked: unknown kind)"}},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		allValues := []PanicKind{PanicKindNone, PanicKindIndex, PanicKindSlice}
+		if ok := CheckExhaustive(rt, allValues, test.handled); ok != test.wantOK {
+			t.Errorf("CheckExhaustive(): Incorrect result: expected %t, got %t in test '%s'", test.wantOK, ok,
+				test.name)
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, test.wantFailures) {
+			t.Errorf("CheckExhaustive(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantFailures, got, test.name)
+		}
+	}
+
+	rt := &testhelptest.RecordingT{}
+	CheckExhaustive(rt, []string{"a", "b"}, func(s string) bool { return s == "a" })
+	if want := []string{"Unhandled values (1 of 2):\n  b (\"b\")"}; !reflect.DeepEqual(rt.Failures(), want) {
+		t.Errorf("CheckExhaustive(): Incorrect failures for strings: expected\n%#+v\ngot\n%#+v", want, rt.Failures())
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	colors := EnumValues[enumfixture.Color](rt, "numColors")
+	wantColors := []enumfixture.Color{enumfixture.Red, enumfixture.Green, enumfixture.Blue}
+	if !reflect.DeepEqual(colors, wantColors) {
+		t.Errorf("EnumValues(): Incorrect values: expected\n%#+v\ngot\n%#+v", wantColors, colors)
+	}
+	if got, want := len(EnumValues[enumfixture.Color](rt)), 4; got != want {
+		t.Errorf("EnumValues(): Incorrect number of values without exclusions: expected %d, got %d", want, got)
+	}
+	sizes := EnumValues[enumfixture.Size](rt)
+	wantSizes := []enumfixture.Size{enumfixture.Small, enumfixture.Medium, enumfixture.Large}
+	if !reflect.DeepEqual(sizes, wantSizes) {
+		t.Errorf("EnumValues(): Incorrect values: expected\n%#+v\ngot\n%#+v", wantSizes, sizes)
+	}
+	flavors := EnumValues[enumfixture.Flavor](rt)
+	wantFlavors := []enumfixture.Flavor{enumfixture.Vanilla, enumfixture.Chocolate}
+	if !reflect.DeepEqual(flavors, wantFlavors) {
+		t.Errorf("EnumValues(): Incorrect values: expected\n%#+v\ngot\n%#+v", wantFlavors, flavors)
+	}
+	if failures := rt.Failures(); len(failures) != 0 {
+		t.Errorf("EnumValues(): Unexpected failures:\n%#+v", failures)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if got := EnumValues[enumfixture.Shape](rt); got != nil || len(rt.Fatals) != 1 ||
+		!strings.HasPrefix(rt.Fatals[0], "No constants of type enumfixture.Shape found") {
+		t.Errorf("EnumValues(): Incorrect results for a type without constants: got %#+v, fatals\n%#+v", got,
+			rt.Fatals)
+	}
+	rt = &testhelptest.RecordingT{}
+	if got := EnumValues[int](rt); got != nil || len(rt.Fatals) != 1 ||
+		!strings.HasPrefix(rt.Fatals[0], "EnumValues requires a named type") {
+		t.Errorf("EnumValues(): Incorrect results for an unnamed type: got %#+v, fatals\n%#+v", got, rt.Fatals)
+	}
+}
+
+func TestSetConstant(t *testing.T) {
+	var (
+		i   int8
+		u   uint16
+		f   float64
+		s   string
+		b   bool
+		big int8
+	)
+	vals := []struct {
+		name    string
+		target  interface{}
+		val     constant.Value
+		wantErr bool
+	}{
+		{"int", &i, constant.MakeInt64(-3), false},
+		{"uint", &u, constant.MakeUint64(7), false},
+		{"float", &f, constant.MakeFloat64(1.5), false},
+		{"string", &s, constant.MakeString("x"), false},
+		{"bool", &b, constant.MakeBool(true), false},
+		{"overflow", &big, constant.MakeInt64(300), true},
+		{"negative uint", &u, constant.MakeInt64(-1), true},
+		{"wrong kind", &s, constant.MakeInt64(5), true},
+	}
+	for _, v := range vals {
+		err := setConstant(reflect.ValueOf(v.target).Elem(), v.val)
+		if (err != nil) != v.wantErr {
+			t.Errorf("setConstant(): Incorrect error: expected error %t, got %v in test '%s'", v.wantErr, err, v.name)
+		}
+	}
+	if i != -3 || u != 7 || f != 1.5 || s != "x" || !b {
+		t.Errorf("setConstant(): Incorrect values: got %d, %d, %g, %q, %t", i, u, f, s, b)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Expectations holds an ordered list of named events that a test expects to happen, such as the steps of a lifecycle
+// (open, write, flush, close).  The code under test (or a Spy, or a wrapper around a dependency) calls Fulfill with
+// each event's name as it happens, and Verify compares what happened against what was expected.
+//
+// Expectations is safe for concurrent use, but the order of events fulfilled from different goroutines is only
+// meaningful if the goroutines synchronize with each other.
+type Expectations struct {
+	want []string
+
+	mu  sync.Mutex
+	got []string
+}
+
+// An ExpectationsResult describes the differences between the expected and actual events of an Expectations.  Missing
+// holds expected events that never happened, Extra holds events that happened but weren't expected, and OutOfOrder
+// holds events that were expected and happened, but not in the expected position.  Event names that were expected (or
+// happened) more than once may appear more than once.
+type ExpectationsResult struct {
+	Missing    []string
+	Extra      []string
+	OutOfOrder []string
+}
+
+// OK returns true if the result shows no differences.
+func (r ExpectationsResult) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.OutOfOrder) == 0
+}
+
+// NewExpectations returns an Expectations for the given events, in order.  It doesn't verify anything by itself; see
+// ExpectInOrder for a version that verifies automatically at the end of the test.
+func NewExpectations(events ...string) *Expectations {
+	return &Expectations{want: append([]string{}, events...)}
+}
+
+// ExpectInOrder returns an Expectations for the given events, in order, and registers a cleanup function with t which
+// calls Verify.
+func ExpectInOrder(t TestingTB, events ...string) *Expectations {
+	t.Helper()
+	e := NewExpectations(events...)
+	t.Cleanup(func() {
+		t.Helper()
+		e.Verify(t)
+	})
+	return e
+}
+
+// Fulfill records that the named event has happened.
+func (e *Expectations) Fulfill(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.got = append(e.got, name)
+}
+
+// Events returns a copy of the events recorded so far, in order.
+func (e *Expectations) Events() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string{}, e.got...)
+}
+
+// Result compares the events recorded so far against the expected events.
+//
+// Events are matched up using the longest common subsequence of the two lists, so that a single misplaced event is
+// reported as out of order, rather than making every event after it look wrong.
+func (e *Expectations) Result() ExpectationsResult {
+	got := e.Events()
+	wantMatched, gotMatched := lcsMatch(e.want, got)
+
+	// Unmatched events that appear on both sides are in the wrong place; pair them up by name
+	unmatchedGot := map[string]int{}
+	for i, name := range got {
+		if !gotMatched[i] {
+			unmatchedGot[name]++
+		}
+	}
+	var result ExpectationsResult
+	for i, name := range e.want {
+		if wantMatched[i] {
+			continue
+		}
+		if unmatchedGot[name] > 0 {
+			unmatchedGot[name]--
+			result.OutOfOrder = append(result.OutOfOrder, name)
+		} else {
+			result.Missing = append(result.Missing, name)
+		}
+	}
+	for i, name := range got {
+		if !gotMatched[i] && unmatchedGot[name] > 0 {
+			unmatchedGot[name]--
+			result.Extra = append(result.Extra, name)
+		}
+	}
+	return result
+}
+
+// Verify compares the events recorded so far against the expected events, and calls t.Errorf with an informative
+// message if they differ.  It returns true if they match.
+func (e *Expectations) Verify(t TestingT) bool {
+	result := e.Result()
+	if result.OK() {
+		return true
+	}
+
+	var b strings.Builder
+	if len(result.Missing) > 0 {
+		fmt.Fprintf(&b, "\nMissing: %s", strings.Join(result.Missing, ", "))
+	}
+	if len(result.Extra) > 0 {
+		fmt.Fprintf(&b, "\nExtra: %s", strings.Join(result.Extra, ", "))
+	}
+	if len(result.OutOfOrder) > 0 {
+		fmt.Fprintf(&b, "\nOut of order: %s", strings.Join(result.OutOfOrder, ", "))
+	}
+	t.Errorf("Events did not happen as expected:%s\nExpected sequence:\n%s\nGot:\n%s",
+		b.String(), strings.Join(e.want, " -> "), strings.Join(e.Events(), " -> "))
+	return false
+}
+
+// maxLCSCells caps the size of lcsMatch's table (in entries, after any common prefix and suffix are removed), so that
+// large, very different inputs don't exhaust memory; above it, only the common prefix and suffix are matched.
+const maxLCSCells = 1 << 22
+
+// lcsFits reports whether lcsMatch can build its table for inputs of lengths n and m.
+func lcsFits(n, m int) bool {
+	return n == 0 || m == 0 || (n+1) <= maxLCSCells/(m+1)
+}
+
+// commonAffixes returns the lengths of the longest common prefix of a and b, and of the longest common suffix of the
+// rest.
+func commonAffixes(a, b []string) (prefix, suffix int) {
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// lcsMatch finds a longest common subsequence of a and b, and returns slices marking which elements of each are part
+// of it.  If the parts of a and b between their common prefix and suffix are too large (see maxLCSCells), none of
+// those parts are marked.
+func lcsMatch(a, b []string) (aMatched, bMatched []bool) {
+	aMatched = make([]bool, len(a))
+	bMatched = make([]bool, len(b))
+	prefix, suffix := commonAffixes(a, b)
+	for i := 0; i < prefix; i++ {
+		aMatched[i], bMatched[i] = true, true
+	}
+	for i := 1; i <= suffix; i++ {
+		aMatched[len(a)-i], bMatched[len(b)-i] = true, true
+	}
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if !lcsFits(len(a), len(b)) {
+		return aMatched, bMatched
+	}
+
+	// lengths[i][j] is the LCS length of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			aMatched[prefix+i], bMatched[prefix+j] = true, true
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return aMatched, bMatched
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestExpectationsResult(t *testing.T) {
+	want := []string{"open", "write", "flush", "close"}
+
+	tests := []struct {
+		name           string
+		got            []string
+		wantMissing    []string
+		wantExtra      []string
+		wantOutOfOrder []string
+	}{
+		{"all correct", []string{"open", "write", "flush", "close"}, nil, nil, nil},
+		{"missing middle", []string{"open", "write", "close"}, []string{"flush"}, nil, nil},
+		{"missing end", []string{"open", "write", "flush"}, []string{"close"}, nil, nil},
+		{"none", []string{}, want, nil, nil},
+		{"extra close", []string{"open", "write", "flush", "close", "close"}, nil, []string{"close"}, nil},
+		{"extra unknown", []string{"open", "seek", "write", "flush", "close"}, nil, []string{"seek"}, nil},
+		// either event could be the one out of place; the tie goes to the expected order
+		{"swapped", []string{"open", "flush", "write", "close"}, nil, nil, []string{"write"}},
+		{"close first", []string{"close", "open", "write", "flush"}, nil, nil, []string{"close"}},
+		{
+			"everything wrong", []string{"seek", "close", "open", "write"},
+			[]string{"flush"}, []string{"seek"}, []string{"close"},
+		},
+	}
+	for _, test := range tests {
+		e := NewExpectations(want...)
+		for _, name := range test.got {
+			e.Fulfill(name)
+		}
+		result := e.Result()
+		if !reflect.DeepEqual(result.Missing, test.wantMissing) {
+			t.Errorf("Result(): Incorrect missing events: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantMissing, result.Missing, test.name)
+		}
+		if !reflect.DeepEqual(result.Extra, test.wantExtra) {
+			t.Errorf("Result(): Incorrect extra events: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantExtra, result.Extra, test.name)
+		}
+		if !reflect.DeepEqual(result.OutOfOrder, test.wantOutOfOrder) {
+			t.Errorf("Result(): Incorrect out-of-order events: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantOutOfOrder, result.OutOfOrder, test.name)
+		}
+		wantOK := test.wantMissing == nil && test.wantExtra == nil && test.wantOutOfOrder == nil
+		if result.OK() != wantOK {
+			t.Errorf("OK(): Expected %t, got %t in test '%s'", wantOK, result.OK(), test.name)
+		}
+	}
+}
+
+func TestExpectInOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     []string
+		wantStr []string // strings the failure should contain; nil for no failure
+	}{
+		{"correct", []string{"a", "b"}, nil},
+		{
+			"wrong", []string{"b", "a", "c"},
+			[]string{"Extra: c", "Out of order: a", "Expected sequence:\na -> b\nGot:\nb -> a -> c"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		e := ExpectInOrder(rt, "a", "b")
+		for _, name := range test.got {
+			e.Fulfill(name)
+		}
+		rt.RunCleanups()
+		failures := rt.Failures()
+		if test.wantStr == nil {
+			if len(failures) != 0 {
+				t.Errorf("ExpectInOrder(): Unexpected failure(s) in test '%s':\n%#+v", test.name, failures)
+			}
+			continue
+		}
+		if len(failures) != 1 {
+			t.Errorf("ExpectInOrder(): Wrong number of failures: expected 1, got %d in test '%s':\n%#+v",
+				len(failures), test.name, failures)
+			continue
+		}
+		for _, wantStr := range test.wantStr {
+			if !strings.Contains(failures[0], wantStr) {
+				t.Errorf("ExpectInOrder(): Incorrect failure: expected a string containing\n\"%s\"\ngot\n\"%s\"\n"+
+					"in test '%s'", wantStr, failures[0], test.name)
+			}
+		}
+	}
+}
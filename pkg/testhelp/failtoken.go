@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+)
+
+// FailureTokensEnvVar is the environment variable that, if set to a non-empty value, makes the failure factories
+// (such as NotContainsFuncErrorFactory and InvalidWantFuncErrorFactory) start their messages with a stable,
+// machine-parsable token giving the category of the failure and the name of the test, such as:
+//
+//	[testhelp:contains "nil input (at parse_test.go:42)"] Incorrect panic value: expected a string containing
+//
+// so that CI tooling that scrapes the test output (e.g. from go test -json) can group the failures from this package;
+// see ParseFailureLine.  The categories are:
+//
+//   - contains, matches, equals, matching, kind: a wrong panic value, from the factories for PanicsStrLoop,
+//     PanicsRELoop, PanicsValLoop, PanicsMatchingLoop, and PanicsRuntimeLoop
+//   - invalid_want: an invalid table entry, from the InvalidWantFunc factories
+//   - flaky: a test that only passed on a retry, from FlakyFuncErrorFactory
+//   - summary: the count of suppressed failures, from SummaryFuncErrorFactory (whose token has no test name)
+//
+// The report loops (such as PanicsStrReportLoop) also use these categories, plus no_panic, unexpected_panic, and
+// setup_panic, in PanicReport.Category.
+const FailureTokensEnvVar = "TESTHELP_FAILURE_TOKENS"
+
+// The failure categories; see FailureTokensEnvVar.
+const (
+	categoryContains        = "contains"
+	categoryMatches         = "matches"
+	categoryEquals          = "equals"
+	categoryMatching        = "matching"
+	categoryKind            = "kind"
+	categoryInvalidWant     = "invalid_want"
+	categoryFlaky           = "flaky"
+	categorySummary         = "summary"
+	categoryNoPanic         = "no_panic"
+	categoryUnexpectedPanic = "unexpected_panic"
+	categorySetupPanic      = "setup_panic"
+)
+
+// categoryOutcomes gives the outcome for each category that ParseFailureLine recognizes.
+var categoryOutcomes = map[string]Outcome{
+	categoryContains:        OutcomeWrongPanicValue,
+	categoryMatches:         OutcomeWrongPanicValue,
+	categoryEquals:          OutcomeWrongPanicValue,
+	categoryMatching:        OutcomeWrongPanicValue,
+	categoryKind:            OutcomeWrongPanicValue,
+	categoryInvalidWant:     OutcomeInvalidWant,
+	categoryFlaky:           OutcomePassed,
+	categorySummary:         OutcomePassed,
+	categoryNoPanic:         OutcomeDidNotPanic,
+	categoryUnexpectedPanic: OutcomeUnexpectedPanic,
+	categorySetupPanic:      OutcomeSetupPanicked,
+}
+
+// failureTokenRE matches a failure token anywhere in a line, capturing the category and the quoted test name (if any).
+var failureTokenRE = regexp.MustCompile(`\[testhelp:([a-z_]+)(?: ("(?:[^"\\]|\\.)*"))?\]`)
+
+// failureToken returns the token to start a failure message with, followed by a space, if the environment variable
+// named by FailureTokensEnvVar is set, or "" if it isn't.  If testName is "", the token has no name.
+func failureToken(category, testName string) string {
+	if os.Getenv(FailureTokensEnvVar) == "" {
+		return ""
+	}
+	if testName == "" {
+		return "[testhelp:" + category + "] "
+	}
+	return "[testhelp:" + category + " " + strconv.Quote(testName) + "] "
+}
+
+// ParseFailureLine looks for a failure token (see FailureTokensEnvVar) in a line of test output, and returns a
+// PanicReport with the category, the name of the test, and the outcome that the category implies (OutcomePassed for
+// flaky and summary).  The other fields of the report aren't set, since the line doesn't include them.  The token can
+// be anywhere in the line, so the line can include the file:line prefix that go test adds to messages.  It returns
+// false if the line doesn't have a token with a known category.
+func ParseFailureLine(s string) (*PanicReport, bool) {
+	m := failureTokenRE.FindStringSubmatch(s)
+	if m == nil {
+		return nil, false
+	}
+	outcome, ok := categoryOutcomes[m[1]]
+	if !ok {
+		return nil, false
+	}
+	r := &PanicReport{Category: m[1], Kind: outcome}
+	if m[2] != "" {
+		name, err := strconv.Unquote(m[2])
+		if err != nil {
+			return nil, false
+		}
+		r.TestName = name
+	}
+	return r, true
+}
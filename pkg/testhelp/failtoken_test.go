@@ -0,0 +1,135 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestFailureTokens(t *testing.T) {
+	var testData = []struct {
+		name     string
+		call     func(rt *testhelptest.RecordingT)
+		wantLine string
+	}{
+		{"contains", func(rt *testhelptest.RecordingT) { NotContainsFuncErrorFactory(rt)("t1", "x", "y") },
+			`[testhelp:contains "t1"] Incorrect panic value: expected a string containing`},
+		{"matches", func(rt *testhelptest.RecordingT) { NotMatchesFuncFatalFactory(rt)("t1", "^x", "y") },
+			`[testhelp:matches "t1"] Incorrect panic value: expected a string matching`},
+		{"equals", func(rt *testhelptest.RecordingT) { NotEqualsFuncErrorFactory(rt)("t1", 1, 2) },
+			`[testhelp:equals "t1"] Incorrect panic value: expected`},
+		{"matching", func(rt *testhelptest.RecordingT) { NoMatchFuncErrorFactory(rt)("t1", Contains("x"), "y") },
+			`[testhelp:matching "t1"] Incorrect panic value: expected a value that contains "x"`},
+		{"kind", func(rt *testhelptest.RecordingT) { WrongKindFuncErrorFactory(rt)("t1", PanicKindNilMap, "y") },
+			`[testhelp:kind "t1"] Incorrect panic kind: expected nil map assignment, got value:`},
+		{"invalid want", func(rt *testhelptest.RecordingT) { InvalidWantFuncFatalFactory(rt)("t1", " ") },
+			`[testhelp:invalid_want "t1"] Invalid test table: empty or whitespace-only want " " in test 't1'`},
+		{"flaky", func(rt *testhelptest.RecordingT) { FlakyFuncErrorFactory(rt)("t1", 2) },
+			`[testhelp:flaky "t1"] Flaky test 't1' passed after 2 failed attempt(s)`},
+		{"summary", func(rt *testhelptest.RecordingT) { SummaryFuncErrorFactory(rt)(3) },
+			`[testhelp:summary] ... and 3 more similar failures`},
+		{"quoted name", func(rt *testhelptest.RecordingT) { NotEqualsFuncErrorFactory(rt)("a \"b\"\nc", 1, 2) },
+			`[testhelp:equals "a \"b\"\nc"] Incorrect panic value: expected`},
+	}
+
+	for _, td := range testData {
+		for _, on := range []bool{false, true} {
+			if on {
+				t.Setenv(FailureTokensEnvVar, "1")
+			} else {
+				t.Setenv(FailureTokensEnvVar, "")
+			}
+			rt := &testhelptest.RecordingT{}
+			td.call(rt)
+			failures := rt.Failures()
+			if len(failures) != 1 {
+				t.Fatalf("Incorrect number of failures: expected 1, got %d in test '%s'", len(failures), td.name)
+			}
+			firstLine := strings.SplitN(failures[0], "\n", 2)[0]
+			wantLine := td.wantLine
+			if !on {
+				wantLine = wantLine[strings.Index(wantLine, "] ")+2:]
+			}
+			if firstLine != wantLine {
+				t.Errorf("Incorrect first line (tokens %t): expected\n%s\ngot\n%s\nin test '%s'", on, wantLine,
+					firstLine, td.name)
+			}
+		}
+	}
+}
+
+func TestParseFailureLine(t *testing.T) {
+	var testData = []struct {
+		name   string
+		line   string
+		want   *PanicReport
+		wantOK bool
+	}{
+		{"contains", `[testhelp:contains "t1"] Incorrect panic value: expected a string containing`,
+			&PanicReport{TestName: "t1", Kind: OutcomeWrongPanicValue, Category: categoryContains}, true},
+		{"go test prefix", `    parse_test.go:42: [testhelp:kind "nil map (at parse_test.go:12)"] Incorrect panic kind`,
+			&PanicReport{TestName: "nil map (at parse_test.go:12)", Kind: OutcomeWrongPanicValue,
+				Category: categoryKind}, true},
+		{"invalid want", `[testhelp:invalid_want "t1"] Invalid test table`,
+			&PanicReport{TestName: "t1", Kind: OutcomeInvalidWant, Category: categoryInvalidWant}, true},
+		{"flaky", `[testhelp:flaky "t1"] Flaky test 't1' passed after 2 failed attempt(s)`,
+			&PanicReport{TestName: "t1", Kind: OutcomePassed, Category: categoryFlaky}, true},
+		{"summary", `[testhelp:summary] ... and 3 more similar failures`,
+			&PanicReport{Kind: OutcomePassed, Category: categorySummary}, true},
+		{"no panic", `[testhelp:no_panic "t1"]`,
+			&PanicReport{TestName: "t1", Kind: OutcomeDidNotPanic, Category: categoryNoPanic}, true},
+		{"quoted name", `[testhelp:equals "a \"b\"\nc"] Incorrect panic value`,
+			&PanicReport{TestName: "a \"b\"\nc", Kind: OutcomeWrongPanicValue, Category: categoryEquals}, true},
+		{"no token", "Incorrect panic value: expected a string containing", nil, false},
+		{"unknown category", `[testhelp:bogus "t1"] Incorrect panic value`, nil, false},
+		{"unterminated name", `[testhelp:contains "t1] Incorrect panic value`, nil, false},
+		{"bad escape", `[testhelp:contains "\q"] Incorrect panic value`, nil, false},
+	}
+
+	for _, td := range testData {
+		got, ok := ParseFailureLine(td.line)
+		if ok != td.wantOK {
+			t.Errorf("ParseFailureLine(): Incorrect ok: expected %t, got %t in test '%s'", td.wantOK, ok, td.name)
+		}
+		if !reflect.DeepEqual(got, td.want) {
+			t.Errorf("ParseFailureLine(): Incorrect report: expected\n%#+v\ngot\n%#+v\nin test '%s'", td.want, got,
+				td.name)
+		}
+	}
+}
+
+func TestParseFailureLineRoundTrip(t *testing.T) {
+	t.Setenv(FailureTokensEnvVar, "1")
+	rt := &testhelptest.RecordingT{}
+	SummaryFuncErrorFactory(rt)(1)
+	NotContainsFuncErrorFactory(rt)("name with ] and \\", "x", "y")
+	want := []*PanicReport{
+		{Kind: OutcomePassed, Category: categorySummary},
+		{TestName: "name with ] and \\", Kind: OutcomeWrongPanicValue, Category: categoryContains},
+	}
+	for i, failure := range rt.Failures() {
+		got, ok := ParseFailureLine(failure)
+		if !ok || !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("ParseFailureLine(): Incorrect report: expected\n%#+v\ngot\n%#+v (ok %t)\nfor line\n%s", want[i], got,
+				ok, failure)
+		}
+	}
+}
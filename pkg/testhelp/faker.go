@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FakerSeedEnvVar is the environment variable that, if set to an integer, sets the random seed for Faker, to
+// reproduce a failure with different data than the default.  Otherwise, the seed is derived from the test's name.
+const FakerSeedEnvVar = "TESTHELP_FAKER_SEED"
+
+var (
+	fakeFirstNames = []string{
+		"Alice", "Amir", "Beatriz", "Chen", "Dmitri", "Elena", "Farah", "George", "Hana", "Ibrahim", "Jun", "Kofi",
+		"Leila", "Marco", "Nadia", "Oscar", "Priya", "Quinn", "Rosa", "Sven", "Tariq", "Uma", "Victor", "Wei", "Yara",
+		"Zoe",
+	}
+	fakeLastNames = []string{
+		"Abadi", "Berg", "Castillo", "Dubois", "Eriksen", "Fischer", "Garcia", "Haddad", "Ito", "Jensen", "Kowalski",
+		"Lopez", "Mensah", "Nakamura", "Okafor", "Petrov", "Quispe", "Rossi", "Silva", "Tanaka", "Ueda", "Varga",
+		"Walsh", "Yilmaz", "Zhang",
+	}
+	fakeWords = []string{
+		"account", "after", "again", "answer", "balance", "before", "blue", "bright", "change", "city", "clear",
+		"early", "every", "field", "final", "green", "group", "house", "large", "later", "light", "little", "market",
+		"morning", "never", "number", "often", "order", "paper", "place", "quiet", "quick", "river", "small",
+		"system", "today", "under", "water", "window", "world",
+	}
+	fakeEmailDomains = []string{"example.com", "example.net", "example.org"}
+	// The TEST-NET blocks from RFC 5737, which are reserved for documentation, so that fake addresses never reach a
+	// real host by accident
+	fakeIPv4Prefixes = []string{"192.0.2", "198.51.100", "203.0.113"}
+)
+
+// A FakeSource generates realistic but deterministic fixture data, such as names and email addresses, from a random
+// seed.  It's created by Faker, and isn't safe for concurrent use.
+type FakeSource struct {
+	rnd  *rand.Rand
+	seed int64
+}
+
+// Faker returns a FakeSource for the test t.  Its seed is derived from t's name, so each test gets the same data on
+// every run (and on every machine), unlike with faker libraries whose defaults are random; setting the environment
+// variable named by FakerSeedEnvVar overrides it.  If t fails, the seed is logged, so that a failure that depends on
+// the data can be reproduced with it.  For example:
+//
+//	fake := testhelp.Faker(t)
+//	user := User{Name: fake.Name(), Email: fake.Email(), LastLogin: fake.TimeIn(start, end)}
+//
+// If FakerSeedEnvVar isn't a valid integer, Faker calls t.Fatalf.
+func Faker(t SeedT) *FakeSource {
+	t.Helper()
+	seed, err := fakerSeed(t)
+	if err != nil {
+		t.Fatalf("Invalid %s: %s", FakerSeedEnvVar, err)
+		return nil
+	}
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("Faker seed: %d (run the tests with %s=%d to reproduce the fixture data)", seed, FakerSeedEnvVar,
+				seed)
+		}
+	})
+	return NewFakeSource(seed)
+}
+
+// fakerSeed returns the seed for a FakeSource for the test t: the value of the environment variable named by
+// FakerSeedEnvVar, if it's set, or a hash of t's name.  It returns an error if the environment variable isn't a valid
+// integer.
+func fakerSeed(t SeedT) (int64, error) {
+	if s := os.Getenv(FakerSeedEnvVar); s != "" {
+		return strconv.ParseInt(s, 10, 64)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name())) // can't fail
+	return int64(h.Sum64()), nil
+}
+
+// NewFakeSource returns a FakeSource with the given seed, for generating fixture data outside of a test (such as in
+// TestMain), or for a test that needs more than one independent source.
+func NewFakeSource(seed int64) *FakeSource {
+	return &FakeSource{rnd: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// Seed returns the seed that the FakeSource was created with.
+func (f *FakeSource) Seed() int64 {
+	return f.seed
+}
+
+// Intn returns a number from 0 to n-1, as with rand.Intn.  It panics if n <= 0.
+func (f *FakeSource) Intn(n int) int {
+	return f.rnd.Intn(n)
+}
+
+// pick returns a random element of words.
+func (f *FakeSource) pick(words []string) string {
+	return words[f.rnd.Intn(len(words))]
+}
+
+// Name returns a person's full name, e.g. "Elena Nakamura".
+func (f *FakeSource) Name() string {
+	return f.pick(fakeFirstNames) + " " + f.pick(fakeLastNames)
+}
+
+// Email returns an email address at one of the domains reserved for examples (example.com, example.net, and
+// example.org), e.g. "elena.nakamura42@example.org".
+func (f *FakeSource) Email() string {
+	return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(f.pick(fakeFirstNames)), strings.ToLower(f.pick(fakeLastNames)),
+		f.rnd.Intn(100), f.pick(fakeEmailDomains))
+}
+
+// IPv4 returns an IPv4 address in one of the blocks reserved for documentation (192.0.2.0/24, 198.51.100.0/24, and
+// 203.0.113.0/24, from RFC 5737), e.g. "198.51.100.23".
+func (f *FakeSource) IPv4() string {
+	return fmt.Sprintf("%s.%d", f.pick(fakeIPv4Prefixes), f.rnd.Intn(254)+1)
+}
+
+// Sentence returns a sentence of 4 to 10 common English words, starting with a capital letter and ending with a
+// period, e.g. "Quiet river before the market opens." (though the words are random, so it won't be that sensible).
+func (f *FakeSource) Sentence() string {
+	words := make([]string, f.rnd.Intn(7)+4)
+	for i := range words {
+		words[i] = f.pick(fakeWords)
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	return strings.Join(words, " ") + "."
+}
+
+// TimeIn returns a time from start (inclusive) to end (exclusive), in start's location, with nanosecond resolution.
+// It panics if end isn't after start.
+func (f *FakeSource) TimeIn(start, end time.Time) time.Time {
+	span := end.Sub(start)
+	if span <= 0 {
+		panic(fmt.Sprintf("TimeIn: end (%s) isn't after start (%s)", end, start))
+	}
+	return start.Add(time.Duration(f.rnd.Int63n(int64(span))))
+}
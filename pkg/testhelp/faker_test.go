@@ -0,0 +1,151 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// seedTMock is a testhelptest.RecordingT with the SeedT methods; it has failed if it has recorded any errors or fatals.
+type seedTMock struct {
+	testhelptest.RecordingT
+	name string
+}
+
+func (s *seedTMock) Name() string {
+	return s.name
+}
+
+func (s *seedTMock) Failed() bool {
+	return len(s.Failures()) > 0
+}
+
+// fakeAll returns one of each kind of fixture data from f, for comparing sources.
+func fakeAll(f *FakeSource) []string {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []string{f.Name(), f.Email(), f.IPv4(), f.Sentence(), f.TimeIn(start, start.AddDate(1, 0, 0)).String()}
+}
+
+func TestFakerDeterministic(t *testing.T) {
+	t.Setenv(FakerSeedEnvVar, "")
+	first := fakeAll(Faker(&seedTMock{name: "TestSomething"}))
+	second := fakeAll(Faker(&seedTMock{name: "TestSomething"}))
+	if fmt.Sprint(first) != fmt.Sprint(second) {
+		t.Errorf("Faker(): Incorrect data: expected the same data for the same test name\n%#+v\ngot\n%#+v",
+			first, second)
+	}
+	other := fakeAll(Faker(&seedTMock{name: "TestSomethingElse"}))
+	if fmt.Sprint(first) == fmt.Sprint(other) {
+		t.Errorf("Faker(): Incorrect data: expected different data for a different test name, got\n%#+v", other)
+	}
+}
+
+func TestFakerSeedEnvVar(t *testing.T) {
+	t.Setenv(FakerSeedEnvVar, "12345")
+	mockT := &seedTMock{name: "TestSomething"}
+	f := Faker(mockT)
+	if f.Seed() != 12345 {
+		t.Errorf("Faker(): Incorrect seed: expected\n%#+v\ngot\n%#+v", int64(12345), f.Seed())
+	}
+	got := fakeAll(f)
+	expected := fakeAll(NewFakeSource(12345))
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Faker(): Incorrect data: expected\n%#+v\ngot\n%#+v", expected, got)
+	}
+
+	t.Setenv(FakerSeedEnvVar, "abc")
+	mockT = &seedTMock{name: "TestSomething"}
+	if f := Faker(mockT); f != nil {
+		t.Errorf("Faker(): Incorrect return value: expected nil, got\n%#+v", f)
+	}
+	if len(mockT.Fatals) != 1 || !strings.Contains(mockT.Fatals[0], "Invalid "+FakerSeedEnvVar) {
+		t.Errorf("Faker(): Incorrect fatals: expected an invalid-seed message, got\n%#+v", mockT.Fatals)
+	}
+}
+
+func TestFakerLogsSeedOnFailure(t *testing.T) {
+	t.Setenv(FakerSeedEnvVar, "42")
+	mockT := &seedTMock{name: "TestSomething"}
+	Faker(mockT)
+	mockT.RunCleanups()
+	if len(mockT.Logs) != 0 {
+		t.Errorf("Faker(): Incorrect logs for a passing test: expected none, got\n%#+v", mockT.Logs)
+	}
+
+	mockT = &seedTMock{name: "TestSomething"}
+	Faker(mockT)
+	mockT.Errorf("failed")
+	mockT.RunCleanups()
+	expected := []string{
+		"Faker seed: 42 (run the tests with " + FakerSeedEnvVar + "=42 to reproduce the fixture data)",
+	}
+	if fmt.Sprint(mockT.Logs) != fmt.Sprint(expected) {
+		t.Errorf("Faker(): Incorrect logs for a failing test: expected\n%#+v\ngot\n%#+v", expected, mockT.Logs)
+	}
+}
+
+func TestFakeSourceFormats(t *testing.T) {
+	nameRE := regexp.MustCompile(`^[A-Z][a-z]+ [A-Z][a-z]+$`)
+	emailRE := regexp.MustCompile(`^[a-z]+\.[a-z]+[0-9]{1,2}@example\.(com|net|org)$`)
+	sentenceRE := regexp.MustCompile(`^[A-Z][a-z]*( [a-z]+){3,9}\.$`)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	docNets := []string{"192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"}
+
+	f := NewFakeSource(1)
+	for i := 0; i < 200; i++ {
+		if name := f.Name(); !nameRE.MatchString(name) {
+			t.Errorf("Name(): Incorrect format: got\n%#+v", name)
+		}
+		if email := f.Email(); !emailRE.MatchString(email) {
+			t.Errorf("Email(): Incorrect format: got\n%#+v", email)
+		}
+		if sentence := f.Sentence(); !sentenceRE.MatchString(sentence) || !utf8.ValidString(sentence) {
+			t.Errorf("Sentence(): Incorrect format: got\n%#+v", sentence)
+		}
+		ip := net.ParseIP(f.IPv4())
+		inDocNet := false
+		for _, cidr := range docNets {
+			_, ipNet, _ := net.ParseCIDR(cidr)
+			if ip != nil && ipNet.Contains(ip) {
+				inDocNet = true
+			}
+		}
+		if !inDocNet {
+			t.Errorf("IPv4(): Incorrect address: expected one in %v, got\n%#+v", docNets, ip)
+		}
+		if tm := f.TimeIn(start, end); tm.Before(start) || !tm.Before(end) {
+			t.Errorf("TimeIn(): Incorrect time: expected one in [%s, %s), got\n%s", start, end, tm)
+		}
+	}
+}
+
+func TestFakeSourceTimeInPanics(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFakeSource(1)
+	if _, ok, _ := PanicsStr(func() { f.TimeIn(start, start) }, "TimeIn: end"); !ok {
+		t.Errorf("TimeIn(): Incorrect panic: expected one for an empty range")
+	}
+}
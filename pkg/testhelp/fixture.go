@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"sync"
+)
+
+// A fixture is a single shared fixture built by OncePer.
+type fixture struct {
+	once     sync.Once
+	val      interface{}
+	teardown func()
+	complete bool // whether build returned
+	didPanic bool
+	pVal     interface{}
+	stack    string
+}
+
+// A fixtureRegistry holds the fixtures built by OncePer, so that they can be torn down in reverse order.
+type fixtureRegistry struct {
+	mu       sync.Mutex
+	fixtures map[string]*fixture
+	built    []*fixture
+}
+
+// defaultFixtures is the registry used by the package-level functions.
+var defaultFixtures = &fixtureRegistry{}
+
+// OncePer returns the fixture identified by key, building it with build the first time it is requested in the test
+// binary's run, and sharing it with every later request.  This is intended for fixtures that are expensive to create
+// but safe to share between tests, such as a database loaded with read-only data.  build returns the fixture and a
+// teardown function for it (or nil, if there isn't one); the teardowns are run, in the reverse order of building, by
+// TeardownFixtures, which should be called from TestMain (as RunMain does).  For example:
+//
+//	func TestQuery(t *testing.T) {
+//		db := testhelp.OncePer(t, "loaded-db", func() (interface{}, func()) {
+//			db := loadTestDB()
+//			return db, func() { db.Close() }
+//		}).(*sql.DB)
+//		// ...
+//	}
+//
+// See OncePerT for a version that doesn't need a type assertion.  Concurrent requests for the same key wait for the
+// first one to finish building the fixture.  If build panics, the current test and every later test that requests
+// the fixture fail with t.Fatalf, reporting the panic; so do later tests if build doesn't return for another reason,
+// such as a call to t.FailNow (which calls runtime.Goexit) or panic(nil).
+func OncePer(t TestingTB, key string, build func() (interface{}, func())) interface{} {
+	t.Helper()
+	return defaultFixtures.get(t, key, build)
+}
+
+// OncePerT is like OncePer, but for fixtures of a specific type.  If the fixture for key was already built with a
+// different type (by OncePer or OncePerT), the test fails with t.Fatalf.
+func OncePerT[T any](t TestingTB, key string, build func() (T, func())) T {
+	t.Helper()
+	val := defaultFixtures.get(t, key, func() (interface{}, func()) { return build() })
+	typed, ok := val.(T)
+	if !ok && val != nil {
+		var zero T
+		t.Fatalf("Fixture '%s' has type %T, not %T", key, val, zero)
+	}
+	return typed
+}
+
+// TeardownFixtures runs the teardown functions of all of the fixtures built by OncePer and OncePerT, in the reverse
+// order of building, and forgets the fixtures, so that later requests build them again.  It should be called once
+// all of the tests that use the fixtures have finished, normally in TestMain after m.Run.  A panic from a teardown
+// function is passed on after the remaining teardowns have been run.
+func TeardownFixtures() {
+	defaultFixtures.teardown()
+}
+
+func (r *fixtureRegistry) get(t TestingTB, key string, build func() (interface{}, func())) interface{} {
+	t.Helper()
+	r.mu.Lock()
+	if r.fixtures == nil {
+		r.fixtures = map[string]*fixture{}
+	}
+	f, ok := r.fixtures[key]
+	if !ok {
+		f = &fixture{}
+		r.fixtures[key] = f
+	}
+	r.mu.Unlock()
+
+	f.once.Do(func() {
+		f.didPanic, f.pVal, f.stack = panicsWithStack(func() {
+			f.val, f.teardown = build()
+			f.complete = true
+		})
+		if !f.complete {
+			return
+		}
+		r.mu.Lock()
+		r.built = append(r.built, f)
+		r.mu.Unlock()
+	})
+	if f.didPanic {
+		t.Fatalf("Fixture '%s' panicked while being built (%T):\n%s\nstack:\n%s", key, f.pVal,
+			PanicMessage(f.pVal), f.stack)
+	} else if !f.complete {
+		t.Fatalf("Fixture '%s' was not built: its build function did not return (because of runtime.Goexit, as "+
+			"called by t.FailNow, or panic(nil))", key)
+	}
+	return f.val
+}
+
+func (r *fixtureRegistry) teardown() {
+	r.mu.Lock()
+	built := r.built
+	r.fixtures = nil
+	r.built = nil
+	r.mu.Unlock()
+
+	runTeardowns(built)
+}
+
+// runTeardowns runs the teardowns of the fixtures in reverse order, continuing after a panic, and then passes on the
+// panic, if there was one.
+func runTeardowns(built []*fixture) {
+	for _, f := range built {
+		if f.teardown != nil {
+			// Deferred, so that they run in reverse order, and a panic doesn't stop the rest
+			defer f.teardown()
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestFixtureRegistry(t *testing.T) {
+	r := &fixtureRegistry{}
+	var events []string
+	builds := 0
+	build := func(name string) func() (interface{}, func()) {
+		return func() (interface{}, func()) {
+			builds++
+			events = append(events, "build "+name)
+			return name + " value", func() { events = append(events, "teardown "+name) }
+		}
+	}
+
+	rt := &testhelptest.RecordingT{}
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if val := r.get(rt, "a", build("a")); val != "a value" {
+				t.Errorf("get(): Incorrect fixture: expected \"a value\", got %#+v", val)
+			}
+		}()
+	}
+	wg.Wait()
+	if builds != 1 {
+		t.Errorf("get(): Fixture built %d times, expected once", builds)
+	}
+	r.get(rt, "b", build("b"))
+	r.get(rt, "c", func() (interface{}, func()) { return 5, nil })
+	if failures := rt.Failures(); len(failures) != 0 {
+		t.Errorf("get(): Unexpected failure(s):\n%#+v", failures)
+	}
+
+	r.teardown()
+	want := []string{"build a", "build b", "teardown b", "teardown a"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("teardown(): Incorrect events: expected\n%#+v\ngot\n%#+v", want, events)
+	}
+
+	// Forgotten after teardown
+	r.get(rt, "a", build("a"))
+	if builds != 3 { // a, b, and a again
+		t.Errorf("get(): Fixture not rebuilt after teardown")
+	}
+}
+
+func TestFixtureRegistryBuildPanics(t *testing.T) {
+	r := &fixtureRegistry{}
+	for i := 0; i < 2; i++ {
+		rt := &testhelptest.RecordingT{}
+		r.get(rt, "bad", func() (interface{}, func()) { panic("no database") })
+		failures := rt.Failures()
+		if len(failures) != 1 || !strings.HasPrefix(failures[0],
+			"Fixture 'bad' panicked while being built (string):\nno database\nstack:\n") {
+			t.Errorf("get(): Incorrect failure(s) on request %d:\n%#+v", i+1, failures)
+		}
+	}
+}
+
+func TestFixtureRegistryBuildDoesNotReturn(t *testing.T) {
+	r := &fixtureRegistry{}
+	// The first request's goroutine exits with the build function, as with t.FailNow
+	rt := &testhelptest.RecordingT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.get(rt, "goexit", func() (interface{}, func()) {
+			runtime.Goexit()
+			return nil, nil
+		})
+	}()
+	<-done
+	for i := 0; i < 2; i++ {
+		rt := &testhelptest.RecordingT{}
+		if val := r.get(rt, "goexit", func() (interface{}, func()) { return 1, nil }); val != nil {
+			t.Errorf("get(): Expected no fixture after runtime.Goexit, got %#+v", val)
+		}
+		want := []string{"Fixture 'goexit' was not built: its build function did not return (because of " +
+			"runtime.Goexit, as called by t.FailNow, or panic(nil))"}
+		if failures := rt.Failures(); !reflect.DeepEqual(failures, want) {
+			t.Errorf("get(): Incorrect failures after runtime.Goexit on request %d: expected\n%#+v\ngot\n%#+v", i+1,
+				want, failures)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		rt := &testhelptest.RecordingT{}
+		r.get(rt, "nil", func() (interface{}, func()) { panic(nil) })
+		// The message depends on the panicnil setting, but panic(nil) mustn't yield a nil fixture silently
+		if failures := rt.Failures(); len(failures) != 1 || !strings.HasPrefix(failures[0], "Fixture 'nil' ") {
+			t.Errorf("get(): Incorrect failure(s) after panic(nil) on request %d:\n%#+v", i+1, failures)
+		}
+	}
+	if len(r.built) != 0 {
+		t.Errorf("get(): Expected no fixtures to be recorded as built, got %d", len(r.built))
+	}
+}
+
+func TestRunTeardownsContinuesAfterPanic(t *testing.T) {
+	var events []string
+	built := []*fixture{
+		{teardown: func() { events = append(events, "a") }},
+		{teardown: func() { panic("b failed") }},
+		{},
+		{teardown: func() { events = append(events, "d") }},
+	}
+	didPanic, pVal := PanicsGet(func() { runTeardowns(built) })
+	if !didPanic || pVal != "b failed" {
+		t.Errorf("runTeardowns(): Expected the panic to be passed on, got %t, %#+v", didPanic, pVal)
+	}
+	if want := []string{"d", "a"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("runTeardowns(): Incorrect teardowns: expected\n%#+v\ngot\n%#+v", want, events)
+	}
+}
+
+func TestOncePerT(t *testing.T) {
+	defer TeardownFixtures()
+	torndown := false
+	build := func() (*strings.Builder, func()) { return &strings.Builder{}, func() { torndown = true } }
+	b1 := OncePerT(t, "TestOncePerT builder", build)
+	b2 := OncePerT(t, "TestOncePerT builder", build)
+	if b1 != b2 || b1 == nil {
+		t.Errorf("OncePerT(): Expected the same fixture from both calls, got %p and %p", b1, b2)
+	}
+	if val := OncePer(t, "TestOncePerT builder", nil); val != b1 {
+		t.Errorf("OncePer(): Expected the same fixture as OncePerT, got %#+v", val)
+	}
+
+	rt := &testhelptest.RecordingT{}
+	OncePerT(rt, "TestOncePerT builder", func() (int, func()) { return 0, nil })
+	want := "Fixture 'TestOncePerT builder' has type *strings.Builder, not int"
+	if failures := rt.Failures(); len(failures) != 1 || failures[0] != want {
+		t.Errorf("OncePerT(): Incorrect failure(s) with the wrong type: expected\n%#+v\ngot\n%#+v", want, failures)
+	}
+
+	TeardownFixtures()
+	if !torndown {
+		t.Errorf("TeardownFixtures(): Teardown not run")
+	}
+}
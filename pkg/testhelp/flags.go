@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"flag"
+	"os"
+)
+
+// WithFlagSet runs f with fs installed as flag.CommandLine and os.Args set to the program name followed by args, so
+// that code which registers flags with the flag package's top-level functions (flag.String, flag.Bool, etc.) and then
+// calls flag.Parse works on fs and args instead of the test binary's own flags.  This avoids "flag redefined" panics
+// when such code runs more than once, and keeps flag values from leaking from one test to another.  If fs is nil, a new
+// empty FlagSet is used, with flag.ContinueOnError, so a bad flag in args doesn't end the test binary.  For example:
+//
+//	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+//	testhelp.WithFlagSet(t, fs, []string{"-port=0"}, func() { srv = server.NewFromFlags() })
+//	if got := fs.Lookup("port").Value.String(); got != "0" { ... }
+//
+// Afterwards (even if f panics), flag.CommandLine, flag.Usage, and os.Args are restored, and so are the values of the
+// original flag.CommandLine's flags, in case f changed them; if a value can't be restored, t.Errorf is called.
+//
+// Since the flag package's state is global, WithFlagSet can't be used in parallel tests.
+func WithFlagSet(t TestingTB, fs *flag.FlagSet, args []string, f func()) {
+	t.Helper()
+	if fs == nil {
+		fs = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	}
+	restore := swapCommandLine(t, fs, args)
+	defer restore()
+	f()
+}
+
+// ResetCommandLine replaces flag.CommandLine with a new, empty FlagSet (with flag.ContinueOnError) for the rest of the
+// test, and restores the original, as in WithFlagSet, when the test finishes.  This is for tests where the code that
+// registers and parses flags can't be wrapped in a single function, such as ones that set flags up in several steps.
+// os.Args is left as it is, but is restored along with everything else, so the test can set it.
+//
+// As with WithFlagSet, ResetCommandLine can't be used in parallel tests.
+func ResetCommandLine(t TestingTB) {
+	t.Helper()
+	restore := swapCommandLine(t, flag.NewFlagSet(os.Args[0], flag.ContinueOnError), os.Args[1:])
+	t.Cleanup(restore)
+}
+
+// swapCommandLine installs fs as flag.CommandLine, with os.Args set to the program name followed by args, and returns
+// a function that restores the original state, reporting any flag values it can't restore to t.
+func swapCommandLine(t TestingTB, fs *flag.FlagSet, args []string) (restore func()) {
+	origCommandLine, origUsage, origArgs := flag.CommandLine, flag.Usage, os.Args
+	values := map[string]string{}
+	origCommandLine.VisitAll(func(f *flag.Flag) { values[f.Name] = f.Value.String() })
+
+	flag.CommandLine = fs
+	os.Args = append([]string{origArgs[0]}, args...)
+	return func() {
+		t.Helper()
+		flag.CommandLine, flag.Usage, os.Args = origCommandLine, origUsage, origArgs
+		origCommandLine.VisitAll(func(f *flag.Flag) {
+			if f.Value.String() == values[f.Name] {
+				return
+			}
+			if err := f.Value.Set(values[f.Name]); err != nil {
+				t.Errorf("Can't restore flag -%s to \"%s\": %s", f.Name, values[f.Name], err)
+			}
+		})
+	}
+}
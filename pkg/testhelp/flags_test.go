@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// registerAndParse registers a flag with the top-level flag functions and parses the command line, as a main package
+// or an init-time flag user would.
+func registerAndParse() string {
+	name := flag.String("name", "default", "the name")
+	flag.Parse()
+	return *name
+}
+
+func TestWithFlagSet(t *testing.T) {
+	origCommandLine, origArgs := flag.CommandLine, os.Args
+
+	// Registering the same flag twice would panic without the isolation
+	for _, args := range [][]string{{"-name=a"}, {"-name=b", "rest"}} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		var got string
+		var gotArgs []string
+		WithFlagSet(t, fs, args, func() {
+			got = registerAndParse()
+			gotArgs = os.Args[1:]
+		})
+		if want := args[0][len("-name="):]; got != want || fs.Lookup("name").Value.String() != want {
+			t.Errorf("WithFlagSet(): Incorrect flag value: expected \"%s\", got \"%s\"", want, got)
+		}
+		if !reflect.DeepEqual(gotArgs, args) {
+			t.Errorf("WithFlagSet(): Incorrect os.Args: expected\n%#+v\ngot\n%#+v", args, gotArgs)
+		}
+	}
+	WithFlagSet(t, nil, nil, func() {
+		if got := registerAndParse(); got != "default" {
+			t.Errorf("WithFlagSet(): Incorrect flag value with a nil FlagSet: expected \"default\", got \"%s\"", got)
+		}
+	})
+
+	didPanic := Panics(func() {
+		WithFlagSet(t, nil, nil, func() { panic("boom") })
+	})
+	if !didPanic {
+		t.Errorf("WithFlagSet(): Panic not passed on")
+	}
+	if flag.CommandLine != origCommandLine || !reflect.DeepEqual(os.Args, origArgs) {
+		t.Errorf("WithFlagSet(): flag.CommandLine or os.Args not restored")
+	}
+}
+
+func TestResetCommandLine(t *testing.T) {
+	origCommandLine := flag.CommandLine
+	t.Run("reset", func(t *testing.T) {
+		ResetCommandLine(t)
+		if flag.CommandLine == origCommandLine || flag.Lookup("test.v") != nil {
+			t.Errorf("ResetCommandLine(): flag.CommandLine not replaced")
+		}
+		level := flag.Int("level", 1, "the level")
+
+		// The values of the flags in the replaced command line are restored along with it
+		WithFlagSet(t, nil, nil, func() {
+			*level = 5
+		})
+		if *level != 1 {
+			t.Errorf("WithFlagSet(): Flag value not restored: expected 1, got %d", *level)
+		}
+	})
+	if flag.CommandLine != origCommandLine {
+		t.Errorf("ResetCommandLine(): flag.CommandLine not restored")
+	}
+}
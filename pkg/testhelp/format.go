@@ -0,0 +1,260 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Defaults for FormatOptions
+const (
+	DefaultFormatDepth = 5
+	DefaultFormatBytes = 64
+)
+
+// formatWidth is the longest that a composite value can be and still be formatted on one line.
+const formatWidth = 80
+
+// FormatOptions holds options for Format.
+type FormatOptions struct {
+	// MaxDepth is the number of pointers that are followed along any one path through the value; pointers beyond that
+	// are shown as addresses.  The default (0) is DefaultFormatDepth, and a negative value means no pointers are
+	// followed.
+	MaxDepth int
+	// MaxBytes is the length beyond which byte slices are summarized (by length and a prefix) instead of being shown
+	// in full.  The default (0) is DefaultFormatBytes.
+	MaxBytes int
+}
+
+// Format returns a readable representation of v, for failure messages, which this package also uses for its own.  It's
+// similar to %#+v, but composite values (structs, slices, and maps) that don't fit on one line are split over several
+// lines and indented, pointers are followed (to a limited depth) instead of being shown as addresses, cycles are
+// detected, long byte slices are summarized, map keys are sorted, and times and durations are shown in their usual
+// forms.  Values of types other than bool, int, float64, and string (and composite types) are shown with their types,
+// e.g. int64(5), so that values of different types don't look the same.
+func Format(v interface{}) string {
+	return new(FormatOptions).Format(v)
+}
+
+// Format is like the package-level Format, but with the options in o.
+func (o *FormatOptions) Format(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	f := o.formatter()
+	return f.format(reflect.ValueOf(v), 0, true)
+}
+
+// formatter returns a formatter with the options in o, with the defaults filled in.
+func (o *FormatOptions) formatter() *formatter {
+	f := &formatter{maxDepth: o.MaxDepth, maxBytes: o.MaxBytes, visiting: map[visitKey]bool{}}
+	if f.maxDepth == 0 {
+		f.maxDepth = DefaultFormatDepth
+	} else if f.maxDepth < 0 {
+		f.maxDepth = 0
+	}
+	if f.maxBytes <= 0 {
+		f.maxBytes = DefaultFormatBytes
+	}
+	return f
+}
+
+// A visitKey identifies a pointer, map, or slice on the current path through the value, for cycle detection.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type formatter struct {
+	maxDepth int
+	maxBytes int
+	visiting map[visitKey]bool
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// format formats v.  depth is the number of pointers followed so far.  showType is true if the type of a scalar
+// isn't evident from the context, which is the case at the top level and inside interfaces.
+func (f *formatter) format(v reflect.Value, depth int, showType bool) string {
+	t := v.Type()
+	switch {
+	case t == durationType:
+		return "time.Duration(" + time.Duration(v.Int()).String() + ")"
+	case t == timeType && v.CanInterface():
+		return "time.Time(" + v.Interface().(time.Time).Format(time.RFC3339Nano) + ")"
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return f.format(v.Elem(), depth, true)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", t)
+		}
+		if depth >= f.maxDepth {
+			return fmt.Sprintf("(%s)(0x%x)", t, v.Pointer())
+		}
+		key := visitKey{v.Pointer(), t}
+		if f.visiting[key] {
+			return fmt.Sprintf("(%s)(<cycle>)", t)
+		}
+		f.visiting[key] = true
+		defer delete(f.visiting, key)
+		return "&" + f.format(v.Elem(), depth+1, true)
+	case reflect.Struct:
+		fields := make([]string, t.NumField())
+		for i := range fields {
+			fields[i] = t.Field(i).Name + ": " + f.format(v.Field(i), depth, false)
+		}
+		return composite(t.String(), fields)
+	case reflect.Slice:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", t)
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return f.formatBytes(v)
+		}
+		key := visitKey{v.Pointer(), t}
+		if f.visiting[key] {
+			return fmt.Sprintf("%s(<cycle>)", t)
+		}
+		f.visiting[key] = true
+		defer delete(f.visiting, key)
+		return composite(t.String(), f.elems(v, depth))
+	case reflect.Array:
+		return composite(t.String(), f.elems(v, depth))
+	case reflect.Map:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", t)
+		}
+		key := visitKey{v.Pointer(), t}
+		if f.visiting[key] {
+			return fmt.Sprintf("%s(<cycle>)", t)
+		}
+		f.visiting[key] = true
+		defer delete(f.visiting, key)
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries = append(entries, f.format(iter.Key(), depth, false)+": "+f.format(iter.Value(), depth, false))
+		}
+		sort.Strings(entries)
+		return composite(t.String(), entries)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", t)
+		}
+		return fmt.Sprintf("(%s)(0x%x)", t, v.Pointer())
+	}
+	return formatScalar(v, showType)
+}
+
+// elems formats the elements of a slice or array.
+func (f *formatter) elems(v reflect.Value, depth int) []string {
+	elems := make([]string, v.Len())
+	for i := range elems {
+		elems[i] = f.format(v.Index(i), depth, false)
+	}
+	return elems
+}
+
+// formatBytes formats a byte slice as a string if it's valid UTF-8, or as hex if not, summarizing it if it's longer
+// than maxBytes.
+func (f *formatter) formatBytes(v reflect.Value) string {
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	show := b
+	if len(b) > f.maxBytes {
+		show = b[:f.maxBytes]
+	}
+	var s string
+	if utf8.Valid(b) {
+		s = strconv.Quote(string(show))
+	} else {
+		s = fmt.Sprintf("0x%x", show)
+	}
+	typ := v.Type().String()
+	if typ == "[]uint8" {
+		typ = "[]byte"
+	}
+	if len(show) < len(b) {
+		return fmt.Sprintf("%s(%d bytes, starting %s...)", typ, len(b), s)
+	}
+	return fmt.Sprintf("%s(%s)", typ, s)
+}
+
+// formatScalar formats a bool, number, or string, with its type if showType is true and the type isn't the default
+// one for a constant of that kind.
+func formatScalar(v reflect.Value, showType bool) string {
+	var s string
+	var defaultType bool
+	switch v.Kind() {
+	case reflect.Bool:
+		s, defaultType = strconv.FormatBool(v.Bool()), v.Type().Name() == "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s, defaultType = strconv.FormatInt(v.Int(), 10), v.Type().Name() == "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		s = strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		s, defaultType = strconv.FormatFloat(v.Float(), 'g', -1, 64), v.Type().Name() == "float64"
+	case reflect.Complex64, reflect.Complex128:
+		s = fmt.Sprint(v.Complex())
+	case reflect.String:
+		s, defaultType = strconv.Quote(v.String()), v.Type().Name() == "string"
+	default:
+		return fmt.Sprintf("%#+v", v)
+	}
+	// Only the predeclared types count as default, not other types with the same names
+	defaultType = defaultType && v.Type().PkgPath() == ""
+	if !showType || defaultType {
+		return s
+	}
+	return fmt.Sprintf("%s(%s)", v.Type(), s)
+}
+
+// composite formats a struct, slice, array, or map, given its type and formatted contents, on one line if it's short
+// enough and all of the contents are on one line, or with each item on its own line, indented, if not.
+func composite(typ string, items []string) string {
+	if len(items) == 0 {
+		return typ + "{}"
+	}
+	oneLine := typ + "{" + strings.Join(items, ", ") + "}"
+	if len(oneLine) <= formatWidth && !strings.Contains(oneLine, "\n") {
+		return oneLine
+	}
+	var b strings.Builder
+	b.WriteString(typ + "{\n")
+	for _, item := range items {
+		b.WriteString("  " + strings.ReplaceAll(item, "\n", "\n  ") + ",\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
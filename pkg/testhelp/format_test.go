@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type formatLevel int
+
+type formatPoint struct {
+	X, Y int
+}
+
+type formatNode struct {
+	Name string
+	Next *formatNode
+}
+
+type formatRecord struct {
+	ID       int64
+	Name     string
+	Tags     []string
+	Attrs    map[string]interface{}
+	Location *formatPoint
+	Timeout  time.Duration
+	secret   string
+}
+
+func TestFormat(t *testing.T) {
+	cycle := &formatNode{Name: "a"}
+	cycle.Next = &formatNode{Name: "b", Next: cycle}
+	deep := &formatNode{Name: "1", Next: &formatNode{Name: "2", Next: &formatNode{Name: "3"}}}
+	selfSlice := []interface{}{1, nil}
+	selfSlice[1] = selfSlice
+	var nilErr error
+
+	tests := []struct {
+		name     string
+		opts     FormatOptions
+		v        interface{}
+		expected string
+	}{
+		{name: "nil", v: nil, expected: "nil"},
+		{name: "nil interface", v: nilErr, expected: "nil"},
+		{name: "int", v: 5, expected: "5"},
+		{name: "sized int", v: int64(-5), expected: "int64(-5)"},
+		{name: "uint", v: uint8(5), expected: "uint8(5)"},
+		{name: "named int", v: formatLevel(2), expected: "testhelp.formatLevel(2)"},
+		{name: "float", v: 1.5, expected: "1.5"},
+		{name: "float32", v: float32(0.1), expected: "float32(0.1)"},
+		{name: "string", v: "a\"b", expected: `"a\"b"`},
+		{name: "bool", v: true, expected: "true"},
+		{name: "complex", v: complex(1, 2), expected: "complex128((1+2i))"},
+		{name: "duration", v: 1500 * time.Millisecond, expected: "time.Duration(1.5s)"},
+		{
+			name: "time", v: time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+			expected: "time.Time(2020-01-02T03:04:05.000000006Z)",
+		},
+		{name: "struct", v: formatPoint{1, 2}, expected: "testhelp.formatPoint{X: 1, Y: 2}"},
+		{name: "empty struct", v: struct{}{}, expected: "struct {}{}"},
+		{name: "pointer", v: &formatPoint{1, 2}, expected: "&testhelp.formatPoint{X: 1, Y: 2}"},
+		{name: "nil pointer", v: (*formatPoint)(nil), expected: "(*testhelp.formatPoint)(nil)"},
+		{name: "error", v: errors.New("oops"), expected: `&errors.errorString{s: "oops"}`},
+		{name: "slice", v: []int{1, 2, 3}, expected: "[]int{1, 2, 3}"},
+		{name: "nil slice", v: []int(nil), expected: "[]int(nil)"},
+		{name: "array", v: [2]string{"a", "b"}, expected: `[2]string{"a", "b"}`},
+		{name: "interface slice", v: []interface{}{1, int8(2), "x", nil}, expected: `[]interface {}{1, int8(2), "x", nil}`},
+		{name: "map", v: map[string]int{"b": 2, "a": 1}, expected: `map[string]int{"a": 1, "b": 2}`},
+		{name: "nil map", v: map[string]int(nil), expected: "map[string]int(nil)"},
+		{name: "bytes", v: []byte("hi"), expected: `[]byte("hi")`},
+		{name: "binary bytes", v: []byte{0, 255}, expected: "[]byte(0x00ff)"},
+		{
+			name: "long bytes", v: []byte(strings.Repeat("x", 100)), opts: FormatOptions{MaxBytes: 4},
+			expected: `[]byte(100 bytes, starting "xxxx"...)`,
+		},
+		{name: "func", v: (func())(nil), expected: "(func())(nil)"},
+		{
+			name: "multi-line",
+			v: formatRecord{
+				ID: 7, Name: "widget", Tags: []string{"a", "b"},
+				Attrs:    map[string]interface{}{"size": 3, "ratio": float32(0.5), "long": strings.Repeat("y", 70)},
+				Location: &formatPoint{3, 4}, Timeout: time.Second, secret: "s",
+			},
+			expected: "testhelp.formatRecord{\n" +
+				"  ID: 7,\n" +
+				"  Name: \"widget\",\n" +
+				"  Tags: []string{\"a\", \"b\"},\n" +
+				"  Attrs: map[string]interface {}{\n" +
+				"    \"long\": \"" + strings.Repeat("y", 70) + "\",\n" +
+				"    \"ratio\": float32(0.5),\n" +
+				"    \"size\": 3,\n" +
+				"  },\n" +
+				"  Location: &testhelp.formatPoint{X: 3, Y: 4},\n" +
+				"  Timeout: time.Duration(1s),\n" +
+				"  secret: \"s\",\n" +
+				"}",
+		},
+		{
+			name: "cycle", v: cycle,
+			expected: "&testhelp.formatNode{\n" +
+				"  Name: \"a\",\n" +
+				"  Next: &testhelp.formatNode{Name: \"b\", Next: (*testhelp.formatNode)(<cycle>)},\n" +
+				"}",
+		},
+		{name: "slice cycle", v: selfSlice, expected: "[]interface {}{1, []interface {}(<cycle>)}"},
+		{
+			name: "depth", v: deep, opts: FormatOptions{MaxDepth: 2},
+			expected: "&testhelp.formatNode{\n" +
+				"  Name: \"1\",\n" +
+				"  Next: &testhelp.formatNode{Name: \"2\", Next: (*testhelp.formatNode)(0x",
+		},
+		{name: "no pointers", v: &formatPoint{}, opts: FormatOptions{MaxDepth: -1}, expected: "(*testhelp.formatPoint)(0x"},
+	}
+	for _, test := range tests {
+		got := test.opts.Format(test.v)
+		if got != test.expected && !(strings.HasSuffix(test.expected, "(0x") && strings.HasPrefix(got, test.expected)) {
+			t.Errorf("FormatOptions.Format(): Incorrect result: expected\n%s\ngot\n%s\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+	if got := Format([]int{1}); got != "[]int{1}" {
+		t.Errorf("Format(): Incorrect result: expected []int{1}, got %s", got)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SeedFuzzFromTestdata adds the contents of each file in dir (such as a directory in testdata of sample HTTP request
+// bodies) to f's seed corpus, as a []byte, so that the fuzz target's function must take a single []byte argument
+// after its *testing.T.  Files are added in name order; subdirectories and hidden files (with names starting with
+// ".", such as .gitkeep) are skipped.  For each file, the result of calling each of the transforms on its contents is
+// also added, for deriving extra inputs from the same samples.  For example:
+//
+//	func FuzzParseRequest(f *testing.F) {
+//		testhelp.SeedFuzzFromTestdata(f, "testdata/requests", bytes.ToUpper, func(b []byte) []byte {
+//			return bytes.ReplaceAll(b, []byte("\n"), []byte("\r\n"))
+//		})
+//		f.Fuzz(func(t *testing.T, data []byte) { ... })
+//	}
+//
+// dir shouldn't be testdata/fuzz/<FuzzTarget>, which the go command already reads (in its own format).
+//
+// If dir can't be read, or has no files to add, the test fails with f.Fatalf, since a fuzz target that was meant to be
+// seeded shouldn't silently start from nothing.
+func SeedFuzzFromTestdata(f FuzzF, dir string, transforms ...func([]byte) []byte) {
+	f.Helper()
+	entries, err := os.ReadDir(dir) // sorted by name
+	if err != nil {
+		f.Fatalf("Can't read fuzz corpus directory: %s", err)
+		return
+	}
+	added := 0
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			f.Fatalf("Can't read fuzz corpus file: %s", err)
+			return
+		}
+		f.Add(data)
+		for _, transform := range transforms {
+			// Each transform gets its own copy, in case it modifies its argument in place
+			f.Add(transform(append([]byte{}, data...)))
+		}
+		added++
+	}
+	if added == 0 {
+		f.Fatalf("No fuzz corpus files in %s", dir)
+	}
+}
+
+// AddJSONVariants adds base, encoded as JSON, to f's seed corpus, as a []byte (so that the fuzz target's function
+// must take a single []byte argument after its *testing.T), along with structured mutations of it, so that the fuzzer
+// starts from inputs close to the edges of what the code under test accepts.  If base is a []byte or a
+// json.RawMessage, it's used as the JSON text itself; otherwise, it's encoded with json.Marshal.
+//
+// The mutations are, for each value in the document (including the whole document):
+//
+//   - replacing it with null
+//   - replacing it with values of other types, and with edge cases of its own type (such as "", 0, -1, a number too
+//     large for an int64, true for false, {}, and [])
+//   - for each member of an object, removing it, and adding an unexpected member
+//   - for each element of an array, removing it, and duplicating the first one
+//
+// as well as the document truncated halfway, as an example of malformed JSON.  Duplicate inputs are only added once.
+//
+// If base isn't valid JSON (or can't be encoded as JSON), the test fails with f.Fatalf.
+func AddJSONVariants(f FuzzF, base interface{}) {
+	f.Helper()
+	var text []byte
+	switch b := base.(type) {
+	case []byte:
+		text = b
+	case json.RawMessage:
+		text = b
+	default:
+		var err error
+		if text, err = json.Marshal(base); err != nil {
+			f.Fatalf("Can't encode the base fuzz input as JSON: %s", err)
+			return
+		}
+	}
+	variants, err := jsonVariants(text)
+	if err != nil {
+		f.Fatalf("Invalid JSON for the base fuzz input: %s", err)
+		return
+	}
+	for _, v := range variants {
+		f.Add(v)
+	}
+}
+
+// jsonVariants returns the inputs for AddJSONVariants: text itself (compacted), followed by its mutations, without
+// duplicates.
+func jsonVariants(text []byte) ([][]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber() // so that numbers are re-encoded exactly as written
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, text); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var variants [][]byte
+	add := func(b []byte) {
+		if !seen[string(b)] {
+			seen[string(b)] = true
+			variants = append(variants, b)
+		}
+	}
+	add(compact.Bytes())
+	jsonMutations(doc, func(v interface{}) interface{} { return v }, func(mutated interface{}) {
+		b, err := json.Marshal(mutated)
+		if err == nil { // everything in a decoded document can be encoded
+			add(b)
+		}
+	})
+	add(compact.Bytes()[:compact.Len()/2])
+	return variants, nil
+}
+
+// jsonMutations calls emit with each mutation of v, and, recursively, of the values inside it, for jsonVariants.
+// rebuild returns the whole document with v replaced by its argument, without changing the original document.
+func jsonMutations(v interface{}, rebuild func(interface{}) interface{}, emit func(interface{})) {
+	for _, r := range jsonReplacements(v) {
+		emit(rebuild(r))
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			without := copyJSONObject(v)
+			delete(without, k)
+			emit(rebuild(without))
+		}
+		extra := copyJSONObject(v)
+		extra["unexpected_field"] = true
+		emit(rebuild(extra))
+		for _, k := range keys {
+			k := k
+			jsonMutations(v[k], func(nv interface{}) interface{} {
+				c := copyJSONObject(v)
+				c[k] = nv
+				return rebuild(c)
+			}, emit)
+		}
+	case []interface{}:
+		for i := range v {
+			without := append(append([]interface{}{}, v[:i]...), v[i+1:]...)
+			emit(rebuild(without))
+		}
+		if len(v) > 0 {
+			emit(rebuild(append([]interface{}{v[0]}, v...)))
+		}
+		for i := range v {
+			i := i
+			jsonMutations(v[i], func(nv interface{}) interface{} {
+				c := append([]interface{}{}, v...)
+				c[i] = nv
+				return rebuild(c)
+			}, emit)
+		}
+	}
+}
+
+// jsonReplacements returns the values that replace v in jsonMutations: null, and values of other types or edge
+// cases of v's type.
+func jsonReplacements(v interface{}) []interface{} {
+	replacements := []interface{}{nil}
+	switch v := v.(type) {
+	case string:
+		replacements = append(replacements, "", strings.Repeat(v+" ", 8), json.Number("0"))
+	case json.Number:
+		replacements = append(replacements, json.Number("0"), json.Number("-1"),
+			json.Number("99999999999999999999"), json.Number("0.5"), v.String())
+	case bool:
+		replacements = append(replacements, !v, "true")
+	case map[string]interface{}:
+		replacements = append(replacements, map[string]interface{}{}, []interface{}{})
+	case []interface{}:
+		replacements = append(replacements, []interface{}{}, map[string]interface{}{})
+	case nil:
+		replacements = append(replacements, "", json.Number("0"), map[string]interface{}{})
+	}
+	return replacements
+}
+
+// copyJSONObject returns a shallow copy of a decoded JSON object.
+func copyJSONObject(obj map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+var _ FuzzF = (*testing.F)(nil)
+
+// fuzzFMock is a testhelptest.RecordingT with the FuzzF methods, which records the inputs added to the corpus.
+type fuzzFMock struct {
+	testhelptest.RecordingT
+	mu    sync.Mutex
+	added []string
+}
+
+func (m *fuzzFMock) Add(args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, arg := range args {
+		m.added = append(m.added, string(arg.([]byte)))
+	}
+}
+
+func TestSeedFuzzFromTestdata(t *testing.T) {
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"b.txt":    "GET /b",
+		"a.txt":    "GET /a",
+		".gitkeep": "",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	emptyDir := t.TempDir()
+
+	tests := []struct {
+		name           string
+		dir            string
+		transforms     []func([]byte) []byte
+		expectedAdded  []string
+		expectedFatals []string
+	}{
+		{
+			name:           "files",
+			dir:            dir,
+			expectedAdded:  []string{"GET /a", "GET /b"},
+			expectedFatals: []string{},
+		},
+		{
+			name: "transforms",
+			dir:  dir,
+			transforms: []func([]byte) []byte{
+				func(b []byte) []byte { return append(b, '!') },
+				func(b []byte) []byte { return []byte(strings.ToLower(string(b))) },
+			},
+			expectedAdded:  []string{"GET /a", "GET /a!", "get /a", "GET /b", "GET /b!", "get /b"},
+			expectedFatals: []string{},
+		},
+		{
+			name:           "empty",
+			dir:            emptyDir,
+			expectedAdded:  []string{},
+			expectedFatals: []string{"No fuzz corpus files in " + emptyDir},
+		},
+	}
+
+	for _, test := range tests {
+		mockF := &fuzzFMock{added: []string{}}
+		SeedFuzzFromTestdata(mockF, test.dir, test.transforms...)
+		if fmt.Sprint(mockF.added) != fmt.Sprint(test.expectedAdded) {
+			t.Errorf("SeedFuzzFromTestdata(): Incorrect inputs: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedAdded, mockF.added, test.name)
+		}
+		if fmt.Sprint(mockF.Failures()) != fmt.Sprint(test.expectedFatals) {
+			t.Errorf("SeedFuzzFromTestdata(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedFatals, mockF.Failures(), test.name)
+		}
+	}
+
+	mockF := &fuzzFMock{}
+	SeedFuzzFromTestdata(mockF, filepath.Join(dir, "missing"))
+	if len(mockF.Fatals) != 1 || !strings.HasPrefix(mockF.Fatals[0], "Can't read fuzz corpus directory: ") {
+		t.Errorf("SeedFuzzFromTestdata(): Incorrect fatals for a missing directory: got\n%#+v", mockF.Fatals)
+	}
+}
+
+func TestAddJSONVariants(t *testing.T) {
+	type request struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	mockF := &fuzzFMock{}
+	AddJSONVariants(mockF, request{ID: 7, Tags: []string{"x"}})
+	if len(mockF.Failures()) != 0 {
+		t.Fatalf("AddJSONVariants(): Unexpected failures:\n%#+v", mockF.Failures())
+	}
+
+	if len(mockF.added) == 0 || mockF.added[0] != `{"id":7,"tags":["x"]}` {
+		t.Errorf("AddJSONVariants(): Incorrect first input: expected the base, got\n%#+v", mockF.added)
+	}
+	if last := mockF.added[len(mockF.added)-1]; last != `{"id":7,"t` {
+		t.Errorf("AddJSONVariants(): Incorrect last input: expected the truncated base, got\n%#+v", last)
+	}
+	seen := map[string]bool{}
+	for _, input := range mockF.added {
+		if seen[input] {
+			t.Errorf("AddJSONVariants(): Duplicate input:\n%#+v", input)
+		}
+		seen[input] = true
+	}
+	for _, expected := range []string{
+		`null`,
+		`{}`,
+		`{"tags":["x"]}`,
+		`{"id":7}`,
+		`{"id":7,"tags":["x"],"unexpected_field":true}`,
+		`{"id":null,"tags":["x"]}`,
+		`{"id":-1,"tags":["x"]}`,
+		`{"id":99999999999999999999,"tags":["x"]}`,
+		`{"id":"7","tags":["x"]}`,
+		`{"id":7,"tags":[]}`,
+		`{"id":7,"tags":{}}`,
+		`{"id":7,"tags":["x","x"]}`,
+		`{"id":7,"tags":[""]}`,
+		`{"id":7,"tags":[0]}`,
+	} {
+		if !seen[expected] {
+			t.Errorf("AddJSONVariants(): Missing input: expected\n%#+v\nin\n%#+v", expected, mockF.added)
+		}
+	}
+	for _, input := range mockF.added[:len(mockF.added)-1] {
+		if !json.Valid([]byte(input)) {
+			t.Errorf("AddJSONVariants(): Invalid JSON input (only the last should be):\n%#+v", input)
+		}
+	}
+
+	mockF = &fuzzFMock{}
+	AddJSONVariants(mockF, []byte(`{"id": 7,`))
+	if len(mockF.Fatals) != 1 || !strings.HasPrefix(mockF.Fatals[0], "Invalid JSON for the base fuzz input: ") {
+		t.Errorf("AddJSONVariants(): Incorrect fatals for invalid JSON: got\n%#+v", mockF.Fatals)
+	}
+	mockF = &fuzzFMock{}
+	AddJSONVariants(mockF, json.RawMessage(`[1, 2]`))
+	if len(mockF.added) == 0 || mockF.added[0] != `[1,2]` {
+		t.Errorf("AddJSONVariants(): Incorrect first input for raw JSON: expected the compacted base, got\n%#+v",
+			mockF.added)
+	}
+}
+
+func FuzzAddJSONVariants(f *testing.F) {
+	AddJSONVariants(f, map[string]interface{}{"name": "x", "n": 1})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v interface{}
+		_ = json.Unmarshal(data, &v) // just exercises the seeds with a real *testing.F
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// UpdateGoldenEnvVar is the environment variable that, if set to a non-empty value, makes the golden-file functions
+// (PanicsGolden, GoldenBytes, GoldenDir, ResponseGolden, RenderGolden, CheckAPICompat, and the archive functions)
+// write golden files (or directories) instead of checking against them.  Package cassette also uses it, to re-record
+// its cassettes, as does package cmptest.  For example:
+//
+//	TESTHELP_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnvVar = "TESTHELP_UPDATE_GOLDEN"
+
+var (
+	addressRE    = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+	lineNumberRE = regexp.MustCompile(`\.go:[0-9]+`)
+)
+
+// NormalizeAddresses replaces hexadecimal numbers starting with 0x (such as pointer addresses) in s with "0xADDR".  It
+// is intended for use as a PanicsGolden normalizer.
+func NormalizeAddresses(s string) string {
+	return addressRE.ReplaceAllString(s, "0xADDR")
+}
+
+// NormalizeLineNumbers replaces line numbers in Go file positions (such as "main.go:42") in s with "LINE".  It is
+// intended for use as a PanicsGolden normalizer.
+func NormalizeLineNumbers(s string) string {
+	return lineNumberRE.ReplaceAllString(s, ".go:LINE")
+}
+
+// PanicsGolden tests that the given function panics, and that its panic message (as returned by PanicMessage) is the
+// same as the one stored in the golden file testdata/<name>.golden, relative to the test's working directory (which is
+// normally the package's directory).  Before the comparison, the message is passed through each of the normalizers in
+// turn, so that details that change from run to run (such as pointer addresses) can be replaced.  If the function does
+// not panic, the golden file does not exist, or the messages are different, t.Errorf is called; for different messages,
+// the failure includes a line-by-line diff.  PanicsGolden returns true if the check passed.
+//
+// This keeps user-visible panic messages from changing by accident between releases.  For example:
+//
+//	testhelp.PanicsGolden(t, "parse-nil-config", func() { mypkg.Parse(nil) }, testhelp.NormalizeAddresses)
+//
+// To create or update the golden files, run the tests with the environment variable named by UpdateGoldenEnvVar set;
+// the normalized messages are then written to the files instead of being checked.
+func PanicsGolden(t TestingTB, name string, f func(), normalizers ...func(string) string) bool {
+	t.Helper()
+	return panicsGoldenFile(t, filepath.Join("testdata", filepath.FromSlash(name)+".golden"), f, normalizers)
+}
+
+func panicsGoldenFile(t TestingTB, path string, f func(), normalizers []func(string) string) bool {
+	t.Helper()
+	didPanic, pVal := PanicsGet(f)
+	if !didPanic {
+		t.Errorf("Expected a panic to compare with golden file %s", path)
+		return false
+	}
+	got := PanicMessage(pVal)
+	for _, normalize := range normalizers {
+		got = normalize(got)
+	}
+	return textGoldenFile(t, path, "Panic message", got)
+}
+
+// textGoldenFile compares got with the golden file at path (or writes it, when updating), reporting a line-by-line diff
+// if they differ.  what describes got for the failure message.
+func textGoldenFile(t TestingTB, path, what, got string) bool {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnvVar) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("Can't create directory for golden file: %s", err)
+			return false
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Errorf("Can't write golden file: %s", err)
+			return false
+		}
+		t.Logf("Updated golden file %s", path)
+		return true
+	}
+
+	wantBytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Golden file %s does not exist; run the tests with %s=1 to create it", path, UpdateGoldenEnvVar)
+		return false
+	} else if err != nil {
+		t.Errorf("Can't read golden file: %s", err)
+		return false
+	}
+	if want := string(wantBytes); got != want {
+		t.Errorf("%s does not match golden file %s (run the tests with %s=1 to update it):\n%s", what, path,
+			UpdateGoldenEnvVar, lineDiff(want, got))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestNormalizers(t *testing.T) {
+	s := "bad pointer 0xc000012345 at widget.go:42 (from main.go:7)"
+	if got, want := NormalizeAddresses(s), "bad pointer 0xADDR at widget.go:42 (from main.go:7)"; got != want {
+		t.Errorf("NormalizeAddresses(): Incorrect result: expected\n%s\ngot\n%s", want, got)
+	}
+	want := "bad pointer 0xc000012345 at widget.go:LINE (from main.go:LINE)"
+	if got := NormalizeLineNumbers(s); got != want {
+		t.Errorf("NormalizeLineNumbers(): Incorrect result: expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestPanicsGolden(t *testing.T) {
+	// Checked against testdata/golden_example.golden
+	w := &struct{ size int }{-1}
+	if !PanicsGolden(t, "golden_example", func() { panic(fmt.Sprintf("Invalid widget at %p: size %d", w, w.size)) },
+		NormalizeAddresses) {
+		t.Errorf("PanicsGolden(): Expected the example to match its golden file")
+	}
+}
+
+func TestPanicsGoldenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub", "msg.golden")
+	panicker := func(msg string) func() { return func() { panic(msg) } }
+
+	rt := &testhelptest.RecordingT{}
+	if panicsGoldenFile(rt, path, panicker("first"), nil) {
+		t.Errorf("panicsGoldenFile(): Expected false with a missing golden file")
+	}
+	if failures := rt.Failures(); len(failures) != 1 || !strings.Contains(failures[0], "does not exist") {
+		t.Errorf("panicsGoldenFile(): Incorrect failure(s) with a missing golden file:\n%#+v", failures)
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "1")
+	rt = &testhelptest.RecordingT{}
+	if !panicsGoldenFile(rt, path, panicker("line 1\nline 2"), nil) {
+		t.Errorf("panicsGoldenFile(): Expected true when updating, got failure(s):\n%#+v", rt.Failures())
+	}
+	if contents, err := os.ReadFile(path); err != nil || string(contents) != "line 1\nline 2" {
+		t.Errorf("panicsGoldenFile(): Golden file not written correctly: got %q, %v", contents, err)
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "")
+	rt = &testhelptest.RecordingT{}
+	if !panicsGoldenFile(rt, path, panicker("line 1\nline 2"), nil) {
+		t.Errorf("panicsGoldenFile(): Expected true with a matching message, got failure(s):\n%#+v", rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if panicsGoldenFile(rt, path, panicker("line 1\nline 3"), nil) {
+		t.Errorf("panicsGoldenFile(): Expected false with a different message")
+	}
+	if failures := rt.Failures(); len(failures) != 1 || !strings.HasSuffix(failures[0], "  line 1\n- line 2\n+ line 3\n") {
+		t.Errorf("panicsGoldenFile(): Incorrect failure(s) with a different message:\n%#+v", failures)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	upper := func(s string) string { return strings.ToUpper(s) }
+	if !panicsGoldenFile(rt, path, panicker("LINE 1\nline 2"), []func(string) string{strings.ToLower}) ||
+		panicsGoldenFile(rt, path, panicker("line 1\nline 2"), []func(string) string{upper}) {
+		t.Errorf("panicsGoldenFile(): Normalizers not applied")
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if panicsGoldenFile(rt, path, func() {}, nil) {
+		t.Errorf("panicsGoldenFile(): Expected false with no panic")
+	}
+	if failures := rt.Failures(); len(failures) != 1 || !strings.HasPrefix(failures[0], "Expected a panic") {
+		t.Errorf("panicsGoldenFile(): Incorrect failure(s) with no panic:\n%#+v", failures)
+	}
+}
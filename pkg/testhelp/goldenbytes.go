@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hexDumpWidth is the number of bytes per row of a hex dump diff.
+const hexDumpWidth = 16
+
+// hexDumpContext is the number of rows shown before and after the first differing row of a hex dump diff.
+const hexDumpContext = 2
+
+// GoldenBytesOptions holds options for GoldenBytes.  The zero value gives the same behavior as the package-level
+// function.
+type GoldenBytesOptions struct {
+	// Gzip makes GoldenBytes store the golden file gzip-compressed, as testdata/<name>.golden.gz, which is worthwhile
+	// for large outputs such as images.
+	Gzip bool
+}
+
+// GoldenBytes compares got, such as the output of a protocol encoder or an image renderer, with the golden file
+// testdata/<name>.golden, relative to the test's working directory (which is normally the package's directory).  If
+// they differ, or the golden file doesn't exist, it calls t.Errorf; for different bytes, the failure gives the
+// lengths, the number of differing bytes, and a side-by-side hex dump of the rows around the first difference.  It
+// returns true if the check passed.
+//
+// As with PanicsGolden, running the tests with the environment variable named by UpdateGoldenEnvVar set writes got to
+// the golden file instead of checking it.
+func GoldenBytes(t TestingTB, name string, got []byte) bool {
+	t.Helper()
+	return new(GoldenBytesOptions).GoldenBytes(t, name, got)
+}
+
+// GoldenBytes is like the package-level GoldenBytes, but with the options in o.
+func (o *GoldenBytesOptions) GoldenBytes(t TestingTB, name string, got []byte) bool {
+	t.Helper()
+	path := filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+	if o.Gzip {
+		path += ".gz"
+	}
+	return o.goldenBytesFile(t, path, got)
+}
+
+func (o *GoldenBytesOptions) goldenBytesFile(t TestingTB, path string, got []byte) bool {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnvVar) != "" {
+		data := got
+		if o.Gzip {
+			var buf bytes.Buffer
+			zw := gzip.NewWriter(&buf) // with no name or time in the header, so the output is reproducible
+			_, _ = zw.Write(got)       // can't fail, since buf can't
+			_ = zw.Close()
+			data = buf.Bytes()
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("Can't create directory for golden file: %s", err)
+			return false
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Errorf("Can't write golden file: %s", err)
+			return false
+		}
+		t.Logf("Updated golden file %s", path)
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Golden file %s does not exist; run the tests with %s=1 to create it", path, UpdateGoldenEnvVar)
+		return false
+	} else if err != nil {
+		t.Errorf("Can't read golden file: %s", err)
+		return false
+	}
+	if o.Gzip {
+		if want, err = gunzip(want); err != nil {
+			t.Errorf("Can't decompress golden file %s: %s", path, err)
+			return false
+		}
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Bytes do not match golden file %s (run the tests with %s=1 to update it):\n%s", path,
+			UpdateGoldenEnvVar, hexDiff(want, got))
+		return false
+	}
+	return true
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// hexDiff describes the differences between two byte slices that aren't equal, with a side-by-side hex dump of the
+// rows around the first difference.  Rows that differ are marked with "*".
+func hexDiff(want, got []byte) string {
+	first, count := -1, 0
+	for i := 0; i < len(want) || i < len(got); i++ {
+		if i >= len(want) || i >= len(got) || want[i] != got[i] {
+			if first < 0 {
+				first = i
+			}
+			count++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "expected %d bytes, got %d bytes; %d byte(s) differ, starting at offset %d (0x%x)\n", len(want),
+		len(got), count, first, first)
+	dumpWidth := hexDumpWidth*3 + 1 // "xx " per byte, plus the gap in the middle
+	fmt.Fprintf(&b, "  %-8s  %-*s  %s", "offset", dumpWidth, "expected", "got")
+
+	firstRow := first/hexDumpWidth - hexDumpContext
+	if firstRow < 0 {
+		firstRow = 0
+	}
+	for row := firstRow; row <= first/hexDumpWidth+hexDumpContext; row++ {
+		start := row * hexDumpWidth
+		if start >= len(want) && start >= len(got) {
+			break
+		}
+		w, g := hexRow(want, start), hexRow(got, start)
+		marker := " "
+		if w != g {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "\n%s %08x  %-*s  %s", marker, start, dumpWidth, w, strings.TrimRight(g, " "))
+	}
+	return b.String()
+}
+
+// hexRow formats the bytes of data from start for one row of a hex dump, leaving blanks past the end of data.
+func hexRow(data []byte, start int) string {
+	var b strings.Builder
+	for i := start; i < start+hexDumpWidth; i++ {
+		if i == start+hexDumpWidth/2 {
+			b.WriteByte(' ')
+		}
+		if i < len(data) {
+			fmt.Fprintf(&b, "%02x ", data[i])
+		} else {
+			b.WriteString("   ")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestHexDiff(t *testing.T) {
+	want := []byte("0123456789abcdef0123456789ABCDEF0123456789abcdef0123456789abcdef0123456789abcdef")
+	got := append([]byte{}, want...)
+	got[70] = 'X'
+	got = append(got, 0xff)
+
+	diff := hexDiff(want, got)
+	lines := strings.Split(diff, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("hexDiff(): Incorrect number of lines: expected 6, got %d:\n%s", len(lines), diff)
+	}
+	checks := []struct {
+		line   int
+		prefix string
+	}{
+		{0, "expected 80 bytes, got 81 bytes; 2 byte(s) differ, starting at offset 70 (0x46)"},
+		{1, "  offset    expected"},
+		{2, "  00000020  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66   30 31"},
+		{4, "* 00000040  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66   30 31 32 33 34 35 58 37"},
+		{5, "* 00000050                                                     ff"},
+	}
+	for _, c := range checks {
+		if !strings.HasPrefix(lines[c.line], c.prefix) {
+			t.Errorf("hexDiff(): Incorrect line %d: expected a prefix of\n%q\ngot\n%q", c.line, c.prefix,
+				lines[c.line])
+		}
+	}
+}
+
+func TestGoldenBytesFile(t *testing.T) {
+	for _, gz := range []bool{false, true} {
+		o := &GoldenBytesOptions{Gzip: gz}
+		path := filepath.Join(t.TempDir(), "sub", "out.golden")
+		data := []byte{0x00, 0x01, 0x02, 0xfe}
+
+		rt := &testhelptest.RecordingT{}
+		if o.goldenBytesFile(rt, path, data) {
+			t.Errorf("goldenBytesFile(): Expected false with a missing golden file (gzip %t)", gz)
+		}
+		if f := rt.Failures(); len(f) != 1 || !strings.Contains(f[0], "does not exist") {
+			t.Errorf("goldenBytesFile(): Incorrect failure(s) with a missing golden file (gzip %t):\n%#+v", gz, f)
+		}
+
+		t.Setenv(UpdateGoldenEnvVar, "1")
+		rt = &testhelptest.RecordingT{}
+		if !o.goldenBytesFile(rt, path, data) || len(rt.Failures()) != 0 {
+			t.Errorf("goldenBytesFile(): Unexpected failure while updating (gzip %t):\n%#+v", gz, rt.Failures())
+		}
+		stored, _ := os.ReadFile(path)
+		if isGzip := len(stored) > 2 && stored[0] == 0x1f && stored[1] == 0x8b; isGzip != gz {
+			t.Errorf("goldenBytesFile(): Incorrect storage format (gzip %t): got\n%#+v", gz, stored)
+		}
+		t.Setenv(UpdateGoldenEnvVar, "")
+
+		rt = &testhelptest.RecordingT{}
+		if !o.goldenBytesFile(rt, path, data) || len(rt.Failures()) != 0 {
+			t.Errorf("goldenBytesFile(): Unexpected failure for matching bytes (gzip %t):\n%#+v", gz, rt.Failures())
+		}
+		rt = &testhelptest.RecordingT{}
+		if o.goldenBytesFile(rt, path, data[:3]) {
+			t.Errorf("goldenBytesFile(): Expected false for different bytes (gzip %t)", gz)
+		}
+		want := "Bytes do not match golden file " + path + " (run the tests with TESTHELP_UPDATE_GOLDEN=1 to " +
+			"update it):\nexpected 4 bytes, got 3 bytes; 1 byte(s) differ, starting at offset 3 (0x3)\n"
+		if f := rt.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], want) {
+			t.Errorf("goldenBytesFile(): Incorrect failure(s) for different bytes (gzip %t):\n%#+v", gz, f)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.golden.gz")
+	if err := os.WriteFile(path, []byte("not gzip"), 0o644); err != nil {
+		t.Fatalf("Can't write test file: %s", err)
+	}
+	rt := &testhelptest.RecordingT{}
+	(&GoldenBytesOptions{Gzip: true}).goldenBytesFile(rt, path, nil)
+	if f := rt.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], "Can't decompress golden file ") {
+		t.Errorf("goldenBytesFile(): Incorrect failure(s) for a corrupt golden file:\n%#+v", f)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// GoldenDirOptions holds options for GoldenDir.  The zero value gives the same behavior as the package-level function.
+type GoldenDirOptions struct {
+	// ComparePerms makes GoldenDir also check whether each file is executable.  (Only the executable bit is compared,
+	// since that's all that version control systems generally record.)
+	ComparePerms bool
+	// Normalizers are applied to the contents of each generated file, in order, before comparison (and before writing
+	// them, when updating); see PanicsGolden.  Files that aren't valid UTF-8 text are not normalized.
+	Normalizers []func(string) string
+}
+
+// GoldenDir compares the directory tree gotDir, such as the output of a code generator or scaffolding tool, with the
+// golden directory testdata/<name>, relative to the test's working directory (which is normally the package's
+// directory).  It calls t.Errorf, listing missing and unexpected files and giving a line-by-line diff for each file
+// whose contents differ, if the trees don't match, or if the golden directory doesn't exist.  It returns true if the
+// check passed.  Only regular files are compared; directories are compared through the files in them, so empty
+// directories are ignored, and other kinds of files (such as symbolic links) are errors.
+//
+// As with PanicsGolden, running the tests with the environment variable named by UpdateGoldenEnvVar set replaces
+// the golden directory with a copy of gotDir instead of checking it.  Since that removes the old golden directory,
+// name must be a relative path to a directory strictly under testdata; other names (such as "" or "..") are errors.
+func GoldenDir(t TestingTB, name, gotDir string) bool {
+	t.Helper()
+	return new(GoldenDirOptions).GoldenDir(t, name, gotDir)
+}
+
+// GoldenDir is like the package-level GoldenDir, but with the options in o.
+func (o *GoldenDirOptions) GoldenDir(t TestingTB, name, gotDir string) bool {
+	t.Helper()
+	goldenDir, err := goldenDirPath(name)
+	if err != nil {
+		t.Errorf("Can't use golden directory: %s", err)
+		return false
+	}
+	return o.goldenDirAt(t, goldenDir, gotDir)
+}
+
+// goldenDirPath returns the path of the golden directory testdata/<name>, or an error if that isn't strictly under
+// testdata, since updating the golden directory removes it.
+func goldenDirPath(name string) (string, error) {
+	if name == "" {
+		return "", errors.New("no golden directory name was given")
+	}
+	path := filepath.Join("testdata", filepath.FromSlash(name))
+	rel, err := filepath.Rel("testdata", path)
+	if err != nil || filepath.IsAbs(filepath.FromSlash(name)) || rel == "." || rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("golden directory name '%s' is not a relative path under testdata", name)
+	}
+	return path, nil
+}
+
+func (o *GoldenDirOptions) goldenDirAt(t TestingTB, goldenDir, gotDir string) bool {
+	t.Helper()
+	got, err := o.readTree(gotDir, true)
+	if err != nil {
+		t.Errorf("Can't read generated directory: %s", err)
+		return false
+	}
+	return o.compareTree(t, "Directory "+gotDir, goldenDir, got)
+}
+
+// compareTree does the work for GoldenDir and the archive golden functions: it compares the files in got (which have
+// already been normalized) with the golden directory, or updates the golden directory with them.  gotDesc describes
+// where the files came from, for the failure message.
+func (o *GoldenDirOptions) compareTree(t TestingTB, gotDesc, goldenDir string, got map[string]treeFile) bool {
+	t.Helper()
+	if os.Getenv(UpdateGoldenEnvVar) != "" {
+		if err := writeTree(goldenDir, got); err != nil {
+			t.Errorf("Can't write golden directory: %s", err)
+			return false
+		}
+		t.Logf("Updated golden directory %s", goldenDir)
+		return true
+	}
+
+	if _, err := os.Stat(goldenDir); errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Golden directory %s does not exist; run the tests with %s=1 to create it", goldenDir,
+			UpdateGoldenEnvVar)
+		return false
+	}
+	want, err := o.readTree(goldenDir, false)
+	if err != nil {
+		t.Errorf("Can't read golden directory: %s", err)
+		return false
+	}
+
+	missing, diffs := o.diffFiles(want, got)
+	var unexpected []string
+	for _, path := range sortedKeys(got) {
+		if _, ok := want[path]; !ok {
+			unexpected = append(unexpected, path)
+		}
+	}
+	if len(missing) == 0 && len(unexpected) == 0 && diffs == "" {
+		return true
+	}
+
+	var b strings.Builder
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\nMissing files:\n%s", strings.Join(missing, "\n"))
+	}
+	if len(unexpected) > 0 {
+		fmt.Fprintf(&b, "\nUnexpected files:\n%s", strings.Join(unexpected, "\n"))
+	}
+	b.WriteString(diffs)
+	t.Errorf("%s does not match golden directory %s (run the tests with %s=1 to update it):%s", gotDesc, goldenDir,
+		UpdateGoldenEnvVar, b.String())
+	return false
+}
+
+// diffFiles compares the files in want with the ones of the same names in got, returning the names of the ones that
+// are missing from got, and a description of the differences in the others (starting with a newline, if there are
+// any).
+func (o *GoldenDirOptions) diffFiles(want, got map[string]treeFile) (missing []string, diffs string) {
+	var b strings.Builder
+	for _, path := range sortedKeys(want) {
+		g, ok := got[path]
+		if !ok {
+			missing = append(missing, path)
+			continue
+		}
+		w := want[path]
+		if !bytes.Equal(g.contents, w.contents) {
+			if isText(g.contents) && isText(w.contents) {
+				fmt.Fprintf(&b, "\nContents of %s differ:\n%s", path, lineDiff(string(w.contents), string(g.contents)))
+			} else {
+				fmt.Fprintf(&b, "\nContents of %s differ (binary file)", path)
+			}
+		}
+		if o.ComparePerms && g.executable != w.executable {
+			fmt.Fprintf(&b, "\nPermissions of %s differ: expected %s, got %s", path, describeExec(w.executable),
+				describeExec(g.executable))
+		}
+	}
+	return missing, b.String()
+}
+
+// A treeFile is a regular file read by readTree.
+type treeFile struct {
+	contents   []byte
+	executable bool
+}
+
+// readTree reads all of the regular files under root, keyed by slash-separated relative path, normalizing their
+// contents if normalize is true.
+func (o *GoldenDirOptions) readTree(root string, normalize bool) (map[string]treeFile, error) {
+	files := map[string]treeFile{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path) // can't fail, since path is under root
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("%s is not a regular file or directory", filepath.Join(root, rel))
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if normalize {
+			contents = o.normalize(contents)
+		}
+		files[filepath.ToSlash(rel)] = treeFile{contents, info.Mode().Perm()&0o111 != 0}
+		return nil
+	})
+	return files, err
+}
+
+// normalize applies the normalizers to contents, if it's valid UTF-8.
+func (o *GoldenDirOptions) normalize(contents []byte) []byte {
+	if len(o.Normalizers) == 0 || !utf8.Valid(contents) {
+		return contents
+	}
+	s := string(contents)
+	for _, n := range o.Normalizers {
+		s = n(s)
+	}
+	return []byte(s)
+}
+
+// writeTree replaces the directory root with the given files.
+func writeTree(root string, files map[string]treeFile) error {
+	if err := os.RemoveAll(root); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+	for _, rel := range sortedKeys(files) {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		perm := fs.FileMode(0o644)
+		if files[rel].executable {
+			perm = 0o755
+		}
+		if err := os.WriteFile(path, files[rel].contents, perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(files map[string]treeFile) []string {
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isText returns true if b looks like text, and so can be diffed line by line.
+func isText(b []byte) bool {
+	return utf8.Valid(b) && bytes.IndexByte(b, 0) < 0
+}
+
+func describeExec(executable bool) string {
+	if executable {
+		return "executable"
+	}
+	return "not executable"
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// writeTestTree writes files (by slash-separated path) under a new temporary directory, making paths ending in ".sh"
+// executable, and returns the directory.
+func writeTestTree(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for rel, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Can't create test directory: %s", err)
+		}
+		perm := os.FileMode(0o644)
+		if strings.HasSuffix(rel, ".sh") {
+			perm = 0o755
+		}
+		if err := os.WriteFile(path, []byte(contents), perm); err != nil {
+			t.Fatalf("Can't write test file: %s", err)
+		}
+	}
+	return dir
+}
+
+func TestGoldenDir(t *testing.T) {
+	// Checked against testdata/golden_dir_example
+	timeRE := regexp.MustCompile(`[0-9]{2}:[0-9]{2}`)
+	gotDir := writeTestTree(t, map[string]string{
+		"widget.toml":  "name = \"widget\"\nversion = 1\n",
+		"cmd/build.sh": "#!/bin/sh\necho \"built at 12:34\"\n",
+	})
+	opts := GoldenDirOptions{
+		ComparePerms: true,
+		Normalizers:  []func(string) string{func(s string) string { return timeRE.ReplaceAllString(s, "TIME") }},
+	}
+	if !opts.GoldenDir(t, "golden_dir_example", gotDir) {
+		t.Errorf("GoldenDirOptions.GoldenDir(): Expected the example to match its golden directory")
+	}
+}
+
+func TestGoldenDirAt(t *testing.T) {
+	golden := map[string]string{
+		"README":     "hello\nworld\n",
+		"main.go":    "package main\n",
+		"run.sh":     "#!/bin/sh\n",
+		"sub/a.txt":  "a\n",
+		"sub/b.data": "\x00\x01",
+	}
+	tests := []struct {
+		name     string
+		got      map[string]string
+		perms    bool
+		expected string // the failure after the first line, or "" for none
+	}{
+		{name: "same", got: golden, perms: true},
+		{
+			name: "different",
+			got: map[string]string{
+				"README": "hello\nthere\n", "run.sh": "#!/bin/sh\n", "sub/a.txt": "a\n", "sub/b.data": "\x00\x02",
+				"extra.go": "package extra\n", "sub/z": "",
+			},
+			expected: "Missing files:\nmain.go\nUnexpected files:\nextra.go\nsub/z\nContents of README differ:\n" +
+				"  hello\n- world\n+ there\n  \n\nContents of sub/b.data differ (binary file)",
+		},
+		{
+			name: "perms", perms: true,
+			got: map[string]string{
+				"README": "hello\nworld\n", "main.go": "package main\n", "run": "", "sub/a.txt": "a\n",
+				"sub/b.data": "\x00\x01", "sub/a.txt.sh": "",
+			},
+			expected: "Missing files:\nrun.sh\nUnexpected files:\nrun\nsub/a.txt.sh",
+		},
+	}
+	goldenDir := writeTestTree(t, golden)
+	for _, test := range tests {
+		gotDir := writeTestTree(t, test.got)
+		rt := &testhelptest.RecordingT{}
+		ok := (&GoldenDirOptions{ComparePerms: test.perms}).goldenDirAt(rt, goldenDir, gotDir)
+		want := []string{}
+		if test.expected != "" {
+			want = []string{"Directory " + gotDir + " does not match golden directory " + goldenDir + " (run the " +
+				"tests with TESTHELP_UPDATE_GOLDEN=1 to update it):\n" + test.expected}
+		}
+		if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+			t.Errorf("goldenDirAt(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got, test.name)
+		}
+	}
+}
+
+func TestGoldenDirPermsAndUpdate(t *testing.T) {
+	goldenDir := filepath.Join(t.TempDir(), "golden")
+	gotDir := writeTestTree(t, map[string]string{"run.sh": "#!/bin/sh\n", "sub/x.txt": "x\n"})
+
+	rt := &testhelptest.RecordingT{}
+	o := &GoldenDirOptions{ComparePerms: true}
+	if o.goldenDirAt(rt, goldenDir, gotDir) {
+		t.Errorf("goldenDirAt(): Expected false with a missing golden directory")
+	}
+	if f := rt.Failures(); len(f) != 1 || !strings.Contains(f[0], "does not exist") {
+		t.Errorf("goldenDirAt(): Incorrect failure(s) with a missing golden directory:\n%#+v", f)
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "1")
+	if err := os.MkdirAll(filepath.Join(goldenDir, "stale"), 0o755); err != nil {
+		t.Fatalf("Can't create test directory: %s", err)
+	}
+	rt = &testhelptest.RecordingT{}
+	if !o.goldenDirAt(rt, goldenDir, gotDir) || len(rt.Failures()) != 0 {
+		t.Errorf("goldenDirAt(): Unexpected failure while updating:\n%#+v", rt.Failures())
+	}
+	if _, err := os.Stat(filepath.Join(goldenDir, "stale")); !os.IsNotExist(err) {
+		t.Errorf("goldenDirAt(): Expected the old golden directory to be replaced")
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "")
+	if err := os.Chmod(filepath.Join(gotDir, "run.sh"), 0o644); err != nil {
+		t.Fatalf("Can't change permissions: %s", err)
+	}
+	rt = &testhelptest.RecordingT{}
+	o.goldenDirAt(rt, goldenDir, gotDir)
+	want := "Permissions of run.sh differ: expected executable, got not executable"
+	if f := rt.Failures(); len(f) != 1 || !strings.HasSuffix(f[0], want) {
+		t.Errorf("goldenDirAt(): Incorrect failure(s) for permissions: expected a suffix of\n%s\ngot\n%#+v", want, f)
+	}
+	rt = &testhelptest.RecordingT{}
+	if !new(GoldenDirOptions).goldenDirAt(rt, goldenDir, gotDir) {
+		t.Errorf("goldenDirAt(): Expected permissions to be ignored by default:\n%#+v", rt.Failures())
+	}
+
+	if err := os.Symlink("run.sh", filepath.Join(gotDir, "link")); err == nil {
+		rt = &testhelptest.RecordingT{}
+		o.goldenDirAt(rt, goldenDir, gotDir)
+		if f := rt.Failures(); len(f) != 1 || !strings.HasSuffix(f[0], "link is not a regular file or directory") {
+			t.Errorf("goldenDirAt(): Incorrect failure(s) for a symlink:\n%#+v", f)
+		}
+	}
+}
+
+// checkBadGoldenDirNames checks that update calls with names that aren't strictly under testdata fail without removing
+// anything.  It runs in a scratch working directory, so that nothing real is lost if the check is broken.
+func checkBadGoldenDirNames(t *testing.T, desc string, update func(rt TestingTB, name string) bool) {
+	dir := writeTestTree(t, map[string]string{"testdata/keep.txt": "keep\n", "outside.txt": "outside\n"})
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Can't get the working directory: %s", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Can't change the working directory: %s", err)
+	}
+	defer func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatalf("Can't restore the working directory: %s", err)
+		}
+	}()
+	t.Setenv(UpdateGoldenEnvVar, "1")
+
+	tests := []struct {
+		name        string
+		goldenName  string
+		expectError string
+	}{
+		{name: "empty", goldenName: "", expectError: "no golden directory name was given"},
+		{name: "testdata itself", goldenName: ".",
+			expectError: "golden directory name '.' is not a relative path under testdata"},
+		{name: "parent", goldenName: "..", expectError: "golden directory name '..' is not a relative path under testdata"},
+		{name: "escaping", goldenName: "a/../../outside.txt",
+			expectError: "golden directory name 'a/../../outside.txt' is not a relative path under testdata"},
+		{name: "absolute", goldenName: dir,
+			expectError: "golden directory name '" + dir + "' is not a relative path under testdata"},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if update(rt, test.goldenName) {
+			t.Errorf("%s(): Expected false in test '%s'", desc, test.name)
+		}
+		want := []string{"Can't use golden directory: " + test.expectError}
+		if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", desc, want, got, test.name)
+		}
+		for _, path := range []string{"testdata/keep.txt", "outside.txt"} {
+			if _, err := os.Stat(filepath.FromSlash(path)); err != nil {
+				t.Errorf("%s(): Expected %s to be left alone in test '%s', got %s", desc, path, test.name, err)
+			}
+		}
+	}
+}
+
+func TestGoldenDirBadNames(t *testing.T) {
+	gotDir := writeTestTree(t, map[string]string{"a.txt": "a\n"})
+	checkBadGoldenDirNames(t, "GoldenDir", func(rt TestingTB, name string) bool { return GoldenDir(rt, name, gotDir) })
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultResponseGoldenHeaders are the headers ResponseGolden includes by default.
+var DefaultResponseGoldenHeaders = []string{"Content-Type"}
+
+var (
+	httpDateRE = regexp.MustCompile(`(Mon|Tue|Wed|Thu|Fri|Sat|Sun), \d\d ` +
+		`(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) \d{4} \d\d:\d\d:\d\d GMT`)
+	timestampRE = regexp.MustCompile(`\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?(Z|[+-]\d\d:\d\d)`)
+	uuidRE      = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+)
+
+// NormalizeHTTPDates replaces dates in the format used by HTTP headers (such as Date and Last-Modified, e.g.
+// "Mon, 02 Jan 2006 15:04:05 GMT") in s with "DATE".  It is intended for use as a ResponseGolden normalizer.
+func NormalizeHTTPDates(s string) string {
+	return httpDateRE.ReplaceAllString(s, "DATE")
+}
+
+// NormalizeTimestamps replaces RFC 3339 timestamps (such as "2006-01-02T15:04:05Z", with or without fractional
+// seconds) in s with "TIMESTAMP".  It is intended for use as a ResponseGolden normalizer, for timestamps in bodies.
+func NormalizeTimestamps(s string) string {
+	return timestampRE.ReplaceAllString(s, "TIMESTAMP")
+}
+
+// NormalizeUUIDs replaces UUIDs in s with "UUID".  It is intended for use as a ResponseGolden normalizer, for request
+// IDs and other generated identifiers.
+func NormalizeUUIDs(s string) string {
+	return uuidRE.ReplaceAllString(s, "UUID")
+}
+
+// ResponseGoldenOptions holds options for ResponseGolden.  The zero value gives the same behavior as the package-level
+// function.
+type ResponseGoldenOptions struct {
+	// Headers are the names of the headers to include; the default (nil) is DefaultResponseGoldenHeaders.  Headers
+	// that aren't in the response are left out.
+	Headers []string
+	// Normalizers are applied to the serialized response, in order, before comparison (and before writing it, when
+	// updating); see PanicsGolden.
+	Normalizers []func(string) string
+}
+
+// ResponseGolden serializes resp, such as the result of calling a handler with an httptest.ResponseRecorder, and
+// compares it with the golden file testdata/<name>.golden, relative to the test's working directory (which is
+// normally the package's directory), for snapshot testing of whole responses.  The serialization has the status line,
+// the headers in DefaultResponseGoldenHeaders (one line per value, in order), an empty line, and the body:
+//
+//	HTTP 404 Not Found
+//	Content-Type: application/json
+//
+//	{"error":"no such widget"}
+//
+// Bodies that aren't valid UTF-8 are base64-encoded, after a line saying so.  The body is read fully, and then
+// replaced with a new reader over the same bytes, so it can still be read afterwards.  If the serialization differs
+// from the golden file, or the golden file doesn't exist, t.Errorf is called, with a line-by-line diff for different
+// responses.  It returns true if the check passed.  For example:
+//
+//	rec := httptest.NewRecorder()
+//	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/widgets/7", nil))
+//	o := testhelp.ResponseGoldenOptions{Headers: []string{"Content-Type", "X-Request-Id"},
+//		Normalizers: []func(string) string{testhelp.NormalizeUUIDs, testhelp.NormalizeTimestamps}}
+//	o.ResponseGolden(t, "get-widget", rec.Result())
+//
+// As with PanicsGolden, running the tests with the environment variable named by UpdateGoldenEnvVar set writes the
+// serialization to the golden file instead of checking it.
+func ResponseGolden(t TestingTB, name string, resp *http.Response) bool {
+	t.Helper()
+	return new(ResponseGoldenOptions).ResponseGolden(t, name, resp)
+}
+
+// ResponseGolden is like the package-level ResponseGolden, but with the options in o.
+func (o *ResponseGoldenOptions) ResponseGolden(t TestingTB, name string, resp *http.Response) bool {
+	t.Helper()
+	return o.responseGoldenFile(t, filepath.Join("testdata", filepath.FromSlash(name)+".golden"), resp)
+}
+
+func (o *ResponseGoldenOptions) responseGoldenFile(t TestingTB, path string, resp *http.Response) bool {
+	t.Helper()
+	got, err := o.serialize(resp)
+	if err != nil {
+		t.Errorf("Can't read response body: %s", err)
+		return false
+	}
+	for _, normalize := range o.Normalizers {
+		got = normalize(got)
+	}
+	return textGoldenFile(t, path, "Response", got)
+}
+
+// serialize returns the serialization of resp described for ResponseGolden, replacing its body.
+func (o *ResponseGoldenOptions) serialize(resp *http.Response) (string, error) {
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+	headers := o.Headers
+	if headers == nil {
+		headers = DefaultResponseGoldenHeaders
+	}
+	for _, name := range headers {
+		for _, val := range resp.Header.Values(name) {
+			fmt.Fprintf(&b, "%s: %s\n", http.CanonicalHeaderKey(name), val)
+		}
+	}
+	b.WriteString("\n")
+	if utf8.Valid(body) {
+		b.Write(body)
+	} else {
+		fmt.Fprintf(&b, "(binary body, %d bytes, base64-encoded)\n%s\n", len(body),
+			base64.StdEncoding.EncodeToString(body))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestResponseNormalizers(t *testing.T) {
+	s := "Date: Mon, 02 Jan 2006 15:04:05 GMT\n" +
+		`{"id":"3F2504E0-4F89-41D3-9A0C-0305E82C3301","at":"2006-01-02T15:04:05.123Z","to":"2006-01-02T15:04:05-07:00"}`
+	want := "Date: DATE\n" + `{"id":"UUID","at":"TIMESTAMP","to":"TIMESTAMP"}`
+	if got := NormalizeUUIDs(NormalizeTimestamps(NormalizeHTTPDates(s))); got != want {
+		t.Errorf("Response normalizers: Incorrect result: expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func testResponse(status int, body string, header ...string) *http.Response {
+	rec := httptest.NewRecorder()
+	for i := 0; i+1 < len(header); i += 2 {
+		rec.Header().Add(header[i], header[i+1])
+	}
+	rec.WriteHeader(status)
+	_, _ = io.WriteString(rec, body)
+	return rec.Result()
+}
+
+func TestResponseGoldenSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  []string
+		resp     *http.Response
+		body     string
+		expected string
+	}{
+		{
+			name:     "default headers",
+			resp:     testResponse(404, `{"error":"no such widget"}`, "Content-Type", "application/json", "X-Other", "x"),
+			body:     `{"error":"no such widget"}`,
+			expected: "HTTP 404 Not Found\nContent-Type: application/json\n\n{\"error\":\"no such widget\"}",
+		},
+		{
+			name:     "selected headers",
+			headers:  []string{"x-request-id", "Vary", "Missing"},
+			resp:     testResponse(200, "ok\n", "X-Request-Id", "abc", "Vary", "Accept", "Vary", "Origin"),
+			body:     "ok\n",
+			expected: "HTTP 200 OK\nX-Request-Id: abc\nVary: Accept\nVary: Origin\n\nok\n",
+		},
+		{
+			name:     "binary body",
+			headers:  []string{},
+			resp:     testResponse(200, "\xff\x00"),
+			body:     "\xff\x00",
+			expected: "HTTP 200 OK\n\n(binary body, 2 bytes, base64-encoded)\n/wA=\n",
+		},
+		{
+			name:     "no body",
+			resp:     &http.Response{StatusCode: 204, Header: http.Header{}},
+			expected: "HTTP 204 No Content\n\n",
+		},
+	}
+	for _, test := range tests {
+		o := &ResponseGoldenOptions{Headers: test.headers}
+		got, err := o.serialize(test.resp)
+		if err != nil || got != test.expected {
+			t.Errorf("serialize(): Incorrect serialization: expected\n%q\ngot\n%q, %v\nin test '%s'", test.expected,
+				got, err, test.name)
+		}
+		if test.resp.Body != nil {
+			if body, _ := io.ReadAll(test.resp.Body); string(body) != test.body {
+				t.Errorf("serialize(): Body not replaced: got %q in test '%s'", body, test.name)
+			}
+		}
+	}
+}
+
+func TestResponseGoldenFile(t *testing.T) {
+	o := &ResponseGoldenOptions{Normalizers: []func(string) string{NormalizeUUIDs}}
+	path := filepath.Join(t.TempDir(), "sub", "resp.golden")
+	resp := func(id string) *http.Response {
+		return testResponse(200, `{"id":"`+id+`"}`, "Content-Type", "application/json")
+	}
+
+	rt := &testhelptest.RecordingT{}
+	if o.responseGoldenFile(rt, path, resp("3f2504e0-4f89-41d3-9a0c-0305e82c3301")) || len(rt.Failures()) != 1 {
+		t.Errorf("responseGoldenFile(): Expected a failure with a missing golden file, got\n%#+v", rt.Failures())
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "1")
+	rt = &testhelptest.RecordingT{}
+	if !o.responseGoldenFile(rt, path, resp("3f2504e0-4f89-41d3-9a0c-0305e82c3301")) || len(rt.Failures()) != 0 {
+		t.Errorf("responseGoldenFile(): Unexpected failure while updating:\n%#+v", rt.Failures())
+	}
+	want := "HTTP 200 OK\nContent-Type: application/json\n\n{\"id\":\"UUID\"}"
+	if stored, err := os.ReadFile(path); err != nil || string(stored) != want {
+		t.Errorf("responseGoldenFile(): Incorrect golden file: expected\n%q\ngot\n%q, %v", want, stored, err)
+	}
+	t.Setenv(UpdateGoldenEnvVar, "")
+
+	rt = &testhelptest.RecordingT{}
+	if !o.responseGoldenFile(rt, path, resp("01234567-89ab-cdef-0123-456789abcdef")) || len(rt.Failures()) != 0 {
+		t.Errorf("responseGoldenFile(): Unexpected failure for a matching response:\n%#+v", rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	o.responseGoldenFile(rt, path, testResponse(500, "oops", "Content-Type", "application/json"))
+	wantFailure := "Response does not match golden file " + path + " (run the tests with " + UpdateGoldenEnvVar +
+		"=1 to update it):\n- HTTP 200 OK\n+ HTTP 500 Internal Server Error\n  Content-Type: application/json\n  \n" +
+		"- {\"id\":\"UUID\"}\n+ oops\n"
+	if failures := rt.Failures(); len(failures) != 1 || failures[0] != wantFailure {
+		t.Errorf("responseGoldenFile(): Incorrect failures for a different response: expected\n%q\ngot\n%#+v",
+			wantFailure, failures)
+	}
+}
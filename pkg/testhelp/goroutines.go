@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// goroutineSampleInterval is how often MaxGoroutines samples the number of goroutines.
+const goroutineSampleInterval = time.Millisecond
+
+// MaxGoroutines runs f, sampling the number of goroutines while it runs, and calls t.Errorf if there were ever more
+// than n goroutines beyond the ones that were already running when MaxGoroutines was called.  The failure message
+// gives the peak number and the stacks of all goroutines at the first sample with that number.  It returns true if
+// the number stayed within the budget.
+//
+// Since the number is sampled (every millisecond), short-lived goroutines can be missed; this is intended for
+// catching goroutines that pile up, such as one per request in a load test, rather than for exact counts.  f runs in
+// the calling goroutine, so a panic from f isn't recovered.
+func MaxGoroutines(t TestingTB, n int, f func()) bool {
+	t.Helper()
+	baseline := runtime.NumGoroutine()
+	var mu sync.Mutex
+	peak := 0
+	var peakStacks string
+	sample := func() {
+		count := runtime.NumGoroutine() - baseline - 1 // not counting the sampler
+		mu.Lock()
+		defer mu.Unlock()
+		if count > peak {
+			peak = count
+			if count > n {
+				peakStacks = allStacks()
+			}
+		}
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(goroutineSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				sample()
+			}
+		}
+	}()
+	func() {
+		defer func() {
+			close(stop)
+			<-stopped
+		}()
+		f()
+		sample()
+	}()
+
+	if peak > n {
+		t.Errorf("Too many goroutines: the budget was %d beyond the %d already running, but the peak was %d beyond "+
+			"them; goroutines at the peak:\n%s", n, baseline, peak, peakStacks)
+		return false
+	}
+	return true
+}
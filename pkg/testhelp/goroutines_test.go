@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// spawnGoroutines starts n goroutines that block until release is closed, and returns once they've all started.
+func spawnGoroutines(n int, release chan struct{}, wg *sync.WaitGroup) {
+	started := make(chan struct{}, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started <- struct{}{}
+			<-release
+		}()
+	}
+	for i := 0; i < n; i++ {
+		<-started
+	}
+}
+
+func TestMaxGoroutines(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	var wg sync.WaitGroup
+	ok := MaxGoroutines(rt, 5, func() {
+		release := make(chan struct{})
+		spawnGoroutines(5, release, &wg)
+		time.Sleep(10 * goroutineSampleInterval)
+		close(release)
+		wg.Wait()
+	})
+	if !ok || len(rt.Failures()) != 0 {
+		t.Errorf("MaxGoroutines(): Unexpected failure within the budget:\n%#+v", rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	ok = MaxGoroutines(rt, 3, func() {
+		release := make(chan struct{})
+		spawnGoroutines(10, release, &wg)
+		time.Sleep(10 * goroutineSampleInterval)
+		close(release)
+		wg.Wait()
+	})
+	f := rt.Failures()
+	if ok || len(f) != 1 || !strings.HasPrefix(f[0], "Too many goroutines: the budget was 3 beyond the ") ||
+		!strings.Contains(f[0], "but the peak was 10 beyond them; goroutines at the peak:\ngoroutine ") ||
+		!strings.Contains(f[0], "spawnGoroutines") {
+		t.Errorf("MaxGoroutines(): Incorrect failures over the budget:\n%#+v", f)
+	}
+
+	// Goroutines still running at the end are caught by the final sample, even without time to sample in between
+	rt = &testhelptest.RecordingT{}
+	release := make(chan struct{})
+	ok = MaxGoroutines(rt, 0, func() { spawnGoroutines(2, release, &wg) })
+	close(release)
+	wg.Wait()
+	if f := rt.Failures(); ok || len(f) != 1 || !strings.Contains(f[0], "the peak was 2 beyond") {
+		t.Errorf("MaxGoroutines(): Incorrect failures for goroutines left running:\n%#+v", f)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sync"
+)
+
+// A GroupPanicError is the error that RunGroup returns for a function that panicked, holding the panic as captured
+// by Capture.  IsRecovered and RecoveredValue recognize it.
+type GroupPanicError struct {
+	Index int
+	CapturedPanic
+}
+
+func (e *GroupPanicError) Error() string {
+	return fmt.Sprintf("function %d panicked: %s", e.Index, PanicMessage(e.PVal))
+}
+
+func (e *GroupPanicError) capturedPanic() CapturedPanic {
+	return e.CapturedPanic
+}
+
+// RunGroup runs each of the functions in its own goroutine, waits for them all to finish, and returns their errors,
+// in the same order as the functions (with nil for the ones that succeeded).  If a function panics, the panic is
+// reported with t.Errorf (with the panic value and stack), instead of crashing the test binary as a panic in a
+// goroutine normally would, and its error is a *GroupPanicError.
+//
+// This is intended for tests that drive concurrent workers directly, in place of an errgroup or a hand-written
+// WaitGroup, so that a panic in one worker is reported as a failure of the test it happened in:
+//
+//	errs := testhelp.RunGroup(t,
+//		func() error { return pool.Submit(jobA) },
+//		func() error { return pool.Submit(jobB) },
+//	)
+//	for i, err := range errs {
+//		if err != nil {
+//			t.Errorf("Submit %d failed: %s", i, err)
+//		}
+//	}
+func RunGroup(t TestingTB, fns ...func() error) []error {
+	t.Helper()
+	errs := make([]error, len(fns))
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		wg.Add(1)
+		go func(i int, fn func() error) {
+			defer wg.Done()
+			var err error
+			c := Capture(func() { err = fn() })
+			if c.DidPanic {
+				err = &GroupPanicError{Index: i, CapturedPanic: c}
+			}
+			errs[i] = err
+		}(i, fn)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if pe, ok := err.(*GroupPanicError); ok {
+			t.Errorf("Panic in group function %d (%T):\n%s\nstack:\n%s", pe.Index, pe.PVal, PanicMessage(pe.PVal),
+				pe.Stack)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestRunGroup(t *testing.T) {
+	errFailed := errors.New("failed")
+	var calls int32
+	rt := &testhelptest.RecordingT{}
+	errs := RunGroup(rt,
+		func() error { atomic.AddInt32(&calls, 1); return nil },
+		func() error { atomic.AddInt32(&calls, 1); return errFailed },
+		func() error { atomic.AddInt32(&calls, 1); panic("boom") },
+		func() error { atomic.AddInt32(&calls, 1); panic(errFailed) },
+	)
+	if calls != 4 || len(errs) != 4 || errs[0] != nil || errs[1] != errFailed {
+		t.Fatalf("RunGroup(): Incorrect results: %d call(s), errors %#+v", calls, errs)
+	}
+	var pe *GroupPanicError
+	if !errors.As(errs[2], &pe) || pe.Index != 2 || pe.PVal != "boom" || errs[2].Error() != "function 2 panicked: boom" {
+		t.Errorf("RunGroup(): Incorrect error for a panic: %#+v", errs[2])
+	}
+	if !errors.As(errs[3], &pe) || pe.PVal != errFailed || !strings.Contains(pe.Stack, "TestRunGroup") {
+		t.Errorf("RunGroup(): Incorrect error for a panic with an error: %#+v", errs[3])
+	}
+	f := rt.Failures()
+	if len(f) != 2 || !strings.HasPrefix(f[0], "Panic in group function 2 (string):\nboom\nstack:\n") ||
+		!strings.HasPrefix(f[1], "Panic in group function 3 (*errors.errorString):\nfailed\nstack:\n") {
+		t.Errorf("RunGroup(): Incorrect failures:\n%#+v", f)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if errs := RunGroup(rt); len(errs) != 0 || len(rt.Failures()) != 0 {
+		t.Errorf("RunGroup(): Incorrect results with no functions: %#+v, %#+v", errs, rt.Failures())
+	}
+}
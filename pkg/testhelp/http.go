@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// httpPanicMessage is the panic value used by AssertRecoversToStatus; it's distinctive so that it can be spotted if it
+// leaks into a response body.
+const httpPanicMessage = "testhelp.AssertRecoversToStatus: deliberate handler panic"
+
+// AssertRecoversToStatus installs a handler that panics behind the given middleware, performs a GET request through
+// the middleware, and calls t.Errorf if the panic escaped from the middleware, if the response status isn't
+// wantStatus, or if the panic message was written into the response body (which usually means that internal details
+// would be leaked to clients).  It returns the recorded response, for further checks on the headers or body, and true
+// if all of the checks passed.
+//
+// This is intended for testing panic-recovery middleware.  For example:
+//
+//	rec, _ := testhelp.AssertRecoversToStatus(t, mypkg.Recoverer, http.StatusInternalServerError)
+//	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+//		t.Errorf("Wrong content type for a panic response: %s", ct)
+//	}
+func AssertRecoversToStatus(t TestingTB, middleware func(http.Handler) http.Handler,
+	wantStatus int) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	handlerCalled := false
+	handler := middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		handlerCalled = true
+		panic(httpPanicMessage)
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	didPanic, pVal := PanicsGet(func() { handler.ServeHTTP(rec, req) })
+	ok := true
+	if !handlerCalled {
+		t.Errorf("The middleware never called the panicking handler")
+		ok = false
+	}
+	if didPanic {
+		t.Errorf("Panic escaped from the middleware (%T):\n%s", pVal, quotedPanicMessage(pVal))
+		return rec, false
+	}
+	if rec.Code != wantStatus {
+		t.Errorf("Incorrect response status after a handler panic: expected %d, got %d", wantStatus, rec.Code)
+		ok = false
+	}
+	if strings.Contains(rec.Body.String(), httpPanicMessage) {
+		t.Errorf("The panic message was written into the response body:\n%s", rec.Body.String())
+		ok = false
+	}
+	return rec, ok
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func recoverTo(status int, body func(pVal interface{}) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if pVal := recover(); pVal != nil {
+					w.WriteHeader(status)
+					fmt.Fprint(w, body(pVal))
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestAssertRecoversToStatus(t *testing.T) {
+	genericBody := func(interface{}) string { return "internal error" }
+	tests := []struct {
+		name         string
+		middleware   func(http.Handler) http.Handler
+		wantStatus   int
+		wantOK       bool
+		wantFailures []string
+	}{
+		{"recovers", recoverTo(http.StatusInternalServerError, genericBody), http.StatusInternalServerError, true,
+			[]string{}},
+		{
+			"wrong status", recoverTo(http.StatusOK, genericBody), http.StatusInternalServerError, false,
+			[]string{"Incorrect response status after a handler panic: expected 500, got 200"},
+		},
+		{
+			"leaks message", recoverTo(http.StatusInternalServerError, func(pVal interface{}) string {
+				return fmt.Sprint(pVal)
+			}), http.StatusInternalServerError, false,
+			[]string{"The panic message was written into the response body:\n" + httpPanicMessage},
+		},
+		{
+			"no recovery", func(next http.Handler) http.Handler { return next }, http.StatusInternalServerError, false,
+			[]string{"Panic escaped from the middleware (string):\n\"" + httpPanicMessage + "\""},
+		},
+		{
+			"handler not called", func(http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				})
+			}, http.StatusInternalServerError, false,
+			[]string{"The middleware never called the panicking handler"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		rec, ok := AssertRecoversToStatus(rt, test.middleware, test.wantStatus)
+		if ok != test.wantOK {
+			t.Errorf("AssertRecoversToStatus(): Incorrect return value: expected %t, got %t in test '%s'",
+				test.wantOK, ok, test.name)
+		}
+		if rec == nil {
+			t.Errorf("AssertRecoversToStatus(): No response recorder returned in test '%s'", test.name)
+		}
+		if failures := rt.Failures(); !reflect.DeepEqual(failures, test.wantFailures) {
+			t.Errorf("AssertRecoversToStatus(): Incorrect failure(s): expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantFailures, failures, test.name)
+		}
+	}
+}
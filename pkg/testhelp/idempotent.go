@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckIdempotent calls op the given number of times, and checks that each call after the first has the same effect
+// as the first: the same result (according to Diff), the same error (by message, or nil), and, if snapshot isn't nil,
+// the same state afterwards, as returned by snapshot.  This is intended for operations that are meant to be safe to
+// retry, such as handlers for requests that clients resend:
+//
+//	testhelp.CheckIdempotent(t, func() (interface{}, error) {
+//		return srv.CreateOrder(ctx, req) // req has an idempotency key
+//	}, 3, func() interface{} {
+//		return store.Orders() // a copy, so that later calls can't change it
+//	})
+//
+// snapshot must return a value that later calls to op won't modify.  If a call differs from the first,
+// CheckIdempotent calls t.Errorf, reporting the first divergent call with a diff of what changed, and stops.  It
+// returns true if all the calls matched.  It panics if times is less than 2.
+func CheckIdempotent(t TestingTB, op func() (interface{}, error), times int, snapshot func() interface{}) bool {
+	t.Helper()
+	if times < 2 {
+		panic(fmt.Sprintf("Invalid number of runs: %d", times))
+	}
+
+	type outcome struct {
+		result interface{}
+		err    string
+		state  interface{}
+	}
+	run := func() outcome {
+		result, err := op()
+		o := outcome{result: result, err: errorString(err)}
+		if snapshot != nil {
+			o.state = snapshot()
+		}
+		return o
+	}
+
+	first := run()
+	for i := 2; i <= times; i++ {
+		o := run()
+		var diffs []string
+		if diff := Diff(first.result, o.result); diff != "" {
+			diffs = append(diffs, "Result:\n"+indentLines(diff))
+		}
+		if o.err != first.err {
+			diffs = append(diffs, fmt.Sprintf("Error: expected %s, got %s", first.err, o.err))
+		}
+		if diff := Diff(first.state, o.state); diff != "" {
+			diffs = append(diffs, "State:\n"+indentLines(diff))
+		}
+		if len(diffs) > 0 {
+			t.Errorf("Operation isn't idempotent: call %d of %d differed from call 1:\n%s", i, times,
+				strings.Join(diffs, "\n"))
+			return false
+		}
+	}
+	return true
+}
+
+// errorString returns err's message, quoted, or "nil", for CheckIdempotent.
+func errorString(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%q", err.Error())
+}
+
+// indentLines indents each line of s by two spaces.
+func indentLines(s string) string {
+	return "  " + strings.ReplaceAll(s, "\n", "\n  ")
+}
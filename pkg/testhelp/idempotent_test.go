@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCheckIdempotent(t *testing.T) {
+	type order struct {
+		ID    int
+		Count int
+	}
+
+	tests := []struct {
+		name             string
+		op               func(calls int, state map[string]int) (interface{}, error)
+		withSnapshot     bool
+		expectedOK       bool
+		expectedCalls    int
+		expectedFailures []string
+	}{
+		{
+			name: "idempotent",
+			op: func(calls int, state map[string]int) (interface{}, error) {
+				state["orders"] = 1
+				return order{ID: 7, Count: 1}, nil
+			},
+			withSnapshot:     true,
+			expectedOK:       true,
+			expectedCalls:    3,
+			expectedFailures: []string{},
+		},
+		{
+			name: "different result",
+			op: func(calls int, state map[string]int) (interface{}, error) {
+				return order{ID: 7, Count: calls}, nil
+			},
+			withSnapshot:  false,
+			expectedOK:    false,
+			expectedCalls: 2,
+			expectedFailures: []string{
+				"Operation isn't idempotent: call 2 of 3 differed from call 1:\nResult:\n" +
+					"  Count: expected 1, got 2",
+			},
+		},
+		{
+			name: "different error",
+			op: func(calls int, state map[string]int) (interface{}, error) {
+				if calls == 3 {
+					return nil, errors.New("already exists")
+				}
+				return nil, nil
+			},
+			withSnapshot:  false,
+			expectedOK:    false,
+			expectedCalls: 3,
+			expectedFailures: []string{
+				"Operation isn't idempotent: call 3 of 3 differed from call 1:\nError: expected nil, got " +
+					"\"already exists\"",
+			},
+		},
+		{
+			name: "different state",
+			op: func(calls int, state map[string]int) (interface{}, error) {
+				state["orders"]++
+				return order{ID: 7, Count: 1}, nil
+			},
+			withSnapshot:  true,
+			expectedOK:    false,
+			expectedCalls: 2,
+			expectedFailures: []string{
+				"Operation isn't idempotent: call 2 of 3 differed from call 1:\nState:\n" +
+					"  [\"orders\"]: expected 1, got 2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		calls := 0
+		state := map[string]int{}
+		op := func() (interface{}, error) {
+			calls++
+			return test.op(calls, state)
+		}
+		var snapshot func() interface{}
+		if test.withSnapshot {
+			snapshot = func() interface{} {
+				c := map[string]int{}
+				for k, v := range state {
+					c[k] = v
+				}
+				return c
+			}
+		}
+
+		mockT := &testhelptest.RecordingT{}
+		ok := CheckIdempotent(mockT, op, 3, snapshot)
+		if ok != test.expectedOK {
+			t.Errorf("CheckIdempotent(): Incorrect return value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedOK, ok, test.name)
+		}
+		if calls != test.expectedCalls {
+			t.Errorf("CheckIdempotent(): Incorrect number of calls: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedCalls, calls, test.name)
+		}
+		if fmt.Sprint(mockT.Failures()) != fmt.Sprint(test.expectedFailures) {
+			t.Errorf("CheckIdempotent(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedFailures, mockT.Failures(), test.name)
+		}
+	}
+
+	if _, ok, _ := PanicsStr(func() {
+		CheckIdempotent(&testhelptest.RecordingT{}, func() (interface{}, error) { return nil, nil }, 1, nil)
+	}, "Invalid number of runs: 1"); !ok {
+		t.Errorf("CheckIdempotent(): Incorrect panic: expected one for 1 run")
+	}
+}
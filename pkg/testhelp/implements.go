@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Implements checks that the dynamic type of value implements the interface type that iface points to, calling
+// t.Errorf with each of the interface's methods that the type is missing or has with a different signature if not.
+// It returns true if the check passed.  iface is a nil pointer to the interface type, as in:
+//
+//	testhelp.Implements(t, (*io.ReadCloser)(nil), plugin.New(config))
+//
+// This is for values that are constructed dynamically, such as plugins or the results of factories that return
+// interface{}, for which the compile-time check (var _ io.ReadCloser = ...) isn't possible and a type assertion only
+// says that something is wrong.  A method that's only missing because it has a pointer receiver and value isn't a
+// pointer is reported as such.
+//
+// Implements panics if iface isn't a pointer to an interface type.
+func Implements(t TestingTB, iface interface{}, value interface{}) bool {
+	t.Helper()
+	ifaceType := reflect.TypeOf(iface)
+	if ifaceType == nil || ifaceType.Kind() != reflect.Pointer || ifaceType.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("Implements requires a pointer to an interface type, such as (*io.Reader)(nil), got %T",
+			iface))
+	}
+	ifaceType = ifaceType.Elem()
+
+	valueType := reflect.TypeOf(value)
+	if valueType == nil {
+		t.Errorf("A nil value does not implement %s", ifaceType)
+		return false
+	}
+	if valueType.Implements(ifaceType) {
+		return true
+	}
+	t.Errorf("%s does not implement %s:\n%s", valueType, ifaceType,
+		strings.Join(methodMismatches(ifaceType, valueType), "\n"))
+	return false
+}
+
+// methodMismatches returns a description of each method of ifaceType that valueType is missing or has with a different
+// signature.
+func methodMismatches(ifaceType, valueType reflect.Type) []string {
+	var problems []string
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		want := ifaceType.Method(i)
+		if !want.IsExported() {
+			problems = append(problems, fmt.Sprintf("  missing method %s (an unexported method, which only types in %s "+
+				"can have)", want.Name, want.PkgPath))
+			continue
+		}
+		m, ok := valueType.MethodByName(want.Name)
+		if !ok {
+			if valueType.Kind() != reflect.Pointer {
+				if pm, ok := reflect.PointerTo(valueType).MethodByName(want.Name); ok {
+					problems = append(problems, fmt.Sprintf("  missing method %s (%s has it, with a pointer receiver, "+
+						"as %s)", want.Name, reflect.PointerTo(valueType), methodSignature(pm.Type)))
+					continue
+				}
+			}
+			problems = append(problems, fmt.Sprintf("  missing method %s %s", want.Name, want.Type))
+			continue
+		}
+		if got := methodSignature(m.Type); got != want.Type.String() {
+			problems = append(problems, fmt.Sprintf("  wrong signature for method %s: expected\n    %s\n  got\n    %s",
+				want.Name, want.Type, got))
+		}
+	}
+	return problems
+}
+
+// methodSignature returns the signature of a method of a concrete type, given the method's function type (whose first
+// parameter is the receiver), without the receiver.
+func methodSignature(methodType reflect.Type) string {
+	in := make([]reflect.Type, methodType.NumIn()-1)
+	for i := range in {
+		in[i] = methodType.In(i + 1)
+	}
+	out := make([]reflect.Type, methodType.NumOut())
+	for i := range out {
+		out[i] = methodType.Out(i)
+	}
+	return reflect.FuncOf(in, out, methodType.IsVariadic()).String()
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type implShape interface {
+	Area() float64
+	Scale(factor float64, opts ...string) error
+	Name() string
+}
+
+type implSealed interface {
+	Name() string
+	sealed()
+}
+
+type implSquare struct{}
+
+func (implSquare) Area() float64                   { return 1 }
+func (implSquare) Name() string                    { return "square" }
+func (*implSquare) Scale(float64, ...string) error { return nil }
+
+type implCircle struct{}
+
+func (implCircle) Area() int                      { return 1 }
+func (implCircle) Scale(factor float64) error     { return nil }
+func (implCircle) Name() string                   { return "circle" }
+func (implCircle) sealedNot()                     {}
+func (implCircle) Close() (err error)             { return nil }
+func (implCircle) Read(p []byte) (n int, e error) { return 0, io.EOF }
+
+func TestImplements(t *testing.T) {
+	tests := []struct {
+		name         string
+		iface        interface{}
+		value        interface{}
+		wantOK       bool
+		wantFailures []string
+	}{
+		{"implements", (*implShape)(nil), &implSquare{}, true, []string{}},
+		{"implements stdlib", (*io.ReadCloser)(nil), implCircle{}, true, []string{}},
+		{"pointer receiver", (*implShape)(nil), implSquare{}, false, []string{
+			"testhelp.implSquare does not implement testhelp.implShape:\n" +
+				"  missing method Scale (*testhelp.implSquare has it, with a pointer receiver, " +
+				"as func(float64, ...string) error)",
+		}},
+		{"wrong signatures", (*implShape)(nil), implCircle{}, false, []string{
+			"testhelp.implCircle does not implement testhelp.implShape:\n" +
+				"  wrong signature for method Area: expected\n    func() float64\n  got\n    func() int\n" +
+				"  wrong signature for method Scale: expected\n    func(float64, ...string) error\n" +
+				"  got\n    func(float64) error",
+		}},
+		{"missing", (*io.Writer)(nil), implCircle{}, false, []string{
+			"testhelp.implCircle does not implement io.Writer:\n" +
+				"  missing method Write func([]uint8) (int, error)",
+		}},
+		{"unexported", (*implSealed)(nil), implCircle{}, false, []string{
+			"testhelp.implCircle does not implement testhelp.implSealed:\n" +
+				"  missing method sealed (an unexported method, which only types in " +
+				"github.com/ocsw/go-testhelp/pkg/testhelp can have)",
+		}},
+		{"nil", (*io.Writer)(nil), nil, false, []string{"A nil value does not implement io.Writer"}},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if ok := Implements(rt, test.iface, test.value); ok != test.wantOK {
+			t.Errorf("Implements(): Incorrect result: expected %t, got %t in test '%s'", test.wantOK, ok, test.name)
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, test.wantFailures) {
+			t.Errorf("Implements(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantFailures,
+				got, test.name)
+		}
+	}
+}
+
+func TestImplementsPanics(t *testing.T) {
+	for _, iface := range []interface{}{nil, (*int)(nil), io.EOF, (**io.Reader)(nil)} {
+		if didPanic := Panics(func() { Implements(&testhelptest.RecordingT{}, iface, 5) }); !didPanic {
+			t.Errorf("Implements(): Expected a panic for %#+v", iface)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InvariantSeedEnvVar is the environment variable that, if set to an integer, sets the random seed for CheckInvariant,
+// to reproduce a failure.  Otherwise, the seed comes from the current time, and it's included in failure messages.
+const InvariantSeedEnvVar = "TESTHELP_INVARIANT_SEED"
+
+// invariantMaxOps is the maximum length of each random sequence of operations in CheckInvariant.
+const invariantMaxOps = 20
+
+// CheckInvariant is a lightweight form of model-based testing for stateful components.  For each of the given number
+// of iterations, it creates a new state with setup, and applies a random sequence of up to 20 of the operations in
+// ops (each chosen independently, so operations can repeat), calling invariant after setup and after each operation.
+// A non-nil error from invariant, or a panic from any of the functions, is a failure; a panic doesn't stop the test,
+// but is reported like any other failure, with its value and stack.
+//
+// On the first failure, CheckInvariant shrinks the sequence of operations, by repeatedly removing operations as long
+// as the sequence still fails (from a fresh state), and calls t.Errorf with the shortest failing sequence (by index
+// into ops), the failure, and the random seed, which can be passed back in with the environment variable named by
+// InvariantSeedEnvVar to reproduce the run.  It returns true if there were no failures.  For example:
+//
+//	testhelp.CheckInvariant(t, "len matches contents", NewSet,
+//		[]func(*Set){
+//			func(s *Set) { s.Add(1) },
+//			func(s *Set) { s.Add(2) },
+//			func(s *Set) { s.Remove(1) },
+//			func(s *Set) { s.Clear() },
+//		},
+//		func(s *Set) error {
+//			if s.Len() != len(s.Items()) {
+//				return fmt.Errorf("Len() is %d, but there are %d items", s.Len(), len(s.Items()))
+//			}
+//			return nil
+//		}, 100)
+//
+// setup must return an independent state each time it's called, since failing sequences are replayed.
+func CheckInvariant[S any](t TestingTB, name string, setup func() S, ops []func(S), invariant func(S) error,
+	iterations int) bool {
+	t.Helper()
+	if len(ops) == 0 {
+		panic("CheckInvariant: no operations")
+	}
+	seed := time.Now().UnixNano()
+	if s := os.Getenv(InvariantSeedEnvVar); s != "" {
+		var err error
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			t.Errorf("Invalid %s: %s", InvariantSeedEnvVar, err)
+			return false
+		}
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < iterations; i++ {
+		seq := make([]int, rnd.Intn(invariantMaxOps)+1)
+		for j := range seq {
+			seq[j] = rnd.Intn(len(ops))
+		}
+		n, failure, stack := runInvariantSeq(setup, ops, invariant, seq)
+		if failure == "" {
+			continue
+		}
+		original := n
+		seq = seq[:n]
+		// Greedily remove one operation at a time, starting over after each success, until no removal still fails
+		for shrunk := true; shrunk; {
+			shrunk = false
+			for j := 0; j < len(seq); j++ {
+				candidate := append(append([]int{}, seq[:j]...), seq[j+1:]...)
+				if n, f, st := runInvariantSeq(setup, ops, invariant, candidate); f != "" {
+					seq, failure, stack, shrunk = candidate[:n], f, st, true
+					break
+				}
+			}
+		}
+		t.Errorf("%s", withStack(fmt.Sprintf("Invariant '%s' broken in iteration %d by operations %s (shrunk from %d "+
+			"operation(s)): %s\nseed: %d (run the tests with %s=%d to reproduce)", name, i, formatOpSeq(seq), original,
+			failure, seed, InvariantSeedEnvVar, seed), stack))
+		return false
+	}
+	return true
+}
+
+// runInvariantSeq applies the operations in seq (by index) to a new state, checking the invariant before the first
+// one and after each one.  If there's a failure, it returns the number of operations that were applied (including
+// the one that failed), a description of the failure, and the stack, if the failure was a panic; otherwise, it
+// returns len(seq), "", and "".
+func runInvariantSeq[S any](setup func() S, ops []func(S), invariant func(S) error,
+	seq []int) (int, string, string) {
+	var s S
+	check := func() (string, string) {
+		var err error
+		if c := Capture(func() { err = invariant(s) }); c.DidPanic {
+			return panicFailure("the invariant", c), c.Stack
+		}
+		if err != nil {
+			return err.Error(), ""
+		}
+		return "", ""
+	}
+	if c := Capture(func() { s = setup() }); c.DidPanic {
+		return 0, panicFailure("setup", c), c.Stack
+	}
+	if f, stack := check(); f != "" {
+		return 0, f, stack
+	}
+	for i, op := range seq {
+		if c := Capture(func() { ops[op](s) }); c.DidPanic {
+			return i + 1, panicFailure(fmt.Sprintf("ops[%d]", op), c), c.Stack
+		}
+		if f, stack := check(); f != "" {
+			return i + 1, f, stack
+		}
+	}
+	return len(seq), "", ""
+}
+
+// formatOpSeq formats a sequence of operation indexes, e.g. "[ops[1], ops[0]]".
+func formatOpSeq(seq []int) string {
+	names := make([]string, len(seq))
+	for i, op := range seq {
+		names[i] = fmt.Sprintf("ops[%d]", op)
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// testSet is a set of ints with a count that, if buggy is true, is wrong when an item is added twice.
+type testSet struct {
+	items map[int]bool
+	n     int
+	buggy bool
+}
+
+func (s *testSet) add(i int) {
+	if !s.items[i] || s.buggy {
+		s.n++
+	}
+	s.items[i] = true
+}
+
+func (s *testSet) remove(i int) {
+	if s.items[i] {
+		s.n--
+	}
+	delete(s.items, i)
+}
+
+func testSetOps() []func(*testSet) {
+	return []func(*testSet){
+		func(s *testSet) { s.add(1) },
+		func(s *testSet) { s.add(2) },
+		func(s *testSet) { s.remove(1) },
+	}
+}
+
+func testSetInvariant(s *testSet) error {
+	if s.n != len(s.items) {
+		return fmt.Errorf("count is %d, but there are %d item(s)", s.n, len(s.items))
+	}
+	return nil
+}
+
+func TestCheckInvariant(t *testing.T) {
+	t.Setenv(InvariantSeedEnvVar, "42")
+	setup := func() *testSet { return &testSet{items: map[int]bool{}} }
+	rt := &testhelptest.RecordingT{}
+	if !CheckInvariant(rt, "count", setup, testSetOps(), testSetInvariant, 100) || len(rt.Failures()) != 0 {
+		t.Errorf("CheckInvariant(): Unexpected failures for a correct set:\n%#+v", rt.Failures())
+	}
+
+	buggySetup := func() *testSet { return &testSet{items: map[int]bool{}, buggy: true} }
+	rt = &testhelptest.RecordingT{}
+	if CheckInvariant(rt, "count", buggySetup, testSetOps(), testSetInvariant, 100) {
+		t.Errorf("CheckInvariant(): Expected false for a buggy set")
+	}
+	// Adding the same item twice is the shortest way to break it
+	failureRE := regexp.MustCompile(`^Invariant 'count' broken in iteration \d+ by operations \[ops\[([01])\], ` +
+		`ops\[([01])\]\] \(shrunk from \d+ operation\(s\)\): count is 2, but there are 1 item\(s\)\n` +
+		`seed: 42 \(run the tests with TESTHELP_INVARIANT_SEED=42 to reproduce\)$`)
+	f := rt.Failures()
+	if len(f) != 1 {
+		t.Fatalf("CheckInvariant(): Expected one failure for a buggy set, got:\n%#+v", f)
+	}
+	if m := failureRE.FindStringSubmatch(f[0]); m == nil || m[1] != m[2] {
+		t.Errorf("CheckInvariant(): Incorrect failure for a buggy set:\n%s", f[0])
+	}
+	rt2 := &testhelptest.RecordingT{}
+	CheckInvariant(rt2, "count", buggySetup, testSetOps(), testSetInvariant, 100)
+	if f2 := rt2.Failures(); len(f2) != 1 || f2[0] != f[0] {
+		t.Errorf("CheckInvariant(): Expected the same failure with the same seed, got:\n%#+v", f2)
+	}
+}
+
+func TestCheckInvariantPanics(t *testing.T) {
+	t.Setenv(InvariantSeedEnvVar, "7")
+	setup := func() *testSet { return &testSet{items: map[int]bool{}} }
+	ops := []func(*testSet){
+		func(s *testSet) { s.add(1) },
+		func(s *testSet) { panic("op failed") },
+	}
+	tests := []struct {
+		name      string
+		setup     func() *testSet
+		ops       []func(*testSet)
+		invariant func(*testSet) error
+		expected  string
+	}{
+		{
+			name: "op", setup: setup, ops: ops, invariant: testSetInvariant,
+			expected: "by operations [ops[1]] (shrunk from ",
+		},
+		{
+			name: "op message", setup: setup, ops: ops, invariant: testSetInvariant,
+			expected: "panic in ops[1] (string): op failed\n",
+		},
+		{
+			name: "setup", setup: func() *testSet { panic("no setup") }, ops: ops, invariant: testSetInvariant,
+			expected: "by operations [] (shrunk from 0 operation(s)): panic in setup (string): no setup\n",
+		},
+		{
+			name: "invariant", setup: setup, ops: ops, invariant: func(*testSet) error { panic("bad invariant") },
+			expected: "by operations [] (shrunk from 0 operation(s)): panic in the invariant (string): bad invariant\n",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := CheckInvariant(rt, "x", test.setup, test.ops, test.invariant, 10)
+		if f := rt.Failures(); ok || len(f) != 1 || !strings.Contains(f[0], test.expected) {
+			t.Errorf("CheckInvariant(): Incorrect failures: expected one containing\n%s\ngot\n%#+v\nin test '%s'",
+				test.expected, f, test.name)
+		} else if !strings.Contains(f[0], "to reproduce)\nstack:\n") || !strings.Contains(f[0], "invariant_test.go") {
+			t.Errorf("CheckInvariant(): Incorrect failures: expected a stack after the seed, got\n%s\nin test '%s'",
+				f[0], test.name)
+		}
+	}
+
+	didPanic := Panics(func() { CheckInvariant(&testhelptest.RecordingT{}, "x", setup, nil, testSetInvariant, 1) })
+	if !didPanic {
+		t.Errorf("CheckInvariant(): Expected a panic with no operations")
+	}
+	t.Setenv(InvariantSeedEnvVar, "abc")
+	rt := &testhelptest.RecordingT{}
+	CheckInvariant(rt, "x", setup, ops, testSetInvariant, 1)
+	if f := rt.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], "Invalid TESTHELP_INVARIANT_SEED: ") {
+		t.Errorf("CheckInvariant(): Incorrect failures with an invalid seed:\n%#+v", f)
+	}
+}
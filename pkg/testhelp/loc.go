@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// A Loc is a position in a source file, used to record where a table entry is defined, so that failure messages can
+// point to it.  This makes it much easier to find a failing entry in a long table than searching by name.  The zero
+// value means that the position is unknown.
+//
+// Locs are normally created with Here:
+//
+//	tests := []testhelp.PanicStrTest{
+//		{Name: "nil input", F: func() { mypkg.Parse(nil) }, WantStr: "nil", Loc: testhelp.Here()},
+//		// ...
+//	}
+//
+// The loops pass the names of entries with a Loc to their failure callbacks as "name (at file.go:line)", so that the
+// messages from the factories (such as NotContainsFuncErrorFactory) include the position; the subtest runners (such as
+// RunPanicStrTests) also include it in their messages.  Hooks such as LoopRunner.BeforeEach are passed the plain name.
+// LoopRunner.ShowCallLoc also adds the position of the code that called the loop, and LoopRunner.FailureContextFunc
+// gets both positions separately.
+type Loc struct {
+	File string
+	Line int
+}
+
+// Here returns the position of the code that calls it.
+func Here() Loc {
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		return Loc{}
+	}
+	return Loc{File: file, Line: line}
+}
+
+// String returns the position in the form "file.go:line", with only the base name of the file (as in the go test
+// output), or "" if the position is unknown.
+func (l Loc) String() string {
+	if l.File == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(l.File), l.Line)
+}
+
+// entryName returns the name of a table entry as passed to failure callbacks: with the entry's position, if it's known.
+func entryName(name string, loc Loc) string {
+	if loc.File == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (at %s)", name, loc)
+}
+
+// locSuffix returns a suffix for the subtest runners' failure messages that gives the entry's position, if it's known.
+func locSuffix(loc Loc) string {
+	if loc.File == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (table entry at %s)", loc)
+}
+
+// packageDir is the directory of this package's source files, for callSite.
+var packageDir = func() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return ""
+	}
+	return filepath.Dir(file)
+}()
+
+// callSite returns the position of the first caller outside of this package (not counting its tests, so that the
+// package's own tests see themselves as callers), or the zero Loc if it can't be found.  This works whether a loop is
+// called directly on a LoopRunner or through one of the package-level functions.
+func callSite() Loc {
+	if packageDir == "" {
+		return Loc{}
+	}
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if frame.File != "" && (filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go")) {
+			return Loc{File: frame.File, Line: frame.Line}
+		}
+		if !more {
+			return Loc{}
+		}
+	}
+}
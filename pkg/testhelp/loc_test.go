@@ -0,0 +1,139 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestHere(t *testing.T) {
+	_, wantFile, wantLine, _ := runtime.Caller(0)
+	loc := Here()
+	if loc.File != wantFile || loc.Line != wantLine+1 {
+		t.Errorf("Here(): Incorrect position: expected %s:%d, got %s:%d", wantFile, wantLine+1, loc.File, loc.Line)
+	}
+	if want := fmt.Sprintf("loc_test.go:%d", wantLine+1); loc.String() != want {
+		t.Errorf("String(): Incorrect result: expected \"%s\", got \"%s\"", want, loc.String())
+	}
+	if s := (Loc{}).String(); s != "" {
+		t.Errorf("String(): Expected \"\" for the zero Loc, got \"%s\"", s)
+	}
+}
+
+func TestLocInLoopCallbacks(t *testing.T) {
+	loc := Loc{File: "/src/mypkg/table_test.go", Line: 42}
+	var names []string
+	notPanicFunc := func(testName string) { names = append(names, testName) }
+	var hookNames []string
+	lr := LoopRunner{BeforeEach: func(testName string) { hookNames = append(hookNames, testName) }}
+	lr.PanicsLoop([]PanicTest{{Name: "with loc", F: func() {}, Loc: loc}, {Name: "without", F: func() {}}},
+		notPanicFunc)
+
+	rt := &testhelptest.RecordingT{}
+	lr.PanicsStrLoop([]PanicStrTest{{Name: "str", F: func() { panic("ppp") }, WantStr: "q", Loc: loc}}, nil,
+		notPanicFunc, NotContainsFuncErrorFactory(rt))
+
+	if want := []string{"with loc (at table_test.go:42)", "without"}; fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("PanicsLoop(): Incorrect callback names: expected\n%#+v\ngot\n%#+v", want, names)
+	}
+	if want := []string{"with loc", "without", "str"}; fmt.Sprint(hookNames) != fmt.Sprint(want) {
+		t.Errorf("PanicsLoop(): Incorrect hook names: expected\n%#+v\ngot\n%#+v", want, hookNames)
+	}
+	want := "Incorrect panic value: expected a string containing\n\"q\"\ngot\n\"ppp\"\n" +
+		"in test 'str (at table_test.go:42)'"
+	if failures := rt.Failures(); len(failures) != 1 || failures[0] != want {
+		t.Errorf("NotContainsFuncErrorFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v", want, failures)
+	}
+}
+
+func TestFailureContextString(t *testing.T) {
+	entry := Loc{File: "/src/mypkg/table_test.go", Line: 12}
+	call := Loc{File: "/src/mypkg/helpers_test.go", Line: 40}
+	tests := []struct {
+		name string
+		fc   FailureContext
+		want string
+	}{
+		{"both", FailureContext{Name: "n", Entry: entry, Call: call}, "n (at table_test.go:12; loop at helpers_test.go:40)"},
+		{"entry only", FailureContext{Name: "n", Entry: entry}, "n (at table_test.go:12)"},
+		{"call only", FailureContext{Name: "n", Call: call}, "n (loop at helpers_test.go:40)"},
+		{"neither", FailureContext{Name: "n"}, "n"},
+	}
+	for _, test := range tests {
+		if s := test.fc.String(); s != test.want {
+			t.Errorf("String(): Incorrect result: expected \"%s\", got \"%s\" in test '%s'", test.want, s, test.name)
+		}
+	}
+}
+
+// runSharedLoop is a stand-in for a helper function shared by several tests; it returns the position of its call to
+// the loop.
+func runSharedLoop(lr *LoopRunner, tests []PanicTest, notPanicFunc func(testName string)) Loc {
+	loc := Here()
+	lr.PanicsLoop(tests, notPanicFunc)
+	return Loc{File: loc.File, Line: loc.Line + 1}
+}
+
+func TestLoopCallLoc(t *testing.T) {
+	entry := Loc{File: "/src/mypkg/table_test.go", Line: 42}
+	table := []PanicTest{{Name: "with loc", F: func() {}, Loc: entry}, {Name: "without", F: func() {}},
+		{Name: "passes", F: func() { panic(1) }}}
+
+	var names []string
+	var contexts []FailureContext
+	lr := &LoopRunner{
+		ShowCallLoc:        true,
+		FailureContextFunc: func(fc FailureContext) { contexts = append(contexts, fc) },
+	}
+	call := runSharedLoop(lr, table, func(testName string) { names = append(names, testName) })
+
+	wantNames := []string{
+		fmt.Sprintf("with loc (at table_test.go:42; loop at %s)", call),
+		fmt.Sprintf("without (loop at %s)", call),
+	}
+	if fmt.Sprint(names) != fmt.Sprint(wantNames) {
+		t.Errorf("PanicsLoop(): Incorrect callback names: expected\n%#+v\ngot\n%#+v", wantNames, names)
+	}
+	wantContexts := []FailureContext{
+		{Name: "with loc", Outcome: OutcomeDidNotPanic, Entry: entry, Call: call},
+		{Name: "without", Outcome: OutcomeDidNotPanic, Call: call},
+	}
+	if fmt.Sprint(contexts) != fmt.Sprint(wantContexts) {
+		t.Errorf("PanicsLoop(): Incorrect failure contexts: expected\n%#+v\ngot\n%#+v", wantContexts, contexts)
+	}
+}
+
+func TestLoopCallLocWithoutShowCallLoc(t *testing.T) {
+	// FailureContextFunc gets the call position even when it isn't added to the names
+	var contexts []FailureContext
+	lr := &LoopRunner{FailureContextFunc: func(fc FailureContext) { contexts = append(contexts, fc) }}
+	var names []string
+	_, file, line, _ := runtime.Caller(0)
+	lr.PanicsLoop([]PanicTest{{Name: "np", F: func() {}}}, func(testName string) { names = append(names, testName) })
+
+	want := Loc{File: file, Line: line + 1}
+	if len(contexts) != 1 || contexts[0].Call != want {
+		t.Errorf("PanicsLoop(): Incorrect failure contexts: expected a call at\n%#+v\ngot\n%#+v", want, contexts)
+	}
+	if want := []string{"np"}; fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("PanicsLoop(): Incorrect callback names: expected\n%#+v\ngot\n%#+v", want, names)
+	}
+}
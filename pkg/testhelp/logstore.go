@@ -0,0 +1,205 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A LogLevel is the severity of a LogEntry.  Adapters for logging libraries map the libraries' own levels onto these.
+type LogLevel int
+
+const (
+	// LogDebug is for debugging messages (including logr's V(1) and above).
+	LogDebug LogLevel = iota - 1
+	// LogInfo is for informational messages; it is the zero value.
+	LogInfo
+	// LogWarn is for warnings.
+	LogWarn
+	// LogError is for errors (including zap's DPanic, Panic, and Fatal levels).
+	LogError
+)
+
+// String returns the name of the level, for use in diagnostic messages.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	case LogError:
+		return "error"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// A LogEntry is a single entry recorded by a LogStore.  Logger is the name of the logger, if the logging library has
+// names, and Fields holds the structured key/value pairs, if it has those.
+type LogEntry struct {
+	Level   LogLevel
+	Logger  string
+	Message string
+	Fields  map[string]interface{}
+}
+
+// String returns a one-line description of the entry, for use in diagnostic messages.
+func (e LogEntry) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s]", e.Level)
+	if e.Logger != "" {
+		fmt.Fprintf(&b, " %s:", e.Logger)
+	}
+	fmt.Fprintf(&b, " %s", e.Message)
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, e.Fields[k])
+	}
+	return b.String()
+}
+
+// mentions returns true if the entry's message or any of its field values contains s.
+func (e LogEntry) mentions(s string) bool {
+	if strings.Contains(e.Message, s) {
+		return true
+	}
+	for _, v := range e.Fields {
+		if strings.Contains(fmt.Sprint(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// A LogStore records log entries in memory, so that tests can check what the code under test logged, whatever
+// logging library it uses.  The zero value is an empty store, ready to use, and a LogStore is safe for concurrent use.
+//
+// Entries get into the store through adapters: StdLogger and Writer for the standard library's log package,
+// SlogHandler for log/slog, and (in the separate github.com/ocsw/go-testhelp/pkg/logtest module, to keep this package
+// free of dependencies) logr and zap.  For example:
+//
+//	var logs testhelp.LogStore
+//	srv := mypkg.NewServer(mypkg.WithLogger(logs.StdLogger(testhelp.LogInfo)))
+//	srv.Handle(badRequest)
+//	logs.AssertLogged(t, testhelp.LogError, "malformed header")
+type LogStore struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// Add records an entry.  It is intended for adapters for logging libraries.
+func (s *LogStore) Add(entry LogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+// Entries returns the entries recorded so far, in order.
+func (s *LogStore) Entries() []LogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]LogEntry{}, s.entries...)
+}
+
+// Reset discards all of the recorded entries.
+func (s *LogStore) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = nil
+}
+
+// Find returns the recorded entries at the given level or above whose message or field values contain substr.
+func (s *LogStore) Find(minLevel LogLevel, substr string) []LogEntry {
+	var found []LogEntry
+	for _, e := range s.Entries() {
+		if e.Level >= minLevel && e.mentions(substr) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// AssertLogged calls t.Errorf, listing the recorded entries, if there is no entry at the given level or above whose
+// message or field values contain substr.  It returns true if there is one.
+func (s *LogStore) AssertLogged(t TestingTB, minLevel LogLevel, substr string) bool {
+	t.Helper()
+	if len(s.Find(minLevel, substr)) > 0 {
+		return true
+	}
+	t.Errorf("Expected a log entry at level %s or above mentioning \"%s\", got:%s", minLevel, substr,
+		describeEntries(s.Entries()))
+	return false
+}
+
+// AssertNotLogged calls t.Errorf, listing the matching entries, if there are any entries at the given level or above
+// whose message or field values contain substr (so with an empty substr, if there are any entries at that level or
+// above at all).  It returns true if there are none.
+func (s *LogStore) AssertNotLogged(t TestingTB, minLevel LogLevel, substr string) bool {
+	t.Helper()
+	found := s.Find(minLevel, substr)
+	if len(found) == 0 {
+		return true
+	}
+	t.Errorf("Expected no log entries at level %s or above mentioning \"%s\", got:%s", minLevel, substr,
+		describeEntries(found))
+	return false
+}
+
+// describeEntries lists entries for failure messages, one per line.
+func describeEntries(entries []LogEntry) string {
+	if len(entries) == 0 {
+		return " (none)"
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString("\n" + e.String())
+	}
+	return b.String()
+}
+
+// logWriter is the io.Writer returned by LogStore.Writer.
+type logWriter struct {
+	store *LogStore
+	level LogLevel
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w.store.Add(LogEntry{Level: w.level, Message: strings.TrimSuffix(string(p), "\n")})
+	return len(p), nil
+}
+
+// Writer returns an io.Writer that records each call to Write as an entry at the given level, with the written text
+// (minus any trailing newline) as the message.  This suits the standard library's log.Logger, which makes one call
+// per message, e.g. with log.SetOutput.
+func (s *LogStore) Writer(level LogLevel) io.Writer {
+	return logWriter{s, level}
+}
+
+// StdLogger returns a *log.Logger, with no prefix or flags, that records each message as an entry at the given level.
+func (s *LogStore) StdLogger(level LogLevel) *log.Logger {
+	return log.New(s.Writer(level), "", 0)
+}
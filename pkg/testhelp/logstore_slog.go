@@ -0,0 +1,109 @@
+//go:build go1.21
+
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler returns a slog.Handler that records each log record in the store.  slog's levels are mapped onto the
+// nearest LogLevel at or below them (so, e.g., slog.LevelWarn+2 is LogWarn), and the record's attributes (including
+// those added with WithAttrs) become the entry's fields, with group names joined to keys with dots.
+//
+// SlogHandler is only available with Go 1.21 or later.
+func (s *LogStore) SlogHandler() slog.Handler {
+	return &slogHandler{store: s}
+}
+
+type slogHandler struct {
+	store  *LogStore
+	attrs  []slog.Attr // already qualified with their groups
+	prefix string      // the current group prefix, e.g. "request."
+}
+
+func (h *slogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := map[string]interface{}{}
+	for _, a := range h.attrs {
+		addSlogAttr(fields, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(fields, h.prefix, a)
+		return true
+	})
+	if len(fields) == 0 {
+		fields = nil
+	}
+	h.store.Add(LogEntry{Level: slogLevel(r.Level), Message: r.Message, Fields: fields})
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, slog.Attr{Key: h.prefix + a.Key, Value: a.Value})
+	}
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// addSlogAttr adds an attribute to fields, flattening groups.
+func addSlogAttr(fields map[string]interface{}, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			addSlogAttr(fields, groupPrefix, ga)
+		}
+		return
+	}
+	if a.Key == "" {
+		return // ignored, per the slog.Handler rules
+	}
+	fields[prefix+a.Key] = v.Any()
+}
+
+// slogLevel maps a slog level onto a LogLevel.
+func slogLevel(l slog.Level) LogLevel {
+	switch {
+	case l >= slog.LevelError:
+		return LogError
+	case l >= slog.LevelWarn:
+		return LogWarn
+	case l >= slog.LevelInfo:
+		return LogInfo
+	}
+	return LogDebug
+}
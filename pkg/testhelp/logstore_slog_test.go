@@ -0,0 +1,46 @@
+//go:build go1.21
+
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestLogStoreSlogHandler(t *testing.T) {
+	var s LogStore
+	logger := slog.New(s.SlogHandler())
+	logger.Debug("dbg")
+	logger.With("svc", "api").WithGroup("req").Warn("slow", "ms", 900, slog.Group("user", "id", 7))
+	logger.Log(context.Background(), slog.LevelError+4, "bad", slog.Group("", "inline", true), "", "ignored")
+	logger.Log(context.Background(), slog.LevelInfo+1, "custom")
+
+	want := []LogEntry{
+		{Level: LogDebug, Message: "dbg"},
+		{Level: LogWarn, Message: "slow",
+			Fields: map[string]interface{}{"svc": "api", "req.ms": int64(900), "req.user.id": int64(7)}},
+		{Level: LogError, Message: "bad", Fields: map[string]interface{}{"inline": true}},
+		{Level: LogInfo, Message: "custom"},
+	}
+	if got := s.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LogStore.SlogHandler(): Incorrect entries: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
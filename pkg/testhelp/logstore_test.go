@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestLogEntryString(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    LogEntry
+		expected string
+	}{
+		{name: "message only", entry: LogEntry{Message: "hi"}, expected: "[info] hi"},
+		{
+			name: "everything",
+			entry: LogEntry{Level: LogError, Logger: "db", Message: "failed",
+				Fields: map[string]interface{}{"table": "users", "attempt": 2}},
+			expected: "[error] db: failed attempt=2 table=users",
+		},
+		{name: "unknown level", entry: LogEntry{Level: 7, Message: "x"}, expected: "[LogLevel(7)] x"},
+	}
+	for _, test := range tests {
+		if got := test.entry.String(); got != test.expected {
+			t.Errorf("LogEntry.String(): Incorrect result: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected,
+				got, test.name)
+		}
+	}
+}
+
+func TestLogStoreStdLogger(t *testing.T) {
+	var s LogStore
+	s.StdLogger(LogWarn).Printf("disk %d%% full", 95)
+	_, _ = s.Writer(LogDebug).Write([]byte("no newline"))
+	want := []LogEntry{{Level: LogWarn, Message: "disk 95% full"}, {Level: LogDebug, Message: "no newline"}}
+	if got := s.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LogStore.StdLogger(): Incorrect entries: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+	s.Reset()
+	if got := s.Entries(); len(got) != 0 {
+		t.Errorf("LogStore.Reset(): Expected no entries, got\n%#+v", got)
+	}
+}
+
+func TestLogStoreAssertions(t *testing.T) {
+	var s LogStore
+	s.Add(LogEntry{Level: LogInfo, Message: "starting"})
+	s.Add(LogEntry{Level: LogError, Message: "request failed", Fields: map[string]interface{}{"path": "/widgets"}})
+
+	tests := []struct {
+		name        string
+		minLevel    LogLevel
+		substr      string
+		expectFound bool
+		expectNot   string
+		expectLog   string
+	}{
+		{
+			name: "in message", minLevel: LogError, substr: "failed", expectFound: true,
+			expectNot: "Expected no log entries at level error or above mentioning \"failed\", got:\n" +
+				"[error] request failed path=/widgets",
+		},
+		{
+			name: "in field", minLevel: LogWarn, substr: "widgets", expectFound: true,
+			expectNot: "Expected no log entries at level warn or above mentioning \"widgets\", got:\n" +
+				"[error] request failed path=/widgets",
+		},
+		{
+			name: "below level", minLevel: LogWarn, substr: "starting",
+			expectLog: "Expected a log entry at level warn or above mentioning \"starting\", got:\n" +
+				"[info] starting\n[error] request failed path=/widgets",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if got := s.AssertLogged(rt, test.minLevel, test.substr); got != test.expectFound {
+			t.Errorf("LogStore.AssertLogged(): Incorrect result: expected %t, got %t in test '%s'", test.expectFound,
+				got, test.name)
+		}
+		want := []string{}
+		if test.expectLog != "" {
+			want = []string{test.expectLog}
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+			t.Errorf("LogStore.AssertLogged(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got,
+				test.name)
+		}
+
+		rt = &testhelptest.RecordingT{}
+		if got := s.AssertNotLogged(rt, test.minLevel, test.substr); got == test.expectFound {
+			t.Errorf("LogStore.AssertNotLogged(): Incorrect result: expected %t, got %t in test '%s'",
+				!test.expectFound, got, test.name)
+		}
+		want = []string{}
+		if test.expectNot != "" {
+			want = []string{test.expectNot}
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+			t.Errorf("LogStore.AssertNotLogged(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want,
+				got, test.name)
+		}
+	}
+
+	rt := &testhelptest.RecordingT{}
+	var empty LogStore
+	empty.AssertLogged(rt, LogDebug, "")
+	want := []string{"Expected a log entry at level debug or above mentioning \"\", got: (none)"}
+	if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LogStore.AssertLogged(): Incorrect failures with an empty store: expected\n%#+v\ngot\n%#+v", want,
+			got)
+	}
+}
@@ -0,0 +1,734 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// An Outcome describes the result of running a single test from a loop's table.
+type Outcome int
+
+const (
+	// OutcomePassed means that the test function did what was expected of it.
+	OutcomePassed Outcome = iota
+	// OutcomeDidNotPanic means that the test function was expected to panic, but did not.
+	OutcomeDidNotPanic
+	// OutcomeUnexpectedPanic means that the test function was expected not to panic, but did.
+	OutcomeUnexpectedPanic
+	// OutcomeWrongPanicValue means that the test function panicked as expected, but the panic value did not contain,
+	// match, or equal what was wanted.
+	OutcomeWrongPanicValue
+	// OutcomeSetupPanicked means that the test's setup function panicked, so the test function was not run.
+	OutcomeSetupPanicked
+	// OutcomeInvalidWant means that the test's wanted string or regular expression was empty or whitespace-only, and
+	// the loop was run with a LoopRunner.InvalidWantFunc.
+	OutcomeInvalidWant
+)
+
+// String returns a short description of the outcome, for use in diagnostic messages.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomePassed:
+		return "passed"
+	case OutcomeDidNotPanic:
+		return "did not panic"
+	case OutcomeUnexpectedPanic:
+		return "unexpected panic"
+	case OutcomeWrongPanicValue:
+		return "wrong panic value"
+	case OutcomeSetupPanicked:
+		return "setup panicked"
+	case OutcomeInvalidWant:
+		return "invalid want"
+	}
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// A LoopRunner runs the same loops as the package-level loop functions (PanicsLoop, PanicsStrLoop, etc.), with
+// additional options set by its fields.  The zero value runs loops exactly as the package-level functions do (which
+// use it themselves), and a LoopRunner can be reused for any number of loops.
+type LoopRunner struct {
+	// BeforeEach, if not nil, is called with the name of each test before it is run.
+	BeforeEach func(testName string)
+	// AfterEach, if not nil, is called with the name and outcome of each test after it is run and any failure
+	// callback has been called.  It is called even if the failure callback stops the test with t.Fatalf, so it can be
+	// used for cleanup.
+	AfterEach func(testName string, outcome Outcome)
+
+	// MaxFailures, if greater than 0, is the maximum number of failure callbacks called in each loop.  Once it is
+	// reached, later failures are only counted, and at the end of the loop, SummaryFunc is called with the number of
+	// failures whose callbacks were skipped.  This keeps a systemic bug that makes every test fail from drowning out
+	// everything else.  (Callbacks for tests that passed, such as the valFunc of PanicsGetLoop, are always called.)
+	MaxFailures int
+	// SummaryFunc, if not nil, is called at the end of each loop in which MaxFailures was exceeded, with the number of
+	// failures whose callbacks were skipped.  See SummaryFuncErrorFactory for a good starting point.
+	SummaryFunc func(suppressed int)
+
+	// ProgressFunc, if not nil, is called before each test is run, with the number of tests in the loop that have
+	// finished so far, the total number of tests in the loop, and the name of the test about to run.  It is called
+	// once more at the end of the loop, with an empty name, and done equal to total (or less, if the loop was stopped
+	// early because of Deadline).  This is intended for showing liveness during long-running loops, and for making it
+	// clear which test was running if the loop hangs; see ProgressFuncLogFactory for a good starting point.
+	ProgressFunc func(done, total int, testName string)
+
+	// Deadline, if not nil, is checked for a go test deadline (from the -timeout flag) before each test is run.  If
+	// the time remaining is less than DeadlineMargin plus the duration of the slowest test run so far in the loop, the
+	// loop stops early, and Deadline's Errorf is called with the number of tests that weren't run.  This gives a clear
+	// report, instead of the whole test binary being killed partway through the loop.  Normally this is set to the
+	// *testing.T that is running the loop.
+	Deadline DeadlineT
+	// DeadlineMargin is the extra time to leave before the deadline when Deadline is set.  If it is 0,
+	// DefaultDeadlineMargin is used.
+	DeadlineMargin time.Duration
+
+	// InvalidWantFunc, if not nil, turns on strict checking of the wanted strings and regular expressions in
+	// PanicsStrLoop, PanicsRELoop, PanicsStrFuncLoop, and PanicsREFuncLoop.  An empty want matches every panic value
+	// that can be cast to a string, so a table entry with a missing want silently passes; in strict mode, any test
+	// whose want is empty or contains only whitespace is treated as a mistake in the table, and InvalidWantFunc is
+	// called with the test's name and the want instead of the usual callbacks.  In PanicsStrLoop and PanicsRELoop,
+	// the test function is not run; in the Func loops, the want is only known after the test function has been run.
+	// See InvalidWantFuncErrorFactory for a good starting point.
+	InvalidWantFunc func(testName string, want string)
+
+	// FlakyFunc, if not nil, is called for each test that failed at least once but then passed on a retry (see the
+	// Retries field of the table types), with the name of the test (as for the failure callbacks) and the number of
+	// failed attempts.  This keeps flakiness visible instead of being hidden by the retries; see FlakyFuncLogFactory
+	// for a good starting point.
+	FlakyFunc func(testName string, failedAttempts int)
+
+	// Report, if not nil, gets a record of each test run, with its outcome and duration.
+	Report *Report
+
+	// SlowestFunc, if not nil, is called at the end of each loop with the SlowestN slowest tests in the loop, slowest
+	// first (or all of them, if SlowestN is 0 or less).  The duration of each test includes its callbacks and any
+	// retries.  This helps find which entries dominate the run time of a large table; see SlowestFuncLogFactory for a
+	// good starting point.  (The durations are also recorded in Report, if it's set; see also Report.Slowest.)
+	SlowestFunc func(slowest []CaseDuration)
+	// SlowestN is the number of tests passed to SlowestFunc.
+	SlowestN int
+
+	// CrashT, if not nil, turns on crash artifacts: for each unexpected panic reported by NotPanicsLoop,
+	// NotPanicsGetLoop, NotPanicsSetupLoop, or their report versions, a CrashArtifact is written to the directory
+	// named by the environment variable CrashDirEnvVar (if it's set), just before the failure callback is called.
+	// The path of each artifact is logged with CrashT.Logf, and errors writing them are reported with CrashT.Errorf.
+	// Normally this is set to the *testing.T that is running the loop.
+	CrashT TestingTB
+	// Seed, if not nil, is the random seed used to generate the table, which is recorded in crash artifacts so that
+	// the table can be regenerated to replay a failure.
+	Seed *int64
+
+	// Eq, if not nil, is used by PanicsValLoop and PanicsValReportLoop to compare the panic values with the wanted
+	// values, as in PanicsValEq, instead of ==.
+	Eq func(a, b interface{}) bool
+
+	// ShowCallLoc adds the position of the code that called the loop to the names passed to the failure callbacks
+	// (and to FlakyFunc and SlowestFunc), as in "name (at table_test.go:12; loop at helpers_test.go:40)".  This is
+	// useful when the callbacks are shared by several loops, or come from a shared helper function, in which case the
+	// position that go test gives for the failure message doesn't say which loop the test was in.
+	ShowCallLoc bool
+	// FailureContextFunc, if not nil, is called for each failed test, just before its failure callback (and not if
+	// the callback is skipped because of MaxFailures), with the test's name, outcome, and positions.  This is for
+	// callers that want to report the positions in their own format, instead of as part of the name.
+	FailureContextFunc func(fc FailureContext)
+}
+
+// A FailureContext describes a failed test from a loop, for LoopRunner.FailureContextFunc.  Name is the plain name of
+// the test; Entry is the position of its table entry, if it has a Loc; and Call is the position of the code that
+// called the loop (the first caller outside of this package), if it could be found.
+type FailureContext struct {
+	Name    string
+	Outcome Outcome
+	Entry   Loc
+	Call    Loc
+}
+
+// String returns the name of the test with the known positions, as in "name (at table_test.go:12; loop at
+// helpers_test.go:40)".
+func (fc FailureContext) String() string {
+	var locs []string
+	if fc.Entry.File != "" {
+		locs = append(locs, "at "+fc.Entry.String())
+	}
+	if fc.Call.File != "" {
+		locs = append(locs, "loop at "+fc.Call.String())
+	}
+	if len(locs) == 0 {
+		return fc.Name
+	}
+	return fmt.Sprintf("%s (%s)", fc.Name, strings.Join(locs, "; "))
+}
+
+// A CaseDuration is the time taken by a single test from a table.  Name is the name of the test, as passed to the
+// failure callbacks.
+type CaseDuration struct {
+	Name     string
+	Duration time.Duration
+}
+
+// DefaultDeadlineMargin is the default for LoopRunner.DeadlineMargin.
+const DefaultDeadlineMargin = time.Second
+
+// DeadlineT is a stub interface intended to be satisfied by a *testing.T.  It extends TestingT with the Deadline
+// method, for use by LoopRunner.
+type DeadlineT interface {
+	TestingT
+	Deadline() (deadline time.Time, ok bool)
+}
+
+// SummaryFuncErrorFactory returns a function suitable for use as a LoopRunner's SummaryFunc.  The returned function is
+// a closure over a *testing.T which uses it to call Errorf with a generic informative message.
+func SummaryFuncErrorFactory(t TestingT) func(suppressed int) {
+	return func(suppressed int) {
+		t.Errorf("%s... and %d more similar failures", failureToken(categorySummary, ""), suppressed)
+	}
+}
+
+// ProgressFuncLogFactory returns a function suitable for use as a LoopRunner's ProgressFunc.  The returned function
+// is a closure over a *testing.T which uses it to call Logf with a progress message before every nth test (counting
+// from the first), and at the end of the loop.  (The messages are only shown in verbose mode, or if the test fails.)
+//
+// ProgressFuncLogFactory panics if every is less than 1.
+func ProgressFuncLogFactory(t TestingTB, every int) func(done, total int, testName string) {
+	if every < 1 {
+		panic(fmt.Sprintf("Invalid progress interval: %d", every))
+	}
+	return func(done, total int, testName string) {
+		switch {
+		case done == total:
+			t.Logf("Finished %d/%d tests", done, total)
+		case done%every == 0:
+			t.Logf("Running test %d/%d: '%s'", done+1, total, testName)
+		}
+	}
+}
+
+// SlowestFuncLogFactory returns a function suitable for use as a LoopRunner's SlowestFunc.  The returned function is
+// a closure over a *testing.T which uses it to call Logf with a list of the tests and their durations.  (The messages
+// are only shown in verbose mode, or if the test fails.)
+func SlowestFuncLogFactory(t TestingTB) func(slowest []CaseDuration) {
+	return func(slowest []CaseDuration) {
+		var b strings.Builder
+		for _, cd := range slowest {
+			fmt.Fprintf(&b, "\n%10s  %s", cd.Duration.Round(time.Microsecond), cd.Name)
+		}
+		t.Logf("Slowest tests:%s", b.String())
+	}
+}
+
+// FlakyFuncLogFactory returns a function suitable for use as a LoopRunner's FlakyFunc.  The returned function is a
+// closure over a *testing.T which uses it to call Logf with a generic informative message.  (The messages are only
+// shown in verbose mode, or if the test fails.)
+func FlakyFuncLogFactory(t TestingTB) func(testName string, failedAttempts int) {
+	return func(testName string, failedAttempts int) {
+		t.Logf("Flaky test '%s' passed after %d failed attempt(s)", testName, failedAttempts)
+	}
+}
+
+// FlakyFuncErrorFactory returns a function suitable for use as a LoopRunner's FlakyFunc.  The returned function is a
+// closure over a *testing.T which uses it to call Errorf with a generic informative message, for suites in which
+// flakiness should fail the test even though the retries passed.
+func FlakyFuncErrorFactory(t TestingT) func(testName string, failedAttempts int) {
+	return func(testName string, failedAttempts int) {
+		t.Errorf("%sFlaky test '%s' passed after %d failed attempt(s)", failureToken(categoryFlaky, testName),
+			testName, failedAttempts)
+	}
+}
+
+// InvalidWantFuncErrorFactory returns a function suitable for use as a LoopRunner's InvalidWantFunc.  The returned
+// function is a closure over a *testing.T which uses it to call Errorf with a generic informative message.
+func InvalidWantFuncErrorFactory(t TestingT) func(testName string, want string) {
+	return func(testName string, want string) {
+		t.Errorf("%sInvalid test table: empty or whitespace-only want %q in test '%s'",
+			failureToken(categoryInvalidWant, testName), want, testName)
+	}
+}
+
+// InvalidWantFuncFatalFactory returns a function suitable for use as a LoopRunner's InvalidWantFunc.  The returned
+// function is a closure over a *testing.T which uses it to call Fatalf with a generic informative message.
+func InvalidWantFuncFatalFactory(t TestingT) func(testName string, want string) {
+	return func(testName string, want string) {
+		t.Fatalf("%sInvalid test table: empty or whitespace-only want %q in test '%s'",
+			failureToken(categoryInvalidWant, testName), want, testName)
+	}
+}
+
+// invalidWant returns true if the runner is in strict mode (see InvalidWantFunc) and want is not acceptable.
+func (lr *LoopRunner) invalidWant(want string) bool {
+	return lr.InvalidWantFunc != nil && strings.TrimSpace(want) == ""
+}
+
+// A loopCase is a single test from a loop's table, in the form used by LoopRunner.run.  The run function carries out
+// the test once, and returns the outcome and the callback to call as a result (or nil, if there isn't one); name is
+// the name to pass to the callback.  If the test fails, it is run up to retries more times.
+type loopCase struct {
+	name    string
+	loc     Loc
+	retries int
+	run     func(name string) (outcome Outcome, callback func())
+}
+
+// run is the engine used by all of the loops: it runs each case in turn, calling the callbacks and hooks.
+func (lr *LoopRunner) run(cases []loopCase) {
+	var call Loc
+	if lr.ShowCallLoc || lr.FailureContextFunc != nil {
+		call = callSite()
+	}
+	failures := 0
+	done := len(cases)
+	var slowest time.Duration
+	var timings []CaseDuration
+	for i, c := range cases {
+		if lr.pastDeadline(slowest) {
+			lr.Deadline.Errorf("Stopped %d of %d tests before the go test deadline; the first test not run was '%s'",
+				len(cases)-i, len(cases), c.name)
+			done = i
+			break // still do the end-of-loop reporting
+		}
+		if lr.ProgressFunc != nil {
+			lr.ProgressFunc(i, len(cases), c.name)
+		}
+		suppress := lr.MaxFailures > 0 && failures >= lr.MaxFailures
+		start := time.Now()
+		if lr.runOne(c, call, suppress) != OutcomePassed {
+			failures++
+		}
+		elapsed := time.Since(start)
+		if elapsed > slowest {
+			slowest = elapsed
+		}
+		if lr.SlowestFunc != nil {
+			timings = append(timings, CaseDuration{lr.callbackName(c, call), elapsed})
+		}
+	}
+	if lr.ProgressFunc != nil {
+		lr.ProgressFunc(done, len(cases), "")
+	}
+	if suppressed := failures - lr.MaxFailures; lr.MaxFailures > 0 && suppressed > 0 && lr.SummaryFunc != nil {
+		lr.SummaryFunc(suppressed)
+	}
+	lr.reportSlowest(timings)
+}
+
+// reportSlowest calls SlowestFunc, if it's set, with the slowest of the timings.
+func (lr *LoopRunner) reportSlowest(timings []CaseDuration) {
+	if lr.SlowestFunc == nil {
+		return
+	}
+	sort.SliceStable(timings, func(i, j int) bool { return timings[i].Duration > timings[j].Duration })
+	if lr.SlowestN > 0 && len(timings) > lr.SlowestN {
+		timings = timings[:lr.SlowestN]
+	}
+	lr.SlowestFunc(timings)
+}
+
+// loopReportCase returns the record of a test for a Report.
+func loopReportCase(name string, outcome Outcome, duration time.Duration) ReportCase {
+	c := ReportCase{Name: name, Outcome: ReportPassed, Duration: duration}
+	if outcome != OutcomePassed {
+		c.Outcome = ReportFailed
+		c.Messages = []string{outcome.String()}
+	}
+	return c
+}
+
+// pastDeadline returns true if there is a deadline to check, and it is too close to run another test that might take
+// as long as slowest.
+func (lr *LoopRunner) pastDeadline(slowest time.Duration) bool {
+	if lr.Deadline == nil {
+		return false
+	}
+	deadline, ok := lr.Deadline.Deadline()
+	if !ok {
+		return false
+	}
+	margin := lr.DeadlineMargin
+	if margin == 0 {
+		margin = DefaultDeadlineMargin
+	}
+	return time.Until(deadline) < margin+slowest
+}
+
+// callbackName returns the name of a case as passed to the callbacks; call is the position of the code that called
+// the loop.
+func (lr *LoopRunner) callbackName(c loopCase, call Loc) string {
+	if lr.ShowCallLoc {
+		return FailureContext{Name: c.name, Entry: c.loc, Call: call}.String()
+	}
+	return entryName(c.name, c.loc)
+}
+
+// runOne runs a single case, including the hooks.  call is the position of the code that called the loop.  If
+// suppress is true, the case's callback is not called if the case failed.
+func (lr *LoopRunner) runOne(c loopCase, call Loc, suppress bool) (outcome Outcome) {
+	if lr.BeforeEach != nil {
+		lr.BeforeEach(c.name)
+	}
+
+	ran := false
+	start := time.Now()
+	defer func() {
+		// Only if the test itself didn't panic (e.g. with a bad RE), but including if the callback calls
+		// runtime.Goexit (e.g. via t.Fatalf)
+		if !ran {
+			return
+		}
+		if lr.Report != nil {
+			lr.Report.add(loopReportCase(c.name, outcome, time.Since(start)))
+		}
+		if lr.AfterEach != nil {
+			lr.AfterEach(c.name, outcome)
+		}
+	}()
+
+	name := lr.callbackName(c, call)
+	var callback func()
+	outcome, callback = c.run(name)
+	failedAttempts := 0
+	for outcome != OutcomePassed && failedAttempts < c.retries {
+		failedAttempts++
+		outcome, callback = c.run(name)
+	}
+	ran = true
+	if outcome == OutcomePassed && failedAttempts > 0 && lr.FlakyFunc != nil {
+		lr.FlakyFunc(name, failedAttempts)
+	}
+	if outcome != OutcomePassed && !suppress && lr.FailureContextFunc != nil {
+		lr.FailureContextFunc(FailureContext{Name: c.name, Outcome: outcome, Entry: c.loc, Call: call})
+	}
+	if callback != nil && !(suppress && outcome != OutcomePassed) {
+		callback()
+	}
+	return outcome
+}
+
+// PanicsLoop is like the package-level PanicsLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsLoop(tests []PanicTest, elseFunc func(testName string)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			if !Panics(test.F) {
+				return OutcomeDidNotPanic, func() { elseFunc(name) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsGetLoop is like the package-level PanicsGetLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsGetLoop(tests []PanicTest, elseFunc func(testName string),
+	valFunc func(pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal := PanicsGet(test.F)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { elseFunc(name) }
+			}
+			return OutcomePassed, func() { valFunc(pVal) }
+		}})
+	}
+	lr.run(cases)
+}
+
+// NotPanicsLoop is like the package-level NotPanicsLoop, but with the runner's options.
+func (lr *LoopRunner) NotPanicsLoop(tests []PanicTest, elseFunc func(testName string)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			if didPanic, pVal, stack := panicsWithStack(test.F); didPanic {
+				return OutcomeUnexpectedPanic, func() {
+					lr.saveCrash(lr.newCrashArtifact(name, pVal, stack))
+					elseFunc(name)
+				}
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// NotPanicsGetLoop is like the package-level NotPanicsGetLoop, but with the runner's options.
+func (lr *LoopRunner) NotPanicsGetLoop(tests []PanicTest, elseFunc func(testName string, pVal interface{})) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			if didPanic, pVal, stack := panicsWithStack(test.F); didPanic {
+				return OutcomeUnexpectedPanic, func() {
+					lr.saveCrash(lr.newCrashArtifact(name, pVal, stack))
+					elseFunc(name, pVal)
+				}
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsStrLoop is like the package-level PanicsStrLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsStrLoop(tests []PanicStrTest, wantStrAll *string, notPanicFunc func(testName string),
+	notContainsFunc func(testName string, wantStr string, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			wantStr := test.WantStr
+			if wantStrAll != nil {
+				wantStr = *wantStrAll
+			}
+			if lr.invalidWant(wantStr) {
+				return OutcomeInvalidWant, func() { lr.InvalidWantFunc(name, wantStr) }
+			}
+			didPanic, pContainsStr, pVal := PanicsStr(test.F, wantStr)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			} else if !pContainsStr {
+				return OutcomeWrongPanicValue, func() { notContainsFunc(name, wantStr, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsRELoop is like the package-level PanicsRELoop, but with the runner's options.
+func (lr *LoopRunner) PanicsRELoop(tests []PanicRETest, wantREAll *string, notPanicFunc func(testName string),
+	notMatchesFunc func(testName string, wantRE string, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			wantRE := test.WantRE
+			if wantREAll != nil {
+				wantRE = *wantREAll
+			}
+			if lr.invalidWant(wantRE) {
+				return OutcomeInvalidWant, func() { lr.InvalidWantFunc(name, wantRE) }
+			}
+			didPanic, pMatchesRE, pVal := PanicsRE(test.F, wantRE)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			} else if !pMatchesRE {
+				return OutcomeWrongPanicValue, func() { notMatchesFunc(name, wantRE, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsValLoop is like the package-level PanicsValLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsValLoop(tests []PanicValTest, wantValAll *interface{}, notPanicFunc func(testName string),
+	notEqualsFunc func(testName string, wantVal interface{}, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			wantVal := test.WantVal
+			if wantValAll != nil {
+				wantVal = *wantValAll
+			}
+			didPanic, pEquals, pVal := PanicsValEq(test.F, wantVal, lr.Eq)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			} else if !pEquals {
+				return OutcomeWrongPanicValue, func() { notEqualsFunc(name, wantVal, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsRuntimeLoop is like the package-level PanicsRuntimeLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsRuntimeLoop(tests []PanicRuntimeTest, notPanicFunc func(testName string),
+	wrongKindFunc func(testName string, wantKind PanicKind, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal := PanicsGet(test.F)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			} else if ClassifyPanic(pVal) != test.WantKind {
+				return OutcomeWrongPanicValue, func() { wrongKindFunc(name, test.WantKind, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsStrFuncLoop is like the package-level PanicsStrFuncLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsStrFuncLoop(tests []PanicStrFuncTest, notPanicFunc func(testName string),
+	notContainsFunc func(testName string, wantStr string, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal := PanicsGet(test.F)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			}
+			wantStr := test.WantStrFunc()
+			if lr.invalidWant(wantStr) {
+				return OutcomeInvalidWant, func() { lr.InvalidWantFunc(name, wantStr) }
+			}
+			if !Contains(wantStr).Match(pVal) {
+				return OutcomeWrongPanicValue, func() { notContainsFunc(name, wantStr, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsREFuncLoop is like the package-level PanicsREFuncLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsREFuncLoop(tests []PanicREFuncTest, notPanicFunc func(testName string),
+	notMatchesFunc func(testName string, wantRE string, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal := PanicsGet(test.F)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			}
+			wantRE := test.WantREFunc()
+			if lr.invalidWant(wantRE) {
+				return OutcomeInvalidWant, func() { lr.InvalidWantFunc(name, wantRE) }
+			}
+			if !Regexp(wantRE).Match(pVal) {
+				return OutcomeWrongPanicValue, func() { notMatchesFunc(name, wantRE, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsMatchingLoop is like the package-level PanicsMatchingLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsMatchingLoop(tests []PanicMatchingTest, notPanicFunc func(testName string),
+	noMatchFunc func(testName string, matcher PanicMatcher, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal := PanicsGet(test.F)
+			if !didPanic {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			}
+			if matcher := firstMismatch(pVal, test.Matchers); matcher != nil {
+				return OutcomeWrongPanicValue, func() { noMatchFunc(name, matcher, pVal) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// CaptureLoop is like the package-level CaptureLoop, but with the runner's options.  Since the tests have no
+// expectations, their outcomes (as passed to AfterEach) are always OutcomePassed.  If the loop is stopped early because
+// of Deadline, only the tests that were run are included in the results.
+func (lr *LoopRunner) CaptureLoop(tests []PanicTest) []CapturedPanic {
+	results := make([]CapturedPanic, 0, len(tests))
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			c := Capture(test.F)
+			c.Name = test.Name
+			results = append(results, c)
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+	return results
+}
+
+// PanicsSetupLoop is like the package-level PanicsSetupLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsSetupLoop(tests []PanicSetupTest, notPanicFunc func(testName string),
+	setupPanicFunc func(testName string, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			setupVal, setupPVal := runSetup(test)
+			if setupPVal != nil {
+				return OutcomeSetupPanicked, func() { setupPanicFunc(name, setupPVal) }
+			}
+			if !Panics(func() { test.F(setupVal) }) {
+				return OutcomeDidNotPanic, func() { notPanicFunc(name) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// NotPanicsSetupLoop is like the package-level NotPanicsSetupLoop, but with the runner's options.
+func (lr *LoopRunner) NotPanicsSetupLoop(tests []PanicSetupTest, setupPanicFunc func(testName string, pVal interface{}),
+	elseFunc func(testName string, pVal interface{}),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			setupVal, setupPVal := runSetup(test)
+			if setupPVal != nil {
+				return OutcomeSetupPanicked, func() { setupPanicFunc(name, setupPVal) }
+			}
+			if didPanic, pVal, stack := panicsWithStack(func() { test.F(setupVal) }); didPanic {
+				return OutcomeUnexpectedPanic, func() {
+					a := lr.newCrashArtifact(name, pVal, stack)
+					a.Input = Format(setupVal)
+					lr.saveCrash(a)
+					elseFunc(name, pVal)
+				}
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// runSetup calls the setup function of a PanicSetupTest, if there is one, and returns its result, or the panic value
+// if it panics.
+func runSetup(test PanicSetupTest) (setupVal interface{}, pVal interface{}) {
+	if test.Setup == nil {
+		return nil, nil
+	}
+	_, pVal = PanicsGet(func() { setupVal = test.Setup() })
+	return setupVal, pVal
+}
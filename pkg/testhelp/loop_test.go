@@ -0,0 +1,532 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestOutcomeString(t *testing.T) {
+	tests := []struct {
+		outcome Outcome
+		want    string
+	}{
+		{OutcomePassed, "passed"},
+		{OutcomeDidNotPanic, "did not panic"},
+		{OutcomeUnexpectedPanic, "unexpected panic"},
+		{OutcomeWrongPanicValue, "wrong panic value"},
+		{OutcomeSetupPanicked, "setup panicked"},
+		{OutcomeInvalidWant, "invalid want"},
+		{Outcome(99), "Outcome(99)"},
+	}
+	for _, test := range tests {
+		if got := test.outcome.String(); got != test.want {
+			t.Errorf("String(): Incorrect description: expected \"%s\", got \"%s\"", test.want, got)
+		}
+	}
+}
+
+// Tests BeforeEach and AfterEach across the different kinds of loops
+func TestLoopRunnerHooks(t *testing.T) {
+	var events []string
+	lr := LoopRunner{
+		BeforeEach: func(testName string) { events = append(events, "before "+testName) },
+		AfterEach: func(testName string, outcome Outcome) {
+			events = append(events, "after "+testName+": "+outcome.String())
+		},
+	}
+	callback := func(kind string) func(testName string) {
+		return func(testName string) { events = append(events, kind+" "+testName) }
+	}
+	valCallback := func(testName string, _ string, _ interface{}) { events = append(events, "value "+testName) }
+	setupCallback := func(testName string, _ interface{}) { events = append(events, "setup "+testName) }
+
+	tests := []struct {
+		name       string
+		f          func()
+		wantEvents []string
+	}{
+		{
+			"PanicsLoop", func() {
+				lr.PanicsLoop([]PanicTest{{Name: "p", F: func() { panic(1) }}, {Name: "np", F: func() {}}}, callback("no panic"))
+			},
+			[]string{"before p", "after p: passed", "before np", "no panic np", "after np: did not panic"},
+		},
+		{
+			"NotPanicsLoop", func() {
+				lr.NotPanicsLoop([]PanicTest{{Name: "p", F: func() { panic(1) }}}, callback("panic"))
+			},
+			[]string{"before p", "panic p", "after p: unexpected panic"},
+		},
+		{
+			"PanicsStrLoop", func() {
+				lr.PanicsStrLoop([]PanicStrTest{{Name: "ncm", F: func() { panic("ppp") }, WantStr: "zzz"}}, nil,
+					callback("no panic"), valCallback)
+			},
+			[]string{"before ncm", "value ncm", "after ncm: wrong panic value"},
+		},
+		{
+			"PanicsSetupLoop", func() {
+				lr.PanicsSetupLoop([]PanicSetupTest{
+					{Name: "sp", Setup: func() interface{} { panic(1) }, F: func(interface{}) {}},
+				}, callback("no panic"), setupCallback)
+			},
+			[]string{"before sp", "setup sp", "after sp: setup panicked"},
+		},
+	}
+	for _, test := range tests {
+		events = nil
+		test.f()
+		if !reflect.DeepEqual(events, test.wantEvents) {
+			t.Errorf("Incorrect hook events: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantEvents, events,
+				test.name)
+		}
+	}
+}
+
+func TestLoopRunnerAfterEachRunsAfterGoexit(t *testing.T) {
+	var after []string
+	lr := LoopRunner{
+		AfterEach: func(testName string, outcome Outcome) { after = append(after, testName) },
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Like a callback that calls t.Fatalf
+		lr.PanicsLoop([]PanicTest{{Name: "np", F: func() {}}}, func(string) { runtime.Goexit() })
+	}()
+	<-done
+
+	if len(after) != 1 || after[0] != "np" {
+		t.Errorf("AfterEach not called after the callback stopped the goroutine: got\n%#+v", after)
+	}
+}
+
+func TestLoopRunnerAfterEachSkippedWhenLoopPanics(t *testing.T) {
+	afterCalled := false
+	lr := LoopRunner{
+		AfterEach: func(string, Outcome) { afterCalled = true },
+	}
+	didPanic := Panics(func() {
+		lr.PanicsRELoop([]PanicRETest{{Name: "bad RE", F: func() { panic("ppp") }, WantRE: "[a-z"}}, nil, func(string) {},
+			func(string, string, interface{}) {})
+	})
+	if !didPanic {
+		t.Errorf("Expected PanicsRELoop() to panic with a bad regexp")
+	}
+	if afterCalled {
+		t.Errorf("AfterEach called for a test that panicked inside the loop")
+	}
+}
+
+func TestLoopRunnerMaxFailures(t *testing.T) {
+	var noPanic []string
+	var summaries []int
+	notPanicFunc := func(testName string) { noPanic = append(noPanic, testName) }
+	table := []PanicTest{
+		{Name: "np1", F: func() {}},
+		{Name: "p", F: func() { panic(1) }},
+		{Name: "np2", F: func() {}},
+		{Name: "np3", F: func() {}},
+		{Name: "np4", F: func() {}},
+	}
+
+	tests := []struct {
+		name          string
+		maxFailures   int
+		wantNoPanic   []string
+		wantSummaries []int
+	}{
+		{"unlimited", 0, []string{"np1", "np2", "np3", "np4"}, nil},
+		{"limit 2", 2, []string{"np1", "np2"}, []int{2}},
+		{"limit 3", 3, []string{"np1", "np2", "np3"}, []int{1}},
+		{"limit not reached", 4, []string{"np1", "np2", "np3", "np4"}, nil},
+	}
+	for _, test := range tests {
+		noPanic = nil
+		summaries = nil
+		lr := LoopRunner{
+			MaxFailures: test.maxFailures,
+			SummaryFunc: func(suppressed int) { summaries = append(summaries, suppressed) },
+		}
+		lr.PanicsLoop(table, notPanicFunc)
+		if !reflect.DeepEqual(noPanic, test.wantNoPanic) {
+			t.Errorf("PanicsLoop(): Wrong panic-test failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantNoPanic, noPanic, test.name)
+		}
+		if !reflect.DeepEqual(summaries, test.wantSummaries) {
+			t.Errorf("PanicsLoop(): Wrong summaries: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantSummaries, summaries, test.name)
+		}
+	}
+}
+
+func TestLoopRunnerMaxFailuresKeepsPassingCallbacks(t *testing.T) {
+	var pVals []interface{}
+	lr := LoopRunner{MaxFailures: 1}
+	lr.PanicsGetLoop([]PanicTest{
+		{Name: "np1", F: func() {}},
+		{Name: "np2", F: func() {}},
+		{Name: "p", F: func() { panic(1) }},
+	}, func(string) {}, func(pVal interface{}) { pVals = append(pVals, pVal) })
+	if len(pVals) != 1 || pVals[0] != 1 {
+		t.Errorf("PanicsGetLoop(): valFunc not called after MaxFailures was reached: got\n%#+v", pVals)
+	}
+}
+
+func TestSummaryFuncErrorFactory(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	SummaryFuncErrorFactory(rt)(3)
+	want := "... and 3 more similar failures"
+	if failures := rt.Failures(); len(failures) != 1 || failures[0] != want {
+		t.Errorf("SummaryFuncErrorFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v", []string{want}, failures)
+	}
+}
+
+func TestLoopRunnerProgressFunc(t *testing.T) {
+	type progress struct {
+		done, total int
+		name        string
+	}
+	var got []progress
+	lr := LoopRunner{
+		ProgressFunc: func(done, total int, testName string) { got = append(got, progress{done, total, testName}) },
+	}
+	lr.NotPanicsLoop([]PanicTest{{Name: "a", F: func() {}}, {Name: "b", F: func() {}}}, func(string) {})
+	want := []progress{{0, 2, "a"}, {1, 2, "b"}, {2, 2, ""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NotPanicsLoop(): Wrong progress calls: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
+
+func TestProgressFuncLogFactory(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	lr := LoopRunner{ProgressFunc: ProgressFuncLogFactory(rt, 2)}
+	lr.NotPanicsLoop([]PanicTest{{Name: "a", F: func() {}}, {Name: "b", F: func() {}}, {Name: "c", F: func() {}}},
+		func(string) {})
+	want := []string{"Running test 1/3: 'a'", "Running test 3/3: 'c'", "Finished 3/3 tests"}
+	if !reflect.DeepEqual(rt.Logs, want) {
+		t.Errorf("ProgressFuncLogFactory(): Wrong log messages: expected\n%#+v\ngot\n%#+v", want, rt.Logs)
+	}
+
+	if !Panics(func() { ProgressFuncLogFactory(rt, 0) }) {
+		t.Errorf("Expected ProgressFuncLogFactory() to panic with an interval of 0")
+	}
+}
+
+type deadlineTMock struct {
+	testhelptest.RecordingT
+	deadline time.Time
+	ok       bool
+}
+
+func (d *deadlineTMock) Deadline() (time.Time, bool) {
+	return d.deadline, d.ok
+}
+
+func TestLoopRunnerDeadline(t *testing.T) {
+	sleepy := func() { time.Sleep(100 * time.Millisecond) }
+	table := []PanicTest{{Name: "a", F: sleepy}, {Name: "b", F: func() {}}, {Name: "c", F: func() {}}}
+
+	tests := []struct {
+		name      string
+		deadline  time.Duration // from now
+		ok        bool
+		margin    time.Duration
+		wantRun   []string
+		wantError string
+	}{
+		{"no deadline", 0, false, 0, []string{"a", "b", "c"}, ""},
+		{"far deadline", time.Hour, true, 0, []string{"a", "b", "c"}, ""},
+		{
+			"within default margin", 500 * time.Millisecond, true, 0, nil,
+			"Stopped 3 of 3 tests before the go test deadline; the first test not run was 'a'",
+		},
+		{
+			// After 'a', there isn't enough time left for another test as slow as 'a'
+			"slow test", 150 * time.Millisecond, true, time.Millisecond, []string{"a"},
+			"Stopped 2 of 3 tests before the go test deadline; the first test not run was 'b'",
+		},
+	}
+	for _, test := range tests {
+		var run []string
+		dt := &deadlineTMock{deadline: time.Now().Add(test.deadline), ok: test.ok}
+		lr := LoopRunner{
+			BeforeEach:     func(testName string) { run = append(run, testName) },
+			Deadline:       dt,
+			DeadlineMargin: test.margin,
+		}
+		lr.NotPanicsLoop(table, func(string) {})
+		if !reflect.DeepEqual(run, test.wantRun) {
+			t.Errorf("NotPanicsLoop(): Wrong tests run: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantRun, run, test.name)
+		}
+		failures := dt.Failures()
+		if test.wantError == "" {
+			if len(failures) != 0 {
+				t.Errorf("NotPanicsLoop(): Unexpected failure(s) in test '%s':\n%#+v", test.name, failures)
+			}
+		} else if len(failures) != 1 || failures[0] != test.wantError {
+			t.Errorf("NotPanicsLoop(): Incorrect failure(s): expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				[]string{test.wantError}, failures, test.name)
+		}
+	}
+}
+
+func TestLoopRunnerDeadlineReporting(t *testing.T) {
+	// The loop stops before 'c', after two failures; the end-of-loop reporting should still happen
+	table := []PanicTest{
+		{Name: "a", F: func() { panic("a") }},
+		{Name: "b", F: func() { time.Sleep(100 * time.Millisecond); panic("b") }},
+		{Name: "c", F: func() {}},
+	}
+	type progressCall struct {
+		done, total int
+		testName    string
+	}
+	var progress []progressCall
+	var suppressed []int
+	dt := &deadlineTMock{deadline: time.Now().Add(150 * time.Millisecond), ok: true}
+	lr := LoopRunner{
+		MaxFailures: 1,
+		SummaryFunc: func(n int) { suppressed = append(suppressed, n) },
+		ProgressFunc: func(done, total int, testName string) {
+			progress = append(progress, progressCall{done, total, testName})
+		},
+		Deadline:       dt,
+		DeadlineMargin: time.Millisecond,
+	}
+	lr.NotPanicsLoop(table, func(string) {})
+
+	wantProgress := []progressCall{{0, 3, "a"}, {1, 3, "b"}, {2, 3, ""}}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Errorf("NotPanicsLoop(): Incorrect progress calls: expected\n%#+v\ngot\n%#+v", wantProgress, progress)
+	}
+	if !reflect.DeepEqual(suppressed, []int{1}) {
+		t.Errorf("NotPanicsLoop(): Incorrect SummaryFunc calls: expected\n%#+v\ngot\n%#+v", []int{1}, suppressed)
+	}
+	if failures := dt.Failures(); len(failures) != 1 {
+		t.Errorf("NotPanicsLoop(): Incorrect failures: expected one for the deadline, got\n%#+v", failures)
+	}
+}
+
+func TestLoopRunnerInvalidWantFunc(t *testing.T) {
+	var invalid, noPanic, wrongVal []string
+	var ran []string
+	lr := LoopRunner{
+		InvalidWantFunc: func(testName string, want string) { invalid = append(invalid, testName+": "+want) },
+		AfterEach: func(testName string, outcome Outcome) {
+			if outcome == OutcomeInvalidWant {
+				ran = append(ran, testName)
+			}
+		},
+	}
+	notPanicFunc := func(testName string) { noPanic = append(noPanic, testName) }
+	wrongValFunc := func(testName string, _ string, _ interface{}) { wrongVal = append(wrongVal, testName) }
+	fCalled := false
+	f := func() { fCalled = true; panic("ppp") }
+
+	lr.PanicsStrLoop([]PanicStrTest{
+		{Name: "str empty", F: f, WantStr: ""},
+		{Name: "str spaces", F: f, WantStr: " \t"},
+		{Name: "str ok", F: func() { panic("ppp") }, WantStr: "p"},
+	}, nil, notPanicFunc, wrongValFunc)
+	if fCalled {
+		t.Errorf("PanicsStrLoop(): Test function run despite an invalid want")
+	}
+	blank := ""
+	lr.PanicsRELoop([]PanicRETest{{Name: "re all", F: f, WantRE: "p"}}, &blank, notPanicFunc, wrongValFunc)
+	lr.PanicsStrFuncLoop([]PanicStrFuncTest{
+		{Name: "str func", F: func() { panic("ppp") }, WantStrFunc: func() string { return "" }},
+	}, notPanicFunc, wrongValFunc)
+	lr.PanicsREFuncLoop([]PanicREFuncTest{
+		{Name: "re func", F: func() { panic("ppp") }, WantREFunc: func() string { return "\n" }},
+	}, notPanicFunc, wrongValFunc)
+
+	wantInvalid := []string{"str empty: ", "str spaces:  \t", "re all: ", "str func: ", "re func: \n"}
+	if !reflect.DeepEqual(invalid, wantInvalid) {
+		t.Errorf("Wrong InvalidWantFunc calls: expected\n%#+v\ngot\n%#+v", wantInvalid, invalid)
+	}
+	wantRan := []string{"str empty", "str spaces", "re all", "str func", "re func"}
+	if !reflect.DeepEqual(ran, wantRan) {
+		t.Errorf("Wrong tests with OutcomeInvalidWant: expected\n%#+v\ngot\n%#+v", wantRan, ran)
+	}
+	if len(noPanic) != 0 || len(wrongVal) != 0 {
+		t.Errorf("Unexpected failure callbacks: got\n%#+v\n%#+v", noPanic, wrongVal)
+	}
+
+	// Without InvalidWantFunc, an empty want matches anything
+	wrongVal = nil
+	new(LoopRunner).PanicsStrLoop([]PanicStrTest{{Name: "str empty", F: f, WantStr: ""}}, nil, notPanicFunc,
+		wrongValFunc)
+	if len(wrongVal) != 0 || len(noPanic) != 0 {
+		t.Errorf("PanicsStrLoop(): Unexpected failure callbacks without InvalidWantFunc")
+	}
+}
+
+func TestInvalidWantFuncFactories(t *testing.T) {
+	want := "Invalid test table: empty or whitespace-only want \" \" in test 'tt'"
+
+	rt := &testhelptest.RecordingT{}
+	InvalidWantFuncErrorFactory(rt)("tt", " ")
+	if !reflect.DeepEqual(rt.Errors, []string{want}) || len(rt.Fatals) != 0 {
+		t.Errorf("InvalidWantFuncErrorFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v\n%#+v", want,
+			rt.Errors, rt.Fatals)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	InvalidWantFuncFatalFactory(rt)("tt", " ")
+	if !reflect.DeepEqual(rt.Fatals, []string{want}) || len(rt.Errors) != 0 {
+		t.Errorf("InvalidWantFuncFatalFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v\n%#+v", want,
+			rt.Fatals, rt.Errors)
+	}
+}
+
+func TestLoopRunnerRetries(t *testing.T) {
+	attempts := map[string]int{}
+	// Panics only on the nth attempt
+	panicsOn := func(name string, n int) func() {
+		return func() {
+			attempts[name]++
+			if attempts[name] == n {
+				panic("ppp")
+			}
+		}
+	}
+	var noPanic, after []string
+	type flaky struct {
+		name     string
+		attempts int
+	}
+	var flakes []flaky
+	lr := LoopRunner{
+		AfterEach: func(testName string, outcome Outcome) { after = append(after, testName+": "+outcome.String()) },
+		FlakyFunc: func(testName string, failedAttempts int) { flakes = append(flakes, flaky{testName, failedAttempts}) },
+	}
+	lr.PanicsLoop([]PanicTest{
+		{Name: "first try", F: panicsOn("first try", 1), Retries: 2},
+		{Name: "third try", F: panicsOn("third try", 3), Retries: 2, Loc: Loc{File: "a_test.go", Line: 4}},
+		{Name: "too late", F: panicsOn("too late", 4), Retries: 2},
+		{Name: "no retries", F: panicsOn("no retries", 2)},
+	}, func(testName string) { noPanic = append(noPanic, testName) })
+
+	wantAttempts := map[string]int{"first try": 1, "third try": 3, "too late": 3, "no retries": 1}
+	if !reflect.DeepEqual(attempts, wantAttempts) {
+		t.Errorf("PanicsLoop(): Wrong numbers of attempts: expected\n%#+v\ngot\n%#+v", wantAttempts, attempts)
+	}
+	if want := []string{"too late", "no retries"}; !reflect.DeepEqual(noPanic, want) {
+		t.Errorf("PanicsLoop(): Wrong panic-test failures: expected\n%#+v\ngot\n%#+v", want, noPanic)
+	}
+	if want := []flaky{{"third try (at a_test.go:4)", 2}}; !reflect.DeepEqual(flakes, want) {
+		t.Errorf("PanicsLoop(): Wrong FlakyFunc calls: expected\n%#+v\ngot\n%#+v", want, flakes)
+	}
+	wantAfter := []string{"first try: passed", "third try: passed", "too late: did not panic",
+		"no retries: did not panic"}
+	if !reflect.DeepEqual(after, wantAfter) {
+		t.Errorf("PanicsLoop(): Wrong AfterEach calls: expected\n%#+v\ngot\n%#+v", wantAfter, after)
+	}
+}
+
+func TestFlakyFuncFactories(t *testing.T) {
+	want := "Flaky test 'tt' passed after 2 failed attempt(s)"
+
+	rt := &testhelptest.RecordingT{}
+	FlakyFuncLogFactory(rt)("tt", 2)
+	if !reflect.DeepEqual(rt.Logs, []string{want}) || len(rt.Failures()) != 0 {
+		t.Errorf("FlakyFuncLogFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v\n%#+v", want, rt.Logs,
+			rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	FlakyFuncErrorFactory(rt)("tt", 2)
+	if !reflect.DeepEqual(rt.Errors, []string{want}) {
+		t.Errorf("FlakyFuncErrorFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v", want, rt.Errors)
+	}
+}
+
+func TestLoopRunnerSlowestFunc(t *testing.T) {
+	sleeper := func(d time.Duration) func() { return func() { time.Sleep(d) } }
+	table := []PanicTest{
+		{Name: "fast", F: sleeper(0)},
+		{Name: "slowest", F: sleeper(60 * time.Millisecond), Loc: Loc{File: "a_test.go", Line: 2}},
+		{Name: "slow", F: sleeper(30 * time.Millisecond)},
+	}
+	tests := []struct {
+		name      string
+		n         int
+		wantNames []string
+	}{
+		{"top 2", 2, []string{"slowest (at a_test.go:2)", "slow"}},
+		{"all", 0, []string{"slowest (at a_test.go:2)", "slow", "fast"}},
+		{"more than the table", 5, []string{"slowest (at a_test.go:2)", "slow", "fast"}},
+	}
+	for _, test := range tests {
+		var names []string
+		calls := 0
+		lr := LoopRunner{
+			SlowestN: test.n,
+			SlowestFunc: func(slowest []CaseDuration) {
+				calls++
+				for _, cd := range slowest {
+					names = append(names, cd.Name)
+				}
+			},
+		}
+		lr.NotPanicsLoop(table, func(string) {})
+		if calls != 1 || !reflect.DeepEqual(names, test.wantNames) {
+			t.Errorf("NotPanicsLoop(): Wrong SlowestFunc calls: expected 1 call with\n%#+v\ngot %d calls with\n%#+v\n"+
+				"in test '%s'", test.wantNames, calls, names, test.name)
+		}
+	}
+}
+
+func TestSlowestFuncLogFactory(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	SlowestFuncLogFactory(rt)([]CaseDuration{{"a", 1500 * time.Millisecond}, {"b", 2 * time.Millisecond}})
+	want := []string{"Slowest tests:\n      1.5s  a\n       2ms  b"}
+	if !reflect.DeepEqual(rt.Logs, want) {
+		t.Errorf("SlowestFuncLogFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v", want, rt.Logs)
+	}
+}
+
+func TestLoopRunnerEq(t *testing.T) {
+	lr := &LoopRunner{Eq: sameFormat}
+	table := []PanicValTest{
+		{Name: "equal by eq", F: func() { panic(int64(5)) }, WantVal: 5},
+		{Name: "not equal", F: func() { panic(6) }, WantVal: 5},
+	}
+
+	var notEquals []string
+	lr.PanicsValLoop(table, nil, func(testName string) {}, func(testName string, wantVal, pVal interface{}) {
+		notEquals = append(notEquals, testName)
+	})
+	if want := []string{"not equal"}; !reflect.DeepEqual(notEquals, want) {
+		t.Errorf("PanicsValLoop(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, notEquals)
+	}
+
+	var reports []string
+	lr.PanicsValReportLoop(table, nil, func(r PanicReport) { reports = append(reports, r.TestName) })
+	if want := []string{"not equal"}; !reflect.DeepEqual(reports, want) {
+		t.Errorf("PanicsValReportLoop(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, reports)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+// runnerM is the part of *testing.M that RunMain uses.
+type runnerM interface {
+	Run() int
+}
+
+// RunMain runs a test binary's tests with setup and teardown, for use in TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testhelp.RunMain(m, startServer, stopServer))
+//	}
+//
+// setup (if not nil) is called first; if it returns an error or panics, the tests are not run.  Then the tests are run
+// with m.Run, and then the fixtures built by OncePer and OncePerT are torn down (see TeardownFixtures), and teardown
+// (if not nil) is called.  The teardowns run even if setup fails, or if setup, m.Run, or the fixture teardowns panic.
+// Errors and panics (with their stacks) are printed to standard error, and the returned exit code is 1 if there were
+// any, or m.Run's result otherwise.
+//
+// Note that a panic in a test itself (as opposed to in m.Run) ends the test binary immediately, as usual, so the
+// teardowns can't run in that case; the testing package reports such panics itself.
+func RunMain(m *testing.M, setup func() error, teardown func()) int {
+	return runMain(m, setup, teardown, os.Stderr)
+}
+
+func runMain(m runnerM, setup func() error, teardown func(), stderr io.Writer) (code int) {
+	fail := func(format string, args ...interface{}) {
+		fmt.Fprintf(stderr, format+"\n", args...)
+		code = 1
+	}
+	report := func(what string, f func()) {
+		if didPanic, pVal, stack := panicsWithStack(f); didPanic {
+			fail("Panic in %s (%T):\n%s\nstack:\n%s", what, pVal, PanicMessage(pVal), stack)
+		}
+	}
+
+	defer func() {
+		report("fixture teardown", TeardownFixtures)
+		if teardown != nil {
+			report("teardown", teardown)
+		}
+	}()
+
+	if setup != nil {
+		var err error
+		report("setup", func() { err = setup() })
+		if err != nil {
+			fail("Setup failed: %s", err)
+		}
+		if code != 0 {
+			return code
+		}
+	}
+
+	report("tests", func() { code = m.Run() })
+	return code
+}
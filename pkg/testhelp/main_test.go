@@ -0,0 +1,121 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type runnerMMock struct {
+	code   int
+	panics bool
+	events *[]string
+}
+
+func (m runnerMMock) Run() int {
+	*m.events = append(*m.events, "run")
+	if m.panics {
+		panic("m.Run panicked")
+	}
+	return m.code
+}
+
+func TestRunMain(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupErr   error
+		setupPanic bool
+		runCode    int
+		runPanics  bool
+		tdPanics   bool
+		wantCode   int
+		wantEvents []string
+		wantStderr []string
+	}{
+		{"success", nil, false, 0, false, false, 0, []string{"setup", "run", "teardown"}, nil},
+		{"tests fail", nil, false, 3, false, false, 3, []string{"setup", "run", "teardown"}, nil},
+		{
+			"setup error", errors.New("no server"), false, 0, false, false, 1, []string{"setup", "teardown"},
+			[]string{"Setup failed: no server"},
+		},
+		{
+			"setup panic", nil, true, 0, false, false, 1, []string{"setup", "teardown"},
+			[]string{"Panic in setup (string):\nsetup panicked\nstack:\n"},
+		},
+		{
+			"run panic", nil, false, 0, true, false, 1, []string{"setup", "run", "teardown"},
+			[]string{"Panic in tests (string):\nm.Run panicked\nstack:\n"},
+		},
+		{
+			"teardown panic", nil, false, 0, false, true, 1, []string{"setup", "run", "teardown"},
+			[]string{"Panic in teardown (string):\nteardown panicked\nstack:\n"},
+		},
+	}
+	for _, test := range tests {
+		var events []string
+		setup := func() error {
+			events = append(events, "setup")
+			if test.setupPanic {
+				panic("setup panicked")
+			}
+			return test.setupErr
+		}
+		teardown := func() {
+			events = append(events, "teardown")
+			if test.tdPanics {
+				panic("teardown panicked")
+			}
+		}
+		var stderr bytes.Buffer
+		code := runMain(runnerMMock{test.runCode, test.runPanics, &events}, setup, teardown, &stderr)
+		if code != test.wantCode {
+			t.Errorf("runMain(): Incorrect exit code: expected %d, got %d in test '%s'", test.wantCode, code,
+				test.name)
+		}
+		if !reflect.DeepEqual(events, test.wantEvents) {
+			t.Errorf("runMain(): Incorrect events: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantEvents, events,
+				test.name)
+		}
+		for _, want := range test.wantStderr {
+			if !strings.Contains(stderr.String(), want) {
+				t.Errorf("runMain(): Incorrect output: expected a string containing\n%s\ngot\n%s\nin test '%s'", want,
+					stderr.String(), test.name)
+			}
+		}
+		if test.wantStderr == nil && stderr.Len() != 0 {
+			t.Errorf("runMain(): Unexpected output in test '%s':\n%s", test.name, stderr.String())
+		}
+	}
+}
+
+func TestRunMainNilFuncsAndFixtures(t *testing.T) {
+	var events []string
+	OncePer(t, "TestRunMainNilFuncsAndFixtures", func() (interface{}, func()) {
+		return 1, func() { events = append(events, "fixture teardown") }
+	})
+	var stderr bytes.Buffer
+	if code := runMain(runnerMMock{0, false, &events}, nil, nil, &stderr); code != 0 || stderr.Len() != 0 {
+		t.Errorf("runMain(): Unexpected result with nil funcs: %d\n%s", code, stderr.String())
+	}
+	if want := []string{"run", "fixture teardown"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("runMain(): Incorrect events: expected\n%#+v\ngot\n%#+v", want, events)
+	}
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// A PanicMatcher checks a panic value against some condition, for use with PanicsMatching and PanicsMatchingLoop.
+// It's the Matcher for panic values, which can be of any type: Match returns true if the panic value meets the
+// condition, and Describe returns a description of the condition for diagnostic messages, phrased to follow "a value
+// that", e.g. `contains "nil"`.
+//
+// This package provides matchers for the most common checks (Contains, Regexp, EqualsVal, IsType, and ErrIs), but any
+// type with these methods can be used, and these matchers can also be used with Assert on values of type
+// interface{}, or, through MatcherFor, on values of other types.
+type PanicMatcher = Matcher[interface{}]
+
+type containsMatcher struct {
+	wantStr string
+	norm    func(s string) string
+}
+
+// Contains returns a PanicMatcher that matches panic values that can be cast to a string or error containing wantStr,
+// as in PanicsStr.
+func Contains(wantStr string) PanicMatcher {
+	return containsMatcher{wantStr: wantStr}
+}
+
+// ContainsNorm returns a PanicMatcher that matches panic values that can be cast to a string or error containing
+// wantStr after both are passed through norm, as in PanicsStrNorm.  If norm is nil, it is the same as Contains.
+func ContainsNorm(wantStr string, norm func(s string) string) PanicMatcher {
+	return containsMatcher{wantStr: wantStr, norm: norm}
+}
+
+func (m containsMatcher) Match(pVal interface{}) bool {
+	pStr, ok := panicString(pVal)
+	return ok && containsNorm(pStr, m.wantStr, m.norm)
+}
+
+func (m containsMatcher) Describe() string {
+	if m.norm != nil {
+		return fmt.Sprintf("contains %q (after normalization)", m.wantStr)
+	}
+	return fmt.Sprintf("contains %q", m.wantStr)
+}
+
+// containsNorm returns true if s contains substr after both are passed through norm, or as they are if norm is nil.
+func containsNorm(s, substr string, norm func(s string) string) bool {
+	if norm != nil {
+		s, substr = norm(s), norm(substr)
+	}
+	return strings.Contains(s, substr)
+}
+
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+// Regexp returns a PanicMatcher that matches panic values that can be cast to a string or error matching the regular
+// expression given by wantRE, as in PanicsRE.
+//
+// Regexp panics if wantRE does not represent a valid regular expression.
+func Regexp(wantRE string) PanicMatcher {
+	re, err := regexp.Compile(wantRE)
+	if err != nil {
+		panic(fmt.Sprintf("Regexp could not be compiled: %s", err))
+	}
+	return regexpMatcher{re}
+}
+
+func (m regexpMatcher) Match(pVal interface{}) bool {
+	pStr, ok := panicString(pVal)
+	return ok && m.re.MatchString(pStr)
+}
+
+func (m regexpMatcher) Describe() string {
+	return fmt.Sprintf("matches regexp %q", m.re.String())
+}
+
+type equalsValMatcher struct {
+	wantVal interface{}
+	eq      func(a, b interface{}) bool
+}
+
+// EqualsVal returns a PanicMatcher that matches panic values equal to wantVal, as in PanicsVal.
+//
+// The matcher's Match method panics if the panic value and wantVal are of the same type, but it's not a type that Go
+// can compare with ==.
+func EqualsVal(wantVal interface{}) PanicMatcher {
+	return equalsValMatcher{wantVal: wantVal}
+}
+
+// EqualsValEq returns a PanicMatcher that matches panic values equal to wantVal according to eq, as in PanicsValEq.  If
+// eq is nil, it is the same as EqualsVal.
+func EqualsValEq(wantVal interface{}, eq func(a, b interface{}) bool) PanicMatcher {
+	return equalsValMatcher{wantVal: wantVal, eq: eq}
+}
+
+func (m equalsValMatcher) Match(pVal interface{}) bool {
+	return valEqual(m.wantVal, pVal, m.eq)
+}
+
+func (m equalsValMatcher) Describe() string {
+	if m.eq != nil {
+		return "equals " + Format(m.wantVal) + " (by custom equality)"
+	}
+	return "equals " + Format(m.wantVal)
+}
+
+type isTypeMatcher struct {
+	wantType reflect.Type
+}
+
+// IsType returns a PanicMatcher that matches panic values of the same dynamic type as example.  For example,
+// IsType(&os.PathError{}) matches panics with any *os.PathError.
+//
+// IsType panics if example is nil.
+func IsType(example interface{}) PanicMatcher {
+	if example == nil {
+		panic("IsType requires a non-nil example value")
+	}
+	return isTypeMatcher{reflect.TypeOf(example)}
+}
+
+func (m isTypeMatcher) Match(pVal interface{}) bool {
+	return reflect.TypeOf(pVal) == m.wantType
+}
+
+func (m isTypeMatcher) Describe() string {
+	return fmt.Sprintf("has type %s", m.wantType)
+}
+
+type errIsMatcher struct {
+	target error
+}
+
+// ErrIs returns a PanicMatcher that matches panic values that are errors for which errors.Is(pVal, target) is true.
+func ErrIs(target error) PanicMatcher {
+	return errIsMatcher{target}
+}
+
+func (m errIsMatcher) Match(pVal interface{}) bool {
+	err, ok := pVal.(error)
+	return ok && errors.Is(err, m.target)
+}
+
+func (m errIsMatcher) Describe() string {
+	return "is an error matching errors.Is(" + Format(m.target) + ")"
+}
+
+type notMatcher[T any] struct {
+	matcher Matcher[T]
+}
+
+// Not returns a Matcher that matches values that the given matcher doesn't match.  For example, to check for a panic
+// about a nil input that isn't a generic internal error:
+//
+//	testhelp.PanicsMatching(f, testhelp.Contains("nil"), testhelp.Not(testhelp.Regexp("internal error")))
+func Not[T any](matcher Matcher[T]) Matcher[T] {
+	return notMatcher[T]{matcher}
+}
+
+func (m notMatcher[T]) Match(got T) bool {
+	return !m.matcher.Match(got)
+}
+
+func (m notMatcher[T]) Describe() string {
+	return fmt.Sprintf("is not a value that %s", m.matcher.Describe())
+}
+
+type allOfMatcher[T any] struct {
+	matchers []Matcher[T]
+}
+
+// AllOf returns a Matcher that matches values that all of the given matchers match (including if there are no
+// matchers, in which case the type has to be given, e.g. AllOf[int]()).  For panic values, this is the same as passing
+// the matchers separately to PanicsMatching, but allows the combination to be used inside other combinators.
+func AllOf[T any](matchers ...Matcher[T]) Matcher[T] {
+	return allOfMatcher[T]{matchers}
+}
+
+func (m allOfMatcher[T]) Match(got T) bool {
+	return firstMismatch(got, m.matchers) == nil
+}
+
+func (m allOfMatcher[T]) Describe() string {
+	return describeAll(m.matchers, "and")
+}
+
+type anyOfMatcher[T any] struct {
+	matchers []Matcher[T]
+}
+
+// AnyOf returns a Matcher that matches values that at least one of the given matchers matches (so with no matchers,
+// it never matches).
+func AnyOf[T any](matchers ...Matcher[T]) Matcher[T] {
+	return anyOfMatcher[T]{matchers}
+}
+
+func (m anyOfMatcher[T]) Match(got T) bool {
+	for _, matcher := range m.matchers {
+		if matcher.Match(got) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m anyOfMatcher[T]) Describe() string {
+	return describeAll(m.matchers, "or")
+}
+
+// describeAll joins the descriptions of the matchers with the given conjunction, in parentheses if there is more than
+// one, so that nested combinations are unambiguous.
+func describeAll[T any](matchers []Matcher[T], conjunction string) string {
+	switch len(matchers) {
+	case 0:
+		if conjunction == "and" {
+			return "is anything"
+		}
+		return "is nothing"
+	case 1:
+		return matchers[0].Describe()
+	}
+	descs := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		descs = append(descs, matcher.Describe())
+	}
+	return "(" + strings.Join(descs, " "+conjunction+" ") + ")"
+}
+
+// PanicsMatching tests if the given function panics, and returns a boolean that is true if it does.  It also takes
+// matchers, to allow checking the panic value; if the function does panic, and the panic value matches all of the
+// matchers, pMatches will be true.  The panic value itself is also returned.  (Specifically, this is the return value
+// from recover, which is nil if the function did not panic.)  For example:
+//
+//	didPanic, pMatches, pVal := testhelp.PanicsMatching(func() { mypkg.Open(nil) },
+//		testhelp.IsType(&mypkg.Error{}), testhelp.Contains("nil config"))
+//
+// With no matchers, pMatches is true whenever the function panics.  See PanicsStr for a string-flavored version of
+// how to use this function.
+func PanicsMatching(f func(), matchers ...PanicMatcher) (didPanic bool, pMatches bool, pVal interface{}) {
+	didPanic, pVal = PanicsGet(f)
+	if !didPanic {
+		return false, false, nil
+	}
+	return true, firstMismatch(pVal, matchers) == nil, pVal
+}
+
+// firstMismatch returns the first of the matchers that doesn't match got, or nil if they all do.
+func firstMismatch[T any](got T, matchers []Matcher[T]) Matcher[T] {
+	for _, m := range matchers {
+		if !m.Match(got) {
+			return m
+		}
+	}
+	return nil
+}
+
+// NoMatchFuncErrorFactory returns a function suitable for passing to PanicsMatchingLoop as a noMatchFunc.  The
+// returned function is a closure over a *testing.T which uses it to call Errorf with a generic informative message.
+func NoMatchFuncErrorFactory(t TestingT) func(testName string, matcher PanicMatcher, pVal interface{}) {
+	return func(testName string, matcher PanicMatcher, pVal interface{}) {
+		t.Errorf("%sIncorrect panic value: expected a value that %s\ngot\n%s\nin test '%s'",
+			failureToken(categoryMatching, testName), matcher.Describe(), quotedPanicMessage(pVal), testName)
+	}
+}
+
+// NoMatchFuncFatalFactory returns a function suitable for passing to PanicsMatchingLoop as a noMatchFunc.  The
+// returned function is a closure over a *testing.T which uses it to call Fatalf with a generic informative message.
+func NoMatchFuncFatalFactory(t TestingT) func(testName string, matcher PanicMatcher, pVal interface{}) {
+	return func(testName string, matcher PanicMatcher, pVal interface{}) {
+		t.Fatalf("%sIncorrect panic value: expected a value that %s\ngot\n%s\nin test '%s'",
+			failureToken(categoryMatching, testName), matcher.Describe(), quotedPanicMessage(pVal), testName)
+	}
+}
+
+// A PanicMatchingTest encapsulates a function that is intended to panic, along with a name for it in diagnostic
+// messages, plus matchers that the panic value should match.
+type PanicMatchingTest struct {
+	Name            string
+	F               func()
+	Matchers        []PanicMatcher
+	Skip            bool
+	SkipReason      string
+	ExpectedFailure bool
+	Retries         int
+	Loc             Loc
+}
+
+// PanicsMatchingLoop runs through a slice of panic tests, including checking the panic values against the tests'
+// matchers.  For any test function that does not panic, notPanicFunc is called with the name from the test's struct.
+// For any test function that does panic, but for which the panic value does not match all of the test's matchers,
+// noMatchFunc is called with the name from the test's struct, the first matcher that did not match, and the panic
+// value.  See also PanicsMatching.
+//
+// This loop can take the place of PanicsStrLoop, PanicsRELoop, and PanicsValLoop, and can mix different kinds of checks
+// in the same table.
+//
+// See NoMatchFuncErrorFactory and NoMatchFuncFatalFactory for good starting points for noMatchFunc.
+func PanicsMatchingLoop(tests []PanicMatchingTest, notPanicFunc func(testName string),
+	noMatchFunc func(testName string, matcher PanicMatcher, pVal interface{}),
+) {
+	new(LoopRunner).PanicsMatchingLoop(tests, notPanicFunc, noMatchFunc)
+}
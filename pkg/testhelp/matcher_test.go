@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type matcherTestError struct {
+	msg string
+}
+
+func (e *matcherTestError) Error() string {
+	return e.msg
+}
+
+var errMatcherSentinel = errors.New("sentinel")
+
+func TestMatchers(t *testing.T) {
+	wrapped := fmt.Errorf("wrapping: %w", errMatcherSentinel)
+	tests := []struct {
+		name         string
+		matcher      PanicMatcher
+		pVal         interface{}
+		wantMatch    bool
+		wantDescribe string
+	}{
+		{"Contains, string", Contains("nil"), "got nil input", true, `contains "nil"`},
+		{"Contains, error", Contains("nil"), errors.New("got nil input"), true, `contains "nil"`},
+		{"Contains, no match", Contains("nil"), "got empty input", false, `contains "nil"`},
+		{"Contains, not a string", Contains("5"), 5, false, `contains "5"`},
+		{"ContainsNorm, match", ContainsNorm("NIL", strings.ToLower), "got nil input", true,
+			`contains "NIL" (after normalization)`},
+		{"ContainsNorm, no match", ContainsNorm("NUL", strings.ToLower), "got nil input", false,
+			`contains "NUL" (after normalization)`},
+		{"ContainsNorm, nil norm", ContainsNorm("NIL", nil), "got nil input", false, `contains "NIL"`},
+		{"Regexp, match", Regexp("^got .* input$"), "got nil input", true, `matches regexp "^got .* input$"`},
+		{"Regexp, no match", Regexp("^nil"), "got nil input", false, `matches regexp "^nil"`},
+		{"Regexp, not a string", Regexp("5"), 5, false, `matches regexp "5"`},
+		{"EqualsVal, match", EqualsVal(5), 5, true, "equals 5"},
+		{"EqualsVal, different type", EqualsVal(5), int64(5), false, "equals 5"},
+		{"EqualsValEq, match", EqualsValEq(5, sameFormat), int64(5), true, "equals 5 (by custom equality)"},
+		{"EqualsValEq, no match", EqualsValEq(5, sameFormat), 6, false, "equals 5 (by custom equality)"},
+		{"EqualsValEq, nil eq", EqualsValEq(5, nil), int64(5), false, "equals 5"},
+		{"IsType, match", IsType(&matcherTestError{}), &matcherTestError{"x"}, true,
+			"has type *testhelp.matcherTestError"},
+		{"IsType, no match", IsType(&matcherTestError{}), "x", false, "has type *testhelp.matcherTestError"},
+		{"ErrIs, direct", ErrIs(errMatcherSentinel), errMatcherSentinel, true,
+			`is an error matching errors.Is(&errors.errorString{s: "sentinel"})`},
+		{"ErrIs, wrapped", ErrIs(errMatcherSentinel), wrapped, true,
+			`is an error matching errors.Is(&errors.errorString{s: "sentinel"})`},
+		{"ErrIs, not an error", ErrIs(errMatcherSentinel), "sentinel", false,
+			`is an error matching errors.Is(&errors.errorString{s: "sentinel"})`},
+	}
+	for _, test := range tests {
+		if got := test.matcher.Match(test.pVal); got != test.wantMatch {
+			t.Errorf("Match(): Incorrect result: expected %t, got %t in test '%s'", test.wantMatch, got, test.name)
+		}
+		if got := test.matcher.Describe(); got != test.wantDescribe {
+			t.Errorf("Describe(): Incorrect description: expected\n%s\ngot\n%s\nin test '%s'", test.wantDescribe, got,
+				test.name)
+		}
+	}
+}
+
+func TestMatcherConstructorsPanic(t *testing.T) {
+	if didPanic, pMatchesRE, pVal := PanicsRE(func() { Regexp("[a-z") }, "^Regexp could not be compiled"); !didPanic ||
+		!pMatchesRE {
+		t.Errorf("Regexp(): Expected a panic about the regexp with an invalid regexp, got\n%#+v", pVal)
+	}
+	if !Panics(func() { IsType(nil) }) {
+		t.Errorf("IsType(): Expected a panic with a nil example")
+	}
+}
+
+func TestPanicsMatching(t *testing.T) {
+	tests := []struct {
+		name        string
+		f           func()
+		matchers    []PanicMatcher
+		wantPanic   bool
+		wantMatches bool
+		wantPVal    interface{}
+	}{
+		{"np", func() {}, []PanicMatcher{Contains("")}, false, false, nil},
+		{"no matchers", func() { panic(5) }, nil, true, true, 5},
+		{"all match", func() { panic("ppp") }, []PanicMatcher{Contains("p"), Regexp("^p+$"), IsType("")}, true, true,
+			"ppp"},
+		{"one doesn't match", func() { panic("ppp") }, []PanicMatcher{Contains("p"), EqualsVal("qqq")}, true, false,
+			"ppp"},
+	}
+	for _, test := range tests {
+		didPanic, pMatches, pVal := PanicsMatching(test.f, test.matchers...)
+		if didPanic != test.wantPanic || pMatches != test.wantMatches || pVal != test.wantPVal {
+			t.Errorf("PanicsMatching(): Incorrect return values: expected\n%t, %t, %#+v\ngot\n%t, %t, %#+v\n"+
+				"in test '%s'", test.wantPanic, test.wantMatches, test.wantPVal, didPanic, pMatches, pVal, test.name)
+		}
+	}
+}
+
+func TestPanicsMatchingLoop(t *testing.T) {
+	var noPanic []string
+	var noMatch []string
+	PanicsMatchingLoop([]PanicMatchingTest{
+		{Name: "np", F: func() {}, Matchers: []PanicMatcher{Contains("")}},
+		{Name: "match", F: func() { panic("ppp") }, Matchers: []PanicMatcher{Contains("p"), IsType("")}},
+		{Name: "no match", F: func() { panic("ppp") }, Matchers: []PanicMatcher{Contains("p"), Contains("q")}},
+	}, func(testName string) {
+		noPanic = append(noPanic, testName)
+	}, func(testName string, matcher PanicMatcher, pVal interface{}) {
+		noMatch = append(noMatch, fmt.Sprintf("%s: %s: %v", testName, matcher.Describe(), pVal))
+	})
+	if want := []string{"np"}; !reflect.DeepEqual(noPanic, want) {
+		t.Errorf("PanicsMatchingLoop(): Wrong panic-test failures: expected\n%#+v\ngot\n%#+v", want, noPanic)
+	}
+	if want := []string{`no match: contains "q": ppp`}; !reflect.DeepEqual(noMatch, want) {
+		t.Errorf("PanicsMatchingLoop(): Wrong matcher failures: expected\n%#+v\ngot\n%#+v", want, noMatch)
+	}
+}
+
+func TestNoMatchFuncFactories(t *testing.T) {
+	want := "Incorrect panic value: expected a value that contains \"q\"\ngot\n\"ppp\"\nin test 'tt'"
+
+	rt := &testhelptest.RecordingT{}
+	NoMatchFuncErrorFactory(rt)("tt", Contains("q"), "ppp")
+	if !reflect.DeepEqual(rt.Errors, []string{want}) || len(rt.Fatals) != 0 {
+		t.Errorf("NoMatchFuncErrorFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v\n%#+v", want, rt.Errors,
+			rt.Fatals)
+	}
+
+	rt = &testhelptest.RecordingT{}
+	NoMatchFuncFatalFactory(rt)("tt", Contains("q"), "ppp")
+	if !reflect.DeepEqual(rt.Fatals, []string{want}) || len(rt.Errors) != 0 {
+		t.Errorf("NoMatchFuncFatalFactory(): Incorrect message(s): expected\n%#+v\ngot\n%#+v\n%#+v", want, rt.Fatals,
+			rt.Errors)
+	}
+}
+
+func TestMatcherCombinators(t *testing.T) {
+	tests := []struct {
+		name         string
+		matcher      PanicMatcher
+		pVal         interface{}
+		wantMatch    bool
+		wantDescribe string
+	}{
+		{"Not, match", Not(Contains("internal")), "nil input", true, `is not a value that contains "internal"`},
+		{"Not, no match", Not(Contains("internal")), "internal error", false,
+			`is not a value that contains "internal"`},
+		{"AllOf, match", AllOf(Contains("nil"), IsType("")), "nil input", true,
+			`(contains "nil" and has type string)`},
+		{"AllOf, no match", AllOf(Contains("nil"), Contains("empty")), "nil input", false,
+			`(contains "nil" and contains "empty")`},
+		{"AllOf, one", AllOf(Contains("nil")), "nil input", true, `contains "nil"`},
+		{"AllOf, none", AllOf[interface{}](), 5, true, "is anything"},
+		{"AnyOf, match", AnyOf(Contains("empty"), Contains("nil")), "nil input", true,
+			`(contains "empty" or contains "nil")`},
+		{"AnyOf, no match", AnyOf(Contains("empty"), EqualsVal(5)), "nil input", false,
+			`(contains "empty" or equals 5)`},
+		{"AnyOf, none", AnyOf[interface{}](), 5, false, "is nothing"},
+		{
+			"nested", AllOf(Contains("nil"), Not(AnyOf(Regexp("internal error"), Contains("bug")))),
+			"nil input", true,
+			`(contains "nil" and is not a value that (matches regexp "internal error" or contains "bug"))`,
+		},
+		{
+			"nested, no match", AllOf(Contains("nil"), Not(AnyOf(Regexp("internal error"), Contains("bug")))),
+			"internal error: nil input", false,
+			`(contains "nil" and is not a value that (matches regexp "internal error" or contains "bug"))`,
+		},
+	}
+	for _, test := range tests {
+		if got := test.matcher.Match(test.pVal); got != test.wantMatch {
+			t.Errorf("Match(): Incorrect result: expected %t, got %t in test '%s'", test.wantMatch, got, test.name)
+		}
+		if got := test.matcher.Describe(); got != test.wantDescribe {
+			t.Errorf("Describe(): Incorrect description: expected\n%s\ngot\n%s\nin test '%s'", test.wantDescribe, got,
+				test.name)
+		}
+	}
+}
+
+func TestMatcherCombinatorsRunOnce(t *testing.T) {
+	calls := 0
+	didPanic, pMatches, _ := PanicsMatching(func() {
+		calls++
+		panic("nil input")
+	}, Contains("nil"), Not(Regexp("internal error")))
+	if !didPanic || !pMatches || calls != 1 {
+		t.Errorf("PanicsMatching(): Expected one call, a panic, and a match; got %d call(s), %t, %t", calls, didPanic,
+			pMatches)
+	}
+}
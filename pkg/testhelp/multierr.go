@@ -0,0 +1,118 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// flattenErrors returns the individual errors in err: if err aggregates other errors, its children, flattened the
+// same way; otherwise, err itself.  Aggregates are values with an Unwrap() []error method (like the ones returned by
+// errors.Join, or by fmt.Errorf with more than one %w), or with a WrappedErrors() or Errors() method returning
+// []error (like hashicorp/go-multierror and go.uber.org/multierr values).  A nil err has no errors.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var children []error
+	switch agg := err.(type) {
+	case interface{ Unwrap() []error }:
+		children = agg.Unwrap()
+	case interface{ WrappedErrors() []error }:
+		children = agg.WrappedErrors()
+	case interface{ Errors() []error }:
+		children = agg.Errors()
+	default:
+		return []error{err}
+	}
+	var errs []error
+	for _, child := range children {
+		errs = append(errs, flattenErrors(child)...)
+	}
+	return errs
+}
+
+// listErrors formats errs for a failure message, one per line.
+func listErrors(errs []error) string {
+	if len(errs) == 0 {
+		return "(no errors)"
+	}
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = fmt.Sprintf("%d: %s", i+1, err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ErrorCount checks that err holds exactly n errors, calling t.Errorf with the list of errors if not.  An aggregate
+// error (such as one from errors.Join) holds the errors it aggregates, flattening nested aggregates; any other non-nil
+// error holds just itself, and a nil error holds none.  It returns true if the check passed.
+//
+// This and the other multi-error assertions catch problems that single-error assertions such as errors.Is miss,
+// because they only find the first matching error in an aggregate.
+func ErrorCount(t TestingTB, err error, n int) bool {
+	t.Helper()
+	errs := flattenErrors(err)
+	if len(errs) == n {
+		return true
+	}
+	t.Errorf("Incorrect number of errors: expected %d, got %d:\n%s", n, len(errs), listErrors(errs))
+	return false
+}
+
+// ContainsErrorMatching checks that at least one of the errors held by err (see ErrorCount) has a message matching
+// the regular expression given by re, calling t.Errorf with the list of errors if not.  It returns true if the check
+// passed.
+//
+// ContainsErrorMatching panics if re does not represent a valid regular expression.
+func ContainsErrorMatching(t TestingTB, err error, re string) bool {
+	t.Helper()
+	compiled := regexp.MustCompile(re)
+	errs := flattenErrors(err)
+	for _, e := range errs {
+		if compiled.MatchString(e.Error()) {
+			return true
+		}
+	}
+	t.Errorf("No error matching\n\"%s\"\nin:\n%s", re, listErrors(errs))
+	return false
+}
+
+// EachError calls check with each of the errors held by err (see ErrorCount), in order, and calls t.Errorf for each
+// one that check returns a non-nil error for, giving the error's position and the problem.  It returns true if all of
+// the errors passed the check (including when there are none).  For example:
+//
+//	testhelp.EachError(t, validate(cfg), func(err error) error {
+//		if !errors.As(err, new(*FieldError)) {
+//			return errors.New("not a *FieldError")
+//		}
+//		return nil
+//	})
+func EachError(t TestingTB, err error, check func(error) error) bool {
+	t.Helper()
+	ok := true
+	errs := flattenErrors(err)
+	for i, e := range errs {
+		if problem := check(e); problem != nil {
+			t.Errorf("Error %d of %d failed the check: %s\nerror: %s", i+1, len(errs), problem, e)
+			ok = false
+		}
+	}
+	return ok
+}
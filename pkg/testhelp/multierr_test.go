@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// joinedErrors is an aggregate error like the ones errors.Join returns (which needs a newer Go than this module).
+type joinedErrors []error
+
+func (j joinedErrors) Error() string {
+	msgs := make([]string, len(j))
+	for i, err := range j {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (j joinedErrors) Unwrap() []error {
+	return j
+}
+
+// legacyMultiError is an aggregate error like a hashicorp/go-multierror value.
+type legacyMultiError struct {
+	errs []error
+}
+
+func (m *legacyMultiError) Error() string          { return fmt.Sprintf("%d errors occurred", len(m.errs)) }
+func (m *legacyMultiError) WrappedErrors() []error { return m.errs }
+
+func TestFlattenErrors(t *testing.T) {
+	a, b, c := errors.New("a"), errors.New("b"), errors.New("c")
+	tests := []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "nil", err: nil, expected: nil},
+		{name: "single", err: a, expected: []error{a}},
+		{name: "joined", err: joinedErrors{a, b}, expected: []error{a, b}},
+		{name: "nested", err: joinedErrors{a, &legacyMultiError{[]error{b, c}}}, expected: []error{a, b, c}},
+		{name: "wrapped", err: fmt.Errorf("context: %w", a), expected: []error{fmt.Errorf("context: %w", a)}},
+	}
+	for _, test := range tests {
+		if got := flattenErrors(test.err); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("flattenErrors(): Incorrect errors: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+}
+
+func TestMultiErrorAssertions(t *testing.T) {
+	err := joinedErrors{errors.New("field name: required"), errors.New("field age: must be positive")}
+	tests := []struct {
+		name     string
+		check    func(rt TestingTB) bool
+		expected []string
+	}{
+		{name: "count", check: func(rt TestingTB) bool { return ErrorCount(rt, err, 2) }, expected: []string{}},
+		{name: "count nil", check: func(rt TestingTB) bool { return ErrorCount(rt, nil, 0) }, expected: []string{}},
+		{
+			name:  "wrong count",
+			check: func(rt TestingTB) bool { return ErrorCount(rt, err, 1) },
+			expected: []string{"Incorrect number of errors: expected 1, got 2:\n1: field name: required\n" +
+				"2: field age: must be positive"},
+		},
+		{
+			name:     "wrong count nil",
+			check:    func(rt TestingTB) bool { return ErrorCount(rt, nil, 1) },
+			expected: []string{"Incorrect number of errors: expected 1, got 0:\n(no errors)"},
+		},
+		{
+			name:     "matching",
+			check:    func(rt TestingTB) bool { return ContainsErrorMatching(rt, err, `^field age: `) },
+			expected: []string{},
+		},
+		{
+			name:  "not matching",
+			check: func(rt TestingTB) bool { return ContainsErrorMatching(rt, err, `email`) },
+			expected: []string{"No error matching\n\"email\"\nin:\n1: field name: required\n" +
+				"2: field age: must be positive"},
+		},
+		{
+			name: "each",
+			check: func(rt TestingTB) bool {
+				return EachError(rt, err, func(e error) error {
+					if !strings.HasPrefix(e.Error(), "field ") {
+						return errors.New("not a field error")
+					}
+					return nil
+				})
+			},
+			expected: []string{},
+		},
+		{
+			name: "each failing",
+			check: func(rt TestingTB) bool {
+				return EachError(rt, err, func(e error) error {
+					if !strings.Contains(e.Error(), "required") {
+						return errors.New("not a required-field error")
+					}
+					return nil
+				})
+			},
+			expected: []string{"Error 2 of 2 failed the check: not a required-field error\n" +
+				"error: field age: must be positive"},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := test.check(rt)
+		if got := rt.Failures(); ok != (len(test.expected) == 0) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Multi-error assertions: Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expected, got, test.name)
+		}
+	}
+}
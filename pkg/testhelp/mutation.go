@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A byteMutation is a small change to an output, for CheckAssertionsDetectChanges.
+type byteMutation struct {
+	desc   string
+	mutate func([]byte) []byte // called with a copy of the output, which it may modify
+}
+
+// CheckAssertionsDetectChanges is a lightweight smoke check for vacuous tests: assertions that would still pass if
+// the code under test were subtly wrong.  It calls produce, which should be a pure function returning the output of
+// the code under test, and checks that assert (the test's assertions on that output, returning an error if they fail)
+// passes on the output as it is, but fails on each of a set of mutated copies of it:
+//
+//   - with the lowest bit of the first, middle, and last bytes flipped
+//   - with the first, middle, and last bytes incremented and decremented (off-by-one values)
+//   - with the last byte removed, and with an extra zero byte appended (off-by-one lengths)
+//   - empty
+//
+// For example:
+//
+//	testhelp.CheckAssertionsDetectChanges(t, func() []byte { return mypkg.Encode(msg) }, func(b []byte) error {
+//		if !bytes.Equal(b, expected) {
+//			return fmt.Errorf("got %q", b)
+//		}
+//		return nil
+//	})
+//
+// If the assertions fail on the original output, any of the mutations survive (the assertions still pass), or produce
+// returns something different when it's called a second time (so isn't pure, and the mutations might not mean
+// anything), CheckAssertionsDetectChanges calls t.Errorf, listing the surviving mutations, if any.  It returns true if
+// the check passed.
+//
+// This isn't a replacement for a mutation testing tool, which mutates the code under test, rather than its output;
+// but it's cheap enough to run with the rest of the tests.
+func CheckAssertionsDetectChanges(t TestingTB, produce func() []byte, assert func([]byte) error) bool {
+	t.Helper()
+	out := produce()
+	if again := produce(); !bytes.Equal(out, again) {
+		t.Errorf("Output isn't deterministic, so mutations can't be checked: first call returned\n%q\nsecond call "+
+			"returned\n%q", out, again)
+		return false
+	}
+	if err := assert(append([]byte{}, out...)); err != nil {
+		t.Errorf("Assertions fail on the unmutated output: %s", err)
+		return false
+	}
+
+	var survivors []string
+	for _, m := range byteMutations(len(out)) {
+		mutated := m.mutate(append([]byte{}, out...))
+		if assert(mutated) == nil {
+			survivors = append(survivors, fmt.Sprintf("%s: %q", m.desc, mutated))
+		}
+	}
+	if len(survivors) > 0 {
+		t.Errorf("Assertions still pass on %d mutated output(s), so they may not be checking enough:\n%s",
+			len(survivors), strings.Join(survivors, "\n"))
+		return false
+	}
+	return true
+}
+
+// byteMutations returns the mutations used by CheckAssertionsDetectChanges for an output of length n.
+func byteMutations(n int) []byteMutation {
+	var mutations []byteMutation
+	for _, pos := range mutationPositions(n) {
+		pos := pos
+		mutations = append(mutations,
+			byteMutation{
+				desc:   fmt.Sprintf("byte %d with its lowest bit flipped", pos),
+				mutate: func(b []byte) []byte { b[pos] ^= 1; return b },
+			},
+			byteMutation{
+				desc:   fmt.Sprintf("byte %d incremented", pos),
+				mutate: func(b []byte) []byte { b[pos]++; return b },
+			},
+			byteMutation{
+				desc:   fmt.Sprintf("byte %d decremented", pos),
+				mutate: func(b []byte) []byte { b[pos]--; return b },
+			},
+		)
+	}
+	if n > 0 {
+		mutations = append(mutations,
+			byteMutation{desc: "last byte removed", mutate: func(b []byte) []byte { return b[:len(b)-1] }},
+		)
+	}
+	mutations = append(mutations,
+		byteMutation{desc: "zero byte appended", mutate: func(b []byte) []byte { return append(b, 0) }},
+	)
+	if n > 0 {
+		mutations = append(mutations,
+			byteMutation{desc: "empty", mutate: func(b []byte) []byte { return b[:0] }},
+		)
+	}
+	return mutations
+}
+
+// mutationPositions returns the positions of the first, middle, and last bytes of an output of length n, without
+// duplicates.
+func mutationPositions(n int) []int {
+	var positions []int
+	for _, pos := range []int{0, n / 2, n - 1} {
+		if pos >= 0 && pos < n && (len(positions) == 0 || positions[len(positions)-1] != pos) {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCheckAssertionsDetectChanges(t *testing.T) {
+	equals := func(expected string) func([]byte) error {
+		return func(b []byte) error {
+			if !bytes.Equal(b, []byte(expected)) {
+				return fmt.Errorf("got %q", b)
+			}
+			return nil
+		}
+	}
+	calls := 0
+
+	tests := []struct {
+		name             string
+		produce          func() []byte
+		assert           func([]byte) error
+		expectedOK       bool
+		expectedFailures []string
+	}{
+		{
+			name:             "exact assertion",
+			produce:          func() []byte { return []byte("abc") },
+			assert:           equals("abc"),
+			expectedOK:       true,
+			expectedFailures: []string{},
+		},
+		{
+			name:    "vacuous assertion",
+			produce: func() []byte { return []byte("ab") },
+			assert: func(b []byte) error {
+				if len(b) < 2 {
+					return errors.New("too short")
+				}
+				return nil
+			},
+			expectedOK: false,
+			expectedFailures: []string{
+				"Assertions still pass on 7 mutated output(s), so they may not be checking enough:\n" +
+					"byte 0 with its lowest bit flipped: \"`b\"\n" +
+					"byte 0 incremented: \"bb\"\n" +
+					"byte 0 decremented: \"`b\"\n" +
+					"byte 1 with its lowest bit flipped: \"ac\"\n" +
+					"byte 1 incremented: \"ac\"\n" +
+					"byte 1 decremented: \"aa\"\n" +
+					"zero byte appended: \"ab\\x00\"",
+			},
+		},
+		{
+			name:             "failing assertion",
+			produce:          func() []byte { return []byte("abc") },
+			assert:           equals("abd"),
+			expectedOK:       false,
+			expectedFailures: []string{"Assertions fail on the unmutated output: got \"abc\""},
+		},
+		{
+			name: "nondeterministic output",
+			produce: func() []byte {
+				calls++
+				return []byte(fmt.Sprint(calls))
+			},
+			assert:     equals("1"),
+			expectedOK: false,
+			expectedFailures: []string{
+				"Output isn't deterministic, so mutations can't be checked: first call returned\n\"1\"\nsecond call " +
+					"returned\n\"2\"",
+			},
+		},
+		{
+			name:             "empty output",
+			produce:          func() []byte { return nil },
+			assert:           equals(""),
+			expectedOK:       true,
+			expectedFailures: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		mockT := &testhelptest.RecordingT{}
+		ok := CheckAssertionsDetectChanges(mockT, test.produce, test.assert)
+		if ok != test.expectedOK {
+			t.Errorf("CheckAssertionsDetectChanges(): Incorrect return value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedOK, ok, test.name)
+		}
+		if strings.Join(mockT.Failures(), "\n---\n") != strings.Join(test.expectedFailures, "\n---\n") {
+			t.Errorf("CheckAssertionsDetectChanges(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedFailures, mockT.Failures(), test.name)
+		}
+	}
+}
+
+func TestMutationPositions(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected []int
+	}{
+		{n: 0, expected: nil},
+		{n: 1, expected: []int{0}},
+		{n: 2, expected: []int{0, 1}},
+		{n: 10, expected: []int{0, 5, 9}},
+	}
+	for _, test := range tests {
+		if got := mutationPositions(test.n); fmt.Sprint(got) != fmt.Sprint(test.expected) {
+			t.Errorf("mutationPositions(): Incorrect positions: expected\n%#+v\ngot\n%#+v\nin test '%d'",
+				test.expected, got, test.n)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxGeneratedNameLen is the length (in runes) beyond which NameFor truncates names.
+const maxGeneratedNameLen = 64
+
+// tableEntryFields are the fields of this package's table types that don't describe the case itself, which NameFor
+// leaves out.
+var tableEntryFields = map[string]bool{
+	"Name": true, "Skip": true, "SkipReason": true, "ExpectedFailure": true, "Retries": true, "Loc": true,
+}
+
+// NameFor returns a stable, readable name for a test case, derived from its data, such as for table entries generated
+// as the cross product of a set of inputs.  For a struct (or a pointer to one), the name lists the exported fields
+// with non-zero values, as Field=value separated by commas, e.g. `Input=abc,Limit=3`, leaving out fields of function
+// and channel types and the bookkeeping fields of this package's table types (Name, Skip, SkipReason,
+// ExpectedFailure, Retries, and Loc); for any other value, it's the value itself.  Strings are given as they are, and
+// other values as with fmt.Sprint (so using their String or Error methods, if they have them), or for composite values,
+// as with Format, on one line.
+//
+// Names longer than 64 runes are truncated, and end with "~" and a hash of the full name, so that cases that differ
+// only after the cut still get different names.  If there's nothing to name a case after (such as for a struct with
+// only function fields set), NameFor returns "".
+//
+// The subtest runners (RunPanicTests, etc.) use NameFor for entries whose Name is empty.  Entries whose derived names
+// are the same are made unique by adding #2, #3, etc. to the second and later ones, and entries for which NameFor
+// returns "" are named after their index, e.g. "entry 3".
+func NameFor(v interface{}) string {
+	name := caseName(reflect.ValueOf(v))
+	if utf8.RuneCountInString(name) <= maxGeneratedNameLen {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name)) // can't fail
+	suffix := fmt.Sprintf("~%08x", h.Sum32())
+	runes := []rune(name)
+	return string(runes[:maxGeneratedNameLen-len(suffix)]) + suffix
+}
+
+// caseName returns the untruncated name for NameFor.
+func caseName(v reflect.Value) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return "nil"
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "nil"
+	}
+	if v.Kind() != reflect.Struct {
+		return caseValueName(v)
+	}
+
+	var parts []string
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		fv := v.Field(i)
+		switch {
+		case !f.IsExported() || tableEntryFields[f.Name] || fv.IsZero():
+			continue
+		case fv.Kind() == reflect.Func || fv.Kind() == reflect.Chan || fv.Kind() == reflect.UnsafePointer:
+			continue
+		}
+		parts = append(parts, f.Name+"="+caseValueName(fv))
+	}
+	return strings.Join(parts, ",")
+}
+
+// caseValueName returns the name for a single value, for NameFor.
+func caseValueName(v reflect.Value) string {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
+		switch v.Interface().(type) {
+		case fmt.Stringer, error:
+		default:
+			return strings.Join(strings.Fields(Format(v.Interface())), " ")
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// A tableNamer names the entries of a table for the subtest runners: entries with names keep them, and the others get
+// names from NameFor, made unique within the table (including against the names that entries were given).
+type tableNamer struct {
+	index int
+	seen  map[string]int
+}
+
+// name returns the name for the next entry of the table, which has the given Name field and is test.
+func (tn *tableNamer) name(name string, test interface{}) string {
+	tn.index++
+	if tn.seen == nil {
+		tn.seen = map[string]int{}
+	}
+	if name != "" {
+		tn.seen[name]++
+		return name
+	}
+	base := NameFor(test)
+	if base == "" {
+		base = fmt.Sprintf("entry %d", tn.index-1)
+	}
+	name = base
+	for n := tn.seen[base] + 1; tn.seen[name] > 0; n++ {
+		name = fmt.Sprintf("%s#%d", base, n)
+	}
+	tn.seen[base]++
+	if name != base {
+		tn.seen[name]++
+	}
+	return name
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nameForCase struct {
+	Input   string
+	Limit   int
+	Timeout time.Duration
+	Err     error
+	Opts    []string
+	Check   func() bool
+	hidden  string
+}
+
+func TestNameFor(t *testing.T) {
+	long := strings.Repeat("x", 80)
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"struct", nameForCase{Input: "abc", Limit: 3}, "Input=abc,Limit=3"},
+		{"pointer", &nameForCase{Input: "abc", Limit: 3}, "Input=abc,Limit=3"},
+		{"stringers and composites", nameForCase{Timeout: time.Second, Err: errors.New("oops"), Opts: []string{"a"}},
+			`Timeout=1s,Err=oops,Opts=[]string{"a"}`},
+		{"skipped fields", nameForCase{Check: func() bool { return true }, hidden: "x"}, ""},
+		{"table type", PanicStrTest{Name: "n", F: func() {}, WantStr: "nil input", Skip: true, Retries: 2,
+			Loc: Loc{File: "a.go", Line: 1}}, "WantStr=nil input"},
+		{"scalar", 5, "5"},
+		{"string", "plain", "plain"},
+		{"nil", nil, "nil"},
+		{"nil pointer", (*nameForCase)(nil), "nil"},
+		{"truncated", nameForCase{Input: long}, "Input=" + strings.Repeat("x", 49) + "~0a237cd2"},
+		{"truncated, different tail", nameForCase{Input: long + "y"}, "Input=" + strings.Repeat("x", 49) + "~a0dd4131"},
+	}
+	for _, test := range tests {
+		got := NameFor(test.v)
+		if got != test.want {
+			t.Errorf("NameFor(): Incorrect name: expected\n%s\ngot\n%s\nin test '%s'", test.want, got, test.name)
+		}
+		if n := len([]rune(got)); n > maxGeneratedNameLen {
+			t.Errorf("NameFor(): Name too long: %d runes in test '%s'", n, test.name)
+		}
+	}
+}
+
+func TestRunTestsWithGeneratedNames(t *testing.T) {
+	r := &subtestRunnerMock{}
+	f := func() { panic("x") }
+	new(SubtestRunner).runPanicStrTests(r, []PanicStrTest{
+		{F: f, WantStr: "x"},
+		{Name: "named", F: f, WantStr: "x"},
+		{F: f, WantStr: "x"},
+		{F: f},
+		{F: f, WantStr: "y"},
+		{Name: "WantStr=z", F: f, WantStr: "x"},
+		{Name: "WantStr=z#2", F: f, WantStr: "x"},
+		{F: f, WantStr: "z"},
+		{F: f, WantStr: "z"},
+	})
+	want := []string{"WantStr=x", "named", "WantStr=x#2", "entry 3", "WantStr=y", "WantStr=z", "WantStr=z#2",
+		"WantStr=z#3", "WantStr=z#4"}
+	if !reflect.DeepEqual(r.names, want) {
+		t.Errorf("runPanicStrTests(): Incorrect subtest names: expected\n%#+v\ngot\n%#+v", want, r.names)
+	}
+	if failures := r.subTs["WantStr=y"].Failures(); len(failures) != 1 {
+		t.Errorf("runPanicStrTests(): Expected one failure for the generated entry, got\n%#+v", failures)
+	}
+}
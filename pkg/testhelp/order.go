@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An Event is an instrumentation point recorded by a Recorder.  Seq is its position in the recorded sequence,
+// starting from 1, and Time is when it was recorded (including the monotonic clock reading, so that durations between
+// events aren't affected by changes to the wall clock).
+type Event struct {
+	Name string
+	Seq  int
+	Time time.Time
+}
+
+// A Recorder records named events (instrumentation points) in the order they happen, for checking their ordering
+// with HappensBefore.  The code under test (or a wrapper around a dependency) calls Mark at each point:
+//
+//	rec := &testhelp.Recorder{}
+//	srv := mypkg.NewServer(mypkg.WithHooks(func(name string) { rec.Mark(name) }))
+//	// ...
+//	testhelp.HappensBefore(t, rec.Events(), "listening", "first request")
+//	testhelp.HappensBefore(t, rec.Events(), "drain", "close")
+//
+// A Recorder is safe for concurrent use.  Events are ordered by when their calls to Mark take the Recorder's lock, so
+// if one Mark call returns before another starts, its event comes first, whatever goroutines they're on; the order of
+// overlapping calls is arbitrary.  The zero value is ready to use.
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Mark records that the named event has happened.
+func (r *Recorder) Mark(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, Event{Name: name, Seq: len(r.events) + 1, Time: time.Now()})
+}
+
+// Events returns a copy of the events recorded so far, in order.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event{}, r.events...)
+}
+
+// HappensBefore checks that the event named a happened before the event named b in events (in the order of the
+// slice, as returned by Recorder.Events), and calls t.Errorf if it didn't, or if either of them didn't happen at all.
+// If a name appears more than once, its first appearance is used, so that HappensBefore checks that b never happened
+// without a having happened already.  The failure message lists the full observed sequence.  HappensBefore returns
+// true if the check passed.
+func HappensBefore(t TestingTB, events []Event, a, b string) bool {
+	t.Helper()
+	posA, posB := eventPos(events, a), eventPos(events, b)
+	var problem string
+	switch {
+	case posA < 0 && posB < 0:
+		problem = fmt.Sprintf("neither %q nor %q happened", a, b)
+	case posA < 0:
+		problem = fmt.Sprintf("%q never happened", a)
+	case posB < 0:
+		problem = fmt.Sprintf("%q never happened", b)
+	case posB < posA:
+		problem = fmt.Sprintf("%q (event %d) happened first, then %q (event %d)", b, posB+1, a, posA+1)
+	default:
+		return true
+	}
+	t.Errorf("Expected %q to happen before %q, but %s\nObserved sequence:\n%s", a, b, problem,
+		eventSequence(events))
+	return false
+}
+
+// eventPos returns the index of the first event with the given name, or -1 if there isn't one.
+func eventPos(events []Event, name string) int {
+	for i, e := range events {
+		if e.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// eventSequence formats events for HappensBefore's failure message, one per line, with their times relative to the
+// first event.
+func eventSequence(events []Event) string {
+	if len(events) == 0 {
+		return "  (no events)"
+	}
+	lines := make([]string, len(events))
+	for i, e := range events {
+		lines[i] = fmt.Sprintf("  %d. %s", i+1, e.Name)
+		if !e.Time.IsZero() && !events[0].Time.IsZero() {
+			lines[i] += fmt.Sprintf(" (+%s)", e.Time.Sub(events[0].Time))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
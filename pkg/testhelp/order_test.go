@@ -0,0 +1,155 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// eventsNamed returns events with the given names and no times, so that failure messages are predictable.
+func eventsNamed(names ...string) []Event {
+	events := make([]Event, len(names))
+	for i, name := range names {
+		events[i] = Event{Name: name, Seq: i + 1}
+	}
+	return events
+}
+
+func TestHappensBefore(t *testing.T) {
+	tests := []struct {
+		name             string
+		events           []Event
+		a                string
+		b                string
+		expectedOK       bool
+		expectedFailures []string
+	}{
+		{
+			name:             "in order",
+			events:           eventsNamed("open", "write", "close"),
+			a:                "open",
+			b:                "close",
+			expectedOK:       true,
+			expectedFailures: []string{},
+		},
+		{
+			name:             "first appearances",
+			events:           eventsNamed("write", "flush", "write", "close"),
+			a:                "write",
+			b:                "flush",
+			expectedOK:       true,
+			expectedFailures: []string{},
+		},
+		{
+			name:       "out of order",
+			events:     eventsNamed("open", "close", "write"),
+			a:          "write",
+			b:          "close",
+			expectedOK: false,
+			expectedFailures: []string{
+				"Expected \"write\" to happen before \"close\", but \"close\" (event 2) happened first, then \"write\" " +
+					"(event 3)\nObserved sequence:\n  1. open\n  2. close\n  3. write",
+			},
+		},
+		{
+			name:       "missing a",
+			events:     eventsNamed("close"),
+			a:          "open",
+			b:          "close",
+			expectedOK: false,
+			expectedFailures: []string{
+				"Expected \"open\" to happen before \"close\", but \"open\" never happened\nObserved sequence:\n  1. close",
+			},
+		},
+		{
+			name:       "missing b",
+			events:     eventsNamed("open"),
+			a:          "open",
+			b:          "close",
+			expectedOK: false,
+			expectedFailures: []string{
+				"Expected \"open\" to happen before \"close\", but \"close\" never happened\nObserved sequence:\n  1. open",
+			},
+		},
+		{
+			name:       "no events",
+			events:     nil,
+			a:          "open",
+			b:          "close",
+			expectedOK: false,
+			expectedFailures: []string{
+				"Expected \"open\" to happen before \"close\", but neither \"open\" nor \"close\" happened\n" +
+					"Observed sequence:\n  (no events)",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		mockT := &testhelptest.RecordingT{}
+		ok := HappensBefore(mockT, test.events, test.a, test.b)
+		if ok != test.expectedOK {
+			t.Errorf("HappensBefore(): Incorrect return value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedOK, ok, test.name)
+		}
+		if fmt.Sprint(mockT.Failures()) != fmt.Sprint(test.expectedFailures) {
+			t.Errorf("HappensBefore(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedFailures, mockT.Failures(), test.name)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	rec := &Recorder{}
+	rec.Mark("start")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec.Mark("worker")
+		}()
+	}
+	wg.Wait()
+	rec.Mark("done")
+
+	events := rec.Events()
+	if len(events) != 12 {
+		t.Fatalf("Recorder: Incorrect number of events: expected\n%#+v\ngot\n%#+v", 12, len(events))
+	}
+	for i, e := range events {
+		if e.Seq != i+1 {
+			t.Errorf("Recorder: Incorrect Seq for event %d: expected\n%#+v\ngot\n%#+v", i, i+1, e.Seq)
+		}
+		if i > 0 && e.Time.Before(events[i-1].Time) {
+			t.Errorf("Recorder: Event %d is earlier than the event before it: %s < %s", i, e.Time, events[i-1].Time)
+		}
+	}
+	HappensBefore(t, events, "start", "worker")
+	HappensBefore(t, events, "worker", "done")
+
+	mockT := &testhelptest.RecordingT{}
+	HappensBefore(mockT, events, "done", "start")
+	if len(mockT.Errors) != 1 || !strings.Contains(mockT.Errors[0], "  12. done (+") {
+		t.Errorf("HappensBefore(): Incorrect failures: expected a sequence with relative times, got\n%#+v",
+			mockT.Errors)
+	}
+}
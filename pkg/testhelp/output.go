@@ -0,0 +1,95 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+)
+
+// CaptureOutput calls f with os.Stdout and os.Stderr redirected to pipes, and returns what f wrote to each.  The
+// originals are restored before CaptureOutput returns, even if f panics (in which case the panic is passed on).  The
+// error is non-nil only if the pipes couldn't be created.  The log package's default logger holds on to the original
+// os.Stderr, so if it's writing there (as it does unless log.SetOutput has been called), it's redirected to the stderr
+// pipe for the duration as well.
+//
+// Since os.Stdout and os.Stderr are global, anything else that writes to them while f is running (such as another
+// goroutine) is captured as well, so CaptureOutput shouldn't be used in parallel tests.  Only the os.Stdout and
+// os.Stderr variables (and the default logger) are redirected, not file descriptors 1 and 2 themselves, so output
+// written to the file descriptors directly (e.g. by C code through cgo, or by a subprocess that inherited them), or
+// through another *os.File saved from os.Stdout or os.Stderr before the call, is not captured.
+func CaptureOutput(f func()) (stdout, stderr string, err error) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return "", "", err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return "", "", err
+	}
+
+	// Read concurrently, so that f can't block on a full pipe
+	var outBuf, errBuf bytes.Buffer
+	done := make(chan struct{}, 2)
+	go func() { _, _ = io.Copy(&outBuf, outR); done <- struct{}{} }()
+	go func() { _, _ = io.Copy(&errBuf, errR); done <- struct{}{} }()
+
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	redirectLog := log.Writer() == io.Writer(origStderr)
+	if redirectLog {
+		log.SetOutput(errW)
+	}
+	defer func() {
+		if redirectLog {
+			log.SetOutput(origStderr)
+		}
+		os.Stdout, os.Stderr = origStdout, origStderr
+		outW.Close()
+		errW.Close()
+		<-done
+		<-done
+		outR.Close()
+		errR.Close()
+		stdout, stderr = outBuf.String(), errBuf.String()
+	}()
+	f()
+	return "", "", nil // overridden by the deferred function
+}
+
+// AssertSilent calls f, and calls t.Errorf, showing the output, if f writes anything to os.Stdout or os.Stderr.  It
+// returns true if f wrote nothing.  This is intended for libraries that must not print, to catch stray debugging
+// output, including from the log package's default logger.  See CaptureOutput for the details and limitations of the
+// capture (in particular, writes straight to file descriptors 1 and 2, such as from cgo or a subprocess, aren't
+// caught); if the output can't be captured, the test fails with t.Fatalf.
+func AssertSilent(t TestingTB, f func()) bool {
+	t.Helper()
+	stdout, stderr, err := CaptureOutput(f)
+	if err != nil {
+		t.Fatalf("Can't capture output: %s", err)
+		return false // for mocks whose Fatalf doesn't stop the test
+	}
+	if stdout == "" && stderr == "" {
+		return true
+	}
+	t.Errorf("Expected no output, got\nstdout:\n%q\nstderr:\n%q", stdout, stderr)
+	return false
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestCaptureOutput(t *testing.T) {
+	origStdout := os.Stdout
+	stdout, stderr, err := CaptureOutput(func() {
+		fmt.Println("to stdout")
+		fmt.Fprint(os.Stderr, strings.Repeat("x", 100000)) // more than a pipe buffer
+	})
+	if err != nil {
+		t.Fatalf("CaptureOutput(): Unexpected error: %s", err)
+	}
+	if stdout != "to stdout\n" || stderr != strings.Repeat("x", 100000) {
+		t.Errorf("CaptureOutput(): Incorrect output: got %q and %d bytes", stdout, len(stderr))
+	}
+	if os.Stdout != origStdout {
+		t.Errorf("CaptureOutput(): os.Stdout not restored")
+	}
+
+	didPanic, pVal := PanicsGet(func() {
+		_, _, _ = CaptureOutput(func() { panic("ppp") })
+	})
+	if !didPanic || pVal != "ppp" || os.Stdout != origStdout {
+		t.Errorf("CaptureOutput(): Expected the panic to be passed on and os.Stdout restored; got %t, %#+v", didPanic,
+			pVal)
+	}
+	origLog, origFlags := log.Writer(), log.Flags()
+	log.SetFlags(0)
+	defer log.SetFlags(origFlags)
+	_, stderr, _ = CaptureOutput(func() { log.Print("from log") })
+	if stderr != "from log\n" {
+		t.Errorf("CaptureOutput(): Incorrect output from the log package: expected\n%#+v\ngot\n%#+v", "from log\n",
+			stderr)
+	}
+	if log.Writer() != origLog {
+		t.Errorf("CaptureOutput(): Log output not restored")
+	}
+}
+
+func TestAssertSilent(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if !AssertSilent(rt, func() {}) || len(rt.Failures()) != 0 {
+		t.Errorf("AssertSilent(): Unexpected failure with no output:\n%#+v", rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	if AssertSilent(rt, func() { fmt.Fprint(os.Stderr, "debug: here") }) {
+		t.Errorf("AssertSilent(): Expected false with output")
+	}
+	want := "Expected no output, got\nstdout:\n\"\"\nstderr:\n\"debug: here\""
+	if failures := rt.Failures(); len(failures) != 1 || failures[0] != want {
+		t.Errorf("AssertSilent(): Incorrect failure(s): expected\n%#+v\ngot\n%#+v", want, failures)
+	}
+}
@@ -18,38 +18,120 @@ package testhelp
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
+	"strconv"
 )
 
 // A PanicTest encapsulates a function that is intended to panic, along with a name for it in diagnostic messages.
+//
+// Like the other table types, it has an optional Loc field, which records where the entry is in the test's source
+// (see Here), so that failure messages can point to it.  The Skip, SkipReason, and ExpectedFailure fields are used by
+// the subtest runners; see RunPanicTests.  Retries is the number of times that the loops run the entry again if it
+// fails, for known-flaky tests; see also LoopRunner.FlakyFunc.
+//
+// Fields are added to the table types from time to time, so tables should be written with keyed composite literals
+// (PanicTest{Name: "empty", F: f}).  Unkeyed literals (PanicTest{"empty", f}) stopped compiling when the Skip,
+// SkipReason, ExpectedFailure, Retries, and Loc fields were added.
 type PanicTest struct {
-	Name string
-	F    func()
+	Name            string
+	F               func()
+	Skip            bool
+	SkipReason      string
+	ExpectedFailure bool
+	Retries         int
+	Loc             Loc
 }
 
 // A PanicStrTest encapsulates a function that is intended to panic, along with a name for it in diagnostic messages,
 // plus a string that should be contained in the panic value.
 type PanicStrTest struct {
-	Name    string
-	F       func()
-	WantStr string
+	Name            string
+	F               func()
+	WantStr         string
+	Skip            bool
+	SkipReason      string
+	ExpectedFailure bool
+	Retries         int
+	Loc             Loc
 }
 
 // A PanicRETest encapsulates a function that is intended to panic, along with a name for it in diagnostic messages,
 // plus a string representing a regular expression that should match the panic value.
 type PanicRETest struct {
-	Name   string
-	F      func()
-	WantRE string
+	Name            string
+	F               func()
+	WantRE          string
+	Skip            bool
+	SkipReason      string
+	ExpectedFailure bool
+	Retries         int
+	Loc             Loc
 }
 
 // A PanicValTest encapsulates a function that is intended to panic, along with a name for it in diagnostic messages,
 // plus a value that should equal the panic value.
 type PanicValTest struct {
+	Name            string
+	F               func()
+	WantVal         interface{}
+	Skip            bool
+	SkipReason      string
+	ExpectedFailure bool
+	Retries         int
+	Loc             Loc
+}
+
+// A PanicRuntimeTest encapsulates a function that is intended to panic, along with a name for it in diagnostic
+// messages, plus the kind of panic it should produce (usually one of the run-time error kinds; see ClassifyPanic).
+//
+// It has the Retries and Loc fields of PanicTest, but not Skip, SkipReason, or ExpectedFailure, which are only used by
+// the subtest runners; PanicRuntimeTest tables are only run by the loops (such as PanicsRuntimeLoop).
+type PanicRuntimeTest struct {
+	Name     string
+	F        func()
+	WantKind PanicKind
+	Retries  int
+	Loc      Loc
+}
+
+// A PanicStrFuncTest is like a PanicStrTest, except that the string that should be contained in the panic value is
+// returned by WantStrFunc when the test is run, so that it can be computed from the same data that F uses.
+//
+// It has the Retries and Loc fields of PanicTest, but not Skip, SkipReason, or ExpectedFailure, which are only used by
+// the subtest runners; PanicStrFuncTest tables are only run by the loops (such as PanicsStrFuncLoop).
+type PanicStrFuncTest struct {
+	Name        string
+	F           func()
+	WantStrFunc func() string
+	Retries     int
+	Loc         Loc
+}
+
+// A PanicREFuncTest is like a PanicRETest, except that the string representing a regular expression that should match
+// the panic value is returned by WantREFunc when the test is run, so that it can be computed from the same data that
+// F uses.
+//
+// It has the Retries and Loc fields of PanicTest, but not Skip, SkipReason, or ExpectedFailure, which are only used by
+// the subtest runners; PanicREFuncTest tables are only run by the loops (such as PanicsREFuncLoop).
+type PanicREFuncTest struct {
+	Name       string
+	F          func()
+	WantREFunc func() string
+	Retries    int
+	Loc        Loc
+}
+
+// A PanicSetupTest encapsulates a function that is intended to panic, along with a name for it in diagnostic
+// messages, plus a setup function whose return value is passed to it.  This allows per-test fixtures to be created
+// when each test runs, rather than when the table is constructed.  Setup may be nil, in which case F is passed nil.
+//
+// It has the Retries and Loc fields of PanicTest, but not Skip, SkipReason, or ExpectedFailure, which are only used by
+// the subtest runners; PanicSetupTest tables are only run by the loops (such as PanicsSetupLoop).
+type PanicSetupTest struct {
 	Name    string
-	F       func()
-	WantVal interface{}
+	Setup   func() interface{}
+	F       func(setupVal interface{})
+	Retries int
+	Loc     Loc
 }
 
 // Panics tests if the given function panics, and returns a boolean that is true if it does.
@@ -134,25 +216,21 @@ func NotPanicsGet(f func()) (didNotPanic bool, pVal interface{}) {
 // pContainsStr will always be true (assuming the panic can be cast to a string), and you will still get the panic
 // value.
 func PanicsStr(f func(), wantStr string) (didPanic bool, pContainsStr bool, pVal interface{}) {
-	defer func() {
-		pVal = recover()
-		didPanic = pVal != nil
-		pStr, ok := pVal.(string)
-		if !ok {
-			var pErr error // pre-allocated so we can reuse ok
-			pErr, ok = pVal.(error)
-			if !ok {
-				pContainsStr = false
-			} else {
-				pStr = pErr.Error()
-			}
-		}
-		if ok { // one of the type assertions succeeded
-			pContainsStr = strings.Contains(pStr, wantStr)
-		}
-	}()
-	f()
-	return false, false, nil // overridden by the deferred function; here for the compiler
+	return PanicsMatching(f, Contains(wantStr))
+}
+
+// PanicsStrNorm is like PanicsStr, but passes both the panic string and wantStr through norm before checking if one
+// contains the other, so that messages that differ only in ways that norm erases (such as case, whitespace, or Unicode
+// normal form) still match.  The panic value is returned as it was, not normalized.  If norm is nil, PanicsStrNorm is
+// the same as PanicsStr.
+//
+// The normtest module provides NormalizeForMatch, which folds case, converts to Unicode NFC, and collapses
+// whitespace, for messages that include user data; it's in a separate module because this package doesn't depend on
+// golang.org/x/text.
+func PanicsStrNorm(f func(), wantStr string, norm func(s string) string) (
+	didPanic bool, pContainsStr bool, pVal interface{},
+) {
+	return PanicsMatching(f, ContainsNorm(wantStr, norm))
 }
 
 // PanicsRE tests if the given function panics, and returns a boolean that is true if it does.  It also takes a string,
@@ -168,31 +246,7 @@ func PanicsStr(f func(), wantStr string) (didPanic bool, pContainsStr bool, pVal
 //
 // PanicsRE itself panics if wantRE does not represent a valid regular expression.
 func PanicsRE(f func(), wantRE string) (didPanic bool, pMatchesRE bool, pVal interface{}) {
-	// Compile so that we can fail immediately if the RE is invalid
-	re, err := regexp.Compile(wantRE)
-	if err != nil {
-		panic(fmt.Sprintf("Regexp could not be compiled: %s", err))
-	}
-
-	defer func() {
-		pVal = recover()
-		didPanic = pVal != nil
-		pStr, ok := pVal.(string)
-		if !ok {
-			var pErr error // pre-allocated so we can reuse ok
-			pErr, ok = pVal.(error)
-			if !ok {
-				pMatchesRE = false
-			} else {
-				pStr = pErr.Error()
-			}
-		}
-		if ok { // one of the type assertions succeeded
-			pMatchesRE = re.MatchString(pStr)
-		}
-	}()
-	f()
-	return false, false, nil // overridden by the deferred function; here for the compiler
+	return PanicsMatching(f, Regexp(wantRE)) // Regexp panics immediately if the RE is invalid
 }
 
 // PanicsVal tests if the given function panics, and returns a boolean that is true if it does.  It also takes a value,
@@ -213,17 +267,81 @@ func PanicsVal(f func(), wantVal interface{}) (didPanic bool, pEquals bool, pVal
 	return false, false, nil // overridden by the deferred function; here for the compiler
 }
 
+// PanicsValEq is like PanicsVal, but compares the panic value with wantVal by calling eq(wantVal, pVal) instead of
+// using ==, for domain-specific equality (such as comparing only the codes of two errors).  eq is only called if the
+// function panics.  If eq is nil, PanicsValEq is the same as PanicsVal.
+//
+// For example:
+//
+//	sameCode := func(a, b interface{}) bool {
+//		var aErr, bErr *mypkg.Error
+//		return errors.As(a.(error), &aErr) && errors.As(b.(error), &bErr) && aErr.Code == bErr.Code
+//	}
+//	didPanic, pEquals, pVal := testhelp.PanicsValEq(f, &mypkg.Error{Code: mypkg.ErrNotFound}, sameCode)
+func PanicsValEq(f func(), wantVal interface{}, eq func(a, b interface{}) bool) (
+	didPanic bool, pEquals bool, pVal interface{},
+) {
+	if eq == nil {
+		return PanicsVal(f, wantVal)
+	}
+	didPanic, pVal = PanicsGet(f)
+	return didPanic, didPanic && eq(wantVal, pVal), pVal
+}
+
+// valEqual compares a panic value with a wanted value, using eq if it isn't nil, and == if it is.
+func valEqual(wantVal, pVal interface{}, eq func(a, b interface{}) bool) bool {
+	if eq != nil {
+		return eq(wantVal, pVal)
+	}
+	return pVal == wantVal
+}
+
+// PanicMessage returns a string describing a panic value, in the same way as this package's own failure messages.  If
+// the value is a string, it is returned as-is; if it is an error, its Error string is returned; if it is a
+// fmt.Stringer, its String result is returned; otherwise, it is formatted with Format.
+//
+// Note that only strings and errors are used for the checks in PanicsStr, PanicsRE, and their loop versions; a
+// Stringer's String result is only used for display.
+func PanicMessage(pVal interface{}) string {
+	if pStr, ok := panicString(pVal); ok {
+		return pStr
+	}
+	if stringer, ok := pVal.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return Format(pVal)
+}
+
+// panicString returns the string that PanicsStr and PanicsRE check a panic value against: the value itself if it is
+// a string, or its Error string if it is an error.  If the value is neither, the boolean is false.
+func panicString(pVal interface{}) (string, bool) {
+	switch v := pVal.(type) {
+	case string:
+		return v, true
+	case error:
+		return v.Error(), true
+	}
+	return "", false
+}
+
+// quotedPanicMessage formats a panic value for the factories' failure messages: PanicMessage's result in quotes if the
+// value can be converted to a string, or the value itself as formatted by Format if not (so that, e.g., the number 5
+// doesn't look like the string "5").
+func quotedPanicMessage(pVal interface{}) string {
+	switch pVal.(type) {
+	case string, error, fmt.Stringer:
+		return strconv.Quote(PanicMessage(pVal))
+	}
+	return Format(pVal)
+}
+
 // PanicsLoop runs through a slice of panic tests.  For any test function that does not panic, elseFunc is called with
 // the name from the test's struct.
 //
 // It is strongly suggested to test the actual panic values with PanicsGetLoop, PanicsStrLoop, PanicsRELoop, or
 // PanicsValLoop instead of using this function.
 func PanicsLoop(tests []PanicTest, elseFunc func(testName string)) {
-	for _, test := range tests {
-		if !Panics(test.F) {
-			elseFunc(test.Name)
-		}
-	}
+	new(LoopRunner).PanicsLoop(tests, elseFunc)
 }
 
 // PanicsGetLoop runs through a slice of panic tests.  For any test function that does not panic, elseFunc is called
@@ -232,14 +350,7 @@ func PanicsLoop(tests []PanicTest, elseFunc func(testName string)) {
 // Note that PanicsStrLoop, PanicsRELoop, and PanicsValLoop provide ways to test the panic values that are generally
 // more convenient than this function.
 func PanicsGetLoop(tests []PanicTest, elseFunc func(testName string), valFunc func(pVal interface{})) {
-	for _, test := range tests {
-		didPanic, pVal := PanicsGet(test.F)
-		if !didPanic {
-			elseFunc(test.Name)
-		} else {
-			valFunc(pVal)
-		}
-	}
+	new(LoopRunner).PanicsGetLoop(tests, elseFunc, valFunc)
 }
 
 // NotPanicsLoop runs through a slice of panic tests.  For any test function that panics, elseFunc is called with the
@@ -248,11 +359,7 @@ func PanicsGetLoop(tests []PanicTest, elseFunc func(testName string), valFunc fu
 // It is strongly suggested to test the actual panic values with NotPanicsGetLoop, PanicsStrLoop, PanicsRELoop, or
 // PanicsValLoop instead of using this function.
 func NotPanicsLoop(tests []PanicTest, elseFunc func(testName string)) {
-	for _, test := range tests {
-		if Panics(test.F) {
-			elseFunc(test.Name)
-		}
-	}
+	new(LoopRunner).NotPanicsLoop(tests, elseFunc)
 }
 
 // NotPanicsGetLoop runs through a slice of panic tests.  For any test function that panics, elseFunc is called with the
@@ -261,12 +368,7 @@ func NotPanicsLoop(tests []PanicTest, elseFunc func(testName string)) {
 // Note that PanicsStrLoop, PanicsRELoop, and PanicsValLoop provide ways to test the panic values that are generally
 // more convenient than this function.
 func NotPanicsGetLoop(tests []PanicTest, elseFunc func(testName string, pVal interface{})) {
-	for _, test := range tests {
-		didPanic, pVal := PanicsGet(test.F)
-		if didPanic {
-			elseFunc(test.Name, pVal)
-		}
-	}
+	new(LoopRunner).NotPanicsGetLoop(tests, elseFunc)
 }
 
 // PanicsStrLoop runs through a slice of panic tests, including checking the panic values to make sure they contain
@@ -279,23 +381,7 @@ func NotPanicsGetLoop(tests []PanicTest, elseFunc func(testName string, pVal int
 func PanicsStrLoop(tests []PanicStrTest, wantStrAll *string, notPanicFunc func(testName string),
 	notContainsFunc func(testName string, wantStr string, pVal interface{}),
 ) {
-	var realWantStr string
-	var didPanic, pContainsStr bool
-	var pVal interface{}
-
-	for _, test := range tests {
-		if wantStrAll != nil {
-			realWantStr = *wantStrAll
-		} else {
-			realWantStr = test.WantStr
-		}
-		didPanic, pContainsStr, pVal = PanicsStr(test.F, realWantStr)
-		if !didPanic {
-			notPanicFunc(test.Name)
-		} else if !pContainsStr {
-			notContainsFunc(test.Name, realWantStr, pVal)
-		}
-	}
+	new(LoopRunner).PanicsStrLoop(tests, wantStrAll, notPanicFunc, notContainsFunc)
 }
 
 // PanicsRELoop runs through a slice of panic tests, including checking the panic values to make sure they match
@@ -311,23 +397,7 @@ func PanicsStrLoop(tests []PanicStrTest, wantStrAll *string, notPanicFunc func(t
 func PanicsRELoop(tests []PanicRETest, wantREAll *string, notPanicFunc func(testName string),
 	notMatchesFunc func(testName string, wantRE string, pVal interface{}),
 ) {
-	var realWantRE string
-	var didPanic, pMatchesRE bool
-	var pVal interface{}
-
-	for _, test := range tests {
-		if wantREAll != nil {
-			realWantRE = *wantREAll
-		} else {
-			realWantRE = test.WantRE
-		}
-		didPanic, pMatchesRE, pVal = PanicsRE(test.F, realWantRE)
-		if !didPanic {
-			notPanicFunc(test.Name)
-		} else if !pMatchesRE {
-			notMatchesFunc(test.Name, realWantRE, pVal)
-		}
-	}
+	new(LoopRunner).PanicsRELoop(tests, wantREAll, notPanicFunc, notMatchesFunc)
 }
 
 // PanicsValLoop runs through a slice of panic tests, including checking the panic values.  For any test function that
@@ -338,27 +408,75 @@ func PanicsRELoop(tests []PanicRETest, wantREAll *string, notPanicFunc func(test
 // See NotEqualsFuncErrorFactory and NotEqualsFuncFatalFactory for good starting points for notEqualsFunc.
 //
 // PanicsValLoop itself panics when attempting to run any test for which the panic value and the test's WantVal are of
-// the same type, but it's not a type that Go can compare with ==.
+// the same type, but it's not a type that Go can compare with ==.  To compare the values some other way, use a
+// LoopRunner with Eq set.
 func PanicsValLoop(tests []PanicValTest, wantValAll *interface{}, notPanicFunc func(testName string),
 	notEqualsFunc func(testName string, wantVal interface{}, pVal interface{}),
 ) {
-	var realWantVal interface{}
-	var didPanic, pEquals bool
-	var pVal interface{}
-
-	for _, test := range tests {
-		if wantValAll != nil {
-			realWantVal = *wantValAll
-		} else {
-			realWantVal = test.WantVal
-		}
-		didPanic, pEquals, pVal = PanicsVal(test.F, realWantVal)
-		if !didPanic {
-			notPanicFunc(test.Name)
-		} else if !pEquals {
-			notEqualsFunc(test.Name, realWantVal, pVal)
-		}
-	}
+	new(LoopRunner).PanicsValLoop(tests, wantValAll, notPanicFunc, notEqualsFunc)
+}
+
+// PanicsRuntimeLoop runs through a slice of panic tests, checking that each test function panics with the kind of
+// panic given by the test's WantKind, as classified by ClassifyPanic, so that tests of run-time errors (such as an
+// index out of range or a nil map assignment) don't depend on the runtime's exact messages.  For any test function
+// that does not panic, notPanicFunc is called with the name from the test's struct.  For any test function that does
+// panic, but with a different kind of panic, wrongKindFunc is called with test information and the panic value.
+//
+// See WrongKindFuncErrorFactory and WrongKindFuncFatalFactory for good starting points for wrongKindFunc.
+func PanicsRuntimeLoop(tests []PanicRuntimeTest, notPanicFunc func(testName string),
+	wrongKindFunc func(testName string, wantKind PanicKind, pVal interface{}),
+) {
+	new(LoopRunner).PanicsRuntimeLoop(tests, notPanicFunc, wrongKindFunc)
+}
+
+// PanicsStrFuncLoop is like PanicsStrLoop, but for tables whose wanted strings are computed when each test is run.
+// For each test, F is called first, and then (if it panicked) WantStrFunc, so the wanted string can depend on
+// anything F does.  For any test function that does not panic, notPanicFunc is called with the name from the test's
+// struct.  For any test function that does panic, but for which the panic value cannot be cast to a string or error
+// containing the string returned by WantStrFunc, notContainsFunc is called with test information and the panic value.
+//
+// See NotContainsFuncErrorFactory and NotContainsFuncFatalFactory for good starting points for notContainsFunc.
+func PanicsStrFuncLoop(tests []PanicStrFuncTest, notPanicFunc func(testName string),
+	notContainsFunc func(testName string, wantStr string, pVal interface{}),
+) {
+	new(LoopRunner).PanicsStrFuncLoop(tests, notPanicFunc, notContainsFunc)
+}
+
+// PanicsREFuncLoop is like PanicsRELoop, but for tables whose wanted regular expressions are computed when each test
+// is run.  For each test, F is called first, and then (if it panicked) WantREFunc, so the wanted regular expression
+// can depend on anything F does.  For any test function that does not panic, notPanicFunc is called with the name from
+// the test's struct.  For any test function that does panic, but for which the panic value cannot be cast to a string
+// or error matching the regular expression returned by WantREFunc, notMatchesFunc is called with test information and
+// the panic value.
+//
+// See NotMatchesFuncErrorFactory and NotMatchesFuncFatalFactory for good starting points for notMatchesFunc.
+//
+// PanicsREFuncLoop itself panics when running any test for which WantREFunc does not return a valid regular
+// expression.
+func PanicsREFuncLoop(tests []PanicREFuncTest, notPanicFunc func(testName string),
+	notMatchesFunc func(testName string, wantRE string, pVal interface{}),
+) {
+	new(LoopRunner).PanicsREFuncLoop(tests, notPanicFunc, notMatchesFunc)
+}
+
+// PanicsSetupLoop runs through a slice of panic tests with setup functions.  For each test, Setup is called, and then
+// F is called with its result.  For any test whose setup function panics, setupPanicFunc is called with the name from
+// the test's struct and the panic value, and F is not called.  For any test function that does not panic,
+// notPanicFunc is called with the name from the test's struct.
+func PanicsSetupLoop(tests []PanicSetupTest, notPanicFunc func(testName string),
+	setupPanicFunc func(testName string, pVal interface{}),
+) {
+	new(LoopRunner).PanicsSetupLoop(tests, notPanicFunc, setupPanicFunc)
+}
+
+// NotPanicsSetupLoop runs through a slice of panic tests with setup functions.  For each test, Setup is called, and
+// then F is called with its result.  For any test whose setup function panics, setupPanicFunc is called with the name
+// from the test's struct and the panic value, and F is not called.  For any test function that panics, elseFunc is
+// called with the name from the test's struct and the panic value.
+func NotPanicsSetupLoop(tests []PanicSetupTest, setupPanicFunc func(testName string, pVal interface{}),
+	elseFunc func(testName string, pVal interface{}),
+) {
+	new(LoopRunner).NotPanicsSetupLoop(tests, setupPanicFunc, elseFunc)
 }
 
 // TestingT is a stub interface intended to be satisfied by a *testing.T.  It is here to help test factory functions
@@ -372,8 +490,8 @@ type TestingT interface {
 // returned function is a closure over a *testing.T which uses it to call Errorf with a generic informative message.
 func NotContainsFuncErrorFactory(t TestingT) func(testName string, wantStr string, pVal interface{}) {
 	return func(testName string, wantStr string, pVal interface{}) {
-		t.Errorf("Incorrect panic value: expected a string containing\n\"%s\"\ngot\n%#+v\nin test '%s'",
-			wantStr, pVal, testName)
+		t.Errorf("%sIncorrect panic value: expected a string containing\n\"%s\"\ngot\n%s\nin test '%s'",
+			failureToken(categoryContains, testName), wantStr, quotedPanicMessage(pVal), testName)
 	}
 }
 
@@ -381,8 +499,8 @@ func NotContainsFuncErrorFactory(t TestingT) func(testName string, wantStr strin
 // returned function is a closure over a *testing.T which uses it to call Fatalf with a generic informative message.
 func NotContainsFuncFatalFactory(t TestingT) func(testName string, wantStr string, pVal interface{}) {
 	return func(testName string, wantStr string, pVal interface{}) {
-		t.Fatalf("Incorrect panic value: expected a string containing\n\"%s\"\ngot\n%#+v\nin test '%s'",
-			wantStr, pVal, testName)
+		t.Fatalf("%sIncorrect panic value: expected a string containing\n\"%s\"\ngot\n%s\nin test '%s'",
+			failureToken(categoryContains, testName), wantStr, quotedPanicMessage(pVal), testName)
 	}
 }
 
@@ -390,8 +508,8 @@ func NotContainsFuncFatalFactory(t TestingT) func(testName string, wantStr strin
 // returned function is a closure over a *testing.T which uses it to call Errorf with a generic informative message.
 func NotMatchesFuncErrorFactory(t TestingT) func(testName string, wantRE string, pVal interface{}) {
 	return func(testName string, wantRE string, pVal interface{}) {
-		t.Errorf("Incorrect panic value: expected a string matching\n\"%s\"\ngot\n%#+v\nin test '%s'",
-			wantRE, pVal, testName)
+		t.Errorf("%sIncorrect panic value: expected a string matching\n\"%s\"\ngot\n%s\nin test '%s'",
+			failureToken(categoryMatches, testName), wantRE, quotedPanicMessage(pVal), testName)
 	}
 }
 
@@ -399,8 +517,8 @@ func NotMatchesFuncErrorFactory(t TestingT) func(testName string, wantRE string,
 // returned function is a closure over a *testing.T which uses it to call Fatalf with a generic informative message.
 func NotMatchesFuncFatalFactory(t TestingT) func(testName string, wantRE string, pVal interface{}) {
 	return func(testName string, wantRE string, pVal interface{}) {
-		t.Fatalf("Incorrect panic value: expected a string matching\n\"%s\"\ngot\n%#+v\nin test '%s'",
-			wantRE, pVal, testName)
+		t.Fatalf("%sIncorrect panic value: expected a string matching\n\"%s\"\ngot\n%s\nin test '%s'",
+			failureToken(categoryMatches, testName), wantRE, quotedPanicMessage(pVal), testName)
 	}
 }
 
@@ -408,8 +526,8 @@ func NotMatchesFuncFatalFactory(t TestingT) func(testName string, wantRE string,
 // returned function is a closure over a *testing.T which uses it to call Errorf with a generic informative message.
 func NotEqualsFuncErrorFactory(t TestingT) func(testName string, wantVal interface{}, pVal interface{}) {
 	return func(testName string, wantVal interface{}, pVal interface{}) {
-		t.Errorf("Incorrect panic value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
-			wantVal, pVal, testName)
+		t.Errorf("%sIncorrect panic value: expected\n%s\ngot\n%s\nin test '%s'",
+			failureToken(categoryEquals, testName), Format(wantVal), Format(pVal), testName)
 	}
 }
 
@@ -417,7 +535,7 @@ func NotEqualsFuncErrorFactory(t TestingT) func(testName string, wantVal interfa
 // returned function is a closure over a *testing.T which uses it to call Fatalf with a generic informative message.
 func NotEqualsFuncFatalFactory(t TestingT) func(testName string, wantVal interface{}, pVal interface{}) {
 	return func(testName string, wantVal interface{}, pVal interface{}) {
-		t.Fatalf("Incorrect panic value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
-			wantVal, pVal, testName)
+		t.Fatalf("%sIncorrect panic value: expected\n%s\ngot\n%s\nin test '%s'",
+			failureToken(categoryEquals, testName), Format(wantVal), Format(pVal), testName)
 	}
 }
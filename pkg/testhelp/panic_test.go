@@ -19,6 +19,7 @@ package testhelp
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -227,6 +228,78 @@ func TestPanicsVal(t *testing.T) {
 	}
 }
 
+// sameFormat is a custom equality function for the Eq tests, which treats values as equal if they format the same, so
+// that values of different integer types can be equal.
+func sameFormat(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func TestPanicsValEq(t *testing.T) {
+	var calls int
+	countingEq := func(a, b interface{}) bool {
+		calls++
+		return sameFormat(a, b)
+	}
+
+	tests := []struct {
+		name       string
+		f          func()
+		inputVal   interface{}
+		eq         func(a, b interface{}) bool
+		wantPanics bool
+		wantEquals bool
+		wantCalls  int
+	}{
+		{"p equal by eq", func() { panic(int64(5)) }, 5, countingEq, true, true, 1},
+		{"p not equal by eq", func() { panic(6) }, 5, countingEq, true, false, 1},
+		{"p uncomparable", func() { panic([]int{1}) }, []int{1}, countingEq, true, true, 1},
+		{"np", func() {}, nil, countingEq, false, false, 0},
+		{"nil eq", func() { panic(int64(5)) }, 5, nil, true, false, 0},
+	}
+	for _, test := range tests {
+		calls = 0
+		didPanic, pEquals, _ := PanicsValEq(test.f, test.inputVal, test.eq)
+		if didPanic != test.wantPanics || pEquals != test.wantEquals {
+			t.Errorf("PanicsValEq(): Incorrect results: expected %t, %t, got %t, %t in test '%s'", test.wantPanics,
+				test.wantEquals, didPanic, pEquals, test.name)
+		}
+		if calls != test.wantCalls {
+			t.Errorf("PanicsValEq(): Incorrect number of eq calls: expected %d, got %d in test '%s'", test.wantCalls,
+				calls, test.name)
+		}
+	}
+}
+
+func TestPanicsStrNorm(t *testing.T) {
+	tests := []struct {
+		name         string
+		f            func()
+		inputStr     string
+		norm         func(s string) string
+		wantPanics   bool
+		wantContains bool
+	}{
+		{"p contains after norm", func() { panic("Bad Input") }, "bad", strings.ToLower, true, true},
+		{"p error contains after norm", func() { panic(errors.New("Bad Input")) }, "INPUT", strings.ToLower, true,
+			true},
+		{"p doesn't contain", func() { panic("Bad Input") }, "good", strings.ToLower, true, false},
+		{"p not a string", func() { panic(5) }, "5", strings.ToLower, true, false},
+		{"np", func() {}, "bad", strings.ToLower, false, false},
+		{"nil norm", func() { panic("Bad Input") }, "bad", nil, true, false},
+	}
+	for _, test := range tests {
+		didPanic, pContainsStr, pVal := PanicsStrNorm(test.f, test.inputStr, test.norm)
+		if didPanic != test.wantPanics || pContainsStr != test.wantContains {
+			t.Errorf("PanicsStrNorm(): Incorrect results: expected %t, %t, got %t, %t in test '%s'", test.wantPanics,
+				test.wantContains, didPanic, pContainsStr, test.name)
+		}
+		if s, ok := pVal.(string); ok && s != "Bad Input" {
+			t.Errorf("PanicsStrNorm(): Incorrect panic value: expected the original value, got %q in test '%s'", s,
+				test.name)
+		}
+	}
+}
+
 func TestPanicsValPanicsWithUncomparableType(t *testing.T) {
 	var didPanic bool
 	var pContainsStr bool
@@ -305,6 +378,35 @@ func TestNotPanicsX2(t *testing.T) {
 	}
 }
 
+type stringerMock struct{}
+
+func (stringerMock) String() string { return "stringer mock" }
+
+func TestPanicMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		pVal  interface{}
+		want  string
+		wantQ string // from quotedPanicMessage
+	}{
+		{"string", "ppp", "ppp", "\"ppp\""},
+		{"error", errors.New("eee"), "eee", "\"eee\""},
+		{"stringer", stringerMock{}, "stringer mock", "\"stringer mock\""},
+		{"float", 27.5, "27.5", "27.5"},
+		{"struct", struct{ A int }{1}, "struct { A int }{A: 1}", "struct { A int }{A: 1}"},
+		{"nil", nil, "nil", "nil"},
+	}
+	for _, test := range tests {
+		if got := PanicMessage(test.pVal); got != test.want {
+			t.Errorf("PanicMessage(): Incorrect message: expected\n%s\ngot\n%s\nin test '%s'", test.want, got, test.name)
+		}
+		if got := quotedPanicMessage(test.pVal); got != test.wantQ {
+			t.Errorf("quotedPanicMessage(): Incorrect message: expected\n%s\ngot\n%s\nin test '%s'",
+				test.wantQ, got, test.name)
+		}
+	}
+}
+
 type PanicStrRETest struct {
 	Name    string
 	F       func()
@@ -463,7 +565,7 @@ func TestPanicsLoopX4(t *testing.T) {
 		noPanic = nil
 		plainTable = []PanicTest{}
 		for _, tableEntry := range test.pTable {
-			plainTable = append(plainTable, PanicTest{tableEntry.Name, tableEntry.F})
+			plainTable = append(plainTable, PanicTest{Name: tableEntry.Name, F: tableEntry.F})
 		}
 		PanicsLoop(plainTable, notPanicFunc)
 		if len(noPanic) != len(test.wantNoPanic) {
@@ -484,7 +586,7 @@ func TestPanicsLoopX4(t *testing.T) {
 		pVals = nil
 		plainTable = []PanicTest{}
 		for _, tableEntry := range test.pTable {
-			plainTable = append(plainTable, PanicTest{tableEntry.Name, tableEntry.F})
+			plainTable = append(plainTable, PanicTest{Name: tableEntry.Name, F: tableEntry.F})
 		}
 		PanicsGetLoop(plainTable, notPanicFunc, valFunc)
 		if len(noPanic) != len(test.wantNoPanic) {
@@ -517,7 +619,7 @@ func TestPanicsLoopX4(t *testing.T) {
 		noContains = nil
 		strTable = []PanicStrTest{}
 		for _, tableEntry := range test.pTable {
-			strTable = append(strTable, PanicStrTest{tableEntry.Name, tableEntry.F, tableEntry.WantStr})
+			strTable = append(strTable, PanicStrTest{Name: tableEntry.Name, F: tableEntry.F, WantStr: tableEntry.WantStr})
 		}
 		PanicsStrLoop(strTable, nil, notPanicFunc, notContainsFunc)
 		if len(noPanic) != len(test.wantNoPanic) {
@@ -550,7 +652,7 @@ func TestPanicsLoopX4(t *testing.T) {
 		noMatches = nil
 		reTable = []PanicRETest{}
 		for _, tableEntry := range test.pTable {
-			reTable = append(reTable, PanicRETest{tableEntry.Name, tableEntry.F, tableEntry.WantRE})
+			reTable = append(reTable, PanicRETest{Name: tableEntry.Name, F: tableEntry.F, WantRE: tableEntry.WantRE})
 		}
 		PanicsRELoop(reTable, nil, notPanicFunc, notMatchesFunc)
 		if len(noPanic) != len(test.wantNoPanic) {
@@ -700,7 +802,7 @@ func TestPanicsLoopWantAllX2(t *testing.T) {
 		noContains = nil
 		strTable = []PanicStrTest{}
 		for _, tableEntry := range test.pTable {
-			strTable = append(strTable, PanicStrTest{tableEntry.Name, tableEntry.F, tableEntry.WantStr})
+			strTable = append(strTable, PanicStrTest{Name: tableEntry.Name, F: tableEntry.F, WantStr: tableEntry.WantStr})
 		}
 		PanicsStrLoop(strTable, &wantStrAll, notPanicFunc, notContainsFunc)
 		if len(noPanic) != 0 {
@@ -726,7 +828,7 @@ func TestPanicsLoopWantAllX2(t *testing.T) {
 		noMatches = nil
 		reTable = []PanicRETest{}
 		for _, tableEntry := range test.pTable {
-			reTable = append(reTable, PanicRETest{tableEntry.Name, tableEntry.F, tableEntry.WantRE})
+			reTable = append(reTable, PanicRETest{Name: tableEntry.Name, F: tableEntry.F, WantRE: tableEntry.WantRE})
 		}
 		PanicsRELoop(reTable, &wantREAll, notPanicFunc, notMatchesFunc)
 		if len(noPanic) != 0 {
@@ -775,8 +877,8 @@ func TestPanicsRELoopPanicsWithBadRE(t *testing.T) {
 			"ok, not ok",
 			[]PanicRETest{
 				// ok but wrong
-				{"ok, not ok: 1", func() { panic("ppp111") }, "c{3}[0-9]{3}"},
-				{"ok, not ok: 2", func() { panic("ppp112") }, badRE2},
+				{Name: "ok, not ok: 1", F: func() { panic("ppp111") }, WantRE: "c{3}[0-9]{3}"},
+				{Name: "ok, not ok: 2", F: func() { panic("ppp112") }, WantRE: badRE2},
 			},
 			// first test within PanicsRELoop proceeds normally, second one panics
 			[]NoCMCallbackResult{{"ok, not ok: 1", "c{3}[0-9]{3}", "ppp111"}},
@@ -784,17 +886,17 @@ func TestPanicsRELoopPanicsWithBadRE(t *testing.T) {
 		{
 			"not ok, ok",
 			[]PanicRETest{
-				{"not ok, ok: 1", func() { panic("ppp221") }, badRE1},
+				{Name: "not ok, ok: 1", F: func() { panic("ppp221") }, WantRE: badRE1},
 				// ok but wrong
-				{"not ok, ok: 2", func() { panic("ppp222") }, "z{3}[0-9]{3}"},
+				{Name: "not ok, ok: 2", F: func() { panic("ppp222") }, WantRE: "z{3}[0-9]{3}"},
 			},
 			[]NoCMCallbackResult{},
 		},
 		{
 			"not ok, not ok",
 			[]PanicRETest{
-				{"not ok, not ok: 1", func() { panic("ppp331") }, badRE1},
-				{"not ok, not ok: 2", func() { panic("ppp332") }, badRE2},
+				{Name: "not ok, not ok: 1", F: func() { panic("ppp331") }, WantRE: badRE1},
+				{Name: "not ok, not ok: 2", F: func() { panic("ppp332") }, WantRE: badRE2},
 			},
 			[]NoCMCallbackResult{},
 		},
@@ -861,8 +963,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"p, eq; p, eq",
 			[]PanicValTest{
-				{"p, eq; p, eq: 1", func() { panic("ppp110") }, "ppp110"},
-				{"p, eq; p, eq: 2", func() { panic("ppp111") }, "ppp111"},
+				{Name: "p, eq; p, eq: 1", F: func() { panic("ppp110") }, WantVal: "ppp110"},
+				{Name: "p, eq; p, eq: 2", F: func() { panic("ppp111") }, WantVal: "ppp111"},
 			},
 			[]string{},
 			[]NoEqualsCallbackResult{},
@@ -871,8 +973,8 @@ func TestPanicsValLoop(t *testing.T) {
 			"p, eq; p, neq",
 			[]PanicValTest{
 				// Non-strings (ints), equal and not equal
-				{"p, eq; p, neq: 1", func() { panic(120) }, 120},
-				{"p, eq; p, neq: 2", func() { panic(121) }, 129},
+				{Name: "p, eq; p, neq: 1", F: func() { panic(120) }, WantVal: 120},
+				{Name: "p, eq; p, neq: 2", F: func() { panic(121) }, WantVal: 129},
 			},
 			[]string{},
 			[]NoEqualsCallbackResult{{"p, eq; p, neq: 2", 129, 121}},
@@ -880,8 +982,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"p, eq; np",
 			[]PanicValTest{
-				{"p, eq; np: 1", func() { panic("ppp130") }, "ppp130"},
-				{"p, eq; np: 2", func() {}, "ppp131"},
+				{Name: "p, eq; np: 1", F: func() { panic("ppp130") }, WantVal: "ppp130"},
+				{Name: "p, eq; np: 2", F: func() {}, WantVal: "ppp131"},
 			},
 			[]string{"p, eq; np: 2"},
 			[]NoEqualsCallbackResult{},
@@ -890,8 +992,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"p, neq; p, eq",
 			[]PanicValTest{
-				{"p, neq; p, eq: 1", func() { panic("ppp210") }, "ccc210"},
-				{"p, neq; p, eq: 2", func() { panic("ppp211") }, "ppp211"},
+				{Name: "p, neq; p, eq: 1", F: func() { panic("ppp210") }, WantVal: "ccc210"},
+				{Name: "p, neq; p, eq: 2", F: func() { panic("ppp211") }, WantVal: "ppp211"},
 			},
 			[]string{},
 			[]NoEqualsCallbackResult{{"p, neq; p, eq: 1", "ccc210", "ppp210"}},
@@ -900,8 +1002,8 @@ func TestPanicsValLoop(t *testing.T) {
 			"p, neq; p, neq",
 			[]PanicValTest{
 				// String vs. int, float vs. int
-				{"p, neq; p, neq: 1", func() { panic("220") }, 220},
-				{"p, neq; p, neq: 2", func() { panic(221.0) }, 221},
+				{Name: "p, neq; p, neq: 1", F: func() { panic("220") }, WantVal: 220},
+				{Name: "p, neq; p, neq: 2", F: func() { panic(221.0) }, WantVal: 221},
 			},
 			[]string{},
 			[]NoEqualsCallbackResult{
@@ -912,8 +1014,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"p, neq; np",
 			[]PanicValTest{
-				{"p, neq; np: 1", func() { panic("ppp230") }, "ccc230"},
-				{"p, neq; np: 2", func() {}, "ppp231"},
+				{Name: "p, neq; np: 1", F: func() { panic("ppp230") }, WantVal: "ccc230"},
+				{Name: "p, neq; np: 2", F: func() {}, WantVal: "ppp231"},
 			},
 			[]string{"p, neq; np: 2"},
 			[]NoEqualsCallbackResult{{"p, neq; np: 1", "ccc230", "ppp230"}},
@@ -922,8 +1024,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"np; p, eq",
 			[]PanicValTest{
-				{"np; p, eq: 1", func() {}, "ppp310"},
-				{"np; p, eq: 2", func() { panic("ppp311") }, "ppp311"},
+				{Name: "np; p, eq: 1", F: func() {}, WantVal: "ppp310"},
+				{Name: "np; p, eq: 2", F: func() { panic("ppp311") }, WantVal: "ppp311"},
 			},
 			[]string{"np; p, eq: 1"},
 			[]NoEqualsCallbackResult{},
@@ -931,8 +1033,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"np; p, neq",
 			[]PanicValTest{
-				{"np; p, neq: 1", func() {}, "ppp320"},
-				{"np; p, neq: 2", func() { panic("ppp321") }, "zzz321"},
+				{Name: "np; p, neq: 1", F: func() {}, WantVal: "ppp320"},
+				{Name: "np; p, neq: 2", F: func() { panic("ppp321") }, WantVal: "zzz321"},
 			},
 			[]string{"np; p, neq: 1"},
 			[]NoEqualsCallbackResult{{"np; p, neq: 2", "zzz321", "ppp321"}},
@@ -940,8 +1042,8 @@ func TestPanicsValLoop(t *testing.T) {
 		{
 			"np; np",
 			[]PanicValTest{
-				{"np; np: 1", func() {}, "ppp330"},
-				{"np; np: 2", func() {}, "ppp331"},
+				{Name: "np; np: 1", F: func() {}, WantVal: "ppp330"},
+				{Name: "np; np: 2", F: func() {}, WantVal: "ppp331"},
 			},
 			[]string{"np; np: 1", "np; np: 2"},
 			[]NoEqualsCallbackResult{},
@@ -996,8 +1098,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval false; eq, eq",
 			[]PanicValTest{
-				{"testval false; eq, eq: 1", func() { panic("ppp11") }, "ccc11"},
-				{"testval false; eq, eq: 2", func() { panic("ppp11") }, "zzz11"},
+				{Name: "testval false; eq, eq: 1", F: func() { panic("ppp11") }, WantVal: "ccc11"},
+				{Name: "testval false; eq, eq: 2", F: func() { panic("ppp11") }, WantVal: "zzz11"},
 			},
 			"ppp11",
 			[]NoEqualsCallbackResult{},
@@ -1005,8 +1107,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval false; eq, neq",
 			[]PanicValTest{
-				{"testval false; eq, neq: 1", func() { panic(12) }, 812},
-				{"testval false; eq, neq: 2", func() { panic(120) }, 912},
+				{Name: "testval false; eq, neq: 1", F: func() { panic(12) }, WantVal: 812},
+				{Name: "testval false; eq, neq: 2", F: func() { panic(120) }, WantVal: 912},
 			},
 			12,
 			[]NoEqualsCallbackResult{{"testval false; eq, neq: 2", 12, 120}},
@@ -1014,8 +1116,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval false; neq, eq",
 			[]PanicValTest{
-				{"testval false; neq, eq: 1", func() { panic("rrr13") }, "ccc13"},
-				{"testval false; neq, eq: 2", func() { panic("ppp13") }, "zzz13"},
+				{Name: "testval false; neq, eq: 1", F: func() { panic("rrr13") }, WantVal: "ccc13"},
+				{Name: "testval false; neq, eq: 2", F: func() { panic("ppp13") }, WantVal: "zzz13"},
 			},
 			"ppp13",
 			[]NoEqualsCallbackResult{{"testval false; neq, eq: 1", "ppp13", "rrr13"}},
@@ -1023,8 +1125,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval false; neq, neq",
 			[]PanicValTest{
-				{"testval false; neq, neq: 1", func() { panic(14) }, 814},
-				{"testval false; neq, neq: 2", func() { panic(14) }, 914},
+				{Name: "testval false; neq, neq: 1", F: func() { panic(14) }, WantVal: 814},
+				{Name: "testval false; neq, neq: 2", F: func() { panic(14) }, WantVal: 914},
 			},
 			140,
 			[]NoEqualsCallbackResult{
@@ -1036,8 +1138,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval true; eq, eq",
 			[]PanicValTest{
-				{"testval true; eq, eq: 1", func() { panic("ppp11") }, "ppp11"},
-				{"testval true; eq, eq: 2", func() { panic("ppp11") }, "ppp11"},
+				{Name: "testval true; eq, eq: 1", F: func() { panic("ppp11") }, WantVal: "ppp11"},
+				{Name: "testval true; eq, eq: 2", F: func() { panic("ppp11") }, WantVal: "ppp11"},
 			},
 			"ppp11",
 			[]NoEqualsCallbackResult{},
@@ -1045,8 +1147,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval true; eq, neq",
 			[]PanicValTest{
-				{"testval true; eq, neq: 1", func() { panic(12) }, 12},
-				{"testval true; eq, neq: 2", func() { panic(120) }, 120},
+				{Name: "testval true; eq, neq: 1", F: func() { panic(12) }, WantVal: 12},
+				{Name: "testval true; eq, neq: 2", F: func() { panic(120) }, WantVal: 120},
 			},
 			12,
 			[]NoEqualsCallbackResult{{"testval true; eq, neq: 2", 12, 120}},
@@ -1054,8 +1156,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval true; neq, eq",
 			[]PanicValTest{
-				{"testval true; neq, eq: 1", func() { panic("rrr13") }, "rrr13"},
-				{"testval true; neq, eq: 2", func() { panic("ppp13") }, "ppp13"},
+				{Name: "testval true; neq, eq: 1", F: func() { panic("rrr13") }, WantVal: "rrr13"},
+				{Name: "testval true; neq, eq: 2", F: func() { panic("ppp13") }, WantVal: "ppp13"},
 			},
 			"ppp13",
 			[]NoEqualsCallbackResult{{"testval true; neq, eq: 1", "ppp13", "rrr13"}},
@@ -1063,8 +1165,8 @@ func TestPanicsValLoopWantValAll(t *testing.T) {
 		{
 			"testval true; neq, neq",
 			[]PanicValTest{
-				{"testval true; neq, neq: 1", func() { panic(14) }, 14},
-				{"testval true; neq, neq: 2", func() { panic(14) }, 14},
+				{Name: "testval true; neq, neq: 1", F: func() { panic(14) }, WantVal: 14},
+				{Name: "testval true; neq, neq: 2", F: func() { panic(14) }, WantVal: 14},
 			},
 			140,
 			[]NoEqualsCallbackResult{
@@ -1121,8 +1223,8 @@ func TestPanicsValLoopPanicsWithUncomparableType(t *testing.T) {
 			"ok, not ok",
 			[]PanicValTest{
 				// ok but wrong
-				{"ok, not ok: 1", func() { panic("ppp111") }, "zzz111"},
-				{"ok, not ok: 2", func() { panic([]string{"a", "b"}) }, []string{"a", "b"}},
+				{Name: "ok, not ok: 1", F: func() { panic("ppp111") }, WantVal: "zzz111"},
+				{Name: "ok, not ok: 2", F: func() { panic([]string{"a", "b"}) }, WantVal: []string{"a", "b"}},
 			},
 			// first test within PanicsValLoop proceeds normally, second one panics
 			[]NoEqualsCallbackResult{{"ok, not ok: 1", "zzz111", "ppp111"}},
@@ -1130,9 +1232,9 @@ func TestPanicsValLoopPanicsWithUncomparableType(t *testing.T) {
 		{
 			"not ok, ok",
 			[]PanicValTest{
-				{"not ok, ok: 1", func() { panic([]string{"a", "b"}) }, []string{"a", "b"}},
+				{Name: "not ok, ok: 1", F: func() { panic([]string{"a", "b"}) }, WantVal: []string{"a", "b"}},
 				// ok but wrong
-				{"not ok, ok: 2", func() { panic("ppp222") }, "zzz222"},
+				{Name: "not ok, ok: 2", F: func() { panic("ppp222") }, WantVal: "zzz222"},
 			},
 			[]NoEqualsCallbackResult{},
 		},
@@ -1140,8 +1242,8 @@ func TestPanicsValLoopPanicsWithUncomparableType(t *testing.T) {
 			"not ok, not ok",
 			[]PanicValTest{
 				// one not ok but correct, one not ok and wrong
-				{"not ok, not ok: 1", func() { panic([]string{"a", "b"}) }, []string{"a", "b"}},
-				{"not ok, not ok: 2", func() { panic([]string{"a", "b"}) }, []string{"c", "d"}},
+				{Name: "not ok, not ok: 1", F: func() { panic([]string{"a", "b"}) }, WantVal: []string{"a", "b"}},
+				{Name: "not ok, not ok: 2", F: func() { panic([]string{"a", "b"}) }, WantVal: []string{"c", "d"}},
 			},
 			[]NoEqualsCallbackResult{},
 		},
@@ -1184,6 +1286,88 @@ func TestPanicsValLoopPanicsWithUncomparableType(t *testing.T) {
 	}
 }
 
+// Tests PanicsStrFuncLoop and PanicsREFuncLoop
+func TestPanicsFuncLoopX2(t *testing.T) {
+	var noPanic []string
+	var noContains []NoCMCallbackResult
+	var noMatches []NoCMCallbackResult
+
+	notPanicFunc := func(testName string) { noPanic = append(noPanic, testName) }
+	notContainsFunc := func(testName string, wantStr string, pVal interface{}) {
+		noContains = append(noContains, NoCMCallbackResult{testName, wantStr, pVal})
+	}
+	notMatchesFunc := func(testName string, wantRE string, pVal interface{}) {
+		noMatches = append(noMatches, NoCMCallbackResult{testName, wantRE, pVal})
+	}
+
+	// The wanted strings depend on values that are only set when the test functions run
+	var index int
+	panicAt := func(i int) func() {
+		return func() {
+			index = i
+			panic(fmt.Sprintf("bad index %d", i))
+		}
+	}
+	wantStrFunc := func() string { return fmt.Sprintf("index %d", index) }
+	wantREFunc := func() string { return fmt.Sprintf("^bad index %d$", index) }
+	wrongStrFunc := func() string { return fmt.Sprintf("index %d", index+1) }
+	wrongREFunc := func() string { return fmt.Sprintf("^bad index %d$", index+1) }
+	uncalledFunc := func() string {
+		t.Errorf("Want function called for a test function that did not panic")
+		return ""
+	}
+
+	PanicsStrFuncLoop([]PanicStrFuncTest{
+		{Name: "cm", F: panicAt(1), WantStrFunc: wantStrFunc},
+		{Name: "ncm", F: panicAt(2), WantStrFunc: wrongStrFunc},
+		{Name: "np", F: func() {}, WantStrFunc: uncalledFunc},
+		{Name: "non-str", F: func() { panic(27) }, WantStrFunc: wantStrFunc},
+	}, notPanicFunc, notContainsFunc)
+	wantNoContains := []NoCMCallbackResult{{"ncm", "index 3", "bad index 2"}, {"non-str", "index 2", 27}}
+	if len(noPanic) != 1 || noPanic[0] != "np" {
+		t.Errorf("PanicsStrFuncLoop(): Wrong panic-test failures: expected\n%#+v\ngot\n%#+v", []string{"np"}, noPanic)
+	}
+	if len(noContains) != len(wantNoContains) {
+		t.Errorf("PanicsStrFuncLoop(): Wrong panic-contains failures: expected\n%#+v\ngot\n%#+v",
+			wantNoContains, noContains)
+	} else {
+		for i := range noContains {
+			if noContains[i] != wantNoContains[i] {
+				t.Errorf("PanicsStrFuncLoop(): Wrong panic-contains failure: expected\n%#+v\ngot\n%#+v",
+					wantNoContains[i], noContains[i])
+			}
+		}
+	}
+
+	noPanic = nil
+	PanicsREFuncLoop([]PanicREFuncTest{
+		{Name: "cm", F: panicAt(1), WantREFunc: wantREFunc},
+		{Name: "ncm", F: panicAt(2), WantREFunc: wrongREFunc},
+		{Name: "np", F: func() {}, WantREFunc: uncalledFunc},
+	}, notPanicFunc, notMatchesFunc)
+	wantNoMatches := []NoCMCallbackResult{{"ncm", "^bad index 3$", "bad index 2"}}
+	if len(noPanic) != 1 || noPanic[0] != "np" {
+		t.Errorf("PanicsREFuncLoop(): Wrong panic-test failures: expected\n%#+v\ngot\n%#+v", []string{"np"}, noPanic)
+	}
+	if len(noMatches) != 1 || noMatches[0] != wantNoMatches[0] {
+		t.Errorf("PanicsREFuncLoop(): Wrong panic-matches failures: expected\n%#+v\ngot\n%#+v",
+			wantNoMatches, noMatches)
+	}
+
+	// Bad regexps cause a panic
+	wantStr := "Regexp could not be compiled"
+	didPanic, pContainsStr, pVal := PanicsStr(func() {
+		PanicsREFuncLoop([]PanicREFuncTest{{Name: "bad", F: panicAt(1), WantREFunc: func() string { return "[a-z" }}},
+			notPanicFunc, notMatchesFunc)
+	}, wantStr)
+	if !didPanic {
+		t.Errorf("Expected PanicsREFuncLoop() itself to panic with a bad regexp")
+	} else if !pContainsStr {
+		t.Errorf("Incorrect panic value from PanicsREFuncLoop() itself: expected string containing\n%#+v\ngot\n%#+v",
+			wantStr, pVal)
+	}
+}
+
 // Tests NotPanicsLoop, NotPanicsGetLoop
 func TestNotPanicsLoopX2(t *testing.T) {
 	var failed []string
@@ -1204,8 +1388,8 @@ func TestNotPanicsLoopX2(t *testing.T) {
 		{
 			"neither panics",
 			[]PanicTest{
-				{"neither panics: 1", func() {}},
-				{"neither panics: 2", func() {}},
+				{Name: "neither panics: 1", F: func() {}},
+				{Name: "neither panics: 2", F: func() {}},
 			},
 			[]string{},
 			[]interface{}{},
@@ -1213,8 +1397,8 @@ func TestNotPanicsLoopX2(t *testing.T) {
 		{
 			"first panics",
 			[]PanicTest{
-				{"first panics: 1", func() { panic("fp1") }},
-				{"first panics: 2", func() {}},
+				{Name: "first panics: 1", F: func() { panic("fp1") }},
+				{Name: "first panics: 2", F: func() {}},
 			},
 			[]string{"first panics: 1"},
 			[]interface{}{"fp1"},
@@ -1222,8 +1406,8 @@ func TestNotPanicsLoopX2(t *testing.T) {
 		{
 			"second panics",
 			[]PanicTest{
-				{"second panics: 1", func() {}},
-				{"second panics: 2", func() { panic("sp2") }},
+				{Name: "second panics: 1", F: func() {}},
+				{Name: "second panics: 2", F: func() { panic("sp2") }},
 			},
 			[]string{"second panics: 2"},
 			[]interface{}{"sp2"},
@@ -1231,8 +1415,8 @@ func TestNotPanicsLoopX2(t *testing.T) {
 		{
 			"both panic",
 			[]PanicTest{
-				{"both panic: 1", func() { panic("bp1") }},
-				{"both panic: 2", func() { panic("bp2") }},
+				{Name: "both panic: 1", F: func() { panic("bp1") }},
+				{Name: "both panic: 2", F: func() { panic("bp2") }},
 			},
 			[]string{"both panic: 1", "both panic: 2"},
 			[]interface{}{"bp1", "bp2"},
@@ -1286,6 +1470,60 @@ func TestNotPanicsLoopX2(t *testing.T) {
 	}
 }
 
+// Tests PanicsSetupLoop and NotPanicsSetupLoop
+func TestPanicsSetupLoopX2(t *testing.T) {
+	var noPanic []string
+	var setupPanics []NoEqualsCallbackResult
+	var panicked []NoEqualsCallbackResult
+
+	notPanicFunc := func(testName string) { noPanic = append(noPanic, testName) }
+	setupPanicFunc := func(testName string, pVal interface{}) {
+		setupPanics = append(setupPanics, NoEqualsCallbackResult{testName, nil, pVal})
+	}
+	elseFunc := func(testName string, pVal interface{}) {
+		panicked = append(panicked, NoEqualsCallbackResult{testName, nil, pVal})
+	}
+
+	// F panics if the fixture's slice is empty
+	f := func(setupVal interface{}) {
+		_ = setupVal.([]int)[0]
+	}
+	fCalled := false
+	noFFunc := func(interface{}) { fCalled = true }
+	table := []PanicSetupTest{
+		{Name: "setup, p", Setup: func() interface{} { return []int{} }, F: f},
+		{Name: "setup, np", Setup: func() interface{} { return []int{1} }, F: f},
+		{Name: "setup panics", Setup: func() interface{} { panic("sss") }, F: noFFunc},
+		{Name: "nil setup, np", Setup: nil, F: func(setupVal interface{}) {
+			if setupVal != nil {
+				panic("setupVal not nil")
+			}
+		}},
+	}
+
+	PanicsSetupLoop(table, notPanicFunc, setupPanicFunc)
+	wantNoPanic := []string{"setup, np", "nil setup, np"}
+	if len(noPanic) != len(wantNoPanic) || noPanic[0] != wantNoPanic[0] || noPanic[1] != wantNoPanic[1] {
+		t.Errorf("PanicsSetupLoop(): Wrong panic-test failures: expected\n%#+v\ngot\n%#+v", wantNoPanic, noPanic)
+	}
+	if len(setupPanics) != 1 || setupPanics[0].Name != "setup panics" || setupPanics[0].Val != "sss" {
+		t.Errorf("PanicsSetupLoop(): Wrong setup-panic failures:\n%#+v", setupPanics)
+	}
+
+	setupPanics = nil
+	NotPanicsSetupLoop(table, setupPanicFunc, elseFunc)
+	if len(panicked) != 1 || panicked[0].Name != "setup, p" {
+		t.Errorf("NotPanicsSetupLoop(): Wrong not-panic-test failures:\n%#+v", panicked)
+	}
+	if len(setupPanics) != 1 || setupPanics[0].Name != "setup panics" || setupPanics[0].Val != "sss" {
+		t.Errorf("NotPanicsSetupLoop(): Wrong setup-panic failures:\n%#+v", setupPanics)
+	}
+
+	if fCalled {
+		t.Errorf("Test function called after its setup function panicked")
+	}
+}
+
 type TestingTMock struct{}
 
 var mockedErrors, mockedFatals []string
@@ -1337,7 +1575,7 @@ func TestPanicsLoopFactoriesX6(t *testing.T) {
 	// Test NotContainsFuncErrorFactory and NotContainsFuncFatalFactory with PanicsStrLoop
 	strTable := []PanicStrTest{}
 	for _, tableEntry := range strReValTable {
-		strTable = append(strTable, PanicStrTest{tableEntry.Name, tableEntry.F, tableEntry.WantStr})
+		strTable = append(strTable, PanicStrTest{Name: tableEntry.Name, F: tableEntry.F, WantStr: tableEntry.WantStr})
 	}
 	mockedErrors = nil
 	mockedFatals = nil
@@ -1374,7 +1612,7 @@ func TestPanicsLoopFactoriesX6(t *testing.T) {
 	// Test NotMatchesFuncErrorFactory and NotMatchesFuncFatalFactory with PanicsRELoop
 	reTable := []PanicRETest{}
 	for _, tableEntry := range strReValTable {
-		reTable = append(reTable, PanicRETest{tableEntry.Name, tableEntry.F, tableEntry.WantRE})
+		reTable = append(reTable, PanicRETest{Name: tableEntry.Name, F: tableEntry.F, WantRE: tableEntry.WantRE})
 	}
 	mockedErrors = nil
 	mockedFatals = nil
@@ -1411,7 +1649,7 @@ func TestPanicsLoopFactoriesX6(t *testing.T) {
 	// Test NotEqualsFuncErrorFactory and NotEqualsFuncFatalFactory with PanicsValLoop
 	valTable := []PanicValTest{}
 	for _, tableEntry := range strReValTable {
-		valTable = append(valTable, PanicValTest{tableEntry.Name, tableEntry.F, tableEntry.WantVal})
+		valTable = append(valTable, PanicValTest{Name: tableEntry.Name, F: tableEntry.F, WantVal: tableEntry.WantVal})
 	}
 	mockedErrors = nil
 	mockedFatals = nil
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+)
+
+// A PanicKind classifies a panic value; see ClassifyPanic.
+type PanicKind int
+
+// Kinds of panic.  The runtime kinds are for the run-time errors that the Go runtime panics with.
+const (
+	PanicKindNone          PanicKind = iota // no panic (a nil value)
+	PanicKindValue                          // an explicit panic with a value that isn't an error, such as a string
+	PanicKindError                          // an explicit panic with an error that isn't a run-time error
+	PanicKindNilDeref                       // a nil pointer dereference (or other invalid memory address)
+	PanicKindIndex                          // an index out of range
+	PanicKindSlice                          // slice bounds out of range
+	PanicKindNilMap                         // an assignment to an entry in a nil map
+	PanicKindDivideByZero                   // an integer divide by zero
+	PanicKindTypeAssertion                  // a failed type assertion (without the two-result form)
+	PanicKindClosedChannel                  // a send on or close of a closed channel, or a close of a nil channel
+	PanicKindOtherRuntime                   // any other run-time error
+)
+
+var panicKindNames = map[PanicKind]string{
+	PanicKindNone:          "no panic",
+	PanicKindValue:         "value",
+	PanicKindError:         "error",
+	PanicKindNilDeref:      "nil dereference",
+	PanicKindIndex:         "index out of range",
+	PanicKindSlice:         "slice bounds out of range",
+	PanicKindNilMap:        "nil map assignment",
+	PanicKindDivideByZero:  "divide by zero",
+	PanicKindTypeAssertion: "type assertion",
+	PanicKindClosedChannel: "closed channel",
+	PanicKindOtherRuntime:  "other run-time error",
+}
+
+func (k PanicKind) String() string {
+	if name, ok := panicKindNames[k]; ok {
+		return name
+	}
+	return "unknown panic kind"
+}
+
+// IsRuntime returns true if k is one of the kinds of run-time error.
+func (k PanicKind) IsRuntime() bool {
+	return k >= PanicKindNilDeref && k <= PanicKindOtherRuntime
+}
+
+// runtimeKinds maps distinctive parts of the runtime's error messages to the kinds they indicate.  The messages are
+// matched here, in one place, so that tests don't have to match them themselves.
+var runtimeKinds = []struct {
+	substr string
+	kind   PanicKind
+}{
+	{"nil pointer dereference", PanicKindNilDeref},
+	{"invalid memory address", PanicKindNilDeref},
+	{"index out of range", PanicKindIndex},
+	{"slice bounds out of range", PanicKindSlice},
+	{"assignment to entry in nil map", PanicKindNilMap},
+	{"integer divide by zero", PanicKindDivideByZero},
+	{"closed channel", PanicKindClosedChannel},
+	{"close of nil channel", PanicKindClosedChannel},
+}
+
+// ClassifyPanic returns the kind of the panic value pVal (as returned by recover): one of the kinds of run-time error
+// if it is (or wraps) a runtime.Error, PanicKindError if it's any other error, PanicKindValue if it's any other
+// non-nil value, or PanicKindNone if it's nil.  This lets tests check for, say, an index out of range without
+// depending on the exact wording of the runtime's messages:
+//
+//	if kind := testhelp.ClassifyPanic(c.PVal); kind != testhelp.PanicKindNilMap {
+//		t.Errorf("Expected a nil map assignment, got %s: %s", kind, testhelp.PanicMessage(c.PVal))
+//	}
+func ClassifyPanic(pVal interface{}) PanicKind {
+	if pVal == nil {
+		return PanicKindNone
+	}
+	err, ok := pVal.(error)
+	if !ok {
+		return PanicKindValue
+	}
+	var rtErr runtime.Error
+	if !errors.As(err, &rtErr) {
+		return PanicKindError
+	}
+	var taErr *runtime.TypeAssertionError
+	if errors.As(err, &taErr) {
+		return PanicKindTypeAssertion
+	}
+	msg := rtErr.Error()
+	for _, rk := range runtimeKinds {
+		if strings.Contains(msg, rk.substr) {
+			return rk.kind
+		}
+	}
+	return PanicKindOtherRuntime
+}
+
+type kindMatcher struct {
+	kind PanicKind
+}
+
+// OfKind returns a PanicMatcher that matches panic values of the given kind, as classified by ClassifyPanic.
+func OfKind(kind PanicKind) PanicMatcher {
+	return kindMatcher{kind}
+}
+
+func (m kindMatcher) Match(pVal interface{}) bool {
+	return ClassifyPanic(pVal) == m.kind
+}
+
+func (m kindMatcher) Describe() string {
+	return "is a panic of kind " + m.kind.String()
+}
+
+// PanicMatchers for the common kinds of run-time error, for use with PanicsMatching, AllOf, CapturedPanic.Matches,
+// and so on.  Like ClassifyPanic, they don't depend on the exact wording of the runtime's messages.
+var (
+	NilDereference      = OfKind(PanicKindNilDeref)
+	IndexOutOfRange     = OfKind(PanicKindIndex)
+	SliceOutOfRange     = OfKind(PanicKindSlice)
+	MapWriteToNil       = OfKind(PanicKindNilMap)
+	DivideByZero        = OfKind(PanicKindDivideByZero)
+	FailedTypeAssertion = OfKind(PanicKindTypeAssertion)
+	ClosedChannel       = OfKind(PanicKindClosedChannel)
+)
+
+// PanicsKind tests if the given function panics, and returns a boolean that is true if it does.  If the function does
+// panic, and the panic is of the given kind (as classified by ClassifyPanic), pIsKind will be true.  The panic value
+// itself is also returned.  (Specifically, this is the return value from recover, which is nil if the function did
+// not panic.)  For example:
+//
+//	if didPanic, pIsKind, pVal := testhelp.PanicsKind(func() { cache.Put("k", v) }, testhelp.PanicKindNilMap); !pIsKind {
+//		t.Errorf("Expected a nil map assignment from an uninitialized cache, got %v, %v", didPanic, pVal)
+//	}
+func PanicsKind(f func(), kind PanicKind) (didPanic bool, pIsKind bool, pVal interface{}) {
+	return PanicsMatching(f, OfKind(kind))
+}
+
+// WrongKindFuncErrorFactory returns a function suitable for passing to PanicsRuntimeLoop as a wrongKindFunc.  The
+// returned function is a closure over a *testing.T which uses it to call Errorf with a generic informative message.
+func WrongKindFuncErrorFactory(t TestingT) func(testName string, wantKind PanicKind, pVal interface{}) {
+	return func(testName string, wantKind PanicKind, pVal interface{}) {
+		t.Errorf("%sIncorrect panic kind: expected %s, got %s:\n%s\nin test '%s'",
+			failureToken(categoryKind, testName), wantKind, ClassifyPanic(pVal), PanicMessage(pVal), testName)
+	}
+}
+
+// WrongKindFuncFatalFactory returns a function suitable for passing to PanicsRuntimeLoop as a wrongKindFunc.  The
+// returned function is a closure over a *testing.T which uses it to call Fatalf with a generic informative message.
+func WrongKindFuncFatalFactory(t TestingT) func(testName string, wantKind PanicKind, pVal interface{}) {
+	return func(testName string, wantKind PanicKind, pVal interface{}) {
+		t.Fatalf("%sIncorrect panic kind: expected %s, got %s:\n%s\nin test '%s'",
+			failureToken(categoryKind, testName), wantKind, ClassifyPanic(pVal), PanicMessage(pVal), testName)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestClassifyPanic(t *testing.T) {
+	var nilPtr *struct{ a int }
+	var nilMap map[string]int
+	var iface interface{} = "str"
+	zero := 0
+	idx := 5
+	tests := []struct {
+		name string
+		f    func()
+		kind PanicKind
+	}{
+		{name: "no panic", f: func() {}, kind: PanicKindNone},
+		{name: "string", f: func() { panic("boom") }, kind: PanicKindValue},
+		{name: "error", f: func() { panic(errors.New("boom")) }, kind: PanicKindError},
+		{name: "nil deref", f: func() { _ = nilPtr.a }, kind: PanicKindNilDeref},
+		{name: "index", f: func() { _ = []int{1}[idx] }, kind: PanicKindIndex},
+		{name: "slice", f: func() { _ = []int{1}[:idx] }, kind: PanicKindSlice},
+		{name: "nil map", f: func() { nilMap["a"] = 1 }, kind: PanicKindNilMap},
+		{name: "divide", f: func() { _ = 1 / zero }, kind: PanicKindDivideByZero},
+		{name: "type assertion", f: func() { _ = iface.(int) }, kind: PanicKindTypeAssertion},
+		{name: "closed channel", f: func() { c := make(chan int); close(c); close(c) }, kind: PanicKindClosedChannel},
+		{
+			name: "wrapped runtime error",
+			f: func() {
+				defer func() { panic(fmt.Errorf("recovered: %w", recover().(error))) }()
+				nilMap["a"] = 1
+			},
+			kind: PanicKindNilMap,
+		},
+	}
+	for _, test := range tests {
+		_, pVal := PanicsGet(test.f)
+		if got := ClassifyPanic(pVal); got != test.kind {
+			t.Errorf("ClassifyPanic(): Incorrect kind: expected %s, got %s (for %#+v) in test '%s'", test.kind, got,
+				pVal, test.name)
+		}
+	}
+}
+
+func TestPanicKindString(t *testing.T) {
+	if got := PanicKindNilMap.String(); got != "nil map assignment" {
+		t.Errorf("PanicKind.String(): Incorrect name: got \"%s\"", got)
+	}
+	if got := PanicKind(-1).String(); got != "unknown panic kind" {
+		t.Errorf("PanicKind.String(): Incorrect name for an unknown kind: got \"%s\"", got)
+	}
+	for kind, want := range map[PanicKind]bool{PanicKindValue: false, PanicKindError: false, PanicKindNilDeref: true,
+		PanicKindOtherRuntime: true} {
+		if got := kind.IsRuntime(); got != want {
+			t.Errorf("PanicKind.IsRuntime(): Incorrect result for %s: expected %t, got %t", kind, want, got)
+		}
+	}
+}
+
+func TestOfKind(t *testing.T) {
+	m := OfKind(PanicKindIndex)
+	_, pVal := PanicsGet(func() { _ = []int{}[len("a")] })
+	if !m.Match(pVal) || m.Match("index out of range") {
+		t.Errorf("OfKind(): Incorrect matches")
+	}
+	if got, want := m.Describe(), "is a panic of kind index out of range"; got != want {
+		t.Errorf("OfKind(): Incorrect description: expected \"%s\", got \"%s\"", want, got)
+	}
+}
+
+func TestPanicsRuntimeLoop(t *testing.T) {
+	var nilMap map[string]int
+	tests := []PanicRuntimeTest{
+		{Name: "nil map", F: func() { nilMap["a"] = 1 }, WantKind: PanicKindNilMap},
+		{Name: "explicit", F: func() { panic("assignment to entry in nil map") }, WantKind: PanicKindNilMap},
+		{Name: "no panic", F: func() {}, WantKind: PanicKindNilMap},
+	}
+	var notPanicked []string
+	var wrongKind []string
+	PanicsRuntimeLoop(tests, func(testName string) { notPanicked = append(notPanicked, testName) },
+		func(testName string, wantKind PanicKind, pVal interface{}) {
+			wrongKind = append(wrongKind, fmt.Sprintf("%s: %s, %s", testName, wantKind, ClassifyPanic(pVal)))
+		})
+	if want := []string{"no panic"}; !reflect.DeepEqual(notPanicked, want) {
+		t.Errorf("PanicsRuntimeLoop(): Incorrect notPanicFunc calls: expected\n%#+v\ngot\n%#+v", want, notPanicked)
+	}
+	if want := []string{"explicit: nil map assignment, value"}; !reflect.DeepEqual(wrongKind, want) {
+		t.Errorf("PanicsRuntimeLoop(): Incorrect wrongKindFunc calls: expected\n%#+v\ngot\n%#+v", want, wrongKind)
+	}
+}
+
+func TestWrongKindFuncFactories(t *testing.T) {
+	want := "Incorrect panic kind: expected nil dereference, got value:\nboom\nin test 'x'"
+	rt := &testhelptest.RecordingT{}
+	WrongKindFuncErrorFactory(rt)("x", PanicKindNilDeref, "boom")
+	WrongKindFuncFatalFactory(rt)("x", PanicKindNilDeref, "boom")
+	if !reflect.DeepEqual(rt.Errors, []string{want}) || !reflect.DeepEqual(rt.Fatals, []string{want}) {
+		t.Errorf("WrongKindFunc factories: Incorrect messages: expected\n%q\ngot\n%#+v\n%#+v", want, rt.Errors,
+			rt.Fatals)
+	}
+}
+
+func TestRuntimeMatchers(t *testing.T) {
+	var nilPtr *struct{ a int }
+	var nilMap map[string]int
+	var iface interface{} = "str"
+	zero, idx := 0, 5
+	funcs := map[string]func(){
+		"nil deref":      func() { _ = nilPtr.a },
+		"index":          func() { _ = []int{1}[idx] },
+		"slice":          func() { _ = []int{1}[:idx] },
+		"nil map":        func() { nilMap["a"] = 1 },
+		"divide":         func() { _ = 1 / zero },
+		"type assertion": func() { _ = iface.(int) },
+		"closed channel": func() { c := make(chan int); close(c); c <- 1 },
+	}
+	matchers := map[string]PanicMatcher{
+		"nil deref":      NilDereference,
+		"index":          IndexOutOfRange,
+		"slice":          SliceOutOfRange,
+		"nil map":        MapWriteToNil,
+		"divide":         DivideByZero,
+		"type assertion": FailedTypeAssertion,
+		"closed channel": ClosedChannel,
+	}
+	for fName, f := range funcs {
+		_, pVal := PanicsGet(f)
+		for mName, m := range matchers {
+			if got := m.Match(pVal); got != (fName == mName) {
+				t.Errorf("Runtime matchers: Incorrect match by the %s matcher for a %s panic: got %t", mName, fName,
+					got)
+			}
+		}
+	}
+}
+
+func TestPanicsKind(t *testing.T) {
+	var nilMap map[string]int
+	tests := []struct {
+		name     string
+		f        func()
+		didPanic bool
+		pIsKind  bool
+	}{
+		{name: "right kind", f: func() { nilMap["a"] = 1 }, didPanic: true, pIsKind: true},
+		{name: "wrong kind", f: func() { panic("assignment to entry in nil map") }, didPanic: true},
+		{name: "no panic", f: func() {}},
+	}
+	for _, test := range tests {
+		didPanic, pIsKind, _ := PanicsKind(test.f, PanicKindNilMap)
+		if didPanic != test.didPanic || pIsKind != test.pIsKind {
+			t.Errorf("PanicsKind(): Incorrect result: expected %t, %t, got %t, %t in test '%s'", test.didPanic,
+				test.pIsKind, didPanic, pIsKind, test.name)
+		}
+	}
+}
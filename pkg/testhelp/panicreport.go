@@ -0,0 +1,475 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"time"
+)
+
+// A PanicReport describes a failed test from one of the report loops (such as PanicsStrReportLoop), which pass
+// everything known about the failure to a single callback, instead of splitting it across two or three callbacks with
+// different signatures.
+//
+// TestName is the name of the test, as passed to the failure callbacks of the other loops (see Loc and
+// LoopRunner.ShowCallLoc).  Kind is the kind of failure.  Want is what the test expected: the wanted string, regular
+// expression, value, PanicKind, or (for PanicsMatchingReportLoop) the first PanicMatcher that didn't match; it is nil
+// for loops that only check whether there was a panic.  Got is the part of the panic value that was checked against
+// Want: the string that the value was cast to (or nil if it couldn't be), the value itself, or its PanicKind; it is
+// nil if there was no panic.  PVal and Stack are the panic value and the stack at the point of the panic, if there was
+// one (for OutcomeSetupPanicked, these are from the setup function).  Duration is the time taken by the test function
+// (and setup function, if any), not counting earlier attempts if the test was retried.  Category is the category of
+// the failure, as in the tokens added to failure messages (see FailureTokensEnvVar).
+//
+// The adapters, such as StrReportFunc, turn callbacks for the other loops (such as the ones returned by
+// NotContainsFuncErrorFactory) into report callbacks.
+type PanicReport struct {
+	TestName string
+	Kind     Outcome
+	Category string
+	Want     interface{}
+	Got      interface{}
+	PVal     interface{}
+	Stack    string
+	Duration time.Duration
+}
+
+// runReported runs f, and returns whether it panicked, the panic value and stack, and the time it took.
+func runReported(f func()) (didPanic bool, pVal interface{}, stack string, duration time.Duration) {
+	start := time.Now()
+	didPanic, pVal, stack = panicsWithStack(f)
+	return didPanic, pVal, stack, time.Since(start)
+}
+
+// stringGot returns the panic value cast to a string, for PanicReport.Got, or nil if it can't be cast.
+func stringGot(pVal interface{}) interface{} {
+	if pStr, ok := panicString(pVal); ok {
+		return pStr
+	}
+	return nil
+}
+
+// PanicsReportLoop is like PanicsLoop, but calls reportFunc with a PanicReport for any test function that does not
+// panic.
+func PanicsReportLoop(tests []PanicTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsReportLoop(tests, reportFunc)
+}
+
+// NotPanicsReportLoop is like NotPanicsGetLoop, but calls reportFunc with a PanicReport for any test function that
+// panics.
+func NotPanicsReportLoop(tests []PanicTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).NotPanicsReportLoop(tests, reportFunc)
+}
+
+// PanicsStrReportLoop is like PanicsStrLoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsStrReportLoop(tests []PanicStrTest, wantStrAll *string, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsStrReportLoop(tests, wantStrAll, reportFunc)
+}
+
+// PanicsREReportLoop is like PanicsRELoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsREReportLoop(tests []PanicRETest, wantREAll *string, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsREReportLoop(tests, wantREAll, reportFunc)
+}
+
+// PanicsValReportLoop is like PanicsValLoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsValReportLoop(tests []PanicValTest, wantValAll *interface{}, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsValReportLoop(tests, wantValAll, reportFunc)
+}
+
+// PanicsRuntimeReportLoop is like PanicsRuntimeLoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsRuntimeReportLoop(tests []PanicRuntimeTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsRuntimeReportLoop(tests, reportFunc)
+}
+
+// PanicsStrFuncReportLoop is like PanicsStrFuncLoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsStrFuncReportLoop(tests []PanicStrFuncTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsStrFuncReportLoop(tests, reportFunc)
+}
+
+// PanicsREFuncReportLoop is like PanicsREFuncLoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsREFuncReportLoop(tests []PanicREFuncTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsREFuncReportLoop(tests, reportFunc)
+}
+
+// PanicsMatchingReportLoop is like PanicsMatchingLoop, but calls reportFunc with a PanicReport for any test that
+// fails.
+func PanicsMatchingReportLoop(tests []PanicMatchingTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsMatchingReportLoop(tests, reportFunc)
+}
+
+// PanicsSetupReportLoop is like PanicsSetupLoop, but calls reportFunc with a PanicReport for any test that fails.
+func PanicsSetupReportLoop(tests []PanicSetupTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).PanicsSetupReportLoop(tests, reportFunc)
+}
+
+// NotPanicsSetupReportLoop is like NotPanicsSetupLoop, but calls reportFunc with a PanicReport for any test that
+// fails.
+func NotPanicsSetupReportLoop(tests []PanicSetupTest, reportFunc func(r PanicReport)) {
+	new(LoopRunner).NotPanicsSetupReportLoop(tests, reportFunc)
+}
+
+// PanicsReportLoop is like the package-level PanicsReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsReportLoop(tests []PanicTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, _, _, duration := runReported(test.F)
+			if !didPanic {
+				r := PanicReport{TestName: name, Kind: OutcomeDidNotPanic, Category: categoryNoPanic, Duration: duration}
+				return r.Kind, func() { reportFunc(r) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// NotPanicsReportLoop is like the package-level NotPanicsReportLoop, but with the runner's options.
+func (lr *LoopRunner) NotPanicsReportLoop(tests []PanicTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal, stack, duration := runReported(test.F)
+			if didPanic {
+				r := PanicReport{TestName: name, Kind: OutcomeUnexpectedPanic, Category: categoryUnexpectedPanic, PVal: pVal,
+					Stack: stack, Duration: duration}
+				return r.Kind, func() {
+					lr.saveCrash(lr.newCrashArtifact(name, pVal, stack))
+					reportFunc(r)
+				}
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// strReportCase returns the run function of a loopCase for the report loops that check a panic value against a
+// string, as with Contains or Regexp.  wantFunc returns the string, and is called before f if preRun is true (for the
+// loops with the want in the table entry), or after f if it panics (for the Func loops, where f sets up the want).
+func (lr *LoopRunner) strReportCase(f func(), preRun bool, wantFunc func() string,
+	matcherFunc func(want string) PanicMatcher, category string, reportFunc func(r PanicReport),
+) func(name string) (Outcome, func()) {
+	return func(name string) (Outcome, func()) {
+		var want string
+		if preRun {
+			want = wantFunc()
+			if lr.invalidWant(want) {
+				return OutcomeInvalidWant, func() { lr.InvalidWantFunc(name, want) }
+			}
+		}
+		didPanic, pVal, stack, duration := runReported(f)
+		r := PanicReport{TestName: name, PVal: pVal, Stack: stack, Duration: duration}
+		if !didPanic {
+			r.Kind, r.Category = OutcomeDidNotPanic, categoryNoPanic
+			return r.Kind, func() { reportFunc(r) }
+		}
+		if !preRun {
+			want = wantFunc()
+			if lr.invalidWant(want) {
+				return OutcomeInvalidWant, func() { lr.InvalidWantFunc(name, want) }
+			}
+		}
+		if !matcherFunc(want).Match(pVal) {
+			r.Kind, r.Category, r.Want, r.Got = OutcomeWrongPanicValue, category, want, stringGot(pVal)
+			return r.Kind, func() { reportFunc(r) }
+		}
+		return OutcomePassed, nil
+	}
+}
+
+// PanicsStrReportLoop is like the package-level PanicsStrReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsStrReportLoop(tests []PanicStrTest, wantStrAll *string, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		wantFunc := func() string {
+			if wantStrAll != nil {
+				return *wantStrAll
+			}
+			return test.WantStr
+		}
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries,
+			lr.strReportCase(test.F, true, wantFunc, Contains, categoryContains, reportFunc)})
+	}
+	lr.run(cases)
+}
+
+// PanicsREReportLoop is like the package-level PanicsREReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsREReportLoop(tests []PanicRETest, wantREAll *string, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		wantFunc := func() string {
+			if wantREAll != nil {
+				return *wantREAll
+			}
+			return test.WantRE
+		}
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries,
+			lr.strReportCase(test.F, true, wantFunc, Regexp, categoryMatches, reportFunc)})
+	}
+	lr.run(cases)
+}
+
+// PanicsStrFuncReportLoop is like the package-level PanicsStrFuncReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsStrFuncReportLoop(tests []PanicStrFuncTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries,
+			lr.strReportCase(test.F, false, test.WantStrFunc, Contains, categoryContains, reportFunc)})
+	}
+	lr.run(cases)
+}
+
+// PanicsREFuncReportLoop is like the package-level PanicsREFuncReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsREFuncReportLoop(tests []PanicREFuncTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries,
+			lr.strReportCase(test.F, false, test.WantREFunc, Regexp, categoryMatches, reportFunc)})
+	}
+	lr.run(cases)
+}
+
+// PanicsValReportLoop is like the package-level PanicsValReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsValReportLoop(tests []PanicValTest, wantValAll *interface{},
+	reportFunc func(r PanicReport),
+) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			wantVal := test.WantVal
+			if wantValAll != nil {
+				wantVal = *wantValAll
+			}
+			didPanic, pVal, stack, duration := runReported(test.F)
+			r := PanicReport{TestName: name, PVal: pVal, Stack: stack, Duration: duration}
+			if !didPanic {
+				r.Kind, r.Category = OutcomeDidNotPanic, categoryNoPanic
+				return r.Kind, func() { reportFunc(r) }
+			} else if !valEqual(wantVal, pVal, lr.Eq) {
+				r.Kind, r.Category, r.Want, r.Got = OutcomeWrongPanicValue, categoryEquals, wantVal, pVal
+				return r.Kind, func() { reportFunc(r) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsRuntimeReportLoop is like the package-level PanicsRuntimeReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsRuntimeReportLoop(tests []PanicRuntimeTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal, stack, duration := runReported(test.F)
+			r := PanicReport{TestName: name, PVal: pVal, Stack: stack, Duration: duration}
+			if !didPanic {
+				r.Kind, r.Category = OutcomeDidNotPanic, categoryNoPanic
+				return r.Kind, func() { reportFunc(r) }
+			} else if kind := ClassifyPanic(pVal); kind != test.WantKind {
+				r.Kind, r.Category, r.Want, r.Got = OutcomeWrongPanicValue, categoryKind, test.WantKind, kind
+				return r.Kind, func() { reportFunc(r) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// PanicsMatchingReportLoop is like the package-level PanicsMatchingReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsMatchingReportLoop(tests []PanicMatchingTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			didPanic, pVal, stack, duration := runReported(test.F)
+			r := PanicReport{TestName: name, PVal: pVal, Stack: stack, Duration: duration}
+			if !didPanic {
+				r.Kind, r.Category = OutcomeDidNotPanic, categoryNoPanic
+				return r.Kind, func() { reportFunc(r) }
+			} else if matcher := firstMismatch(pVal, test.Matchers); matcher != nil {
+				r.Kind, r.Category, r.Want, r.Got = OutcomeWrongPanicValue, categoryMatching, matcher, pVal
+				return r.Kind, func() { reportFunc(r) }
+			}
+			return OutcomePassed, nil
+		}})
+	}
+	lr.run(cases)
+}
+
+// setupReportCase runs the setup function of a PanicSetupTest, if there is one, and then the test function.  It
+// returns the report for a setup panic (with ok false), or the report for the test function, with TestName and Kind
+// for the caller to fill in, along with the value returned by the setup function.
+func setupReportCase(test PanicSetupTest) (r PanicReport, setupVal interface{}, ok bool) {
+	var duration time.Duration
+	if test.Setup != nil {
+		var didPanic bool
+		var pVal interface{}
+		var stack string
+		didPanic, pVal, stack, duration = runReported(func() { setupVal = test.Setup() })
+		if didPanic {
+			r := PanicReport{Kind: OutcomeSetupPanicked, Category: categorySetupPanic, PVal: pVal, Stack: stack,
+				Duration: duration}
+			return r, nil, false
+		}
+	}
+	_, pVal, stack, fDuration := runReported(func() { test.F(setupVal) })
+	return PanicReport{PVal: pVal, Stack: stack, Duration: duration + fDuration}, setupVal, true
+}
+
+// PanicsSetupReportLoop is like the package-level PanicsSetupReportLoop, but with the runner's options.
+func (lr *LoopRunner) PanicsSetupReportLoop(tests []PanicSetupTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			r, _, ok := setupReportCase(test)
+			r.TestName = name
+			if ok && r.PVal != nil {
+				return OutcomePassed, nil
+			} else if ok {
+				r.Kind, r.Category = OutcomeDidNotPanic, categoryNoPanic
+			}
+			return r.Kind, func() { reportFunc(r) }
+		}})
+	}
+	lr.run(cases)
+}
+
+// NotPanicsSetupReportLoop is like the package-level NotPanicsSetupReportLoop, but with the runner's options.
+func (lr *LoopRunner) NotPanicsSetupReportLoop(tests []PanicSetupTest, reportFunc func(r PanicReport)) {
+	cases := make([]loopCase, 0, len(tests))
+	for _, test := range tests {
+		test := test
+		cases = append(cases, loopCase{test.Name, test.Loc, test.Retries, func(name string) (Outcome, func()) {
+			r, setupVal, ok := setupReportCase(test)
+			r.TestName = name
+			if ok && r.PVal == nil {
+				return OutcomePassed, nil
+			} else if !ok {
+				return r.Kind, func() { reportFunc(r) }
+			}
+			r.Kind, r.Category = OutcomeUnexpectedPanic, categoryUnexpectedPanic
+			return r.Kind, func() {
+				a := lr.newCrashArtifact(name, r.PVal, r.Stack)
+				a.Input = Format(setupVal)
+				lr.saveCrash(a)
+				reportFunc(r)
+			}
+		}})
+	}
+	lr.run(cases)
+}
+
+// ElseReportFunc returns a report callback that calls elseFunc with the name of the test, for any failure.  This
+// adapts the callbacks for PanicsLoop and NotPanicsLoop for use with PanicsReportLoop and NotPanicsReportLoop.
+func ElseReportFunc(elseFunc func(testName string)) func(r PanicReport) {
+	return func(r PanicReport) { elseFunc(r.TestName) }
+}
+
+// ElseValReportFunc returns a report callback that calls elseFunc with the name of the test and the panic value, for
+// any failure.  This adapts the callback for NotPanicsGetLoop for use with NotPanicsReportLoop.
+func ElseValReportFunc(elseFunc func(testName string, pVal interface{})) func(r PanicReport) {
+	return func(r PanicReport) { elseFunc(r.TestName, r.PVal) }
+}
+
+// StrReportFunc returns a report callback that calls notPanicFunc for tests that did not panic, and wrongStrFunc for
+// tests whose panic values were wrong.  This adapts the callbacks for PanicsStrLoop and PanicsRELoop (and their Func
+// versions) for use with the corresponding report loops.
+func StrReportFunc(notPanicFunc func(testName string),
+	wrongStrFunc func(testName string, want string, pVal interface{}),
+) func(r PanicReport) {
+	return func(r PanicReport) {
+		if r.Kind == OutcomeDidNotPanic {
+			notPanicFunc(r.TestName)
+			return
+		}
+		want, _ := r.Want.(string)
+		wrongStrFunc(r.TestName, want, r.PVal)
+	}
+}
+
+// ValReportFunc returns a report callback that calls notPanicFunc for tests that did not panic, and notEqualsFunc for
+// tests whose panic values were wrong.  This adapts the callbacks for PanicsValLoop for use with PanicsValReportLoop.
+func ValReportFunc(notPanicFunc func(testName string),
+	notEqualsFunc func(testName string, wantVal interface{}, pVal interface{}),
+) func(r PanicReport) {
+	return func(r PanicReport) {
+		if r.Kind == OutcomeDidNotPanic {
+			notPanicFunc(r.TestName)
+			return
+		}
+		notEqualsFunc(r.TestName, r.Want, r.PVal)
+	}
+}
+
+// KindReportFunc returns a report callback that calls notPanicFunc for tests that did not panic, and wrongKindFunc for
+// tests whose panics were of the wrong kind.  This adapts the callbacks for PanicsRuntimeLoop for use with
+// PanicsRuntimeReportLoop.
+func KindReportFunc(notPanicFunc func(testName string),
+	wrongKindFunc func(testName string, wantKind PanicKind, pVal interface{}),
+) func(r PanicReport) {
+	return func(r PanicReport) {
+		if r.Kind == OutcomeDidNotPanic {
+			notPanicFunc(r.TestName)
+			return
+		}
+		wantKind, _ := r.Want.(PanicKind)
+		wrongKindFunc(r.TestName, wantKind, r.PVal)
+	}
+}
+
+// MatchingReportFunc returns a report callback that calls notPanicFunc for tests that did not panic, and noMatchFunc
+// for tests whose panic values didn't match.  This adapts the callbacks for PanicsMatchingLoop for use with
+// PanicsMatchingReportLoop.
+func MatchingReportFunc(notPanicFunc func(testName string),
+	noMatchFunc func(testName string, matcher PanicMatcher, pVal interface{}),
+) func(r PanicReport) {
+	return func(r PanicReport) {
+		if r.Kind == OutcomeDidNotPanic {
+			notPanicFunc(r.TestName)
+			return
+		}
+		matcher, _ := r.Want.(PanicMatcher)
+		noMatchFunc(r.TestName, matcher, r.PVal)
+	}
+}
+
+// SetupReportFunc returns a report callback that calls setupPanicFunc for tests whose setup functions panicked, and
+// reportFunc for any other failure.  Together with ElseReportFunc or ElseValReportFunc, this adapts the callbacks for
+// PanicsSetupLoop and NotPanicsSetupLoop for use with the corresponding report loops:
+//
+//	testhelp.PanicsSetupReportLoop(tests, testhelp.SetupReportFunc(setupPanicFunc,
+//		testhelp.ElseReportFunc(notPanicFunc)))
+func SetupReportFunc(setupPanicFunc func(testName string, pVal interface{}),
+	reportFunc func(r PanicReport),
+) func(r PanicReport) {
+	return func(r PanicReport) {
+		if r.Kind == OutcomeSetupPanicked {
+			setupPanicFunc(r.TestName, r.PVal)
+			return
+		}
+		reportFunc(r)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// reportCollector collects PanicReports, without their stacks and durations, which vary; it records whether the stack
+// was set instead.
+type reportCollector struct {
+	reports  []PanicReport
+	hasStack []bool
+}
+
+func (rc *reportCollector) add(r PanicReport) {
+	rc.hasStack = append(rc.hasStack, strings.Contains(r.Stack, "goroutine"))
+	r.Stack = ""
+	if r.Duration < 0 {
+		r.Duration = -1 // shows up in the comparison
+	} else {
+		r.Duration = 0
+	}
+	rc.reports = append(rc.reports, r)
+}
+
+func TestReportLoops(t *testing.T) {
+	errOops := errors.New("oops")
+	matcher := Contains("zzz")
+	var nilMap map[string]int
+	wantAll := "b"
+	wantValAll := interface{}(2)
+
+	tests := []struct {
+		name         string
+		run          func(reportFunc func(r PanicReport))
+		wantReports  []PanicReport
+		wantHasStack []bool
+	}{
+		{"panics", func(reportFunc func(r PanicReport)) {
+			PanicsReportLoop([]PanicTest{{Name: "p", F: func() { panic(1) }}, {Name: "np", F: func() {}}}, reportFunc)
+		}, []PanicReport{{TestName: "np", Kind: OutcomeDidNotPanic, Category: categoryNoPanic}}, []bool{false}},
+		{"not panics", func(reportFunc func(r PanicReport)) {
+			NotPanicsReportLoop([]PanicTest{{Name: "p", F: func() { panic(1) }}, {Name: "np", F: func() {}}},
+				reportFunc)
+		}, []PanicReport{
+			{TestName: "p", Kind: OutcomeUnexpectedPanic, Category: categoryUnexpectedPanic, PVal: 1},
+		}, []bool{true}},
+		{"str", func(reportFunc func(r PanicReport)) {
+			PanicsStrReportLoop([]PanicStrTest{
+				{Name: "ok", F: func() { panic("abc") }, WantStr: "b"},
+				{Name: "wrong", F: func() { panic(errOops) }, WantStr: "x"},
+				{Name: "not a string", F: func() { panic(1) }, WantStr: "x"},
+				{Name: "np", F: func() {}, WantStr: "x"},
+			}, nil, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryContains, Want: "x", Got: "oops", PVal: errOops},
+			{TestName: "not a string", Kind: OutcomeWrongPanicValue, Category: categoryContains, Want: "x", PVal: 1},
+			{TestName: "np", Kind: OutcomeDidNotPanic, Category: categoryNoPanic},
+		}, []bool{true, true, false}},
+		{"str with wantStrAll", func(reportFunc func(r PanicReport)) {
+			PanicsStrReportLoop([]PanicStrTest{
+				{Name: "ok", F: func() { panic("abc") }, WantStr: "x"},
+				{Name: "wrong", F: func() { panic("xyz") }, WantStr: "x"},
+			}, &wantAll, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryContains, Want: "b", Got: "xyz", PVal: "xyz"},
+		},
+			[]bool{true}},
+		{"re", func(reportFunc func(r PanicReport)) {
+			PanicsREReportLoop([]PanicRETest{
+				{Name: "ok", F: func() { panic("abc") }, WantRE: "^a"},
+				{Name: "wrong", F: func() { panic("abc") }, WantRE: "^b"},
+			}, nil, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryMatches, Want: "^b", Got: "abc", PVal: "abc"},
+		},
+			[]bool{true}},
+		{"str func", func(reportFunc func(r PanicReport)) {
+			want := ""
+			PanicsStrFuncReportLoop([]PanicStrFuncTest{
+				{Name: "wrong", F: func() { want = "q"; panic("abc") }, WantStrFunc: func() string { return want }},
+				{Name: "np", F: func() {}, WantStrFunc: func() string { panic("not called") }},
+			}, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryContains, Want: "q", Got: "abc", PVal: "abc"},
+			{TestName: "np", Kind: OutcomeDidNotPanic, Category: categoryNoPanic},
+		}, []bool{true, false}},
+		{"re func", func(reportFunc func(r PanicReport)) {
+			PanicsREFuncReportLoop([]PanicREFuncTest{
+				{Name: "ok", F: func() { panic("abc") }, WantREFunc: func() string { return "c$" }},
+				{Name: "wrong", F: func() { panic("abc") }, WantREFunc: func() string { return "^c" }},
+			}, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryMatches, Want: "^c", Got: "abc", PVal: "abc"},
+		},
+			[]bool{true}},
+		{"val", func(reportFunc func(r PanicReport)) {
+			PanicsValReportLoop([]PanicValTest{
+				{Name: "ok", F: func() { panic(2) }, WantVal: 3},
+				{Name: "wrong", F: func() { panic(1) }, WantVal: 3},
+			}, &wantValAll, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryEquals, Want: 2, Got: 1, PVal: 1},
+		},
+			[]bool{true}},
+		{"runtime", func(reportFunc func(r PanicReport)) {
+			PanicsRuntimeReportLoop([]PanicRuntimeTest{
+				{Name: "ok", F: func() { nilMap["a"] = 1 }, WantKind: PanicKindNilMap},
+				{Name: "wrong", F: func() { panic("x") }, WantKind: PanicKindNilMap},
+			}, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryKind,
+				Want: PanicKindNilMap, Got: PanicKindValue, PVal: "x"},
+		}, []bool{true}},
+		{"matching", func(reportFunc func(r PanicReport)) {
+			PanicsMatchingReportLoop([]PanicMatchingTest{
+				{Name: "ok", F: func() { panic("zzz") }, Matchers: []PanicMatcher{matcher}},
+				{Name: "wrong", F: func() { panic("a") }, Matchers: []PanicMatcher{IsType(""), matcher}},
+			}, reportFunc)
+		}, []PanicReport{
+			{TestName: "wrong", Kind: OutcomeWrongPanicValue, Category: categoryMatching, Want: matcher, Got: "a", PVal: "a"},
+		},
+			[]bool{true}},
+		{"setup", func(reportFunc func(r PanicReport)) {
+			PanicsSetupReportLoop([]PanicSetupTest{
+				{Name: "ok", Setup: func() interface{} { return 1 }, F: func(v interface{}) { panic(v) }},
+				{Name: "setup panics", Setup: func() interface{} { panic("s") }, F: func(interface{}) { panic(1) }},
+				{Name: "np", F: func(interface{}) {}},
+			}, reportFunc)
+		}, []PanicReport{
+			{TestName: "setup panics", Kind: OutcomeSetupPanicked, Category: categorySetupPanic, PVal: "s"},
+			{TestName: "np", Kind: OutcomeDidNotPanic, Category: categoryNoPanic},
+		}, []bool{true, false}},
+		{"not panics setup", func(reportFunc func(r PanicReport)) {
+			NotPanicsSetupReportLoop([]PanicSetupTest{
+				{Name: "ok", Setup: func() interface{} { return 1 }, F: func(interface{}) {}},
+				{Name: "setup panics", Setup: func() interface{} { panic("s") }, F: func(interface{}) {}},
+				{Name: "p", Setup: func() interface{} { return 1 }, F: func(v interface{}) { panic(v) }},
+			}, reportFunc)
+		}, []PanicReport{
+			{TestName: "setup panics", Kind: OutcomeSetupPanicked, Category: categorySetupPanic, PVal: "s"},
+			{TestName: "p", Kind: OutcomeUnexpectedPanic, Category: categoryUnexpectedPanic, PVal: 1},
+		}, []bool{true, true}},
+	}
+	for _, test := range tests {
+		rc := &reportCollector{}
+		test.run(rc.add)
+		if !reflect.DeepEqual(rc.reports, test.wantReports) {
+			t.Errorf("Incorrect reports: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantReports, rc.reports,
+				test.name)
+		}
+		if !reflect.DeepEqual(rc.hasStack, test.wantHasStack) {
+			t.Errorf("Incorrect stacks: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantHasStack, rc.hasStack,
+				test.name)
+		}
+	}
+}
+
+func TestReportLoopsWithRunner(t *testing.T) {
+	var invalid []string
+	lr := &LoopRunner{
+		InvalidWantFunc: func(testName string, want string) { invalid = append(invalid, testName) },
+	}
+	rc := &reportCollector{}
+	attempts := 0
+	lr.PanicsStrReportLoop([]PanicStrTest{
+		{Name: "empty", F: func() { panic("abc") }, WantStr: " "},
+		{Name: "flaky", F: func() {
+			attempts++
+			if attempts == 1 {
+				panic("x")
+			}
+			panic("abc")
+		}, WantStr: "b", Retries: 1, Loc: Loc{File: "/src/table_test.go", Line: 7}},
+		{Name: "wrong", F: func() { panic("abc") }, WantStr: "q", Loc: Loc{File: "/src/table_test.go", Line: 8}},
+	}, nil, rc.add)
+
+	if want := []string{"empty"}; !reflect.DeepEqual(invalid, want) {
+		t.Errorf("PanicsStrReportLoop(): Incorrect invalid wants: expected\n%#+v\ngot\n%#+v", want, invalid)
+	}
+	want := []PanicReport{{TestName: "wrong (at table_test.go:8)", Kind: OutcomeWrongPanicValue,
+		Category: categoryContains, Want: "q", Got: "abc",
+		PVal: "abc"}}
+	if !reflect.DeepEqual(rc.reports, want) {
+		t.Errorf("PanicsStrReportLoop(): Incorrect reports: expected\n%#+v\ngot\n%#+v", want, rc.reports)
+	}
+	if attempts != 2 {
+		t.Errorf("PanicsStrReportLoop(): Incorrect number of attempts: expected 2, got %d", attempts)
+	}
+}
+
+func TestReportFuncAdapters(t *testing.T) {
+	// The adapters should give the same calls as the separate callbacks of the other loops
+	var calls []string
+	notPanicFunc := func(testName string) { calls = append(calls, "notPanic "+testName) }
+	valFunc := func(kind string) func(testName string, want interface{}, pVal interface{}) {
+		return func(testName string, want interface{}, pVal interface{}) {
+			calls = append(calls, fmt.Sprintf("%s %s %v %v", kind, testName, want, pVal))
+		}
+	}
+	strFunc := func(testName string, want string, pVal interface{}) { valFunc("str")(testName, want, pVal) }
+	kindFunc := func(testName string, want PanicKind, pVal interface{}) { valFunc("kind")(testName, want, pVal) }
+	matchFunc := func(testName string, m PanicMatcher, pVal interface{}) {
+		valFunc("match")(testName, m.Describe(), pVal)
+	}
+	elseValFunc := func(testName string, pVal interface{}) { valFunc("else")(testName, "-", pVal) }
+	setupFunc := func(testName string, pVal interface{}) { valFunc("setup")(testName, "-", pVal) }
+
+	didNotPanic := PanicReport{TestName: "np", Kind: OutcomeDidNotPanic}
+	tests := []struct {
+		name       string
+		reportFunc func(r PanicReport)
+		report     PanicReport
+		wantCalls  []string
+	}{
+		{"else", ElseReportFunc(notPanicFunc), didNotPanic, []string{"notPanic np"}},
+		{"else val", ElseValReportFunc(elseValFunc), PanicReport{TestName: "p", Kind: OutcomeUnexpectedPanic, PVal: 1},
+			[]string{"else p - 1"}},
+		{"str did not panic", StrReportFunc(notPanicFunc, strFunc), didNotPanic, []string{"notPanic np"}},
+		{"str wrong", StrReportFunc(notPanicFunc, strFunc),
+			PanicReport{TestName: "w", Kind: OutcomeWrongPanicValue, Want: "q", Got: "a", PVal: "a"},
+			[]string{"str w q a"}},
+		{"val did not panic", ValReportFunc(notPanicFunc, valFunc("val")), didNotPanic, []string{"notPanic np"}},
+		{"val wrong", ValReportFunc(notPanicFunc, valFunc("val")),
+			PanicReport{TestName: "w", Kind: OutcomeWrongPanicValue, Want: 2, Got: 1, PVal: 1},
+			[]string{"val w 2 1"}},
+		{"kind did not panic", KindReportFunc(notPanicFunc, kindFunc), didNotPanic, []string{"notPanic np"}},
+		{"kind wrong", KindReportFunc(notPanicFunc, kindFunc),
+			PanicReport{TestName: "w", Kind: OutcomeWrongPanicValue, Want: PanicKindNilMap, Got: PanicKindValue,
+				PVal: "x"},
+			[]string{"kind w nil map assignment x"}},
+		{"matching did not panic", MatchingReportFunc(notPanicFunc, matchFunc), didNotPanic, []string{"notPanic np"}},
+		{"matching wrong", MatchingReportFunc(notPanicFunc, matchFunc),
+			PanicReport{TestName: "w", Kind: OutcomeWrongPanicValue, Want: Contains("q"), Got: "a", PVal: "a"},
+			[]string{"match w contains \"q\" a"}},
+		{"setup panicked", SetupReportFunc(setupFunc, ElseReportFunc(notPanicFunc)),
+			PanicReport{TestName: "s", Kind: OutcomeSetupPanicked, PVal: "boom"}, []string{"setup s - boom"}},
+		{"setup other", SetupReportFunc(setupFunc, ElseReportFunc(notPanicFunc)), didNotPanic,
+			[]string{"notPanic np"}},
+	}
+	for _, test := range tests {
+		calls = nil
+		test.reportFunc(test.report)
+		if !reflect.DeepEqual(calls, test.wantCalls) {
+			t.Errorf("Incorrect calls: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.wantCalls, calls, test.name)
+		}
+	}
+}
+
+func TestReportFuncAdaptersWithFactories(t *testing.T) {
+	rtReport := &testhelptest.RecordingT{}
+	rtClassic := &testhelptest.RecordingT{}
+	tests := []PanicStrTest{{Name: "wrong", F: func() { panic("abc") }, WantStr: "q"}}
+	PanicsStrReportLoop(tests, nil, StrReportFunc(func(string) {}, NotContainsFuncErrorFactory(rtReport)))
+	PanicsStrLoop(tests, nil, func(string) {}, NotContainsFuncErrorFactory(rtClassic))
+	if !reflect.DeepEqual(rtReport.Failures(), rtClassic.Failures()) || len(rtReport.Failures()) != 1 {
+		t.Errorf("StrReportFunc(): Incorrect messages: expected\n%#+v\ngot\n%#+v", rtClassic.Failures(),
+			rtReport.Failures())
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+// A propagationSentinel is the panic value used by MustPropagatePanic.  It's a pointer to an unexported type, so
+// nothing else can produce an equal value.
+type propagationSentinel struct {
+	_ byte // make the type non-zero-size, so that each pointer is distinct
+}
+
+func (*propagationSentinel) String() string {
+	return "testhelp.MustPropagatePanic sentinel"
+}
+
+// MustPropagatePanic calls wrap with an inner function that panics with a sentinel value, and calls t.Errorf if the
+// sentinel doesn't come back out of wrap unchanged: if wrap never calls the inner function, if it recovers from the
+// panic without panicking again, or if it panics with some other value instead.  It returns true if the sentinel was
+// propagated.
+//
+// This is intended for testing middleware, decorators, and other wrappers that are supposed to be transparent to
+// panics (even if they do something on the way, such as logging or releasing a lock).  For example:
+//
+//	testhelp.MustPropagatePanic(t, func(inner func()) {
+//		mypkg.WithRetries(3, func() error { inner(); return nil })
+//	})
+func MustPropagatePanic(t TestingTB, wrap func(inner func())) bool {
+	t.Helper()
+	sentinel := &propagationSentinel{}
+	innerCalled := false
+	didPanic, pVal := PanicsGet(func() {
+		wrap(func() {
+			innerCalled = true
+			panic(sentinel)
+		})
+	})
+	switch {
+	case !innerCalled:
+		t.Errorf("The wrapper never called the inner function, so the panic couldn't be propagated")
+	case !didPanic:
+		t.Errorf("The wrapper swallowed the panic from the inner function instead of propagating it")
+	case pVal != sentinel:
+		t.Errorf("The wrapper replaced the panic from the inner function with a different value (%T):\n%s", pVal,
+			quotedPanicMessage(pVal))
+	default:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestMustPropagatePanic(t *testing.T) {
+	tests := []struct {
+		name         string
+		wrap         func(inner func())
+		wantOK       bool
+		wantFailures []string
+	}{
+		{"transparent", func(inner func()) { inner() }, true, []string{}},
+		{
+			"recovers and re-panics", func(inner func()) {
+				defer func() {
+					if pVal := recover(); pVal != nil {
+						panic(pVal)
+					}
+				}()
+				inner()
+			}, true, []string{},
+		},
+		{
+			"never calls inner", func(inner func()) {}, false,
+			[]string{"The wrapper never called the inner function, so the panic couldn't be propagated"},
+		},
+		{
+			"swallows", func(inner func()) {
+				defer func() { _ = recover() }()
+				inner()
+			}, false,
+			[]string{"The wrapper swallowed the panic from the inner function instead of propagating it"},
+		},
+		{
+			"replaces", func(inner func()) {
+				defer func() {
+					if pVal := recover(); pVal != nil {
+						panic(fmt.Sprintf("wrapped: %v", pVal))
+					}
+				}()
+				inner()
+			}, false,
+			[]string{"The wrapper replaced the panic from the inner function with a different value (string):\n" +
+				"\"wrapped: testhelp.MustPropagatePanic sentinel\""},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if ok := MustPropagatePanic(rt, test.wrap); ok != test.wantOK {
+			t.Errorf("MustPropagatePanic(): Incorrect return value: expected %t, got %t in test '%s'", test.wantOK, ok,
+				test.name)
+		}
+		if failures := rt.Failures(); !reflect.DeepEqual(failures, test.wantFailures) {
+			t.Errorf("MustPropagatePanic(): Incorrect failure(s): expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantFailures, failures, test.name)
+		}
+	}
+}
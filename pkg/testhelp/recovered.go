@@ -0,0 +1,88 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+)
+
+// A RecoveredError is the error that Recovered returns for a function that panicked, holding the panic as captured by
+// Capture.  If the panic value is an error, RecoveredError wraps it, so errors.Is and errors.As see through the panic.
+type RecoveredError struct {
+	CapturedPanic
+}
+
+func (e *RecoveredError) Error() string {
+	return "recovered from panic: " + PanicMessage(e.PVal)
+}
+
+// Unwrap returns the panic value if it's an error, or nil if not.
+func (e *RecoveredError) Unwrap() error {
+	err, _ := e.PVal.(error)
+	return err
+}
+
+func (e *RecoveredError) capturedPanic() CapturedPanic {
+	return e.CapturedPanic
+}
+
+// recoveredPanic is implemented by the errors this package makes from panics.
+type recoveredPanic interface {
+	error
+	capturedPanic() CapturedPanic
+}
+
+// Recovered runs f, and returns a *RecoveredError, with the panic value and the stack at the point of the panic, if f
+// panics, or nil if not.  It's meant for production code as well as tests, wherever a panic should be turned into an
+// error, such as at the top of a worker goroutine or a request handler, so that the code under test and its tests use
+// the same vocabulary for recovered panics:
+//
+//	// In the code under test
+//	func (w *Worker) run(job Job) error {
+//		return testhelp.Recovered(func() { w.process(job) })
+//	}
+//
+//	// In the test
+//	err := w.run(badJob)
+//	if pVal, ok := testhelp.RecoveredValue(err); !ok || pVal != "bad job" {
+//		t.Errorf("Incorrect error from run: %v", err)
+//	}
+//
+// The package only uses the standard library, so depending on it from production code is cheap.
+func Recovered(f func()) error {
+	c := Capture(f)
+	if !c.DidPanic {
+		return nil
+	}
+	return &RecoveredError{CapturedPanic: c}
+}
+
+// IsRecovered returns true if err (or any error it wraps) was made from a recovered panic, by Recovered or RunGroup.
+func IsRecovered(err error) bool {
+	var rp recoveredPanic
+	return errors.As(err, &rp)
+}
+
+// RecoveredValue returns the panic value from the first error in err's chain that was made from a recovered panic (see
+// IsRecovered), and true, or nil and false if there isn't one.
+func RecoveredValue(err error) (pVal interface{}, ok bool) {
+	var rp recoveredPanic
+	if !errors.As(err, &rp) {
+		return nil, false
+	}
+	return rp.capturedPanic().PVal, true
+}
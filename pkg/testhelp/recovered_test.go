@@ -0,0 +1,71 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRecovered(t *testing.T) {
+	if err := Recovered(func() {}); err != nil {
+		t.Errorf("Recovered(): Expected nil without a panic, got %v", err)
+	}
+
+	err := Recovered(func() { panic("bad job") })
+	var re *RecoveredError
+	if !errors.As(err, &re) || re.PVal != "bad job" || !strings.Contains(re.Stack, "TestRecovered") {
+		t.Fatalf("Recovered(): Incorrect error: got %#+v", err)
+	}
+	if got, want := err.Error(), "recovered from panic: bad job"; got != want {
+		t.Errorf("Recovered(): Incorrect message: expected \"%s\", got \"%s\"", want, got)
+	}
+
+	err = Recovered(func() { panic(fmt.Errorf("reading: %w", io.EOF)) })
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Recovered(): Error panic value not wrapped: got %#+v", err)
+	}
+}
+
+func TestIsRecovered(t *testing.T) {
+	recovered := Recovered(func() { panic(42) })
+	group := &GroupPanicError{Index: 1, CapturedPanic: CapturedPanic{DidPanic: true, PVal: "boom"}}
+	tests := []struct {
+		name    string
+		err     error
+		isRec   bool
+		wantVal interface{}
+	}{
+		{name: "nil", err: nil},
+		{name: "plain", err: errors.New("plain")},
+		{name: "recovered", err: recovered, isRec: true, wantVal: 42},
+		{name: "wrapped", err: fmt.Errorf("job 3: %w", recovered), isRec: true, wantVal: 42},
+		{name: "group", err: group, isRec: true, wantVal: "boom"},
+	}
+	for _, test := range tests {
+		if got := IsRecovered(test.err); got != test.isRec {
+			t.Errorf("IsRecovered(): Incorrect result: expected %t, got %t in test '%s'", test.isRec, got, test.name)
+		}
+		if pVal, ok := RecoveredValue(test.err); ok != test.isRec || pVal != test.wantVal {
+			t.Errorf("RecoveredValue(): Incorrect result: expected %#+v, %t\ngot %#+v, %t\nin test '%s'",
+				test.wantVal, test.isRec, pVal, ok, test.name)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReportFormat is the file format of a written Report.
+type ReportFormat int
+
+const (
+	// ReportJSON is a JSON object with overall counts and a list of cases.
+	ReportJSON ReportFormat = iota
+	// ReportJUnit is JUnit-style XML, as read by most CI systems.
+	ReportJUnit
+)
+
+// Values for ReportCase.Outcome.
+const (
+	ReportPassed  = "passed"
+	ReportFailed  = "failed"
+	ReportSkipped = "skipped"
+)
+
+// A ReportCase is the record of a single table entry in a Report.  Suite is the name of the parent test for the
+// subtest runners, and empty for the loops.  Outcome is ReportPassed, ReportFailed, or ReportSkipped.  Messages holds
+// the failure messages for a failed case (for the loops, which don't see the messages, a description of the outcome),
+// or the reason for a skipped case.
+type ReportCase struct {
+	Suite    string
+	Name     string
+	Outcome  string
+	Duration time.Duration
+	Messages []string
+}
+
+// A Report collects a record of each table entry run by the LoopRunners and SubtestRunners whose Report field points
+// to it, and writes a summary for tools such as CI dashboards.  The zero value is an empty report, ready to use.  A
+// Report is safe for concurrent use, so it can be shared by parallel tests.
+//
+// A typical use is to share one report across a package's tests, and write it out at the end:
+//
+//	var report testhelp.Report
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		if err := report.WriteFile("panic-tests.xml", testhelp.ReportJUnit); err != nil {
+//			fmt.Fprintln(os.Stderr, err)
+//		}
+//		os.Exit(code)
+//	}
+type Report struct {
+	mu    sync.Mutex
+	cases []ReportCase
+}
+
+// add records a case.
+func (r *Report) add(c ReportCase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cases = append(r.cases, c)
+}
+
+// Cases returns the cases recorded so far, in the order they finished.
+func (r *Report) Cases() []ReportCase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ReportCase{}, r.cases...)
+}
+
+// Slowest returns the n slowest cases recorded so far, slowest first (or all of them, if n is 0 or less).
+func (r *Report) Slowest(n int) []ReportCase {
+	cases := r.Cases()
+	sort.SliceStable(cases, func(i, j int) bool { return cases[i].Duration > cases[j].Duration })
+	if n > 0 && len(cases) > n {
+		cases = cases[:n]
+	}
+	return cases
+}
+
+// Write writes a summary of the report to w, in the given format.
+func (r *Report) Write(w io.Writer, format ReportFormat) error {
+	cases := r.Cases()
+	switch format {
+	case ReportJSON:
+		return writeReportJSON(w, cases)
+	case ReportJUnit:
+		return writeReportJUnit(w, cases)
+	}
+	return fmt.Errorf("unknown report format: %d", int(format))
+}
+
+// WriteFile writes a summary of the report to the file at path (replacing it if it exists), in the given format.
+func (r *Report) WriteFile(path string, format ReportFormat) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return r.Write(f, format)
+}
+
+type reportJSONCase struct {
+	Suite           string   `json:"suite,omitempty"`
+	Name            string   `json:"name"`
+	Outcome         string   `json:"outcome"`
+	DurationSeconds float64  `json:"duration_seconds"`
+	Messages        []string `json:"messages,omitempty"`
+}
+
+type reportJSON struct {
+	Total           int              `json:"total"`
+	Passed          int              `json:"passed"`
+	Failed          int              `json:"failed"`
+	Skipped         int              `json:"skipped"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Cases           []reportJSONCase `json:"cases"`
+}
+
+func writeReportJSON(w io.Writer, cases []ReportCase) error {
+	out := reportJSON{Total: len(cases), Cases: make([]reportJSONCase, 0, len(cases))}
+	var total time.Duration
+	for _, c := range cases {
+		switch c.Outcome {
+		case ReportPassed:
+			out.Passed++
+		case ReportFailed:
+			out.Failed++
+		case ReportSkipped:
+			out.Skipped++
+		}
+		total += c.Duration
+		out.Cases = append(out.Cases, reportJSONCase{c.Suite, c.Name, c.Outcome, c.Duration.Seconds(), c.Messages})
+	}
+	out.DurationSeconds = total.Seconds()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+func writeReportJUnit(w io.Writer, cases []ReportCase) error {
+	suiteIndex := map[string]int{}
+	var out junitSuites
+	suiteTimes := map[string]time.Duration{}
+	for _, c := range cases {
+		suiteName := c.Suite
+		if suiteName == "" {
+			suiteName = "testhelp"
+		}
+		i, ok := suiteIndex[suiteName]
+		if !ok {
+			i = len(out.Suites)
+			suiteIndex[suiteName] = i
+			out.Suites = append(out.Suites, junitSuite{Name: suiteName})
+		}
+		suite := &out.Suites[i]
+		jc := junitCase{Name: c.Name, ClassName: suiteName, Time: junitTime(c.Duration)}
+		msg := strings.Join(c.Messages, "\n")
+		switch c.Outcome {
+		case ReportFailed:
+			suite.Failures++
+			jc.Failure = &junitMessage{Message: firstLine(msg), Text: msg}
+		case ReportSkipped:
+			suite.Skipped++
+			jc.Skipped = &junitMessage{Message: msg}
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, jc)
+		suiteTimes[suiteName] += c.Duration
+	}
+	sort.SliceStable(out.Suites, func(i, j int) bool { return out.Suites[i].Name < out.Suites[j].Name })
+	for i := range out.Suites {
+		out.Suites[i].Time = junitTime(suiteTimes[out.Suites[i].Name])
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitTime formats a duration as JUnit expects: seconds, with millisecond precision.
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
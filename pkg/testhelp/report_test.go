@@ -0,0 +1,155 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func reportForTest() *Report {
+	r := &Report{}
+	r.add(ReportCase{Suite: "TestA", Name: "p", Outcome: ReportPassed, Duration: 1500 * time.Millisecond})
+	r.add(ReportCase{Suite: "TestA", Name: "np", Outcome: ReportFailed, Duration: 250 * time.Millisecond,
+		Messages: []string{"Expected a panic", "second <line>"}})
+	r.add(ReportCase{Name: "loop", Outcome: ReportSkipped, Messages: []string{"flaky"}})
+	return r
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := reportForTest().Write(&b, ReportJSON); err != nil {
+		t.Fatalf("Write(): Unexpected error: %s", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("Write(): Invalid JSON: %s\n%s", err, b.String())
+	}
+	want := map[string]interface{}{
+		"total": 3.0, "passed": 1.0, "failed": 1.0, "skipped": 1.0, "duration_seconds": 1.75,
+		"cases": []interface{}{
+			map[string]interface{}{"suite": "TestA", "name": "p", "outcome": "passed", "duration_seconds": 1.5},
+			map[string]interface{}{"suite": "TestA", "name": "np", "outcome": "failed", "duration_seconds": 0.25,
+				"messages": []interface{}{"Expected a panic", "second <line>"}},
+			map[string]interface{}{"name": "loop", "outcome": "skipped", "duration_seconds": 0.0,
+				"messages": []interface{}{"flaky"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Write(): Incorrect JSON: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
+
+func TestReportWriteJUnit(t *testing.T) {
+	var b bytes.Buffer
+	if err := reportForTest().Write(&b, ReportJUnit); err != nil {
+		t.Fatalf("Write(): Unexpected error: %s", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="TestA" tests="2" failures="1" skipped="0" time="1.750">
+    <testcase name="p" classname="TestA" time="1.500"></testcase>
+    <testcase name="np" classname="TestA" time="0.250">
+      <failure message="Expected a panic">Expected a panic&#xA;second &lt;line&gt;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="testhelp" tests="1" failures="0" skipped="1" time="0.000">
+    <testcase name="loop" classname="testhelp" time="0.000">
+      <skipped message="flaky"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if b.String() != want {
+		t.Errorf("Write(): Incorrect XML: expected\n%s\ngot\n%s", want, b.String())
+	}
+}
+
+func TestReportWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := reportForTest().WriteFile(path, ReportJSON); err != nil {
+		t.Fatalf("WriteFile(): Unexpected error: %s", err)
+	}
+	if contents, err := os.ReadFile(path); err != nil || !strings.Contains(string(contents), `"total": 3`) {
+		t.Errorf("WriteFile(): Incorrect file contents: got %v\n%s", err, contents)
+	}
+	if err := reportForTest().Write(&bytes.Buffer{}, ReportFormat(99)); err == nil {
+		t.Errorf("Write(): Expected an error with an unknown format")
+	}
+}
+
+// Returns the cases without their durations, for comparison
+func casesWithoutDurations(r *Report) []ReportCase {
+	cases := r.Cases()
+	for i := range cases {
+		cases[i].Duration = 0
+	}
+	return cases
+}
+
+func TestLoopRunnerReport(t *testing.T) {
+	r := &Report{}
+	lr := LoopRunner{Report: r}
+	lr.PanicsLoop([]PanicTest{{Name: "p", F: func() { panic(1) }}, {Name: "np", F: func() {}}}, func(string) {})
+	want := []ReportCase{
+		{Name: "p", Outcome: ReportPassed},
+		{Name: "np", Outcome: ReportFailed, Messages: []string{"did not panic"}},
+	}
+	if got := casesWithoutDurations(r); !reflect.DeepEqual(got, want) {
+		t.Errorf("PanicsLoop(): Incorrect report: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
+
+func TestSubtestRunnerReport(t *testing.T) {
+	r := &Report{}
+	sr := SubtestRunner{Report: r}
+	sr.runPanicStrTests(&subtestRunnerMock{}, []PanicStrTest{
+		{Name: "cm", F: func() { panic("ppp") }, WantStr: "p"},
+		{Name: "np", F: func() {}, WantStr: "p"},
+		{Name: "skip", Skip: true, SkipReason: "flaky"},
+		{Name: "expected failure", F: func() {}, ExpectedFailure: true},
+	})
+	want := []ReportCase{
+		{Suite: "TestMock", Name: "cm", Outcome: ReportPassed},
+		{Suite: "TestMock", Name: "np", Outcome: ReportFailed, Messages: []string{"Expected a panic"}},
+		{Suite: "TestMock", Name: "skip", Outcome: ReportSkipped, Messages: []string{"flaky"}},
+		{Suite: "TestMock", Name: "expected failure", Outcome: ReportPassed},
+	}
+	if got := casesWithoutDurations(r); !reflect.DeepEqual(got, want) {
+		t.Errorf("runPanicStrTests(): Incorrect report: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
+
+func TestReportSlowest(t *testing.T) {
+	r := reportForTest()
+	var names []string
+	for _, c := range r.Slowest(2) {
+		names = append(names, c.Name)
+	}
+	if want := []string{"p", "np"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Slowest(): Incorrect cases: expected\n%#+v\ngot\n%#+v", want, names)
+	}
+	if n := len(r.Slowest(0)); n != 3 {
+		t.Errorf("Slowest(): Expected all 3 cases with n = 0, got %d", n)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+)
+
+// CheckRoundTrip checks that each of the cases survives a round trip through encode and decode unchanged (according
+// to Diff), as marshal/unmarshal pairs should.  For each case that doesn't (including one for which encode or decode
+// returns an error or panics), it calls t.Errorf with the case's index, a diff, the error, or the panic value and
+// stack, and the input and encoded form, and carries on with the next case.  It returns true if all the cases passed.
+// For example:
+//
+//	testhelp.CheckRoundTrip(t, mypkg.Marshal, mypkg.Unmarshal, []mypkg.Message{
+//		{},
+//		{ID: 1, Body: "hello"},
+//		{ID: -1, Body: "\x00\xff", Tags: []string{""}},
+//	})
+func CheckRoundTrip[T any](t TestingTB, encode func(T) ([]byte, error), decode func([]byte) (T, error),
+	cases []T) bool {
+	t.Helper()
+	ok := true
+	for i, c := range cases {
+		if failure, stack := roundTrip(encode, decode, c); failure != "" {
+			t.Errorf("%s", withStack(fmt.Sprintf("Round trip failed for case %d: %s", i, failure), stack))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// CheckRoundTripRandom is a randomized version of CheckRoundTrip: it checks the round trip for the given number of
+// values from gen, which builds each one from a FakeSource (see Faker), stopping at the first failure.  A panic in
+// gen, encode, or decode is a failure like any other, reported with the input (if there is one), the panic value, and
+// the stack.  The FakeSource's seed is derived from t's name (or comes from the environment variable named by
+// FakerSeedEnvVar), and is included in failure messages, so that failures can be reproduced.  For example:
+//
+//	testhelp.CheckRoundTripRandom(t, mypkg.Marshal, mypkg.Unmarshal, func(fake *testhelp.FakeSource) mypkg.User {
+//		return mypkg.User{Name: fake.Name(), Email: fake.Email(), Bio: fake.Sentence()}
+//	}, 1000)
+//
+// gen should be deterministic given the FakeSource, so that the seed reproduces the same values.  If FakerSeedEnvVar
+// isn't a valid integer, CheckRoundTripRandom calls t.Errorf.  It returns true if all the values passed.
+func CheckRoundTripRandom[T any](t SeedT, encode func(T) ([]byte, error), decode func([]byte) (T, error),
+	gen func(*FakeSource) T, iterations int) bool {
+	t.Helper()
+	seed, err := fakerSeed(t)
+	if err != nil {
+		t.Errorf("Invalid %s: %s", FakerSeedEnvVar, err)
+		return false
+	}
+	fake := NewFakeSource(seed)
+	for i := 0; i < iterations; i++ {
+		var v T
+		var failure, stack string
+		if c := Capture(func() { v = gen(fake) }); c.DidPanic {
+			failure, stack = panicFailure("gen", c), c.Stack
+		} else {
+			failure, stack = roundTrip(encode, decode, v)
+		}
+		if failure != "" {
+			t.Errorf("%s", withStack(fmt.Sprintf("Round trip failed in iteration %d: %s\nseed: %d (run the tests with "+
+				"%s=%d to reproduce)", i, failure, seed, FakerSeedEnvVar, seed), stack))
+			return false
+		}
+	}
+	return true
+}
+
+// roundTrip encodes and decodes v, and returns a description of the failure if there was an error or a panic, or the
+// result differs from v, along with the stack if there was a panic; it returns "" and "" if the round trip worked.
+func roundTrip[T any](encode func(T) ([]byte, error), decode func([]byte) (T, error), v T) (string, string) {
+	var encoded []byte
+	var err error
+	if c := Capture(func() { encoded, err = encode(v) }); c.DidPanic {
+		return fmt.Sprintf("%s\ninput: %s", panicFailure("encode", c), Format(v)), c.Stack
+	}
+	if err != nil {
+		return fmt.Sprintf("encode error: %s\ninput: %s", err, Format(v)), ""
+	}
+	var decoded T
+	if c := Capture(func() { decoded, err = decode(encoded) }); c.DidPanic {
+		return fmt.Sprintf("%s\ninput: %s\nencoded: %q", panicFailure("decode", c), Format(v), encoded), c.Stack
+	}
+	if err != nil {
+		return fmt.Sprintf("decode error: %s\ninput: %s\nencoded: %q", err, Format(v), encoded), ""
+	}
+	if diff := Diff(v, decoded); diff != "" {
+		return fmt.Sprintf("decoded value differs from the input:\n%s\nencoded: %q", diff, encoded), ""
+	}
+	return "", ""
+}
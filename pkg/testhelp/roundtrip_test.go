@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type roundTripUser struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+func encodeUser(u roundTripUser) ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func decodeUser(b []byte) (roundTripUser, error) {
+	var u roundTripUser
+	err := json.Unmarshal(b, &u)
+	return u, err
+}
+
+// decodeUserLossy drops Age, as a buggy decoder might.
+func decodeUserLossy(b []byte) (roundTripUser, error) {
+	u, err := decodeUser(b)
+	u.Age = 0
+	return u, err
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	cases := []roundTripUser{{}, {Name: "Ana", Email: "ana@example.com", Age: 30}}
+
+	tests := []struct {
+		name             string
+		encode           func(roundTripUser) ([]byte, error)
+		decode           func([]byte) (roundTripUser, error)
+		expectedOK       bool
+		expectedFailures []string
+	}{
+		{
+			name:             "lossless",
+			encode:           encodeUser,
+			decode:           decodeUser,
+			expectedOK:       true,
+			expectedFailures: []string{},
+		},
+		{
+			name:       "lossy",
+			encode:     encodeUser,
+			decode:     decodeUserLossy,
+			expectedOK: false,
+			expectedFailures: []string{
+				"Round trip failed for case 1: decoded value differs from the input:\nAge: expected 30, got 0\n" +
+					`encoded: "{\"Name\":\"Ana\",\"Email\":\"ana@example.com\",\"Age\":30}"`,
+			},
+		},
+		{
+			name: "encode error",
+			encode: func(u roundTripUser) ([]byte, error) {
+				if u.Name == "" {
+					return nil, errors.New("no name")
+				}
+				return encodeUser(u)
+			},
+			decode:     decodeUser,
+			expectedOK: false,
+			expectedFailures: []string{
+				"Round trip failed for case 0: encode error: no name\ninput: testhelp.roundTripUser{Name: \"\", " +
+					"Email: \"\", Age: 0}",
+			},
+		},
+		{
+			name:       "decode error",
+			encode:     func(u roundTripUser) ([]byte, error) { return []byte("{"), nil },
+			decode:     decodeUser,
+			expectedOK: false,
+			expectedFailures: []string{
+				"Round trip failed for case 0: decode error: unexpected end of JSON input\ninput: " +
+					"testhelp.roundTripUser{Name: \"\", Email: \"\", Age: 0}\nencoded: \"{\"",
+				"Round trip failed for case 1: decode error: unexpected end of JSON input\ninput: " +
+					"testhelp.roundTripUser{Name: \"Ana\", Email: \"ana@example.com\", Age: 30}\nencoded: \"{\"",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		mockT := &testhelptest.RecordingT{}
+		ok := CheckRoundTrip(mockT, test.encode, test.decode, cases)
+		if ok != test.expectedOK {
+			t.Errorf("CheckRoundTrip(): Incorrect return value: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedOK, ok, test.name)
+		}
+		if strings.Join(mockT.Failures(), "\n---\n") != strings.Join(test.expectedFailures, "\n---\n") {
+			t.Errorf("CheckRoundTrip(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectedFailures, mockT.Failures(), test.name)
+		}
+	}
+}
+
+func TestCheckRoundTripRandom(t *testing.T) {
+	t.Setenv(FakerSeedEnvVar, "99")
+	gen := func(fake *FakeSource) roundTripUser {
+		return roundTripUser{Name: fake.Name(), Email: fake.Email(), Age: fake.Intn(100)}
+	}
+
+	mockT := &seedTMock{name: "TestUsers"}
+	if !CheckRoundTripRandom(mockT, encodeUser, decodeUser, gen, 50) || len(mockT.Failures()) != 0 {
+		t.Errorf("CheckRoundTripRandom(): Unexpected failures for a lossless codec:\n%#+v", mockT.Failures())
+	}
+
+	mockT = &seedTMock{name: "TestUsers"}
+	if CheckRoundTripRandom(mockT, encodeUser, decodeUserLossy, gen, 50) {
+		t.Errorf("CheckRoundTripRandom(): Incorrect return value: expected false for a lossy codec")
+	}
+	// The first value with a non-zero Age fails
+	fake := NewFakeSource(99)
+	iteration := 0
+	for gen(fake).Age == 0 {
+		iteration++
+	}
+	expectedPrefix := fmt.Sprintf("Round trip failed in iteration %d: decoded value differs from the input:\nAge: ",
+		iteration)
+	expectedSuffix := "\nseed: 99 (run the tests with " + FakerSeedEnvVar + "=99 to reproduce)"
+	if len(mockT.Errors) != 1 || !strings.HasPrefix(mockT.Errors[0], expectedPrefix) ||
+		!strings.HasSuffix(mockT.Errors[0], expectedSuffix) {
+		t.Errorf("CheckRoundTripRandom(): Incorrect failures: expected one starting with\n%#+v\nand ending with\n"+
+			"%#+v\ngot\n%#+v", expectedPrefix, expectedSuffix, mockT.Errors)
+	}
+}
+
+func TestCheckRoundTripPanics(t *testing.T) {
+	panicOn := func(name string) func(roundTripUser) ([]byte, error) {
+		return func(u roundTripUser) ([]byte, error) {
+			if u.Name == name {
+				panic("can't encode " + name)
+			}
+			return encodeUser(u)
+		}
+	}
+
+	mockT := &testhelptest.RecordingT{}
+	cases := []roundTripUser{{Name: "Ana"}, {Name: "Bo"}}
+	ok := CheckRoundTrip(mockT, panicOn("Ana"), decodeUser, cases)
+	expectedPrefix := "Round trip failed for case 0: panic in encode (string): can't encode Ana\ninput: " +
+		"testhelp.roundTripUser{Name: \"Ana\", Email: \"\", Age: 0}\nstack:\n"
+	if f := mockT.Failures(); ok || len(f) != 1 || !strings.HasPrefix(f[0], expectedPrefix) ||
+		!strings.Contains(f[0], "roundtrip_test.go") {
+		t.Errorf("CheckRoundTrip(): Incorrect failures for a panic in encode: expected one starting with\n%s\n"+
+			"with a stack, got\n%#+v", expectedPrefix, f)
+	}
+
+	mockT = &testhelptest.RecordingT{}
+	decode := func([]byte) (roundTripUser, error) { panic(errors.New("truncated")) }
+	CheckRoundTrip(mockT, encodeUser, decode, cases[:1])
+	expectedPrefix = "Round trip failed for case 0: panic in decode (*errors.errorString): truncated\ninput: " +
+		"testhelp.roundTripUser{Name: \"Ana\", Email: \"\", Age: 0}\n" +
+		`encoded: "{\"Name\":\"Ana\",\"Email\":\"\",\"Age\":0}"` + "\nstack:\n"
+	if f := mockT.Failures(); len(f) != 1 || !strings.HasPrefix(f[0], expectedPrefix) {
+		t.Errorf("CheckRoundTrip(): Incorrect failures for a panic in decode: expected one starting with\n%s\ngot\n"+
+			"%#+v", expectedPrefix, f)
+	}
+
+	t.Setenv(FakerSeedEnvVar, "5")
+	tests := []struct {
+		name           string
+		encode         func(roundTripUser) ([]byte, error)
+		gen            func(*FakeSource) roundTripUser
+		expectedPrefix string
+	}{
+		{
+			name:   "encode",
+			encode: panicOn("Ana"),
+			gen: func(fake *FakeSource) roundTripUser {
+				return roundTripUser{Name: "Ana"}
+			},
+			expectedPrefix: "Round trip failed in iteration 0: panic in encode (string): can't encode Ana\ninput: " +
+				"testhelp.roundTripUser{Name: \"Ana\", Email: \"\", Age: 0}\nseed: 5 (run the tests with " +
+				FakerSeedEnvVar + "=5 to reproduce)\nstack:\n",
+		},
+		{
+			name:   "gen",
+			encode: encodeUser,
+			gen: func(fake *FakeSource) roundTripUser {
+				panic("no users")
+			},
+			expectedPrefix: "Round trip failed in iteration 0: panic in gen (string): no users\nseed: 5 (run the " +
+				"tests with " + FakerSeedEnvVar + "=5 to reproduce)\nstack:\n",
+		},
+	}
+	for _, test := range tests {
+		mockT := &seedTMock{name: "TestUsers"}
+		ok := CheckRoundTripRandom(mockT, test.encode, decodeUser, test.gen, 10)
+		if f := mockT.Failures(); ok || len(f) != 1 || !strings.HasPrefix(f[0], test.expectedPrefix) {
+			t.Errorf("CheckRoundTripRandom(): Incorrect failures: expected one starting with\n%s\ngot\n%#+v\n"+
+				"in test '%s'", test.expectedPrefix, f, test.name)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"time"
+)
+
+// A MessageSink stands in for a message broker or queue (such as a Kafka topic) in tests of event-driven code: the
+// code under test publishes to it instead of to the real broker, and the test waits for and checks the published
+// messages.  It is built on Collector, and has the same concurrency guarantees; the zero value is an empty sink, ready
+// to use.
+//
+// Publish can usually be adapted to the code under test's publisher interface with a small wrapper:
+//
+//	type sinkPublisher struct{ sink *testhelp.MessageSink[Event] }
+//
+//	func (p sinkPublisher) Publish(ctx context.Context, e Event) error {
+//		p.sink.Publish(e)
+//		return nil
+//	}
+type MessageSink[T any] struct {
+	c Collector[T]
+}
+
+// Publish adds a message to the sink.  It is safe to call from any goroutine.
+func (s *MessageSink[T]) Publish(msg T) {
+	s.c.Add(msg)
+}
+
+// Messages returns a copy of the messages published so far, in the order they were published.
+func (s *MessageSink[T]) Messages() []T {
+	return s.c.Items()
+}
+
+// Reset removes all published messages.
+func (s *MessageSink[T]) Reset() {
+	s.c.Reset()
+}
+
+// Await waits until at least n messages have been published, or until timeout has passed, whichever comes first.  It
+// returns the messages published so far, and a boolean that is true if there are at least n of them.
+func (s *MessageSink[T]) Await(n int, timeout time.Duration) ([]T, bool) {
+	return s.c.Wait(n, timeout)
+}
+
+// ContainsMessage checks that at least one published message satisfies pred, and calls t.Errorf, listing the
+// messages, if not.  It returns true if the check passed.
+func (s *MessageSink[T]) ContainsMessage(t TestingT, pred func(msg T) bool) bool {
+	msgs := s.Messages()
+	for _, msg := range msgs {
+		if pred(msg) {
+			return true
+		}
+	}
+	t.Errorf("No published message matched the predicate; got messages:\n%s", Format(msgs))
+	return false
+}
+
+// AwaitMessage waits until a published message satisfies pred, or until timeout has passed, and returns the first
+// such message.  If there isn't one, it calls t.Errorf, listing the messages, and returns the zero value and false.
+func (s *MessageSink[T]) AwaitMessage(t TestingT, pred func(msg T) bool, timeout time.Duration) (T, bool) {
+	deadline := time.Now().Add(timeout)
+	msgs := s.Messages()
+	for checked := 0; ; {
+		for ; checked < len(msgs); checked++ {
+			if pred(msgs[checked]) {
+				return msgs[checked], true
+			}
+		}
+		var more bool
+		if msgs, more = s.c.Wait(checked+1, time.Until(deadline)); !more {
+			break
+		}
+	}
+	var zero T
+	t.Errorf("No published message matched the predicate within %s; got messages:\n%s", timeout, Format(msgs))
+	return zero, false
+}
+
+// InOrder checks that, for each of preds in turn, some published message satisfies it, with each such message coming
+// after the one that satisfied the previous predicate; other messages may come before, between, or after them.  This
+// checks the relative order of particular messages (e.g. that "order created" is published before "order shipped")
+// without requiring the exact sequence, as Collector's InOrder does.  If the check fails, InOrder calls t.Errorf,
+// identifying the first predicate that couldn't be satisfied and listing the messages.  It returns true if the check
+// passed.
+func (s *MessageSink[T]) InOrder(t TestingT, preds ...func(msg T) bool) bool {
+	msgs := s.Messages()
+	next := 0
+	for i, pred := range preds {
+		found := false
+		for ; next < len(msgs); next++ {
+			if pred(msgs[next]) {
+				found = true
+				next++
+				break
+			}
+		}
+		if !found {
+			after := "the messages"
+			if i > 0 {
+				after = fmt.Sprintf("the messages after the one matching predicate %d", i)
+			}
+			t.Errorf("Messages not in the expected order: predicate %d (of %d) matched none of %s; got messages:\n%s",
+				i+1, len(preds), after, Format(msgs))
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type sinkEvent struct {
+	Kind  string
+	Order int
+}
+
+func kindIs(kind string) func(e sinkEvent) bool {
+	return func(e sinkEvent) bool { return e.Kind == kind }
+}
+
+func TestMessageSinkAwait(t *testing.T) {
+	var sink MessageSink[sinkEvent]
+	go func() {
+		for i := 1; i <= 3; i++ {
+			sink.Publish(sinkEvent{"created", i})
+		}
+	}()
+	msgs, ok := sink.Await(3, 5*time.Second)
+	if !ok || len(msgs) != 3 {
+		t.Errorf("MessageSink.Await(): Expected 3 messages, got %t, %#+v", ok, msgs)
+	}
+	if _, ok := sink.Await(4, time.Millisecond); ok {
+		t.Errorf("MessageSink.Await(): Expected false when waiting for more messages than were published")
+	}
+	sink.Reset()
+	if msgs := sink.Messages(); len(msgs) != 0 {
+		t.Errorf("MessageSink.Reset(): Expected no messages, got %#+v", msgs)
+	}
+}
+
+func TestMessageSinkAwaitMessage(t *testing.T) {
+	var sink MessageSink[sinkEvent]
+	sink.Publish(sinkEvent{"created", 1})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		sink.Publish(sinkEvent{"paid", 1})
+		sink.Publish(sinkEvent{"shipped", 1})
+	}()
+
+	rt := &testhelptest.RecordingT{}
+	got, ok := sink.AwaitMessage(rt, kindIs("shipped"), 5*time.Second)
+	if !ok || got != (sinkEvent{"shipped", 1}) || len(rt.Failures()) != 0 {
+		t.Errorf("MessageSink.AwaitMessage(): Expected the shipped event, got %t, %#+v, %#+v", ok, got, rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	got, ok = sink.AwaitMessage(rt, kindIs("refunded"), time.Millisecond)
+	if ok || got != (sinkEvent{}) {
+		t.Errorf("MessageSink.AwaitMessage(): Expected no match, got %t, %#+v", ok, got)
+	}
+	if f := rt.Failures(); len(f) != 1 ||
+		!strings.HasPrefix(f[0], "No published message matched the predicate within 1ms; got messages:\n") {
+		t.Errorf("MessageSink.AwaitMessage(): Incorrect failures:\n%#+v", f)
+	}
+}
+
+func TestMessageSinkAssertions(t *testing.T) {
+	var sink MessageSink[sinkEvent]
+	for _, kind := range []string{"created", "paid", "note", "shipped"} {
+		sink.Publish(sinkEvent{kind, 1})
+	}
+	listing := "got messages:\n[]testhelp.sinkEvent{\n" +
+		"  testhelp.sinkEvent{Kind: \"created\", Order: 1},\n" +
+		"  testhelp.sinkEvent{Kind: \"paid\", Order: 1},\n" +
+		"  testhelp.sinkEvent{Kind: \"note\", Order: 1},\n" +
+		"  testhelp.sinkEvent{Kind: \"shipped\", Order: 1},\n" +
+		"}"
+
+	tests := []struct {
+		name         string
+		check        func(rt TestingT) bool
+		expectResult bool
+		expectErrors []string
+	}{
+		{
+			name: "contains", expectResult: true,
+			check: func(rt TestingT) bool { return sink.ContainsMessage(rt, kindIs("paid")) },
+		},
+		{
+			name:         "doesn't contain",
+			check:        func(rt TestingT) bool { return sink.ContainsMessage(rt, kindIs("refunded")) },
+			expectErrors: []string{"No published message matched the predicate; " + listing},
+		},
+		{
+			name: "in order", expectResult: true,
+			check: func(rt TestingT) bool { return sink.InOrder(rt, kindIs("created"), kindIs("shipped")) },
+		},
+		{
+			name: "no predicates", expectResult: true,
+			check: func(rt TestingT) bool { return sink.InOrder(rt) },
+		},
+		{
+			name:  "out of order",
+			check: func(rt TestingT) bool { return sink.InOrder(rt, kindIs("paid"), kindIs("created")) },
+			expectErrors: []string{"Messages not in the expected order: predicate 2 (of 2) matched none of the " +
+				"messages after the one matching predicate 1; " + listing},
+		},
+		{
+			name:  "first missing",
+			check: func(rt TestingT) bool { return sink.InOrder(rt, kindIs("refunded")) },
+			expectErrors: []string{"Messages not in the expected order: predicate 1 (of 1) matched none of the " +
+				"messages; " + listing},
+		},
+		{
+			name: "same message twice",
+			check: func(rt TestingT) bool {
+				return sink.InOrder(rt, kindIs("shipped"), kindIs("shipped"))
+			},
+			expectErrors: []string{"Messages not in the expected order: predicate 2 (of 2) matched none of the " +
+				"messages after the one matching predicate 1; " + listing},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if got := test.check(rt); got != test.expectResult {
+			t.Errorf("MessageSink: Incorrect result: expected %t, got %t in test '%s'", test.expectResult, got,
+				test.name)
+		}
+		want := test.expectErrors
+		if want == nil {
+			want = []string{}
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+			t.Errorf("MessageSink: Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got, test.name)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// A SpyCall records a single call to a Spy.  If the call panicked, Panicked is true, PVal is the panic value, and Out
+// is the zero value.
+type SpyCall[In, Out any] struct {
+	In       In
+	Out      Out
+	Time     time.Time
+	Panicked bool
+	PVal     interface{}
+}
+
+// A Spy wraps a function, recording every call made through it, and optionally overriding the results of specific
+// calls.  Functions with more than one argument or result can be spied on by using a struct for In or Out.
+//
+// The function to pass to the code under test is Call (or the result of Func).  A Spy is safe for concurrent use;
+// calls are numbered in the order they start.
+type Spy[In, Out any] struct {
+	f func(In) Out
+
+	mu      sync.Mutex
+	calls   []SpyCall[In, Out]
+	actions map[int]spyAction[Out] // by call number
+}
+
+type spyAction[Out any] struct {
+	out      Out
+	doPanic  bool
+	panicVal interface{}
+}
+
+// NewSpy returns a Spy wrapping f.  f may be nil, in which case calls that haven't been programmed with ReturnOn or
+// PanicOn return the zero value of Out.
+func NewSpy[In, Out any](f func(In) Out) *Spy[In, Out] {
+	return &Spy[In, Out]{
+		f:       f,
+		actions: map[int]spyAction[Out]{},
+	}
+}
+
+// ReturnOn programs the spy to return out from call number n (counting from 1), instead of calling the wrapped
+// function.  It returns the spy, so that calls can be chained.
+//
+// ReturnOn panics if n is less than 1.
+func (s *Spy[In, Out]) ReturnOn(n int, out Out) *Spy[In, Out] {
+	s.program(n, spyAction[Out]{out: out})
+	return s
+}
+
+// PanicOn programs the spy to panic with pVal on call number n (counting from 1), instead of calling the wrapped
+// function.  It returns the spy, so that calls can be chained.
+//
+// PanicOn panics if n is less than 1, or if pVal is nil (which can't be distinguished from not panicking).
+func (s *Spy[In, Out]) PanicOn(n int, pVal interface{}) *Spy[In, Out] {
+	if pVal == nil {
+		panic("Spy can't be programmed to panic with nil")
+	}
+	s.program(n, spyAction[Out]{doPanic: true, panicVal: pVal})
+	return s
+}
+
+func (s *Spy[In, Out]) program(n int, action spyAction[Out]) {
+	if n < 1 {
+		panic(fmt.Sprintf("Invalid call number: %d", n))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions[n] = action
+}
+
+// Call records a call with the argument in, and then either carries out the action programmed for this call number,
+// or calls the wrapped function.  If the call panics, the panic is recorded and then passed on.
+func (s *Spy[In, Out]) Call(in In) (out Out) {
+	s.mu.Lock()
+	s.calls = append(s.calls, SpyCall[In, Out]{In: in, Time: time.Now()})
+	n := len(s.calls)
+	action, programmed := s.actions[n]
+	s.mu.Unlock()
+
+	defer func() {
+		pVal := recover()
+		s.mu.Lock()
+		call := &s.calls[n-1]
+		if pVal != nil {
+			call.Panicked = true
+			call.PVal = pVal
+		} else {
+			call.Out = out
+		}
+		s.mu.Unlock()
+		if pVal != nil {
+			panic(pVal)
+		}
+	}()
+
+	switch {
+	case programmed && action.doPanic:
+		panic(action.panicVal)
+	case programmed:
+		return action.out
+	case s.f != nil:
+		return s.f(in)
+	}
+	return out // zero value
+}
+
+// Func returns the spy's Call method as a plain function value, for passing to code under test.
+func (s *Spy[In, Out]) Func() func(In) Out {
+	return s.Call
+}
+
+// Calls returns a copy of the calls recorded so far, in order.
+func (s *Spy[In, Out]) Calls() []SpyCall[In, Out] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SpyCall[In, Out]{}, s.calls...)
+}
+
+// CalledTimes checks that the spy has been called exactly n times, and calls t.Errorf with an informative message if
+// not.  It returns true if the check passed.
+func (s *Spy[In, Out]) CalledTimes(t TestingT, n int) bool {
+	got := len(s.Calls())
+	if got != n {
+		t.Errorf("Spy called the wrong number of times: expected %d, got %d", n, got)
+		return false
+	}
+	return true
+}
+
+// CalledWith checks that at least one call to the spy had an argument equal to in (as determined by
+// reflect.DeepEqual), and calls t.Errorf with an informative message if not.  It returns true if the check passed.
+func (s *Spy[In, Out]) CalledWith(t TestingT, in In) bool {
+	calls := s.Calls()
+	for _, call := range calls {
+		if reflect.DeepEqual(call.In, in) {
+			return true
+		}
+	}
+
+	args := make([]In, 0, len(calls))
+	for _, call := range calls {
+		args = append(args, call.In)
+	}
+	t.Errorf("Spy not called with expected argument: expected\n%s\ngot calls with\n%s", Format(in), Format(args))
+	return false
+}
+
+// NthCall returns call number n (counting from 1).  If there have been fewer than n calls, it calls t.Errorf with an
+// informative message, and returns false along with a zero SpyCall.
+func (s *Spy[In, Out]) NthCall(t TestingT, n int) (SpyCall[In, Out], bool) {
+	calls := s.Calls()
+	if n < 1 || n > len(calls) {
+		t.Errorf("Spy call #%d requested, but the spy was called %d times", n, len(calls))
+		return SpyCall[In, Out]{}, false
+	}
+	return calls[n-1], true
+}
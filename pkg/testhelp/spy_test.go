@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestSpyCall(t *testing.T) {
+	spy := NewSpy(func(in int) string { return strings.Repeat("x", in) })
+	spy.ReturnOn(2, "programmed").PanicOn(3, "ppp")
+
+	tests := []struct {
+		name string
+		in   int
+		// want from the call
+		wantOut    string
+		wantPanics bool
+	}{
+		{"1: wrapped", 1, "x", false},
+		{"2: programmed return", 2, "programmed", false},
+		{"3: programmed panic", 3, "", true},
+		{"4: wrapped", 4, "xxxx", false},
+	}
+	for _, test := range tests {
+		var out string
+		didPanic, pVal := PanicsGet(func() { out = spy.Call(test.in) })
+		if didPanic != test.wantPanics {
+			t.Errorf("Call(): Wrong panic behavior: expected %t, got %t (%#+v) in test '%s'",
+				test.wantPanics, didPanic, pVal, test.name)
+		}
+		if out != test.wantOut {
+			t.Errorf("Call(): Incorrect result: expected \"%s\", got \"%s\" in test '%s'", test.wantOut, out, test.name)
+		}
+	}
+
+	calls := spy.Calls()
+	if len(calls) != len(tests) {
+		t.Fatalf("Calls(): Wrong number of calls: expected %d, got %d", len(tests), len(calls))
+	}
+	for i, test := range tests {
+		if calls[i].In != test.in || calls[i].Out != test.wantOut || calls[i].Panicked != test.wantPanics {
+			t.Errorf("Calls(): Incorrect call record in test '%s':\n%#+v", test.name, calls[i])
+		}
+		if calls[i].Time.IsZero() {
+			t.Errorf("Calls(): Missing timestamp in test '%s'", test.name)
+		}
+	}
+	if calls[2].PVal != "ppp" {
+		t.Errorf("Calls(): Incorrect panic value: expected \"ppp\", got %#+v", calls[2].PVal)
+	}
+}
+
+func TestSpyRecordsWrappedPanic(t *testing.T) {
+	spy := NewSpy(func(struct{}) int { panic("inner") })
+	_, pVal := PanicsGet(func() { spy.Func()(struct{}{}) })
+	if pVal != "inner" {
+		t.Errorf("Call(): Wrapped panic not passed on: expected \"inner\", got %#+v", pVal)
+	}
+	if calls := spy.Calls(); len(calls) != 1 || !calls[0].Panicked || calls[0].PVal != "inner" {
+		t.Errorf("Calls(): Wrapped panic not recorded:\n%#+v", calls)
+	}
+}
+
+func TestSpyNilFunc(t *testing.T) {
+	spy := NewSpy[string, int](nil)
+	if got := spy.Call("a"); got != 0 {
+		t.Errorf("Call(): Expected zero value from unprogrammed nil spy, got %d", got)
+	}
+}
+
+func TestSpyProgramPanicsWithBadInput(t *testing.T) {
+	spy := NewSpy[int, int](nil)
+	tests := []struct {
+		name    string
+		f       func()
+		wantStr string
+	}{
+		{"ReturnOn 0", func() { spy.ReturnOn(0, 1) }, "Invalid call number: 0"},
+		{"PanicOn -1", func() { spy.PanicOn(-1, "x") }, "Invalid call number: -1"},
+		{"PanicOn nil", func() { spy.PanicOn(1, nil) }, "can't be programmed to panic with nil"},
+	}
+	for _, test := range tests {
+		didPanic, pContainsStr, pVal := PanicsStr(test.f, test.wantStr)
+		if !didPanic {
+			t.Errorf("Expected function to panic in test '%s'", test.name)
+		} else if !pContainsStr {
+			t.Errorf("Incorrect panic value: expected a string containing\n\"%s\"\ngot\n%#+v\nin test '%s'",
+				test.wantStr, pVal, test.name)
+		}
+	}
+}
+
+func TestSpyAssertions(t *testing.T) {
+	type args struct {
+		A string
+		B []int
+	}
+	spy := NewSpy(func(args) bool { return true })
+	spy.Call(args{"a", []int{1}})
+	spy.Call(args{"b", []int{2, 3}})
+
+	tests := []struct {
+		name       string
+		f          func(rt *testhelptest.RecordingT) bool
+		wantOK     bool
+		wantErrStr string
+	}{
+		{"CalledTimes correct", func(rt *testhelptest.RecordingT) bool { return spy.CalledTimes(rt, 2) }, true, ""},
+		{
+			"CalledTimes wrong", func(rt *testhelptest.RecordingT) bool { return spy.CalledTimes(rt, 3) }, false,
+			"expected 3, got 2",
+		},
+		{
+			"CalledWith found",
+			func(rt *testhelptest.RecordingT) bool { return spy.CalledWith(rt, args{"b", []int{2, 3}}) }, true, "",
+		},
+		{
+			"CalledWith not found", func(rt *testhelptest.RecordingT) bool { return spy.CalledWith(rt, args{"b", []int{2}}) },
+			false, "Spy not called with expected argument",
+		},
+		{
+			"NthCall present", func(rt *testhelptest.RecordingT) bool {
+				call, ok := spy.NthCall(rt, 2)
+				return ok && call.In.A == "b"
+			}, true, "",
+		},
+		{
+			"NthCall missing", func(rt *testhelptest.RecordingT) bool {
+				_, ok := spy.NthCall(rt, 3)
+				return ok
+			}, false, "Spy call #3 requested, but the spy was called 2 times",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := test.f(rt)
+		if ok != test.wantOK {
+			t.Errorf("Wrong result: expected %t, got %t in test '%s'", test.wantOK, ok, test.name)
+		}
+		failures := rt.Failures()
+		if test.wantErrStr == "" {
+			if len(failures) != 0 {
+				t.Errorf("Unexpected failure(s) in test '%s':\n%#+v", test.name, failures)
+			}
+		} else if len(failures) != 1 || !strings.Contains(failures[0], test.wantErrStr) {
+			t.Errorf("Incorrect failure(s): expected one containing\n\"%s\"\ngot\n%#+v\nin test '%s'",
+				test.wantErrStr, failures, test.name)
+		}
+	}
+}
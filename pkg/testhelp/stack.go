@@ -0,0 +1,85 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// callerStack returns a formatted stack trace of the calling goroutine, in the same function/file:line layout as
+// runtime/debug.Stack, but without the header line.  skip is the number of stack frames to skip, with 0 identifying
+// the caller of callerStack.
+func callerStack(skip int) string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs) // +2 for runtime.Callers and callerStack
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// panicsWithStack is like PanicsGet, but also returns the stack of the panicking goroutine at the point of the panic
+// (or "" if there was no panic).  The stack is captured by the deferred function, before the goroutine unwinds, so it
+// includes the frames that led to the panic.
+func panicsWithStack(f func()) (didPanic bool, pVal interface{}, stack string) {
+	defer func() {
+		pVal = recover()
+		didPanic = pVal != nil
+		if didPanic {
+			stack = string(debug.Stack())
+		}
+	}()
+	f()
+	return false, nil, "" // overridden by the deferred function; here for the compiler
+}
+
+// panicFailure describes a panic captured by one of the property-style checkers (such as CheckInvariant and
+// CheckRoundTrip) in their failure messages, as "panic in <where> (<type>): <message>".
+func panicFailure(where string, c CapturedPanic) string {
+	return fmt.Sprintf("panic in %s (%T): %s", where, c.PVal, PanicMessage(c.PVal))
+}
+
+// withStack appends a panic's stack to a failure message, if there is one.  It goes last, since it's much longer than
+// the rest of the message.
+func withStack(msg, stack string) string {
+	if stack == "" {
+		return msg
+	}
+	return msg + "\nstack:\n" + stack
+}
+
+// allStacks returns the stacks of all goroutines, as formatted by runtime.Stack, growing the buffer until they fit.
+func allStacks() string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// A stackBudget is the state of a MaxStackDepth call, for StackProbe.
+type stackBudget struct {
+	base     int // the depth of the frame that calls f
+	frames   int
+	exceeded string // the stack at the probe that exceeded the budget, or "" if it hasn't been exceeded
+	probed   bool
+}
+
+// stackBudgetExceeded is the panic value that StackProbe uses to unwind f once the budget is exceeded.
+type stackBudgetExceeded struct{}
+
+// stackBudgets holds the active MaxStackDepth calls, by goroutine ID.
+var stackBudgets = struct {
+	sync.Mutex
+	byGoroutine map[uint64]*stackBudget
+}{byGoroutine: map[uint64]*stackBudget{}}
+
+// MaxStackDepth runs f, and calls t.Errorf if the stack grew deeper than the given number of frames below f, as
+// measured at each call to StackProbe.  This catches runaway recursion as a normal test failure, before it becomes a
+// fatal stack overflow (which can't be recovered from, and ends the whole test binary).  It returns true if the depth
+// stayed within the budget.
+//
+// Go can't inspect the stack of a running function from outside, so the code under test has to cooperate by calling
+// StackProbe at the points that should be measured, normally at the start of the recursive function.  As with
+// DeferTracker, a package-level hook that the test replaces is a common pattern:
+//
+//	// In the code under test
+//	var probeStack = func() {}
+//
+//	func (n *Node) Walk(visit func(*Node)) {
+//		probeStack()
+//		// ...
+//	}
+//
+//	// In the test
+//	probeStack = testhelp.StackProbe
+//	testhelp.MaxStackDepth(t, func() { tree.Walk(visit) }, 100)
+//
+// f itself is frame 1, so if f calls StackProbe directly, the depth is 1; if the hook is used as above, with each
+// level of the recursion calling it, the depth is the depth of the recursion, plus the frames between f and the
+// first level.  Frames are counted as by runtime.Callers, so functions that have been inlined aren't counted.
+//
+// Once the budget is exceeded, StackProbe panics to stop the recursion, and the failure message gives the innermost
+// frames at that point.  (If f recovers from that panic, the failure is still reported.)  If StackProbe isn't called
+// at all while f runs, that's also a failure, since the depth wasn't checked.  f runs in the calling goroutine, so
+// any other panic from f isn't recovered; probes from other goroutines are ignored.
+func MaxStackDepth(t TestingTB, f func(), frames int) bool {
+	t.Helper()
+	gid := goroutineID()
+	budget := &stackBudget{frames: frames}
+	stackBudgets.Lock()
+	outer := stackBudgets.byGoroutine[gid]
+	stackBudgets.byGoroutine[gid] = budget
+	stackBudgets.Unlock()
+	defer func() {
+		stackBudgets.Lock()
+		if outer != nil {
+			stackBudgets.byGoroutine[gid] = outer
+		} else {
+			delete(stackBudgets.byGoroutine, gid)
+		}
+		stackBudgets.Unlock()
+	}()
+
+	func() {
+		defer func() {
+			if pVal := recover(); pVal != nil {
+				if _, ok := pVal.(stackBudgetExceeded); !ok {
+					panic(pVal)
+				}
+			}
+		}()
+		budget.base = stackDepth()
+		f()
+	}()
+
+	switch {
+	case budget.exceeded != "":
+		t.Errorf("Stack depth exceeded the budget of %d frame(s); innermost frames at the probe that exceeded it:\n%s",
+			frames, budget.exceeded)
+		return false
+	case !budget.probed:
+		t.Errorf("The stack depth was never checked: StackProbe wasn't called while the function ran")
+		return false
+	}
+	return true
+}
+
+// StackProbe checks the depth of the stack for MaxStackDepth; see there for how to use it.  It does nothing if it
+// isn't called from a function run by MaxStackDepth (in the same goroutine), so it's safe to leave in place as a hook.
+// It panics (in a way that MaxStackDepth recovers from) if the depth exceeds MaxStackDepth's budget.
+func StackProbe() {
+	gid := goroutineID()
+	stackBudgets.Lock()
+	budget := stackBudgets.byGoroutine[gid]
+	stackBudgets.Unlock()
+	if budget == nil {
+		return
+	}
+
+	// -1 for StackProbe itself, so that f calling it directly is depth 1
+	depth := stackDepth() - budget.base - 1
+	budget.probed = true
+	if depth > budget.frames {
+		if budget.exceeded == "" {
+			budget.exceeded = callerStack(1)
+		}
+		panic(stackBudgetExceeded{})
+	}
+}
+
+// stackDepth returns the number of frames on the stack above its caller.
+func stackDepth() int {
+	pcs := make([]uintptr, 256)
+	for {
+		n := runtime.Callers(2, pcs)
+		if n < len(pcs) {
+			return n
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
+// goroutineID returns the ID of the calling goroutine, from the header of its stack trace, or 0 if it can't be parsed.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	m := goroutineHeaderRE.FindSubmatch(buf)
+	if m == nil {
+		return 0
+	}
+	id, _ := strconv.ParseUint(string(m[1]), 10, 64)
+	return id
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// recurseProbed recurses n levels, calling StackProbe at each one.
+func recurseProbed(n int) {
+	StackProbe()
+	if n > 1 {
+		recurseProbed(n - 1)
+	}
+}
+
+func TestMaxStackDepth(t *testing.T) {
+	exceeded := "Stack depth exceeded the budget of %d frame(s); innermost frames at the probe that exceeded it:\n"
+	tests := []struct {
+		name        string
+		f           func()
+		frames      int
+		wantResult  bool
+		wantMsg     string // prefix
+		wantInStack string
+	}{
+		{"direct probe", func() { StackProbe() }, 1, true, "", ""},
+		{"direct probe over budget", func() { StackProbe() }, 0, false, fmt.Sprintf(exceeded, 0), "TestMaxStackDepth.func"},
+		// f, then each level of recurseProbed
+		{"recursion within budget", func() { recurseProbed(10) }, 11, true, "", ""},
+		{"recursion over budget", func() { recurseProbed(10) }, 10, false, fmt.Sprintf(exceeded, 10), "recurseProbed"},
+		{"runaway recursion", func() { recurseProbed(1 << 30) }, 1000, false, fmt.Sprintf(exceeded, 1000),
+			"recurseProbed"},
+		{"recovered", func() {
+			defer func() { _ = recover() }()
+			recurseProbed(10)
+		}, 5, false, fmt.Sprintf(exceeded, 5), "recurseProbed"},
+		{"not probed", func() {}, 10, false,
+			"The stack depth was never checked: StackProbe wasn't called while the function ran", ""},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		result := MaxStackDepth(rt, test.f, test.frames)
+		if result != test.wantResult {
+			t.Errorf("MaxStackDepth(): Incorrect result: expected %t, got %t in test '%s'", test.wantResult, result,
+				test.name)
+		}
+		failures := rt.Failures()
+		if test.wantMsg == "" {
+			if len(failures) != 0 {
+				t.Errorf("MaxStackDepth(): Unexpected failures: %#+v in test '%s'", failures, test.name)
+			}
+			continue
+		}
+		if len(failures) != 1 || !strings.HasPrefix(failures[0], test.wantMsg) ||
+			!strings.Contains(failures[0], test.wantInStack) {
+			t.Errorf("MaxStackDepth(): Incorrect failure: expected a message starting with\n%s\nand containing "+
+				"\"%s\"\ngot\n%#+v\nin test '%s'", test.wantMsg, test.wantInStack, failures, test.name)
+		}
+	}
+}
+
+func TestMaxStackDepthPassesOtherPanics(t *testing.T) {
+	didPanic, pVal := PanicsGet(func() { MaxStackDepth(&testhelptest.RecordingT{}, func() { panic("other") }, 10) })
+	if !didPanic || pVal != "other" {
+		t.Errorf("MaxStackDepth(): Expected the panic to pass through, got %t, %#+v", didPanic, pVal)
+	}
+	// The budget is removed afterwards
+	StackProbe()
+}
+
+func TestStackProbeOutsideMaxStackDepth(t *testing.T) {
+	if Panics(func() { recurseProbed(100) }) {
+		t.Errorf("StackProbe(): Unexpected panic outside MaxStackDepth")
+	}
+}
+
+func TestMaxStackDepthNested(t *testing.T) {
+	var failures [][]string
+	outer := &testhelptest.RecordingT{}
+	MaxStackDepth(outer, func() {
+		inner := &testhelptest.RecordingT{}
+		MaxStackDepth(inner, func() { recurseProbed(3) }, 2)
+		failures = append(failures, inner.Failures())
+		StackProbe() // checked against the outer budget again
+	}, 3)
+	if len(failures) != 1 || len(failures[0]) != 1 {
+		t.Errorf("MaxStackDepth(): Expected the inner call to fail, got %#+v", failures)
+	}
+	if got := outer.Failures(); !reflect.DeepEqual(got, []string{}) {
+		t.Errorf("MaxStackDepth(): Unexpected failures from the outer call: %#+v", got)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Stress runs f concurrently from the given number of goroutines, each of which calls it the given number of times,
+// with its goroutine number and iteration number (both starting at 0), and waits for them all to finish.  This is
+// intended for checking invariants of concurrent code, ideally with the race detector enabled (go test -race); f can
+// report a broken invariant with t.Errorf.
+//
+// The goroutines are released together, to make overlapping calls more likely.  If f panics, the panic is reported
+// with t.Errorf (with the stack) and that goroutine stops, but the others carry on.  Stress returns true if there were
+// no panics.  It panics if goroutines or iterations is less than 1.
+func Stress(t TestingTB, goroutines, iterations int, f func(goroutine, iteration int)) bool {
+	t.Helper()
+	if goroutines < 1 || iterations < 1 {
+		panic(fmt.Sprintf("Invalid number of goroutines or iterations: %d, %d", goroutines, iterations))
+	}
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var panics []string
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < iterations; i++ {
+				if didPanic, pVal, stack := panicsWithStack(func() { f(g, i) }); didPanic {
+					mu.Lock()
+					panics = append(panics, fmt.Sprintf("Panic in goroutine %d, iteration %d (%T):\n%s\nstack:\n%s",
+						g, i, pVal, PanicMessage(pVal), stack))
+					mu.Unlock()
+					return
+				}
+			}
+		}(g)
+	}
+	close(start)
+	wg.Wait()
+
+	for _, msg := range panics {
+		t.Errorf("%s", msg)
+	}
+	return len(panics) == 0
+}
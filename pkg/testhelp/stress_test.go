@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestStress(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[[2]int]int{}
+	rt := &testhelptest.RecordingT{}
+	ok := Stress(rt, 4, 25, func(g, i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[[2]int{g, i}]++
+	})
+	if !ok || len(rt.Failures()) != 0 || len(calls) != 100 {
+		t.Errorf("Stress(): Expected 100 distinct calls and no failures, got %t, %d, %#+v", ok, len(calls),
+			rt.Failures())
+	}
+
+	rt = &testhelptest.RecordingT{}
+	var count int
+	ok = Stress(rt, 3, 5, func(g, i int) {
+		if g == 1 && i == 2 {
+			panic("boom")
+		}
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+	failures := rt.Failures()
+	if ok || len(failures) != 1 ||
+		!strings.HasPrefix(failures[0], "Panic in goroutine 1, iteration 2 (string):\nboom\nstack:\n") {
+		t.Errorf("Stress(): Incorrect result for a panic: got %t, %#+v", ok, failures)
+	}
+	if count != 12 {
+		t.Errorf("Stress(): Expected the other goroutines to carry on: expected 12 calls, got %d", count)
+	}
+
+	for _, args := range [][2]int{{0, 1}, {1, 0}} {
+		if !Panics(func() { Stress(rt, args[0], args[1], func(int, int) {}) }) {
+			t.Errorf("Stress(): Expected a panic for %d goroutines and %d iterations", args[0], args[1])
+		}
+	}
+}
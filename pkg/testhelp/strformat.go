@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// A StringFormat describes a format that strings can be checked against with MatchesFormat.  Check returns nil if s
+// is in the format, or an error saying specifically what's wrong with it if not.
+type StringFormat struct {
+	Name  string
+	Check func(s string) error
+}
+
+// Built-in formats for MatchesFormat
+var (
+	// UUIDv4Format matches a version 4 (random) UUID in the standard hyphenated form, in either case.
+	UUIDv4Format = StringFormat{Name: "UUIDv4", Check: checkUUIDv4}
+	// ULIDFormat matches a ULID: 26 characters of Crockford's base32, in either case, with a timestamp that fits in 48
+	// bits.
+	ULIDFormat = StringFormat{Name: "ULID", Check: checkULID}
+	// EmailFormat matches a bare email address (without a display name or angle brackets), as parsed by net/mail.
+	EmailFormat = StringFormat{Name: "email address", Check: checkEmail}
+	// HostnameFormat matches a DNS hostname: dot-separated labels of 1 to 63 letters, digits, and hyphens, not
+	// starting or ending with a hyphen, with a total length of at most 253 (not counting an optional trailing dot).
+	HostnameFormat = StringFormat{Name: "hostname", Check: checkHostname}
+	// Base64Format matches standard, padded base64 (as produced by base64.StdEncoding).
+	Base64Format = StringFormat{Name: "base64 string", Check: checkBase64}
+)
+
+// HexFormat returns a format that matches n hexadecimal digits, in either case (so, n/2 bytes).  If n is 0 or less,
+// any non-empty number of digits matches.
+func HexFormat(n int) StringFormat {
+	name := "hex string"
+	if n > 0 {
+		name = fmt.Sprintf("hex string of length %d", n)
+	}
+	return StringFormat{Name: name, Check: func(s string) error {
+		if n > 0 && len(s) != n {
+			return fmt.Errorf("length is %d", len(s))
+		}
+		if s == "" {
+			return errors.New("it's empty")
+		}
+		for i := 0; i < len(s); i++ {
+			if !isHexDigit(s[i]) {
+				return fmt.Errorf("%s is not a hex digit", describeChar(s, i))
+			}
+		}
+		return nil
+	}}
+}
+
+// MatchesFormat checks that s is in the given format, calling t.Errorf with the string and what's wrong with it if
+// not.  It returns true if the check passed.
+func MatchesFormat(t TestingTB, s string, format StringFormat) bool {
+	t.Helper()
+	if err := format.Check(s); err != nil {
+		t.Errorf("String %q is not a valid %s: %s", s, format.Name, err)
+		return false
+	}
+	return true
+}
+
+// IsUUIDv4 is MatchesFormat with UUIDv4Format.
+func IsUUIDv4(t TestingTB, s string) bool {
+	t.Helper()
+	return MatchesFormat(t, s, UUIDv4Format)
+}
+
+// IsULID is MatchesFormat with ULIDFormat.
+func IsULID(t TestingTB, s string) bool {
+	t.Helper()
+	return MatchesFormat(t, s, ULIDFormat)
+}
+
+func checkUUIDv4(s string) error {
+	if len(s) != 36 {
+		return fmt.Errorf("length is %d, expected 36", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return fmt.Errorf("%s should be '-'", describeChar(s, i))
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return fmt.Errorf("%s is not a hex digit", describeChar(s, i))
+			}
+		}
+	}
+	if s[14] != '4' {
+		return fmt.Errorf("version is %c, expected 4", s[14])
+	}
+	if !strings.ContainsRune("89abAB", rune(s[19])) {
+		return fmt.Errorf("variant %s should be one of 8, 9, a, or b", describeChar(s, 19))
+	}
+	return nil
+}
+
+// crockfordBase32 is the alphabet used by ULIDs.
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func checkULID(s string) error {
+	if len(s) != 26 {
+		return fmt.Errorf("length is %d, expected 26", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		if !strings.ContainsRune(crockfordBase32, rune(upperASCII(s[i]))) {
+			return fmt.Errorf("%s is not in Crockford's base32 alphabet", describeChar(s, i))
+		}
+	}
+	if s[0] > '7' {
+		return fmt.Errorf("%s makes the timestamp overflow 48 bits", describeChar(s, 0))
+	}
+	return nil
+}
+
+func checkEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return err
+	}
+	if addr.Name != "" || addr.Address != s {
+		return fmt.Errorf("expected a bare address, got one that parses as %q", addr.Address)
+	}
+	return nil
+}
+
+func checkHostname(s string) error {
+	name := strings.TrimSuffix(s, ".")
+	if name == "" {
+		return errors.New("it's empty")
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("length is %d, more than 253", len(name))
+	}
+	for _, label := range strings.Split(name, ".") {
+		switch {
+		case label == "":
+			return errors.New("it has an empty label")
+		case len(label) > 63:
+			return fmt.Errorf("label %q is %d characters long, more than 63", label, len(label))
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return fmt.Errorf("label %q starts or ends with a hyphen", label)
+		}
+		for i := 0; i < len(label); i++ {
+			c := upperASCII(label[i])
+			if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("label %q contains invalid %s", label, describeChar(label, i))
+			}
+		}
+	}
+	return nil
+}
+
+func checkBase64(s string) error {
+	_, err := base64.StdEncoding.DecodeString(s)
+	return err
+}
+
+// describeChar describes the byte at index i of s, for a failure message.
+func describeChar(s string, i int) string {
+	return fmt.Sprintf("character %q at index %d", s[i], i)
+}
+
+func isHexDigit(c byte) bool {
+	c = upperASCII(c)
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'F'
+}
+
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
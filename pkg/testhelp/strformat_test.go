@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestMatchesFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   StringFormat
+		s        string
+		expected string // the failure after the format name, or "" for none
+	}{
+		{name: "uuid", format: UUIDv4Format, s: "0b1c6f2e-8d3a-4f5b-9c7d-1e2f3a4b5c6d"},
+		{name: "uuid upper", format: UUIDv4Format, s: "0B1C6F2E-8D3A-4F5B-AC7D-1E2F3A4B5C6D"},
+		{name: "uuid length", format: UUIDv4Format, s: "0b1c6f2e", expected: "length is 8, expected 36"},
+		{
+			name: "uuid hyphen", format: UUIDv4Format, s: "0b1c6f2e_8d3a-4f5b-9c7d-1e2f3a4b5c6d",
+			expected: "character '_' at index 8 should be '-'",
+		},
+		{
+			name: "uuid digit", format: UUIDv4Format, s: "0b1c6f2e-8d3a-4f5b-9c7d-1e2f3a4b5c6g",
+			expected: "character 'g' at index 35 is not a hex digit",
+		},
+		{
+			name: "uuid version", format: UUIDv4Format, s: "0b1c6f2e-8d3a-1f5b-9c7d-1e2f3a4b5c6d",
+			expected: "version is 1, expected 4",
+		},
+		{
+			name: "uuid variant", format: UUIDv4Format, s: "0b1c6f2e-8d3a-4f5b-cc7d-1e2f3a4b5c6d",
+			expected: "variant character 'c' at index 19 should be one of 8, 9, a, or b",
+		},
+		{name: "ulid", format: ULIDFormat, s: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "ulid lower", format: ULIDFormat, s: "01arz3ndektsv4rrffq69g5fav"},
+		{name: "ulid length", format: ULIDFormat, s: "01ARZ3", expected: "length is 6, expected 26"},
+		{
+			name: "ulid alphabet", format: ULIDFormat, s: "01ARZ3NDEKTSV4RRFFQ69G5FAU",
+			expected: "character 'U' at index 25 is not in Crockford's base32 alphabet",
+		},
+		{
+			name: "ulid overflow", format: ULIDFormat, s: "81ARZ3NDEKTSV4RRFFQ69G5FAV",
+			expected: "character '8' at index 0 makes the timestamp overflow 48 bits",
+		},
+		{name: "email", format: EmailFormat, s: "someone@example.com"},
+		{name: "email invalid", format: EmailFormat, s: "someone", expected: "mail: missing '@' or angle-addr"},
+		{
+			name: "email display name", format: EmailFormat, s: "Someone <someone@example.com>",
+			expected: "expected a bare address, got one that parses as \"someone@example.com\"",
+		},
+		{name: "hostname", format: HostnameFormat, s: "api-1.example.com."},
+		{name: "hostname empty", format: HostnameFormat, s: "", expected: "it's empty"},
+		{name: "hostname empty label", format: HostnameFormat, s: "a..b", expected: "it has an empty label"},
+		{
+			name: "hostname hyphen", format: HostnameFormat, s: "-a.example.com",
+			expected: "label \"-a\" starts or ends with a hyphen",
+		},
+		{
+			name: "hostname character", format: HostnameFormat, s: "a_b.example.com",
+			expected: "label \"a_b\" contains invalid character '_' at index 1",
+		},
+		{
+			name: "hostname label length", format: HostnameFormat, s: strings.Repeat("a", 64) + ".com",
+			expected: "label \"" + strings.Repeat("a", 64) + "\" is 64 characters long, more than 63",
+		},
+		{
+			name: "hostname length", format: HostnameFormat, s: strings.Repeat("a.", 127) + "a",
+			expected: "length is 255, more than 253",
+		},
+		{name: "base64", format: Base64Format, s: "aGVsbG8="},
+		{
+			name: "base64 padding", format: Base64Format, s: "aGVsbG8",
+			expected: "illegal base64 data at input byte 4",
+		},
+		{name: "hex", format: HexFormat(8), s: "deadBEEF"},
+		{name: "hex any", format: HexFormat(0), s: "abc"},
+		{name: "hex empty", format: HexFormat(0), s: "", expected: "it's empty"},
+		{name: "hex length", format: HexFormat(8), s: "dead", expected: "length is 4"},
+		{name: "hex digit", format: HexFormat(4), s: "dexd", expected: "character 'x' at index 2 is not a hex digit"},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := MatchesFormat(rt, test.s, test.format)
+		want := []string{}
+		if test.expected != "" {
+			want = []string{"String \"" + test.s + "\" is not a valid " + test.format.Name + ": " + test.expected}
+		}
+		if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+			t.Errorf("MatchesFormat(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got,
+				test.name)
+		}
+	}
+}
+
+func TestIsUUIDv4AndIsULID(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if !IsUUIDv4(rt, "0b1c6f2e-8d3a-4f5b-9c7d-1e2f3a4b5c6d") || !IsULID(rt, "01ARZ3NDEKTSV4RRFFQ69G5FAV") {
+		t.Errorf("IsUUIDv4()/IsULID(): Unexpected failures:\n%#+v", rt.Failures())
+	}
+	if IsUUIDv4(rt, "01ARZ3NDEKTSV4RRFFQ69G5FAV") || IsULID(rt, "0b1c6f2e-8d3a-4f5b-9c7d-1e2f3a4b5c6d") {
+		t.Errorf("IsUUIDv4()/IsULID(): Expected false for the other kind of identifier")
+	}
+	want := []string{
+		"String \"01ARZ3NDEKTSV4RRFFQ69G5FAV\" is not a valid UUIDv4: length is 26, expected 36",
+		"String \"0b1c6f2e-8d3a-4f5b-9c7d-1e2f3a4b5c6d\" is not a valid ULID: length is 36, expected 26",
+	}
+	if got := rt.Failures(); !reflect.DeepEqual(got, want) {
+		t.Errorf("IsUUIDv4()/IsULID(): Incorrect failures: expected\n%#+v\ngot\n%#+v", want, got)
+	}
+}
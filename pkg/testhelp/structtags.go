@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// TagRules holds the rules for CheckStructTags.  Duplicate names are always checked; the other rules are only checked
+// if they're set.
+type TagRules struct {
+	// Key is the struct tag key to check, such as "json" or "yaml"; if it's "", "json" is used.
+	Key string
+	// Required makes each exported field need a tag with the key (including "-", which excludes the field).
+	Required bool
+	// SnakeCase makes each name given in a tag need to be snake_case: lowercase letters and digits, starting with a
+	// letter, in words separated by single underscores.
+	SnakeCase bool
+}
+
+var snakeCaseRE = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// CheckStructTags checks the struct tags of the fields of v (a struct, or a pointer to one) against rules, calling
+// t.Errorf with a list of all of the violations if there are any.  It returns true if there were none.  This replaces
+// the hand-written tests that keep struct tags consistent, such as in API types:
+//
+//	testhelp.CheckStructTags(t, Response{}, testhelp.TagRules{Required: true, SnakeCase: true})
+//
+// Fields are treated the way encoding/json treats them: unexported fields are ignored; a tag name of "-" (with no
+// options) excludes a field; a field without a name in its tag gets its Go name; and the fields of an embedded struct
+// without a name in its tag are checked as if they were fields of v, so their names can duplicate v's.  (encoding/json
+// would resolve such a duplicate by depth; CheckStructTags reports it, since it's rarely intended.)  Only the fields of
+// v's own type are checked, not those of the types of its fields.
+//
+// CheckStructTags panics if v isn't a struct or a pointer to one.
+func CheckStructTags(t TestingTB, v interface{}, rules TagRules) bool {
+	t.Helper()
+	typ := reflect.TypeOf(v)
+	if typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("CheckStructTags requires a struct or a pointer to one, got %T", v))
+	}
+	if rules.Key == "" {
+		rules.Key = "json"
+	}
+
+	tc := &tagChecker{rules: rules, firstField: map[string]string{}}
+	tc.checkFields(typ, typ.Name(), map[reflect.Type]bool{typ: true})
+	if len(tc.problems) > 0 {
+		t.Errorf("Invalid %s tags in %s:\n%s", rules.Key, typ, strings.Join(tc.problems, "\n"))
+		return false
+	}
+	return true
+}
+
+// A tagChecker collects the violations found by CheckStructTags.  firstField maps each name seen so far to the field
+// it was seen on.
+type tagChecker struct {
+	rules      TagRules
+	problems   []string
+	firstField map[string]string
+}
+
+// checkFields checks the fields of typ, naming them with prefix (the path to typ from the checked type, such as
+// "Response.Base").  seen holds the types that are already being checked, to stop embedding cycles through pointers.
+func (tc *tagChecker) checkFields(typ reflect.Type, prefix string, seen map[reflect.Type]bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		desc := prefix + "." + f.Name
+		tag, hasTag := f.Tag.Lookup(tc.rules.Key)
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if tag != "-" && !seen[ft] {
+					seen[ft] = true
+					tc.checkFields(ft, desc, seen)
+					delete(seen, ft)
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		switch {
+		case !hasTag:
+			if tc.rules.Required {
+				tc.problems = append(tc.problems, fmt.Sprintf("field %s: no %s tag", desc, tc.rules.Key))
+			}
+		case tag == "-":
+			continue
+		case name != "" && tc.rules.SnakeCase && !snakeCaseRE.MatchString(name):
+			tc.problems = append(tc.problems, fmt.Sprintf("field %s: %s name %q is not snake_case", desc,
+				tc.rules.Key, name))
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if first, ok := tc.firstField[name]; ok {
+			tc.problems = append(tc.problems, fmt.Sprintf("field %s: %s name %q is the same as field %s's", desc,
+				tc.rules.Key, name, first))
+		} else {
+			tc.firstField[name] = desc
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+type tagsBase struct {
+	ID      string `json:"id"`
+	Created string `json:"created_at"`
+}
+
+type tagsCycle struct {
+	*tagsCycle
+	Next string `json:"next"`
+}
+
+type tagsGood struct {
+	tagsBase
+	UserName string            `json:"user_name,omitempty"`
+	Score2   int               `json:"score2"`
+	Internal string            `json:"-"`
+	Meta     map[string]string `json:"meta" yaml:"Meta"`
+	hidden   string
+}
+
+type tagsBad struct {
+	tagsBase
+	UserName  string `json:"userName"`
+	Untagged  string
+	Options   string `json:",omitempty"`
+	Other     string `json:"id"`
+	Trailing  string `json:"trailing_"`
+	Embedded  tagsBase
+	NoTagEnum int
+}
+
+func TestCheckStructTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		v            interface{}
+		rules        TagRules
+		wantOK       bool
+		wantFailures []string
+	}{
+		{"good", tagsGood{}, TagRules{Required: true, SnakeCase: true}, true, []string{}},
+		{"good, pointer", &tagsGood{}, TagRules{Key: "json", Required: true, SnakeCase: true}, true, []string{}},
+		{"bad, all rules", tagsBad{}, TagRules{Required: true, SnakeCase: true}, false, []string{
+			"Invalid json tags in testhelp.tagsBad:\n" +
+				"field tagsBad.UserName: json name \"userName\" is not snake_case\n" +
+				"field tagsBad.Untagged: no json tag\n" +
+				"field tagsBad.Other: json name \"id\" is the same as field tagsBad.tagsBase.ID's\n" +
+				"field tagsBad.Trailing: json name \"trailing_\" is not snake_case\n" +
+				"field tagsBad.Embedded: no json tag\n" +
+				"field tagsBad.NoTagEnum: no json tag",
+		}},
+		{"bad, duplicates only", tagsBad{}, TagRules{}, false, []string{
+			"Invalid json tags in testhelp.tagsBad:\n" +
+				"field tagsBad.Other: json name \"id\" is the same as field tagsBad.tagsBase.ID's",
+		}},
+		{"other key", tagsGood{}, TagRules{Key: "yaml", Required: true, SnakeCase: true}, false, []string{
+			"Invalid yaml tags in testhelp.tagsGood:\n" +
+				"field tagsGood.tagsBase.ID: no yaml tag\n" +
+				"field tagsGood.tagsBase.Created: no yaml tag\n" +
+				"field tagsGood.UserName: no yaml tag\n" +
+				"field tagsGood.Score2: no yaml tag\n" +
+				"field tagsGood.Internal: no yaml tag\n" +
+				"field tagsGood.Meta: yaml name \"Meta\" is not snake_case",
+		}},
+		{"embedding cycle", tagsCycle{}, TagRules{Required: true}, true, []string{}},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		if ok := CheckStructTags(rt, test.v, test.rules); ok != test.wantOK {
+			t.Errorf("CheckStructTags(): Incorrect result: expected %t, got %t in test '%s'", test.wantOK, ok,
+				test.name)
+		}
+		if got := rt.Failures(); !reflect.DeepEqual(got, test.wantFailures) {
+			t.Errorf("CheckStructTags(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantFailures, got, test.name)
+		}
+	}
+}
+
+func TestCheckStructTagsPanics(t *testing.T) {
+	for _, v := range []interface{}{nil, 5, new(int), []tagsGood{}} {
+		if didPanic := Panics(func() { CheckStructTags(&testhelptest.RecordingT{}, v, TagRules{}) }); !didPanic {
+			t.Errorf("CheckStructTags(): Expected a panic for %#+v", v)
+		}
+	}
+}
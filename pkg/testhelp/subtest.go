@@ -0,0 +1,397 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// SubtestT is the part of *testing.T that the subtest runners use within each subtest.  It extends TestingTB with
+// Skip.
+type SubtestT interface {
+	TestingTB
+	Skip(args ...interface{})
+}
+
+// subtestRunner is the part of *testing.T that the subtest runners use, with Run adapted so that it can be mocked.
+type subtestRunner interface {
+	Helper()
+	Name() string
+	runSubtest(name string, f func(st SubtestT)) bool
+}
+
+// testingRunner adapts a *testing.T to subtestRunner.
+type testingRunner struct {
+	t *testing.T
+}
+
+func (r testingRunner) Helper() {
+	r.t.Helper()
+}
+
+func (r testingRunner) Name() string {
+	return r.t.Name()
+}
+
+func (r testingRunner) runSubtest(name string, f func(st SubtestT)) bool {
+	return r.t.Run(name, func(st *testing.T) { f(st) })
+}
+
+// entryOptions holds the fields of a table entry that control how the subtest runners treat it.
+type entryOptions struct {
+	name            string
+	loc             Loc
+	skip            bool
+	skipReason      string
+	expectedFailure bool
+	kind            string // the kind of check, for the testhelp.kind attribute
+}
+
+// failureCollector is a TestingT that collects failure messages instead of reporting them, for expected-failure
+// entries.  (The checks only use Errorf, so Fatalf doesn't need to stop the test.)
+type failureCollector struct {
+	failures []string
+}
+
+func (fc *failureCollector) Errorf(format string, args ...interface{}) {
+	fc.failures = append(fc.failures, fmt.Sprintf(format, args...))
+}
+
+func (fc *failureCollector) Fatalf(format string, args ...interface{}) {
+	fc.failures = append(fc.failures, fmt.Sprintf(format, args...))
+}
+
+// reportingT passes calls through to a SubtestT, keeping a record of the failures and skips for a Report.
+type reportingT struct {
+	SubtestT
+	messages []string
+	failed   bool
+	skipped  bool
+}
+
+func (rt *reportingT) Errorf(format string, args ...interface{}) {
+	rt.SubtestT.Helper()
+	rt.failed = true
+	rt.messages = append(rt.messages, fmt.Sprintf(format, args...))
+	rt.SubtestT.Errorf(format, args...)
+}
+
+func (rt *reportingT) Fatalf(format string, args ...interface{}) {
+	rt.SubtestT.Helper()
+	rt.failed = true
+	rt.messages = append(rt.messages, fmt.Sprintf(format, args...))
+	rt.SubtestT.Fatalf(format, args...)
+}
+
+func (rt *reportingT) Skip(args ...interface{}) {
+	rt.SubtestT.Helper()
+	rt.skipped = true
+	rt.messages = append(rt.messages, fmt.Sprint(args...))
+	rt.SubtestT.Skip(args...)
+}
+
+// runEntry runs the check for a single table entry in a subtest of r, taking the entry's options into account, and
+// adds it to the runner's report, if there is one (and attaches its attributes, if Attrs is set).  The check reports
+// failures through the TestingT it's given.
+func (sr *SubtestRunner) runEntry(r subtestRunner, st SubtestT, opts entryOptions, check func(t TestingT)) {
+	st.Helper()
+	if sr.Report != nil || sr.Attrs {
+		rt := &reportingT{SubtestT: st}
+		attrT := st
+		st = rt
+		start := time.Now()
+		defer func() { // also after Skip or Fatalf
+			c := ReportCase{Suite: r.Name(), Name: opts.name, Outcome: ReportPassed, Duration: time.Since(start),
+				Messages: rt.messages}
+			switch {
+			case rt.skipped:
+				c.Outcome = ReportSkipped
+			case rt.failed:
+				c.Outcome = ReportFailed
+			default:
+				c.Messages = nil // e.g. logged expected failures
+			}
+			if sr.Report != nil {
+				sr.Report.add(c)
+			}
+			if sr.Attrs {
+				setEntryAttrs(attrT, opts, c)
+			}
+		}()
+	}
+
+	if opts.skip {
+		if opts.skipReason == "" {
+			st.Skip("Skipped" + locSuffix(opts.loc))
+		}
+		st.Skip(opts.skipReason)
+	}
+	if !opts.expectedFailure {
+		check(st)
+		return
+	}
+	fc := &failureCollector{}
+	check(fc)
+	if len(fc.failures) == 0 {
+		st.Errorf("Test marked as an expected failure passed%s; if the bug has been fixed, remove ExpectedFailure",
+			locSuffix(opts.loc))
+		return
+	}
+	for _, failure := range fc.failures {
+		st.Logf("Expected failure: %s", failure)
+	}
+}
+
+// A SubtestRunner runs the same subtests as the package-level subtest runners (RunPanicTests, RunPanicStrTests, etc.),
+// with additional options set by its fields.  The zero value runs subtests exactly as the package-level functions do
+// (which use it themselves), and a SubtestRunner can be reused for any number of tables.
+type SubtestRunner struct {
+	// Report, if not nil, gets a record of each subtest run, with its outcome, duration, and failure messages.
+	Report *Report
+	// Attrs, if true, attaches attributes to each subtest with testing.T.Attr (from Go 1.25 on; with earlier versions,
+	// it does nothing), so that tools reading the go test -json output get machine-readable metadata for each table
+	// entry: testhelp.case (the entry's name), testhelp.kind (the kind of check, such as panics_str for
+	// RunPanicStrTests), testhelp.loc (the entry's Loc, if it's set), testhelp.outcome (as in ReportCase), and
+	// testhelp.duration.
+	Attrs bool
+	// Eq, if not nil, is used by RunPanicValTests to compare the panic values with the tests' WantVals, as in
+	// PanicsValEq.
+	Eq func(a, b interface{}) bool
+}
+
+// RunPanicTests runs each of the given panic tests as a subtest of t, named from the test's struct, and fails any
+// subtest whose function does not panic.  (Entries with empty names are named after their other fields, with NameFor.)
+// Unlike with the loops, the tests can be selected and reported individually (e.g. with go test -run), and failure
+// messages include the position of the table entry if its Loc is set.
+//
+// The subtest runners also support the tests' Skip and ExpectedFailure fields (which the loops ignore).  An entry with
+// Skip set is skipped with t.Skip, giving SkipReason if it isn't empty.  An entry with ExpectedFailure set passes if
+// its check fails (logging the failures), and fails if the check passes, so that known bugs can be recorded in the
+// table and noticed when they're fixed.
+//
+// As with PanicsGetLoop, it is strongly suggested to test the actual panic values, with RunPanicStrTests,
+// RunPanicRETests, RunPanicValTests, or RunPanicMatchingTests.
+func RunPanicTests(t *testing.T, tests []PanicTest) {
+	t.Helper()
+	new(SubtestRunner).RunPanicTests(t, tests)
+}
+
+// RunPanicTests is like the package-level RunPanicTests, but with the runner's options.
+func (sr *SubtestRunner) RunPanicTests(t *testing.T, tests []PanicTest) {
+	t.Helper()
+	sr.runPanicTests(testingRunner{t}, tests)
+}
+
+func (sr *SubtestRunner) runPanicTests(r subtestRunner, tests []PanicTest) {
+	r.Helper()
+	var names tableNamer
+	for _, test := range tests {
+		test := test
+		name := names.name(test.Name, test)
+		r.runSubtest(name, func(st SubtestT) {
+			st.Helper()
+			opts := entryOptions{name, test.Loc, test.Skip, test.SkipReason, test.ExpectedFailure, "panics"}
+			sr.runEntry(r, st, opts, func(t TestingT) {
+				if !Panics(test.F) {
+					t.Errorf("Expected a panic%s", locSuffix(test.Loc))
+				}
+			})
+		})
+	}
+}
+
+// RunNotPanicTests runs each of the given panic tests as a subtest of t, named from the test's struct, and fails any
+// subtest whose function panics.  See RunPanicTests.
+func RunNotPanicTests(t *testing.T, tests []PanicTest) {
+	t.Helper()
+	new(SubtestRunner).RunNotPanicTests(t, tests)
+}
+
+// RunNotPanicTests is like the package-level RunNotPanicTests, but with the runner's options.
+func (sr *SubtestRunner) RunNotPanicTests(t *testing.T, tests []PanicTest) {
+	t.Helper()
+	sr.runNotPanicTests(testingRunner{t}, tests)
+}
+
+func (sr *SubtestRunner) runNotPanicTests(r subtestRunner, tests []PanicTest) {
+	r.Helper()
+	var names tableNamer
+	for _, test := range tests {
+		test := test
+		name := names.name(test.Name, test)
+		r.runSubtest(name, func(st SubtestT) {
+			st.Helper()
+			opts := entryOptions{name, test.Loc, test.Skip, test.SkipReason, test.ExpectedFailure, "not_panics"}
+			sr.runEntry(r, st, opts, func(t TestingT) {
+				if didPanic, pVal := PanicsGet(test.F); didPanic {
+					t.Errorf("Unexpected panic%s:\n%s", locSuffix(test.Loc), quotedPanicMessage(pVal))
+				}
+			})
+		})
+	}
+}
+
+// RunPanicStrTests runs each of the given panic tests as a subtest of t, named from the test's struct, and fails any
+// subtest whose function does not panic, or whose panic value cannot be cast to a string or error containing the
+// test's WantStr.  See RunPanicTests and PanicsStr.
+func RunPanicStrTests(t *testing.T, tests []PanicStrTest) {
+	t.Helper()
+	new(SubtestRunner).RunPanicStrTests(t, tests)
+}
+
+// RunPanicStrTests is like the package-level RunPanicStrTests, but with the runner's options.
+func (sr *SubtestRunner) RunPanicStrTests(t *testing.T, tests []PanicStrTest) {
+	t.Helper()
+	sr.runPanicStrTests(testingRunner{t}, tests)
+}
+
+func (sr *SubtestRunner) runPanicStrTests(r subtestRunner, tests []PanicStrTest) {
+	r.Helper()
+	var names tableNamer
+	for _, test := range tests {
+		test := test
+		name := names.name(test.Name, test)
+		r.runSubtest(name, func(st SubtestT) {
+			st.Helper()
+			opts := entryOptions{name, test.Loc, test.Skip, test.SkipReason, test.ExpectedFailure, "panics_str"}
+			sr.runEntry(r, st, opts, func(t TestingT) {
+				didPanic, pContainsStr, pVal := PanicsStr(test.F, test.WantStr)
+				if !didPanic {
+					t.Errorf("Expected a panic%s", locSuffix(test.Loc))
+				} else if !pContainsStr {
+					t.Errorf("Incorrect panic value%s: expected a string containing\n\"%s\"\ngot\n%s",
+						locSuffix(test.Loc), test.WantStr, quotedPanicMessage(pVal))
+				}
+			})
+		})
+	}
+}
+
+// RunPanicRETests runs each of the given panic tests as a subtest of t, named from the test's struct, and fails any
+// subtest whose function does not panic, or whose panic value cannot be cast to a string or error matching the
+// regular expression given by the test's WantRE.  See RunPanicTests and PanicsRE.
+//
+// As with PanicsRE, the subtest panics if WantRE does not represent a valid regular expression.
+func RunPanicRETests(t *testing.T, tests []PanicRETest) {
+	t.Helper()
+	new(SubtestRunner).RunPanicRETests(t, tests)
+}
+
+// RunPanicRETests is like the package-level RunPanicRETests, but with the runner's options.
+func (sr *SubtestRunner) RunPanicRETests(t *testing.T, tests []PanicRETest) {
+	t.Helper()
+	sr.runPanicRETests(testingRunner{t}, tests)
+}
+
+func (sr *SubtestRunner) runPanicRETests(r subtestRunner, tests []PanicRETest) {
+	r.Helper()
+	var names tableNamer
+	for _, test := range tests {
+		test := test
+		name := names.name(test.Name, test)
+		r.runSubtest(name, func(st SubtestT) {
+			st.Helper()
+			opts := entryOptions{name, test.Loc, test.Skip, test.SkipReason, test.ExpectedFailure, "panics_re"}
+			sr.runEntry(r, st, opts, func(t TestingT) {
+				didPanic, pMatchesRE, pVal := PanicsRE(test.F, test.WantRE)
+				if !didPanic {
+					t.Errorf("Expected a panic%s", locSuffix(test.Loc))
+				} else if !pMatchesRE {
+					t.Errorf("Incorrect panic value%s: expected a string matching\n\"%s\"\ngot\n%s",
+						locSuffix(test.Loc), test.WantRE, quotedPanicMessage(pVal))
+				}
+			})
+		})
+	}
+}
+
+// RunPanicValTests runs each of the given panic tests as a subtest of t, named from the test's struct, and fails any
+// subtest whose function does not panic, or whose panic value does not equal the test's WantVal.  See RunPanicTests
+// and PanicsVal.
+//
+// As with PanicsVal, the subtest panics if the panic value and WantVal are of the same type, but it's not a type that
+// Go can compare with ==.  To compare the values some other way, use a SubtestRunner with Eq set.
+func RunPanicValTests(t *testing.T, tests []PanicValTest) {
+	t.Helper()
+	new(SubtestRunner).RunPanicValTests(t, tests)
+}
+
+// RunPanicValTests is like the package-level RunPanicValTests, but with the runner's options.
+func (sr *SubtestRunner) RunPanicValTests(t *testing.T, tests []PanicValTest) {
+	t.Helper()
+	sr.runPanicValTests(testingRunner{t}, tests)
+}
+
+func (sr *SubtestRunner) runPanicValTests(r subtestRunner, tests []PanicValTest) {
+	r.Helper()
+	var names tableNamer
+	for _, test := range tests {
+		test := test
+		name := names.name(test.Name, test)
+		r.runSubtest(name, func(st SubtestT) {
+			st.Helper()
+			opts := entryOptions{name, test.Loc, test.Skip, test.SkipReason, test.ExpectedFailure, "panics_val"}
+			sr.runEntry(r, st, opts, func(t TestingT) {
+				didPanic, pEquals, pVal := PanicsValEq(test.F, test.WantVal, sr.Eq)
+				if !didPanic {
+					t.Errorf("Expected a panic%s", locSuffix(test.Loc))
+				} else if !pEquals {
+					t.Errorf("Incorrect panic value%s: expected\n%s\ngot\n%s", locSuffix(test.Loc),
+						Format(test.WantVal), Format(pVal))
+				}
+			})
+		})
+	}
+}
+
+// RunPanicMatchingTests runs each of the given panic tests as a subtest of t, named from the test's struct, and fails
+// any subtest whose function does not panic, or whose panic value does not match all of the test's matchers.  See
+// RunPanicTests and PanicsMatching.
+func RunPanicMatchingTests(t *testing.T, tests []PanicMatchingTest) {
+	t.Helper()
+	new(SubtestRunner).RunPanicMatchingTests(t, tests)
+}
+
+// RunPanicMatchingTests is like the package-level RunPanicMatchingTests, but with the runner's options.
+func (sr *SubtestRunner) RunPanicMatchingTests(t *testing.T, tests []PanicMatchingTest) {
+	t.Helper()
+	sr.runPanicMatchingTests(testingRunner{t}, tests)
+}
+
+func (sr *SubtestRunner) runPanicMatchingTests(r subtestRunner, tests []PanicMatchingTest) {
+	r.Helper()
+	var names tableNamer
+	for _, test := range tests {
+		test := test
+		name := names.name(test.Name, test)
+		r.runSubtest(name, func(st SubtestT) {
+			st.Helper()
+			opts := entryOptions{name, test.Loc, test.Skip, test.SkipReason, test.ExpectedFailure, "panics_matching"}
+			sr.runEntry(r, st, opts, func(t TestingT) {
+				didPanic, pVal := PanicsGet(test.F)
+				if !didPanic {
+					t.Errorf("Expected a panic%s", locSuffix(test.Loc))
+				} else if matcher := firstMismatch(pVal, test.Matchers); matcher != nil {
+					t.Errorf("Incorrect panic value%s: expected a value that %s\ngot\n%s", locSuffix(test.Loc),
+						matcher.Describe(), quotedPanicMessage(pVal))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,48 @@
+//go:build go1.25
+
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"strings"
+	"testing"
+)
+
+// attrT is the part of *testing.T used by setEntryAttrs.
+type attrT interface {
+	Attr(key, value string)
+}
+
+var _ attrT = (*testing.T)(nil)
+
+// setEntryAttrs attaches the attributes for SubtestRunner.Attrs to st, if it supports them.
+func setEntryAttrs(st SubtestT, opts entryOptions, c ReportCase) {
+	at, ok := st.(attrT)
+	if !ok {
+		return
+	}
+	// Attribute values can't contain newlines
+	clean := strings.NewReplacer("\r", " ", "\n", " ").Replace
+	at.Attr("testhelp.case", clean(opts.name))
+	at.Attr("testhelp.kind", opts.kind)
+	if opts.loc.File != "" {
+		at.Attr("testhelp.loc", opts.loc.String())
+	}
+	at.Attr("testhelp.outcome", c.Outcome)
+	at.Attr("testhelp.duration", c.Duration.String())
+}
@@ -0,0 +1,77 @@
+//go:build go1.25
+
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func (st *subtestTMock) Attr(key, value string) {
+	st.attrs = append(st.attrs, [2]string{key, value})
+}
+
+func TestSubtestRunnerAttrs(t *testing.T) {
+	loc := Loc{File: "/src/table_test.go", Line: 7}
+	r := &subtestRunnerMock{}
+	report := &Report{}
+	sr := &SubtestRunner{Attrs: true, Report: report}
+	sr.runPanicStrTests(r, []PanicStrTest{
+		{Name: "pass", F: func() { panic("ppp") }, WantStr: "pp", Loc: loc},
+		{Name: "multi\nline", F: func() {}, WantStr: "pp"},
+		{Name: "skipped", F: func() {}, Skip: true},
+	})
+
+	// The durations vary
+	for _, st := range r.subTs {
+		for i := range st.attrs {
+			if st.attrs[i][0] == "testhelp.duration" {
+				st.attrs[i][1] = "(duration)"
+			}
+		}
+	}
+	want := map[string][][2]string{
+		"pass": {{"testhelp.case", "pass"}, {"testhelp.kind", "panics_str"}, {"testhelp.loc", "table_test.go:7"},
+			{"testhelp.outcome", "passed"}, {"testhelp.duration", "(duration)"}},
+		"multi\nline": {{"testhelp.case", "multi line"}, {"testhelp.kind", "panics_str"},
+			{"testhelp.outcome", "failed"}, {"testhelp.duration", "(duration)"}},
+		"skipped": {{"testhelp.case", "skipped"}, {"testhelp.kind", "panics_str"}, {"testhelp.outcome", "skipped"},
+			{"testhelp.duration", "(duration)"}},
+	}
+	for name, wantAttrs := range want {
+		if got := r.subTs[name].attrs; !reflect.DeepEqual(got, wantAttrs) {
+			t.Errorf("runPanicStrTests(): Incorrect attributes: expected\n%#+v\ngot\n%#+v\nin test '%s'", wantAttrs,
+				got, name)
+		}
+	}
+	if len(report.Cases()) != 3 {
+		t.Errorf("runPanicStrTests(): Expected 3 report cases along with the attributes, got %d", len(report.Cases()))
+	}
+
+	// Without Attrs
+	r = &subtestRunnerMock{}
+	new(SubtestRunner).runPanicTests(r, []PanicTest{{Name: "p", F: func() { panic(1) }}})
+	if attrs := r.subTs["p"].attrs; attrs != nil {
+		t.Errorf("runPanicTests(): Unexpected attributes without Attrs: %#+v", attrs)
+	}
+}
+
+func TestSubtestRunnerAttrsWithTestingT(t *testing.T) {
+	(&SubtestRunner{Attrs: true}).RunPanicTests(t, []PanicTest{{Name: "p", F: func() { panic(1) }}})
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// subtestTMock is a testhelptest.RecordingT that can also be skipped.  With Go 1.25 or later, it also records
+// attributes.
+type subtestTMock struct {
+	testhelptest.RecordingT
+	skipped string
+	attrs   [][2]string
+}
+
+func (st *subtestTMock) Skip(args ...interface{}) {
+	st.skipped = fmt.Sprint(args...)
+	runtime.Goexit()
+}
+
+// subtestRunnerMock records the subtests run through it, giving each one its own subtestTMock.
+type subtestRunnerMock struct {
+	names []string
+	subTs map[string]*subtestTMock
+}
+
+func (m *subtestRunnerMock) Helper() {}
+
+func (m *subtestRunnerMock) Name() string {
+	return "TestMock"
+}
+
+func (m *subtestRunnerMock) runSubtest(name string, f func(st SubtestT)) bool {
+	if m.subTs == nil {
+		m.subTs = map[string]*subtestTMock{}
+	}
+	st := &subtestTMock{}
+	m.names = append(m.names, name)
+	m.subTs[name] = st
+	done := make(chan struct{})
+	go func() { // like testing.T.Run, so that Skip can stop the subtest
+		defer close(done)
+		f(st)
+	}()
+	<-done
+	return len(st.Failures()) == 0
+}
+
+// failures returns the failures of each subtest that had any.
+func (m *subtestRunnerMock) failures() map[string][]string {
+	failures := map[string][]string{}
+	for name, st := range m.subTs {
+		if f := st.Failures(); len(f) > 0 {
+			failures[name] = f
+		}
+	}
+	return failures
+}
+
+func TestSubtestRunners(t *testing.T) {
+	loc := Loc{File: "/src/table_test.go", Line: 7}
+	pStr := func() { panic("ppp") }
+	np := func() {}
+	tests := []struct {
+		name         string
+		run          func(r subtestRunner)
+		wantNames    []string
+		wantFailures map[string][]string
+	}{
+		{
+			"runPanicTests", func(r subtestRunner) {
+				new(SubtestRunner).runPanicTests(r, []PanicTest{{Name: "p", F: pStr}, {Name: "np", F: np, Loc: loc}})
+			},
+			[]string{"p", "np"},
+			map[string][]string{"np": {"Expected a panic (table entry at table_test.go:7)"}},
+		},
+		{
+			"runNotPanicTests", func(r subtestRunner) {
+				new(SubtestRunner).runNotPanicTests(r, []PanicTest{{Name: "p", F: pStr}, {Name: "np", F: np}})
+			},
+			[]string{"p", "np"},
+			map[string][]string{"p": {"Unexpected panic:\n\"ppp\""}},
+		},
+		{
+			"runPanicStrTests", func(r subtestRunner) {
+				new(SubtestRunner).runPanicStrTests(r, []PanicStrTest{
+					{Name: "cm", F: pStr, WantStr: "pp"},
+					{Name: "ncm", F: pStr, WantStr: "q", Loc: loc},
+					{Name: "np", F: np, WantStr: "q"},
+				})
+			},
+			[]string{"cm", "ncm", "np"},
+			map[string][]string{
+				"ncm": {"Incorrect panic value (table entry at table_test.go:7): expected a string containing\n" +
+					"\"q\"\ngot\n\"ppp\""},
+				"np": {"Expected a panic"},
+			},
+		},
+		{
+			"runPanicRETests", func(r subtestRunner) {
+				new(SubtestRunner).runPanicRETests(r, []PanicRETest{
+					{Name: "cm", F: pStr, WantRE: "^p+$"},
+					{Name: "ncm", F: pStr, WantRE: "^q"},
+				})
+			},
+			[]string{"cm", "ncm"},
+			map[string][]string{"ncm": {"Incorrect panic value: expected a string matching\n\"^q\"\ngot\n\"ppp\""}},
+		},
+		{
+			"runPanicValTests", func(r subtestRunner) {
+				new(SubtestRunner).runPanicValTests(r, []PanicValTest{
+					{Name: "cm", F: pStr, WantVal: "ppp"},
+					{Name: "ncm", F: pStr, WantVal: 5},
+				})
+			},
+			[]string{"cm", "ncm"},
+			map[string][]string{"ncm": {"Incorrect panic value: expected\n5\ngot\n\"ppp\""}},
+		},
+		{
+			"runPanicValTests with Eq", func(r subtestRunner) {
+				(&SubtestRunner{Eq: sameFormat}).runPanicValTests(r, []PanicValTest{
+					{Name: "cm", F: func() { panic(int64(5)) }, WantVal: 5},
+					{Name: "ncm", F: pStr, WantVal: 5},
+				})
+			},
+			[]string{"cm", "ncm"},
+			map[string][]string{"ncm": {"Incorrect panic value: expected\n5\ngot\n\"ppp\""}},
+		},
+		{
+			"runPanicMatchingTests", func(r subtestRunner) {
+				new(SubtestRunner).runPanicMatchingTests(r, []PanicMatchingTest{
+					{Name: "cm", F: pStr, Matchers: []PanicMatcher{IsType("")}},
+					{Name: "ncm", F: pStr, Matchers: []PanicMatcher{IsType(""), Contains("q")}},
+					{Name: "np", F: np},
+				})
+			},
+			[]string{"cm", "ncm", "np"},
+			map[string][]string{
+				"ncm": {"Incorrect panic value: expected a value that contains \"q\"\ngot\n\"ppp\""},
+				"np":  {"Expected a panic"},
+			},
+		},
+	}
+	for _, test := range tests {
+		r := &subtestRunnerMock{}
+		test.run(r)
+		if !reflect.DeepEqual(r.names, test.wantNames) {
+			t.Errorf("%s(): Incorrect subtests: expected\n%#+v\ngot\n%#+v", test.name, test.wantNames, r.names)
+		}
+		if failures := r.failures(); !reflect.DeepEqual(failures, test.wantFailures) {
+			t.Errorf("%s(): Incorrect failures: expected\n%#+v\ngot\n%#+v", test.name, test.wantFailures, failures)
+		}
+	}
+}
+
+func TestRunPanicTests(t *testing.T) {
+	// The real *testing.T adapter, with passing tests
+	RunPanicTests(t, []PanicTest{{Name: "p", F: func() { panic(1) }, Loc: Here()}})
+	RunNotPanicTests(t, []PanicTest{{Name: "np", F: func() {}}})
+	RunPanicStrTests(t, []PanicStrTest{{Name: "cm", F: func() { panic("ppp") }, WantStr: "p"}})
+	RunPanicRETests(t, []PanicRETest{{Name: "cm", F: func() { panic("ppp") }, WantRE: "p+"}})
+	RunPanicValTests(t, []PanicValTest{{Name: "cm", F: func() { panic(5) }, WantVal: 5}})
+	RunPanicMatchingTests(t, []PanicMatchingTest{{Name: "cm", F: func() { panic(5) }, Matchers: []PanicMatcher{}}})
+}
+
+func TestSubtestRunnersSkipAndExpectedFailure(t *testing.T) {
+	loc := Loc{File: "/src/table_test.go", Line: 3}
+	r := &subtestRunnerMock{}
+	new(SubtestRunner).runPanicStrTests(r, []PanicStrTest{
+		{Name: "skip", F: func() { t.Errorf("Skipped test run") }, Skip: true, SkipReason: "flaky, see #12"},
+		{Name: "skip, no reason", F: func() { t.Errorf("Skipped test run") }, Skip: true, Loc: loc},
+		{Name: "expected failure", F: func() {}, WantStr: "p", ExpectedFailure: true},
+		{Name: "fixed", F: func() { panic("ppp") }, WantStr: "p", ExpectedFailure: true, Loc: loc},
+	})
+
+	wantFailures := map[string][]string{
+		"fixed": {"Test marked as an expected failure passed (table entry at table_test.go:3); if the bug has been " +
+			"fixed, remove ExpectedFailure"},
+	}
+	if failures := r.failures(); !reflect.DeepEqual(failures, wantFailures) {
+		t.Errorf("runPanicStrTests(): Incorrect failures: expected\n%#+v\ngot\n%#+v", wantFailures, failures)
+	}
+	if skipped := r.subTs["skip"].skipped; skipped != "flaky, see #12" {
+		t.Errorf("runPanicStrTests(): Incorrect skip reason: got \"%s\"", skipped)
+	}
+	if skipped := r.subTs["skip, no reason"].skipped; skipped != "Skipped (table entry at table_test.go:3)" {
+		t.Errorf("runPanicStrTests(): Incorrect default skip reason: got \"%s\"", skipped)
+	}
+	wantLogs := []string{"Expected failure: Expected a panic"}
+	if logs := r.subTs["expected failure"].Logs; !reflect.DeepEqual(logs, wantLogs) {
+		t.Errorf("runPanicStrTests(): Incorrect logs for an expected failure: got\n%#+v", logs)
+	}
+}
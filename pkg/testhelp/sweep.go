@@ -0,0 +1,156 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A NilSweepResult describes the outcome of calling a function with one of its parameters set to its zero value (nil,
+// for pointers, slices, maps, channels, functions, and interfaces).  Index is the position of the parameter, and Type
+// is its type.  If the call panicked, Panicked is true and PVal is the panic value.
+type NilSweepResult struct {
+	Index    int
+	Type     reflect.Type
+	Panicked bool
+	PVal     interface{}
+}
+
+// PanicsOnNilSweep calls fn once per parameter, with that parameter set to its zero value and the others set from
+// defaults (which must have one value per parameter, in order).  It returns one result per parameter, describing
+// whether that call panicked and with what value.
+//
+// This automates the most common panic test: what happens if each argument in turn is nil.  For example:
+//
+//	for _, r := range testhelp.PanicsOnNilSweep(mypkg.Render, tmpl, data, &bytes.Buffer{}) {
+//		if r.Panicked {
+//			t.Errorf("Render panics with a nil parameter %d (%s): %v", r.Index, r.Type, r.PVal)
+//		}
+//	}
+//
+// A nil default is converted to the zero value of the parameter's type.  If fn is variadic, its last default must be
+// a slice, which is passed as the variadic arguments (and the zero value of the last parameter is a nil slice).
+//
+// PanicsOnNilSweep itself panics if fn isn't a function, if the number of defaults doesn't match the number of
+// parameters, or if a default can't be assigned to its parameter.
+func PanicsOnNilSweep(fn interface{}, defaults ...interface{}) []NilSweepResult {
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func || fnVal.IsNil() {
+		panic(fmt.Sprintf("PanicsOnNilSweep requires a non-nil function, got %T", fn))
+	}
+	fnType := fnVal.Type()
+	if len(defaults) != fnType.NumIn() {
+		panic(fmt.Sprintf("Wrong number of defaults: function has %d parameters, got %d defaults",
+			fnType.NumIn(), len(defaults)))
+	}
+
+	defaultVals := make([]reflect.Value, len(defaults))
+	for i, d := range defaults {
+		paramType := fnType.In(i)
+		if d == nil {
+			defaultVals[i] = reflect.Zero(paramType)
+			continue
+		}
+		v := reflect.ValueOf(d)
+		if !v.Type().AssignableTo(paramType) {
+			panic(fmt.Sprintf("Default %d of type %s can't be used for a parameter of type %s", i, v.Type(),
+				paramType))
+		}
+		defaultVals[i] = v
+	}
+
+	results := make([]NilSweepResult, 0, len(defaults))
+	for i := range defaultVals {
+		args := append([]reflect.Value{}, defaultVals...)
+		args[i] = reflect.Zero(fnType.In(i))
+		didPanic, pVal := PanicsGet(func() {
+			if fnType.IsVariadic() {
+				fnVal.CallSlice(args)
+			} else {
+				fnVal.Call(args)
+			}
+		})
+		results = append(results, NilSweepResult{
+			Index:    i,
+			Type:     fnType.In(i),
+			Panicked: didPanic,
+			PVal:     pVal,
+		})
+	}
+	return results
+}
+
+// CheckMethodsNoPanic calls each exported method of v that takes no arguments (other than optional variadic ones),
+// and for any that panics, calls t.Errorf with the method name, the panic value, and the stack at the point of the
+// panic.  Methods named in skip are not called.  It returns true if none of the methods panicked.
+//
+// This is intended for catching getters and similar methods that panic on partially-initialized values, e.g. because
+// of a nil map or pointer field.  If v is a pointer, the methods of both the pointer and the value it points to are
+// checked; note that calling the methods may modify v.  It panics if v is nil (with no type).
+func CheckMethodsNoPanic(t TestingTB, v interface{}, skip ...string) bool {
+	t.Helper()
+	return checkMethods(t, "CheckMethodsNoPanic", v, false, skip)
+}
+
+// CheckAllMethodsNoPanic is like CheckMethodsNoPanic, but calls every exported method of v not named in skip, passing
+// the zero value for each argument (and no variadic arguments).
+func CheckAllMethodsNoPanic(t TestingTB, v interface{}, skip ...string) bool {
+	t.Helper()
+	return checkMethods(t, "CheckAllMethodsNoPanic", v, true, skip)
+}
+
+func checkMethods(t TestingTB, funcName string, v interface{}, withArgs bool, skip []string) bool {
+	t.Helper()
+	if v == nil {
+		panic(funcName + " requires a non-nil value, got nil")
+	}
+	skipSet := map[string]bool{}
+	for _, name := range skip {
+		skipSet[name] = true
+	}
+
+	val := reflect.ValueOf(v)
+	valType := val.Type()
+	ok := true
+	for i := 0; i < valType.NumMethod(); i++ {
+		method := valType.Method(i) // only exported methods are listed
+		if skipSet[method.Name] {
+			continue
+		}
+		methodType := method.Type // includes the receiver
+		numArgs := methodType.NumIn() - 1
+		if methodType.IsVariadic() {
+			numArgs--
+		}
+		if numArgs > 0 && !withArgs {
+			continue
+		}
+
+		args := []reflect.Value{val}
+		for j := 1; j <= numArgs; j++ {
+			args = append(args, reflect.Zero(methodType.In(j)))
+		}
+		didPanic, pVal, stack := panicsWithStack(func() { method.Func.Call(args) })
+		if didPanic {
+			ok = false
+			t.Errorf("Unexpected panic from method %s.%s\npanic value (%T):\n%s\nstack:\n%s",
+				valType, method.Name, pVal, PanicMessage(pVal), stack)
+		}
+	}
+	return ok
+}
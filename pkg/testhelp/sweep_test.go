@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func sweepTarget(m map[string]int, p *int, w io.Writer, n int) {
+	if m == nil {
+		panic("nil map")
+	}
+	*p = m["a"] // panics with a nil pointer
+	_ = w       // never used, so nil is fine
+	_ = n
+}
+
+func sweepVariadic(prefix *string, rest ...string) {
+	_ = *prefix + strings.Join(rest, "")
+}
+
+func TestPanicsOnNilSweep(t *testing.T) {
+	var x int
+	results := PanicsOnNilSweep(sweepTarget, map[string]int{"a": 1}, &x, nil, 5)
+
+	wantPanics := []bool{true, true, false, false}
+	if len(results) != len(wantPanics) {
+		t.Fatalf("PanicsOnNilSweep(): Wrong number of results: expected %d, got %d", len(wantPanics), len(results))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("PanicsOnNilSweep(): Wrong index: expected %d, got %d", i, r.Index)
+		}
+		if r.Panicked != wantPanics[i] {
+			t.Errorf("PanicsOnNilSweep(): Wrong panic result for parameter %d (%s): expected %t, got %t (%#+v)",
+				i, r.Type, wantPanics[i], r.Panicked, r.PVal)
+		}
+	}
+	if results[0].PVal != "nil map" {
+		t.Errorf("PanicsOnNilSweep(): Incorrect panic value: expected \"nil map\", got %#+v", results[0].PVal)
+	}
+	if results[1].Type.String() != "*int" {
+		t.Errorf("PanicsOnNilSweep(): Incorrect type: expected *int, got %s", results[1].Type)
+	}
+
+	prefix := "p"
+	results = PanicsOnNilSweep(sweepVariadic, &prefix, []string{"a"})
+	if len(results) != 2 || !results[0].Panicked || results[1].Panicked {
+		t.Errorf("PanicsOnNilSweep(): Incorrect results for a variadic function:\n%#+v", results)
+	}
+}
+
+func TestPanicsOnNilSweepPanicsWithBadInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func()
+		wantStr string
+	}{
+		{"not a func", func() { PanicsOnNilSweep(5) }, "requires a non-nil function, got int"},
+		{"nil func", func() { PanicsOnNilSweep((func())(nil)) }, "requires a non-nil function"},
+		{"too few", func() { PanicsOnNilSweep(sweepTarget, nil) }, "function has 4 parameters, got 1 defaults"},
+		{
+			"wrong type", func() { PanicsOnNilSweep(sweepTarget, nil, nil, nil, "5") },
+			"Default 3 of type string can't be used for a parameter of type int",
+		},
+	}
+	for _, test := range tests {
+		didPanic, pContainsStr, pVal := PanicsStr(test.f, test.wantStr)
+		if !didPanic {
+			t.Errorf("Expected PanicsOnNilSweep() itself to panic in test '%s'", test.name)
+		} else if !pContainsStr {
+			t.Errorf("Incorrect panic value from PanicsOnNilSweep() itself: expected string containing\n%#+v\ngot\n"+
+				"%#+v\nin test '%s'", test.wantStr, pVal, test.name)
+		}
+	}
+}
+
+type methodTarget struct {
+	m map[string]int
+	p *int
+}
+
+func (mt methodTarget) Count() int           { return len(mt.m) }
+func (mt methodTarget) Value() int           { return *mt.p }
+func (mt *methodTarget) Set(k string, v int) { mt.m[k] = v }
+func (mt methodTarget) Names(...string) int  { return mt.m["x"] }
+
+func TestCheckMethodsNoPanic(t *testing.T) {
+	tests := []struct {
+		name        string
+		f           func(rt *testhelptest.RecordingT) bool
+		wantMethods []string // methods that should be reported
+	}{
+		{
+			"value, zero args",
+			func(rt *testhelptest.RecordingT) bool { return CheckMethodsNoPanic(rt, methodTarget{}) },
+			[]string{"methodTarget.Value"},
+		},
+		{
+			"pointer, zero args",
+			func(rt *testhelptest.RecordingT) bool { return CheckMethodsNoPanic(rt, &methodTarget{}) },
+			[]string{"*testhelp.methodTarget.Value"},
+		},
+		{
+			"pointer, all args",
+			func(rt *testhelptest.RecordingT) bool { return CheckAllMethodsNoPanic(rt, &methodTarget{}) },
+			[]string{"methodTarget.Set", "methodTarget.Value"},
+		},
+		{
+			"pointer, all args, skipped",
+			func(rt *testhelptest.RecordingT) bool {
+				return CheckAllMethodsNoPanic(rt, &methodTarget{}, "Set", "Value")
+			},
+			nil,
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := test.f(rt)
+		if ok != (len(test.wantMethods) == 0) {
+			t.Errorf("Wrong result: expected %t, got %t in test '%s'", len(test.wantMethods) == 0, ok, test.name)
+		}
+		failures := rt.Failures()
+		if len(failures) != len(test.wantMethods) {
+			t.Errorf("Wrong number of failures: expected %d, got %d in test '%s':\n%#+v",
+				len(test.wantMethods), len(failures), test.name, failures)
+			continue
+		}
+		for i, wantStr := range test.wantMethods {
+			if !strings.Contains(failures[i], wantStr) {
+				t.Errorf("Incorrect failure: expected a string containing\n\"%s\"\ngot\n\"%s\"\nin test '%s'",
+					wantStr, failures[i], test.name)
+			}
+		}
+	}
+}
+
+func TestCheckMethodsNoPanicPanicsWithNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func()
+		wantStr string
+	}{
+		{
+			"zero args", func() { CheckMethodsNoPanic(&testhelptest.RecordingT{}, nil) },
+			"CheckMethodsNoPanic requires a non-nil value, got nil",
+		},
+		{
+			"all args", func() { CheckAllMethodsNoPanic(&testhelptest.RecordingT{}, error(nil)) },
+			"CheckAllMethodsNoPanic requires a non-nil value, got nil",
+		},
+	}
+	for _, test := range tests {
+		didPanic, pContainsStr, pVal := PanicsStr(test.f, test.wantStr)
+		if !didPanic {
+			t.Errorf("Expected the check itself to panic in test '%s'", test.name)
+		} else if !pContainsStr {
+			t.Errorf("Incorrect panic value from the check itself: expected string containing\n%#+v\ngot\n"+
+				"%#+v\nin test '%s'", test.wantStr, pVal, test.name)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	htmltemplate "html/template"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+)
+
+// TemplateOptions holds options for RenderEqual and RenderGolden.  The zero value gives the same behavior as the
+// package-level functions.
+type TemplateOptions struct {
+	// HTML makes the template an html/template, with its contextual escaping, instead of a text/template.
+	HTML bool
+	// Funcs are added to the template's function map before it's parsed.
+	Funcs map[string]interface{}
+}
+
+// RenderEqual parses tmpl as a text/template, executes it with data, and checks that the output is want, calling
+// t.Errorf with a line-by-line diff, with line numbers, if not.  If the template can't be parsed or executed, that's
+// reported instead (with the output so far, for execution errors), since a comparison with partial output wouldn't be
+// useful.  It returns true if the check passed.  For example:
+//
+//	testhelp.RenderEqual(t, "Hello, {{.Name}}!", User{Name: "Ada"}, "Hello, Ada!")
+func RenderEqual(t TestingTB, tmpl string, data interface{}, want string) bool {
+	t.Helper()
+	return new(TemplateOptions).RenderEqual(t, tmpl, data, want)
+}
+
+// RenderEqual is like the package-level RenderEqual, but with the options in o.
+func (o *TemplateOptions) RenderEqual(t TestingTB, tmpl string, data interface{}, want string) bool {
+	t.Helper()
+	got, ok := o.render(t, tmpl, data)
+	if !ok {
+		return false
+	}
+	if got != want {
+		t.Errorf("Rendered template does not match: expected\n%s\ngot\n%s\ndiff:\n%s", want, got,
+			strings.TrimSuffix(numberedLineDiff(want, got), "\n"))
+		return false
+	}
+	return true
+}
+
+// RenderGolden is like RenderEqual, but compares the output with the golden file testdata/<name>.golden, relative to
+// the test's working directory (which is normally the package's directory).  As with PanicsGolden, running the tests
+// with the environment variable named by UpdateGoldenEnvVar set writes the output to the golden file instead of
+// checking it.
+func RenderGolden(t TestingTB, name, tmpl string, data interface{}) bool {
+	t.Helper()
+	return new(TemplateOptions).RenderGolden(t, name, tmpl, data)
+}
+
+// RenderGolden is like the package-level RenderGolden, but with the options in o.
+func (o *TemplateOptions) RenderGolden(t TestingTB, name, tmpl string, data interface{}) bool {
+	t.Helper()
+	return o.renderGoldenFile(t, filepath.Join("testdata", filepath.FromSlash(name)+".golden"), tmpl, data)
+}
+
+func (o *TemplateOptions) renderGoldenFile(t TestingTB, path, tmpl string, data interface{}) bool {
+	t.Helper()
+	got, ok := o.render(t, tmpl, data)
+	if !ok {
+		return false
+	}
+	return textGoldenFile(t, path, "Rendered template", got)
+}
+
+// render parses and executes the template, returning the output and true, or reporting the problem to t and returning
+// false.
+func (o *TemplateOptions) render(t TestingTB, tmpl string, data interface{}) (string, bool) {
+	t.Helper()
+	var b strings.Builder
+	var parseErr, execErr error
+	if o.HTML {
+		var parsed *htmltemplate.Template
+		if parsed, parseErr = htmltemplate.New("template").Funcs(o.Funcs).Parse(tmpl); parseErr == nil {
+			execErr = parsed.Execute(&b, data)
+		}
+	} else {
+		var parsed *texttemplate.Template
+		if parsed, parseErr = texttemplate.New("template").Funcs(o.Funcs).Parse(tmpl); parseErr == nil {
+			execErr = parsed.Execute(&b, data)
+		}
+	}
+	switch {
+	case parseErr != nil:
+		t.Errorf("Can't parse template: %s", parseErr)
+		return "", false
+	case execErr != nil:
+		t.Errorf("Template execution failed: %s\noutput so far:\n%s", execErr, b.String())
+		return "", false
+	}
+	return b.String(), true
+}
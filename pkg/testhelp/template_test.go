@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestRenderEqual(t *testing.T) {
+	type user struct{ Name string }
+	tests := []struct {
+		name     string
+		opts     TemplateOptions
+		tmpl     string
+		data     interface{}
+		want     string
+		expected []string
+	}{
+		{name: "matching", tmpl: "Hello, {{.Name}}!", data: user{"Ada"}, want: "Hello, Ada!", expected: []string{}},
+		{
+			name: "different",
+			tmpl: "Hello,\n{{.Name}}!",
+			data: user{"Bob"},
+			want: "Hello,\nAda!",
+			expected: []string{"Rendered template does not match: expected\nHello,\nAda!\ngot\nHello,\nBob!\ndiff:\n" +
+				"     1    1 | Hello,\n-    2      | Ada!\n+         2 | Bob!"},
+		},
+		{name: "text", tmpl: "{{.}}", data: "<b>", want: "<b>", expected: []string{}},
+		{name: "html", opts: TemplateOptions{HTML: true}, tmpl: "{{.}}", data: "<b>", want: "&lt;b&gt;",
+			expected: []string{}},
+		{
+			name:     "funcs",
+			opts:     TemplateOptions{Funcs: map[string]interface{}{"upper": strings.ToUpper}},
+			tmpl:     "{{upper .}}",
+			data:     "hi",
+			want:     "HI",
+			expected: []string{},
+		},
+		{
+			name:     "parse error",
+			tmpl:     "{{.Name",
+			want:     "",
+			expected: []string{"Can't parse template: template: template:1: unclosed action"},
+		},
+		{
+			name: "execution error",
+			tmpl: "Hello, {{.Missing}}",
+			data: user{"Ada"},
+			want: "Hello, Ada",
+			expected: []string{"Template execution failed: template: template:1:9: executing \"template\" at " +
+				"<.Missing>: can't evaluate field Missing in type testhelp.user\noutput so far:\nHello, "},
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := test.opts.RenderEqual(rt, test.tmpl, test.data, test.want)
+		if got := rt.Failures(); ok != (len(test.expected) == 0) || !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("RenderEqual(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", test.expected, got,
+				test.name)
+		}
+	}
+}
+
+func TestRenderGoldenFile(t *testing.T) {
+	o := &TemplateOptions{}
+	path := filepath.Join(t.TempDir(), "sub", "page.golden")
+
+	rt := &testhelptest.RecordingT{}
+	if o.renderGoldenFile(rt, path, "{{.}}", "a") || len(rt.Failures()) != 1 {
+		t.Errorf("renderGoldenFile(): Expected a failure with a missing golden file, got\n%#+v", rt.Failures())
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "1")
+	rt = &testhelptest.RecordingT{}
+	if !o.renderGoldenFile(rt, path, "{{.}}", "a") || len(rt.Failures()) != 0 {
+		t.Errorf("renderGoldenFile(): Unexpected failure while updating:\n%#+v", rt.Failures())
+	}
+	if stored, err := os.ReadFile(path); err != nil || string(stored) != "a" {
+		t.Errorf("renderGoldenFile(): Incorrect golden file: expected \"a\", got %q, %v", stored, err)
+	}
+	t.Setenv(UpdateGoldenEnvVar, "")
+
+	rt = &testhelptest.RecordingT{}
+	if !o.renderGoldenFile(rt, path, "{{.}}", "a") || len(rt.Failures()) != 0 {
+		t.Errorf("renderGoldenFile(): Unexpected failure for matching output:\n%#+v", rt.Failures())
+	}
+	rt = &testhelptest.RecordingT{}
+	o.renderGoldenFile(rt, path, "{{.}}", "b")
+	if failures := rt.Failures(); len(failures) != 1 ||
+		!strings.HasPrefix(failures[0], "Rendered template does not match golden file "+path) {
+		t.Errorf("renderGoldenFile(): Incorrect failures for different output:\n%#+v", failures)
+	}
+}
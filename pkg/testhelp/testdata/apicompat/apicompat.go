@@ -0,0 +1,68 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apicompat is a fixture for the CheckAPICompat tests.
+package apicompat
+
+import "io"
+
+const Version = "1.0"
+
+const Limit int = 10
+
+var Default = &Widget{}
+
+type Widget struct {
+	Name  string
+	Size  int
+	cache map[string]int
+}
+
+func (w Widget) String() string { return w.Name }
+
+func (w *Widget) Resize(size int, opts ...string) error { return nil }
+
+func (w *Widget) reset() {}
+
+type Named struct {
+	Widget
+	Label string
+}
+
+type Sizer interface {
+	Size() int
+	io.Closer
+	hidden()
+}
+
+type Number interface {
+	~int | ~float64
+}
+
+type Kind int
+
+type Bytes = []byte
+
+func New(name string) (*Widget, error) { return &Widget{Name: name}, nil }
+
+func Max[T Number](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func helper() {}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package enumfixture is a fixture for the EnumValues tests.
+package enumfixture
+
+import (
+	"github.com/ocsw/go-testhelp/pkg/testhelp/testdata/enumfixture/othercolor"
+)
+
+// Color is an iota-style enum.
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+	numColors // a sentinel
+
+	DefaultColor = Green // an alias
+)
+
+// Mauve has a type named Color, but from another package, so it isn't one of the Color values.
+const Mauve othercolor.Color = "mauve"
+
+// Size is an enum with unsigned values and gaps.
+type Size uint8
+
+const (
+	Small  Size = 1
+	Medium Size = 5
+	Large  Size = 10
+)
+
+// Flavor is a string enum.
+type Flavor string
+
+const (
+	Vanilla   Flavor = "vanilla"
+	Chocolate Flavor = "chocolate"
+)
+
+// Shape has no constants.
+type Shape struct{}
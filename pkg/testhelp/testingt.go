@@ -0,0 +1,51 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+// TestingTB is a stub interface intended to be satisfied by a *testing.T or *testing.B.  It extends TestingT with the
+// other methods used by the helpers in this package that report failures themselves, so that those helpers can be
+// tested with a mock.
+type TestingTB interface {
+	TestingT
+	Helper()
+	Logf(format string, args ...interface{})
+	Cleanup(f func())
+}
+
+// EnvT is a stub interface intended to be satisfied by a *testing.T.  It extends TestingTB with the methods used by the
+// helpers in this package that change the test's environment.
+type EnvT interface {
+	TestingTB
+	TempDir() string
+	Setenv(key, value string)
+}
+
+// SeedT is a stub interface intended to be satisfied by a *testing.T or *testing.B.  It extends TestingTB with the
+// methods used by the helpers in this package that derive random seeds from the test's name and report them if the
+// test fails.
+type SeedT interface {
+	TestingTB
+	Name() string
+	Failed() bool
+}
+
+// FuzzF is a stub interface intended to be satisfied by a *testing.F.  It extends TestingTB with the method used by the
+// helpers in this package that seed fuzz corpuses.
+type FuzzF interface {
+	TestingTB
+	Add(args ...interface{})
+}
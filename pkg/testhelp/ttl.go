@@ -0,0 +1,105 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ExpireAndAssertEvicted checks that an entry in a cache-like component expires: present reports whether the entry is
+// still there, and must be true to begin with; then clock is advanced by d, and present must be false.  It calls
+// t.Errorf if either check fails, and returns true if both pass.  For example:
+//
+//	clock := testhelp.NewFakeClock(time.Time{})
+//	cache := mypkg.NewCache(time.Minute, clock.Now)
+//	cache.Set("k", 1)
+//	testhelp.ExpireAndAssertEvicted(t, clock, time.Minute+time.Second, func() bool {
+//		_, ok := cache.Get("k")
+//		return ok
+//	})
+func ExpireAndAssertEvicted(t TestingTB, clock *FakeClock, d time.Duration, present func() bool) bool {
+	t.Helper()
+	if !present() {
+		t.Errorf("Entry was not present before the clock was advanced")
+		return false
+	}
+	clock.Advance(d)
+	if present() {
+		t.Errorf("Entry was still present after the clock was advanced by %s", d)
+		return false
+	}
+	return true
+}
+
+// AssertTTLUnderStress checks the invariant that no entry survives past its TTL in a cache-like component, under
+// concurrent access.  It uses Stress to run the given number of goroutines, each of which stores the given number of
+// entries with set, advancing clock a little after each one (so that, across all of the goroutines, the clock moves by
+// a quarter of ttl per round of iterations), and then looks each of its earlier entries up again with present.  An
+// entry whose TTL has certainly passed (according to the clock time read just after it was stored) must not be
+// present, and, once the goroutines have finished and clock has been advanced past every TTL, no entry may be.  Broken
+// invariants are reported with t.Errorf (each key at most once); AssertTTLUnderStress returns true if there were none.
+//
+// Keys are strings of the form "g<goroutine>-<iteration>".  Entries whose TTL hasn't passed may or may not be present,
+// since caches are allowed to evict early (e.g. when full).  Like Stress, it panics if goroutines or iterations is less
+// than 1.
+func AssertTTLUnderStress(t TestingTB, clock *FakeClock, ttl time.Duration, goroutines, iterations int,
+	set func(key string), present func(key string) bool) bool {
+	t.Helper()
+	if goroutines < 1 || iterations < 1 {
+		panic(fmt.Sprintf("Invalid number of goroutines or iterations: %d, %d", goroutines, iterations))
+	}
+	step := ttl / time.Duration(4*goroutines)
+	if step <= 0 {
+		step = 1
+	}
+
+	var mu sync.Mutex
+	reported := map[string]bool{}
+	report := func(key, format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !reported[key] {
+			reported[key] = true
+			t.Errorf(format, args...)
+		}
+	}
+	stored := make([][]time.Time, goroutines) // per goroutine, the time just after each entry was stored
+	stressOK := Stress(t, goroutines, iterations, func(g, i int) {
+		set(fmt.Sprintf("g%d-%d", g, i))
+		stored[g] = append(stored[g], clock.Now())
+		clock.Advance(step)
+		for j, after := range stored[g] {
+			key := fmt.Sprintf("g%d-%d", g, j)
+			if age := clock.Since(after); age > ttl && present(key) {
+				report(key, "Entry '%s' was present %s after it was stored, with a TTL of %s", key, age, ttl)
+			}
+		}
+	})
+
+	clock.Advance(ttl + step)
+	for g := 0; g < goroutines; g++ {
+		for j := range stored[g] {
+			key := fmt.Sprintf("g%d-%d", g, j)
+			if present(key) {
+				report(key, "Entry '%s' was still present after every TTL had passed", key)
+			}
+		}
+	}
+	return len(reported) == 0 && stressOK
+}
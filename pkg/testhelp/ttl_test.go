@@ -0,0 +1,155 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// ttlCache is a minimal cache with a TTL, for testing the TTL helpers; extraLife simulates an expiry bug.
+type ttlCache struct {
+	clock     *FakeClock
+	ttl       time.Duration
+	extraLife time.Duration
+
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+func newTTLCache(clock *FakeClock, ttl, extraLife time.Duration) *ttlCache {
+	return &ttlCache{clock: clock, ttl: ttl, extraLife: extraLife, expires: map[string]time.Time{}}
+}
+
+func (c *ttlCache) Set(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires[key] = c.clock.Now().Add(c.ttl + c.extraLife)
+}
+
+func (c *ttlCache) Present(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	exp, ok := c.expires[key]
+	return ok && c.clock.Now().Before(exp)
+}
+
+func TestExpireAndAssertEvicted(t *testing.T) {
+	tests := []struct {
+		name         string
+		extraLife    time.Duration
+		set          bool
+		expectResult bool
+		expectErrors []string
+	}{
+		{name: "evicted", set: true, expectResult: true, expectErrors: []string{}},
+		{
+			name: "not evicted", set: true, extraLife: time.Hour,
+			expectErrors: []string{"Entry was still present after the clock was advanced by 1m1s"},
+		},
+		{name: "never present", expectErrors: []string{"Entry was not present before the clock was advanced"}},
+	}
+	for _, test := range tests {
+		clock := NewFakeClock(time.Time{})
+		cache := newTTLCache(clock, time.Minute, test.extraLife)
+		if test.set {
+			cache.Set("k")
+		}
+		rt := &testhelptest.RecordingT{}
+		got := ExpireAndAssertEvicted(rt, clock, time.Minute+time.Second, func() bool { return cache.Present("k") })
+		if got != test.expectResult {
+			t.Errorf("ExpireAndAssertEvicted(): Incorrect result: expected %t, got %t in test '%s'",
+				test.expectResult, got, test.name)
+		}
+		if failures := rt.Failures(); !reflect.DeepEqual(failures, test.expectErrors) {
+			t.Errorf("ExpireAndAssertEvicted(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.expectErrors, failures, test.name)
+		}
+	}
+}
+
+func TestAssertTTLUnderStress(t *testing.T) {
+	clock := NewFakeClock(time.Time{})
+	cache := newTTLCache(clock, time.Minute, 0)
+	rt := &testhelptest.RecordingT{}
+	if !AssertTTLUnderStress(rt, clock, time.Minute, 4, 50, cache.Set, cache.Present) || len(rt.Failures()) != 0 {
+		t.Errorf("AssertTTLUnderStress(): Unexpected failures for a correct cache:\n%#+v", rt.Failures())
+	}
+
+	clock = NewFakeClock(time.Time{})
+	cache = newTTLCache(clock, time.Minute, time.Minute)
+	rt = &testhelptest.RecordingT{}
+	if AssertTTLUnderStress(rt, clock, time.Minute, 4, 50, cache.Set, cache.Present) {
+		t.Errorf("AssertTTLUnderStress(): Expected false for entries that outlive their TTL")
+	}
+	failures := rt.Failures()
+	keys := map[string]bool{}
+	for _, f := range failures {
+		key := strings.SplitN(f, "'", 3)[1]
+		if keys[key] {
+			t.Errorf("AssertTTLUnderStress(): Expected at most one failure per key, got more for '%s'", key)
+		}
+		keys[key] = true
+		if !strings.Contains(f, "after it was stored, with a TTL of 1m0s") &&
+			!strings.HasSuffix(f, "was still present after every TTL had passed") {
+			t.Errorf("AssertTTLUnderStress(): Unexpected failure:\n%s", f)
+			break
+		}
+	}
+	if len(keys) < 100 {
+		t.Errorf("AssertTTLUnderStress(): Expected failures for most keys, got %d", len(keys))
+	}
+
+	clock = NewFakeClock(time.Time{})
+	cache = newTTLCache(clock, time.Minute, time.Hour)
+	rt = &testhelptest.RecordingT{}
+	AssertTTLUnderStress(rt, clock, time.Minute, 2, 3, cache.Set, cache.Present)
+	stillPresent := 0
+	for _, f := range rt.Failures() {
+		if strings.HasSuffix(f, "was still present after every TTL had passed") {
+			stillPresent++
+		}
+	}
+	if stillPresent == 0 {
+		t.Errorf("AssertTTLUnderStress(): Expected the final check to catch entries that outlive every TTL:\n%#+v",
+			rt.Failures())
+	}
+}
+
+func TestAssertTTLUnderStressPanicsWithBadCounts(t *testing.T) {
+	clock := NewFakeClock(time.Time{})
+	cache := newTTLCache(clock, time.Minute, 0)
+	for _, counts := range [][2]int{{0, 1}, {1, 0}, {-1, 5}} {
+		wantStr := fmt.Sprintf("Invalid number of goroutines or iterations: %d, %d", counts[0], counts[1])
+		didPanic, pContainsStr, pVal := PanicsStr(func() {
+			AssertTTLUnderStress(&testhelptest.RecordingT{}, clock, time.Minute, counts[0], counts[1], cache.Set,
+				cache.Present)
+		}, wantStr)
+		if !didPanic {
+			t.Errorf("Expected AssertTTLUnderStress() itself to panic for counts %v", counts)
+		} else if !pContainsStr {
+			t.Errorf("Incorrect panic value from AssertTTLUnderStress() itself: expected string containing\n%#+v\n"+
+				"got\n%#+v\nfor counts %v", wantStr, pVal, counts)
+		}
+	}
+}
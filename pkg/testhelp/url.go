@@ -0,0 +1,119 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// URLOptions holds options for URLEqual.
+type URLOptions struct {
+	// IgnoreParams lists query parameters that aren't compared, such as timestamps and nonces.
+	IgnoreParams []string
+}
+
+// URLEqual parses want and got as URLs and compares them part by part, calling t.Errorf with a list of the parts
+// that differ if they don't match.  The scheme and host are compared case-insensitively, and the query parameters
+// are compared as multisets, so neither the order of the parameters nor the order of repeated values of one parameter
+// matters.  Everything else is compared exactly (and the path is compared in its escaped form, so "%2F" and "/" are
+// different).  It returns true if the URLs match.
+func URLEqual(t TestingTB, want, got string) bool {
+	t.Helper()
+	return new(URLOptions).URLEqual(t, want, got)
+}
+
+// URLEqual is like the package-level URLEqual, but with the options in o.
+func (o *URLOptions) URLEqual(t TestingTB, want, got string) bool {
+	t.Helper()
+	wantURL, err := url.Parse(want)
+	if err != nil {
+		t.Errorf("Can't parse expected URL: %s", err)
+		return false
+	}
+	gotURL, err := url.Parse(got)
+	if err != nil {
+		t.Errorf("Can't parse URL: %s", err)
+		return false
+	}
+
+	var diffs []string
+	addDiff := func(part, w, g string) {
+		if w != g {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %q, got %q", part, w, g))
+		}
+	}
+	addDiff("scheme", strings.ToLower(wantURL.Scheme), strings.ToLower(gotURL.Scheme))
+	addDiff("user info", wantURL.User.String(), gotURL.User.String())
+	addDiff("host", strings.ToLower(wantURL.Host), strings.ToLower(gotURL.Host))
+	addDiff("opaque", wantURL.Opaque, gotURL.Opaque)
+	addDiff("path", wantURL.EscapedPath(), gotURL.EscapedPath())
+	diffs = append(diffs, o.diffQuery(wantURL.Query(), gotURL.Query())...)
+	addDiff("fragment", wantURL.EscapedFragment(), gotURL.EscapedFragment())
+
+	if len(diffs) == 0 {
+		return true
+	}
+	t.Errorf("URL does not match: expected\n%s\ngot\n%s\ndifferences:\n%s", want, got, strings.Join(diffs, "\n"))
+	return false
+}
+
+// diffQuery compares two sets of query parameters, returning a description of each parameter that differs.
+func (o *URLOptions) diffQuery(want, got url.Values) []string {
+	ignore := map[string]bool{}
+	for _, name := range o.IgnoreParams {
+		ignore[name] = true
+	}
+	names := map[string]bool{}
+	for name := range want {
+		names[name] = true
+	}
+	for name := range got {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		if !ignore[name] {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+
+	var diffs []string
+	for _, name := range sorted {
+		w, g := sortedCopy(want[name]), sortedCopy(got[name])
+		switch {
+		case len(g) == 0:
+			diffs = append(diffs, fmt.Sprintf("query parameter %q: expected %q, got none", name, w))
+		case len(w) == 0:
+			diffs = append(diffs, fmt.Sprintf("query parameter %q: unexpected %q", name, g))
+		case !reflect.DeepEqual(w, g):
+			diffs = append(diffs, fmt.Sprintf("query parameter %q: expected %q, got %q", name, w, g))
+		}
+	}
+	return diffs
+}
+
+// sortedCopy returns a sorted copy of s.
+func sortedCopy(s []string) []string {
+	c := append([]string{}, s...)
+	sort.Strings(c)
+	return c
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+func TestURLEqual(t *testing.T) {
+	tests := []struct {
+		name      string
+		want, got string
+		ignore    []string
+		expected  string // the differences, or "" for none
+	}{
+		{name: "same", want: "https://example.com/a?x=1", got: "https://example.com/a?x=1"},
+		{name: "query order", want: "https://example.com/?a=1&b=2&a=3", got: "https://example.com/?b=2&a=3&a=1"},
+		{name: "case", want: "HTTPS://Example.COM/a", got: "https://example.com/a"},
+		{
+			name: "ignored", want: "https://example.com/?q=x&ts=1&nonce=a", got: "https://example.com/?ts=2&q=x",
+			ignore: []string{"ts", "nonce"},
+		},
+		{
+			name: "parts", want: "https://u:p@example.com/a/b?x=1#top", got: "http://example.org/a%2Fb?x=1#bottom",
+			expected: "scheme: expected \"https\", got \"http\"\nuser info: expected \"u:p\", got \"\"\n" +
+				"host: expected \"example.com\", got \"example.org\"\npath: expected \"/a/b\", got \"/a%2Fb\"\n" +
+				"fragment: expected \"top\", got \"bottom\"",
+		},
+		{
+			name: "query", want: "/s?a=1&a=2&b=x&c=", got: "/s?a=2&a=2&c=&d=y",
+			expected: "query parameter \"a\": expected [\"1\" \"2\"], got [\"2\" \"2\"]\n" +
+				"query parameter \"b\": expected [\"x\"], got none\nquery parameter \"d\": unexpected [\"y\"]",
+		},
+		{
+			name: "opaque", want: "mailto:a@example.com", got: "mailto:b@example.com",
+			expected: "opaque: expected \"a@example.com\", got \"b@example.com\"",
+		},
+	}
+	for _, test := range tests {
+		rt := &testhelptest.RecordingT{}
+		ok := (&URLOptions{IgnoreParams: test.ignore}).URLEqual(rt, test.want, test.got)
+		want := []string{}
+		if test.expected != "" {
+			want = []string{"URL does not match: expected\n" + test.want + "\ngot\n" + test.got + "\ndifferences:\n" +
+				test.expected}
+		}
+		if got := rt.Failures(); ok != (test.expected == "") || !reflect.DeepEqual(got, want) {
+			t.Errorf("URLEqual(): Incorrect failures: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, got, test.name)
+		}
+	}
+}
+
+func TestURLEqualParseErrors(t *testing.T) {
+	rt := &testhelptest.RecordingT{}
+	if URLEqual(rt, "http://a b", "http://a") || URLEqual(rt, "http://a", "%zz") {
+		t.Errorf("URLEqual(): Expected false with unparsable URLs")
+	}
+	f := rt.Failures()
+	if len(f) != 2 || !strings.HasPrefix(f[0], "Can't parse expected URL: ") ||
+		!strings.HasPrefix(f[1], "Can't parse URL: ") {
+		t.Errorf("URLEqual(): Incorrect failures with unparsable URLs:\n%#+v", f)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A TableError is returned by ValidateTable, listing all of the problems found in a table, one per element of
+// Problems.
+type TableError struct {
+	Problems []string
+}
+
+func (e *TableError) Error() string {
+	return "Invalid test table:\n" + strings.Join(e.Problems, "\n")
+}
+
+// ValidateTable checks a table of tests (a slice of any of this package's table types, such as []PanicStrTest, or of
+// any struct type with a string Name field and a function F field) for entries with empty names, names that are the
+// same as an earlier entry's, or nil F functions.  If there are any, it returns a *TableError listing all of them;
+// otherwise it returns nil.  Entries are identified by index, and by position if their Loc is set.
+//
+// Checking the table before running it turns mistakes that would otherwise cause confusing failures (such as a nil F,
+// which panics inside the loop) or hide tests (such as a duplicate name) into a clear report:
+//
+//	if err := testhelp.ValidateTable(tests); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// ValidateTable panics if tests isn't a slice of a struct type with the required fields.
+func ValidateTable(tests interface{}) error {
+	val := reflect.ValueOf(tests)
+	if val.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("ValidateTable requires a slice of tests, got %T", tests))
+	}
+	elemType := val.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("ValidateTable requires a slice of structs, got %T", tests))
+	}
+	nameField, ok := elemType.FieldByName("Name")
+	if !ok || nameField.Type.Kind() != reflect.String {
+		panic(fmt.Sprintf("ValidateTable requires a struct type with a string Name field, got %s", elemType))
+	}
+	fField, ok := elemType.FieldByName("F")
+	if !ok || fField.Type.Kind() != reflect.Func {
+		panic(fmt.Sprintf("ValidateTable requires a struct type with a function F field, got %s", elemType))
+	}
+	locField, hasLoc := elemType.FieldByName("Loc")
+	hasLoc = hasLoc && locField.Type == reflect.TypeOf(Loc{})
+
+	var problems []string
+	firstIndex := map[string]int{}
+	for i := 0; i < val.Len(); i++ {
+		entry := val.Index(i)
+		name := entry.FieldByIndex(nameField.Index).String()
+		desc := fmt.Sprintf("entry %d", i)
+		if hasLoc {
+			desc += locSuffix(entry.FieldByIndex(locField.Index).Interface().(Loc))
+		}
+
+		if name == "" {
+			problems = append(problems, desc+": empty Name")
+		} else if first, seen := firstIndex[name]; seen {
+			problems = append(problems, fmt.Sprintf("%s: Name '%s' is the same as entry %d's", desc, name, first))
+		} else {
+			firstIndex[name] = i
+		}
+		if entry.FieldByIndex(fField.Index).IsNil() {
+			problems = append(problems, fmt.Sprintf("%s ('%s'): nil F", desc, name))
+		}
+	}
+	if len(problems) > 0 {
+		return &TableError{Problems: problems}
+	}
+	return nil
+}
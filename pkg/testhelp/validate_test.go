@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateTable(t *testing.T) {
+	f := func() {}
+	loc := Loc{File: "/src/table_test.go", Line: 9}
+	tests := []struct {
+		name         string
+		table        interface{}
+		wantProblems []string
+	}{
+		{"valid", []PanicTest{{Name: "a", F: f}, {Name: "b", F: f}}, nil},
+		{"empty table", []PanicStrTest{}, nil},
+		{
+			"problems", []PanicStrTest{
+				{Name: "a", F: f},
+				{Name: "", F: f},
+				{Name: "a", F: f, Loc: loc},
+				{Name: "b"},
+				{Name: ""},
+			},
+			[]string{
+				"entry 1: empty Name",
+				"entry 2 (table entry at table_test.go:9): Name 'a' is the same as entry 0's",
+				"entry 3 ('b'): nil F",
+				"entry 4: empty Name",
+				"entry 4 (''): nil F",
+			},
+		},
+		{
+			"setup table", []PanicSetupTest{{Name: "a", F: func(interface{}) {}}, {Name: "a"}},
+			[]string{"entry 1: Name 'a' is the same as entry 0's", "entry 1 ('a'): nil F"},
+		},
+		{
+			"other struct", []struct {
+				Name string
+				F    func(int)
+			}{{Name: "a"}}, []string{"entry 0 ('a'): nil F"},
+		},
+	}
+	for _, test := range tests {
+		err := ValidateTable(test.table)
+		if test.wantProblems == nil {
+			if err != nil {
+				t.Errorf("ValidateTable(): Unexpected error in test '%s': %s", test.name, err)
+			}
+			continue
+		}
+		tableErr, ok := err.(*TableError)
+		if !ok {
+			t.Errorf("ValidateTable(): Expected a *TableError in test '%s', got %#+v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tableErr.Problems, test.wantProblems) {
+			t.Errorf("ValidateTable(): Incorrect problems: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				test.wantProblems, tableErr.Problems, test.name)
+		}
+	}
+
+	err := ValidateTable([]PanicTest{{Name: ""}})
+	want := "Invalid test table:\nentry 0: empty Name\nentry 0 (''): nil F"
+	if err == nil || err.Error() != want {
+		t.Errorf("Error(): Incorrect message: expected\n%s\ngot\n%v", want, err)
+	}
+}
+
+func TestValidateTableBadInput(t *testing.T) {
+	for _, table := range []interface{}{
+		nil,
+		PanicTest{Name: "a"},
+		[]int{1},
+		[]struct{ F func() }{},
+		[]struct {
+			Name string
+			F    int
+		}{},
+	} {
+		if !Panics(func() { _ = ValidateTable(table) }) {
+			t.Errorf("ValidateTable(): Expected a panic with %#+v", table)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The columns of a test vector file with special meanings for LoadPanicStrTable.
+const (
+	VectorNameColumn            = "name"
+	VectorWantStrColumn         = "want_str"
+	VectorSkipColumn            = "skip"
+	VectorSkipReasonColumn      = "skip_reason"
+	VectorExpectedFailureColumn = "expected_failure"
+)
+
+// LoadPanicStrTable reads test vectors from a CSV or JSON file (such as one in testdata), and returns a PanicStrTest
+// for each one, so that vector files maintained outside of the Go code can drive PanicsStrLoop, RunPanicStrTests, and
+// so on.  bind is called with each vector, and returns the test function for it, which normally calls the code under
+// test with values from the vector.
+//
+// A CSV file (with a name ending in .csv) has a header row naming the columns, followed by one row per vector.  A JSON
+// file (with a name ending in .json) is an array of objects, one per vector, whose values are strings, numbers,
+// booleans, or null; numbers and booleans are passed to bind as they're written in the file, and null as "".  Either
+// way, each vector is passed to bind as a map from column names to values, including the columns used by
+// LoadPanicStrTable itself:
+//
+//   - name (required): the test's Name
+//   - want_str (required): the test's WantStr
+//   - skip, expected_failure (optional): the test's Skip and ExpectedFailure, as parsed by strconv.ParseBool, with ""
+//     meaning false
+//   - skip_reason (optional): the test's SkipReason
+//
+// Each test's Loc is set to the position of its vector in the file, so that failure messages point to it.
+//
+// For example, with a testdata/parse_vectors.csv such as:
+//
+//	name,want_str,input
+//	empty,empty input,
+//	too long,too long,aaaaaaaaaaaaaaaaaaaaaa
+//
+// a test could do:
+//
+//	tests := testhelp.LoadPanicStrTable(t, "testdata/parse_vectors.csv", func(row map[string]string) func() {
+//		return func() { mypkg.MustParse(row["input"]) }
+//	})
+//	testhelp.RunPanicStrTests(t, tests)
+//
+// If the file can't be read or parsed, is missing a required column, or has an invalid value in one of the columns
+// above, the test fails with t.Fatalf.
+func LoadPanicStrTable(t TestingTB, path string, bind func(row map[string]string) func()) []PanicStrTest {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Can't read test vectors: %s", err)
+		return nil
+	}
+	var vectors []testVector
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		vectors, err = csvVectors(data)
+	case ".json":
+		vectors, err = jsonVectors(data)
+	default:
+		err = fmt.Errorf("unknown file type %q (expected .csv or .json)", ext)
+	}
+	if err != nil {
+		t.Fatalf("Can't parse test vectors in %s: %s", path, err)
+		return nil
+	}
+
+	tests := make([]PanicStrTest, 0, len(vectors))
+	for _, v := range vectors {
+		loc := Loc{File: path, Line: v.line}
+		test, err := v.panicStrTest(loc)
+		if err != nil {
+			t.Fatalf("Invalid test vector at %s: %s", loc, err)
+			return nil
+		}
+		test.F = bind(v.row)
+		tests = append(tests, test)
+	}
+	return tests
+}
+
+// A testVector is a single vector from a file read by LoadPanicStrTable, with the line it starts on.
+type testVector struct {
+	row  map[string]string
+	line int
+}
+
+// panicStrTest returns the test for a vector, without its function.
+func (v testVector) panicStrTest(loc Loc) (PanicStrTest, error) {
+	test := PanicStrTest{Loc: loc}
+	var ok bool
+	if test.Name, ok = v.row[VectorNameColumn]; !ok {
+		return test, fmt.Errorf("missing %q column", VectorNameColumn)
+	}
+	if test.WantStr, ok = v.row[VectorWantStrColumn]; !ok {
+		return test, fmt.Errorf("missing %q column", VectorWantStrColumn)
+	}
+	test.SkipReason = v.row[VectorSkipReasonColumn]
+	for _, b := range []struct {
+		column string
+		field  *bool
+	}{
+		{VectorSkipColumn, &test.Skip},
+		{VectorExpectedFailureColumn, &test.ExpectedFailure},
+	} {
+		s := v.row[b.column]
+		if s == "" {
+			continue
+		}
+		val, err := strconv.ParseBool(s)
+		if err != nil {
+			return test, fmt.Errorf("invalid %q value %q (expected a boolean)", b.column, s)
+		}
+		*b.field = val
+	}
+	return test, nil
+}
+
+// csvVectors parses the vectors in a CSV file.
+func csvVectors(data []byte) ([]testVector, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1 // reported below, with the row's position
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("no header row")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var vectors []testVector
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return vectors, nil
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		if len(record) != len(header) {
+			return nil, fmt.Errorf("line %d: expected %d field(s), got %d", line, len(header), len(record))
+		}
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+		vectors = append(vectors, testVector{row, line})
+	}
+}
+
+// jsonVectors parses the vectors in a JSON file.
+func jsonVectors(data []byte) ([]testVector, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('[') {
+		return nil, fmt.Errorf("expected an array of objects")
+	}
+
+	var vectors []testVector
+	for dec.More() {
+		// The offset is the end of the previous token, so the object starts at the next non-space, non-comma byte
+		start := int(dec.InputOffset())
+		for start < len(data) && strings.IndexByte(" \t\r\n,", data[start]) >= 0 {
+			start++
+		}
+		line := 1 + bytes.Count(data[:start], []byte("\n"))
+
+		var obj map[string]interface{}
+		if err := dec.Decode(&obj); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		row := make(map[string]string, len(obj))
+		for key, val := range obj {
+			switch val := val.(type) {
+			case nil:
+				row[key] = ""
+			case string:
+				row[key] = val
+			case json.Number:
+				row[key] = val.String()
+			case bool:
+				row[key] = strconv.FormatBool(val)
+			default:
+				return nil, fmt.Errorf("line %d: value of %q is not a string, number, boolean, or null", line, key)
+			}
+		}
+		vectors = append(vectors, testVector{row, line})
+	}
+	return vectors, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testhelp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// writeVectors writes a test vector file in a temporary directory, and returns its path.
+func writeVectors(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Can't write test vectors: %s", err)
+	}
+	return path
+}
+
+func TestLoadPanicStrTable(t *testing.T) {
+	csvData := "name,want_str,input,skip,skip_reason,expected_failure\n" +
+		"empty,empty input,,,,\n" +
+		"\"multi\nline\",too long,aaaa,true,slow,\n" +
+		"known bug,bad,b,,,1\n"
+	jsonData := `[
+  {"name": "empty", "want_str": "empty input", "input": null},
+  {"name": "multi\nline", "want_str": "too long", "input": "aaaa", "skip": true, "skip_reason": "slow"},
+
+  {"name": "known bug", "want_str": "bad", "input": "b", "expected_failure": 1}
+]`
+
+	tests := []struct {
+		name      string
+		file      string
+		contents  string
+		wantLines []int
+	}{
+		{"csv", "vectors.csv", csvData, []int{2, 3, 5}},
+		{"json", "vectors.JSON", jsonData, []int{2, 3, 5}},
+	}
+	for _, test := range tests {
+		path := writeVectors(t, test.file, test.contents)
+		var inputs []string
+		rt := &testhelptest.RecordingT{}
+		table := LoadPanicStrTable(rt, path, func(row map[string]string) func() {
+			return func() { inputs = append(inputs, row["input"]) }
+		})
+		if failures := rt.Failures(); len(failures) != 0 {
+			t.Errorf("LoadPanicStrTable(): Unexpected failures: %#+v in test '%s'", failures, test.name)
+			continue
+		}
+
+		want := []PanicStrTest{
+			{Name: "empty", WantStr: "empty input", Loc: Loc{File: path, Line: test.wantLines[0]}},
+			{Name: "multi\nline", WantStr: "too long", Skip: true, SkipReason: "slow",
+				Loc: Loc{File: path, Line: test.wantLines[1]}},
+			{Name: "known bug", WantStr: "bad", ExpectedFailure: true, Loc: Loc{File: path, Line: test.wantLines[2]}},
+		}
+		for i := range table {
+			if table[i].F == nil {
+				t.Errorf("LoadPanicStrTable(): Missing test function for '%s' in test '%s'", table[i].Name, test.name)
+				continue
+			}
+			table[i].F()
+			table[i].F = nil
+		}
+		if !reflect.DeepEqual(table, want) {
+			t.Errorf("LoadPanicStrTable(): Incorrect table: expected\n%#+v\ngot\n%#+v\nin test '%s'", want, table,
+				test.name)
+		}
+		if wantInputs := []string{"", "aaaa", "b"}; !reflect.DeepEqual(inputs, wantInputs) {
+			t.Errorf("LoadPanicStrTable(): Incorrect bound inputs: expected\n%#+v\ngot\n%#+v\nin test '%s'",
+				wantInputs, inputs, test.name)
+		}
+	}
+}
+
+func TestLoadPanicStrTableErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		wantMsg  string // with {path} for the path, and {base} for its base name
+	}{
+		{"unknown type", "v.txt", "",
+			`Can't parse test vectors in {path}: unknown file type ".txt" (expected .csv or .json)`},
+		{"empty csv", "v.csv", "", "Can't parse test vectors in {path}: no header row"},
+		{"short csv row", "v.csv", "name,want_str\na\n",
+			"Can't parse test vectors in {path}: line 2: expected 2 field(s), got 1"},
+		{"missing name", "v.csv", "want_str\na\n", `Invalid test vector at {base}:2: missing "name" column`},
+		{"missing want", "v.json", `[{"name": "a"}]`, `Invalid test vector at {base}:1: missing "want_str" column`},
+		{"bad bool", "v.csv", "name,want_str,skip\na,b,maybe\n",
+			`Invalid test vector at {base}:2: invalid "skip" value "maybe" (expected a boolean)`},
+		{"not an array", "v.json", `{"name": "a"}`, "Can't parse test vectors in {path}: expected an array of objects"},
+		{"nested value", "v.json", "[\n{\"name\": \"a\", \"want_str\": [1]}]",
+			`Can't parse test vectors in {path}: line 2: value of "want_str" is not a string, number, boolean, or null`},
+	}
+	for _, test := range tests {
+		path := writeVectors(t, test.file, test.contents)
+		rt := &testhelptest.RecordingT{}
+		table := LoadPanicStrTable(rt, path, func(map[string]string) func() { return func() {} })
+		msg := strings.NewReplacer("{path}", path, "{base}", filepath.Base(path)).Replace(test.wantMsg)
+		want := []string{msg}
+		if table != nil || !reflect.DeepEqual(rt.Fatals, want) {
+			t.Errorf("LoadPanicStrTable(): Incorrect failure: expected\n%#+v\ngot\n%#+v\nin test '%s'", want,
+				rt.Fatals, test.name)
+		}
+	}
+
+	rt := &testhelptest.RecordingT{}
+	LoadPanicStrTable(rt, filepath.Join(t.TempDir(), "missing.csv"), nil)
+	if len(rt.Fatals) != 1 {
+		t.Errorf("LoadPanicStrTable(): Expected a failure for a missing file, got %#+v", rt.Fatals)
+	}
+}
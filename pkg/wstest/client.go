@@ -0,0 +1,189 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wstest provides a websocket client for testing websocket handlers served by an httptest.Server, with JSON
+// helpers and automatic verification that the connection closes cleanly.  It's a separate module so that the main
+// testhelp package doesn't depend on a websocket library.
+package wstest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ocsw/go-testhelp/pkg/testhelp"
+)
+
+// closeTimeout is how long Close waits for the server's side of the close handshake.
+const closeTimeout = 5 * time.Second
+
+// A Client is a websocket connection to a test server, made by DialTestWS.  Its methods report problems with t.Errorf
+// and return false, so a test can check a whole conversation without handling errors at each step.  A Client is not
+// safe for concurrent use; in particular, Close reads from the connection (for the close handshake), so it mustn't be
+// called while another goroutine is reading from it.
+type Client struct {
+	t    testhelp.TestingTB
+	Conn *websocket.Conn
+
+	closeOnce  sync.Once
+	readFailed bool // the connection can't be read from after an error, including a timeout
+}
+
+// DialTestWS opens a websocket connection to path (e.g. "/ws") on server, and returns a Client for it.  It registers a
+// cleanup function with t which calls Close, so the close handshake is always checked.  If the connection can't be
+// made, DialTestWS calls t.Fatalf, including the HTTP status, if the server responded.
+//
+// The underlying *websocket.Conn is available as Client.Conn, for anything the helpers don't cover.
+func DialTestWS(t testhelp.TestingTB, server *httptest.Server, path string) *Client {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + path
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		if resp != nil {
+			t.Fatalf("Could not open a websocket connection to %s: %s (HTTP status %s)", url, err, resp.Status)
+		} else {
+			t.Fatalf("Could not open a websocket connection to %s: %s", url, err)
+		}
+		return nil // for mocks whose Fatalf doesn't stop the test
+	}
+	c := &Client{t: t, Conn: conn}
+	t.Cleanup(func() {
+		t.Helper()
+		c.Close()
+	})
+	return c
+}
+
+// SendJSON sends v, encoded as JSON, as a text message.  It returns true if it succeeds.
+func (c *Client) SendJSON(v interface{}) bool {
+	c.t.Helper()
+	if err := c.Conn.WriteJSON(v); err != nil {
+		c.t.Errorf("Could not send websocket message: %s", err)
+		return false
+	}
+	return true
+}
+
+// ReadJSON waits up to timeout for the next message, and decodes it as JSON into v.  It returns true if it succeeds.
+func (c *Client) ReadJSON(v interface{}, timeout time.Duration) bool {
+	c.t.Helper()
+	data, ok := c.read(timeout)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		c.t.Errorf("Could not decode websocket message as JSON: %s\nmessage:\n%s", err, data)
+		return false
+	}
+	return true
+}
+
+// ExpectJSON waits up to timeout for the next message, and checks that it is JSON equivalent to want; that is, that
+// want, encoded as JSON, and the message decode to the same value (so the order of object keys, and spacing, don't
+// matter).  It returns true if the check passed.
+func (c *Client) ExpectJSON(want interface{}, timeout time.Duration) bool {
+	c.t.Helper()
+	wantData, err := json.Marshal(want)
+	if err != nil {
+		panic(fmt.Sprintf("ExpectJSON: want can't be encoded as JSON: %s", err))
+	}
+	data, ok := c.read(timeout)
+	if !ok {
+		return false
+	}
+	var wantVal, gotVal interface{}
+	_ = json.Unmarshal(wantData, &wantVal) // can't fail, since json.Marshal produced it
+	if err := json.Unmarshal(data, &gotVal); err != nil {
+		c.t.Errorf("Could not decode websocket message as JSON: %s\nmessage:\n%s", err, data)
+		return false
+	}
+	if !reflect.DeepEqual(wantVal, gotVal) {
+		c.t.Errorf("Incorrect websocket message: expected\n%s\ngot\n%s", wantData, data)
+		return false
+	}
+	return true
+}
+
+// read returns the next message, waiting up to timeout.
+func (c *Client) read(timeout time.Duration) ([]byte, bool) {
+	c.t.Helper()
+	_ = c.Conn.SetReadDeadline(time.Now().Add(timeout))
+	_, data, err := c.Conn.ReadMessage()
+	if err != nil {
+		var netErr interface{ Timeout() bool }
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			c.t.Errorf("No websocket message within %s", timeout)
+		} else {
+			c.t.Errorf("Could not read websocket message: %s", err)
+		}
+		c.readFailed = true
+		return nil, false
+	}
+	return data, true
+}
+
+// Close closes the connection with a normal closure, and checks that the server completes the close handshake.  Any
+// messages that arrive before the server's close frame were never read by the test, so they are reported as
+// unexpected.  It returns true if the connection closed cleanly.  If a read has already failed (for example, by timing
+// out), the connection is unusable, so Close just closes it, without reporting another failure, and returns false.
+// Close is called automatically at the end of the test; calls after the first do nothing and return true.
+func (c *Client) Close() bool {
+	c.t.Helper()
+	ok := true
+	c.closeOnce.Do(func() {
+		ok = c.close()
+	})
+	return ok
+}
+
+func (c *Client) close() bool {
+	c.t.Helper()
+	defer c.Conn.Close()
+	if c.readFailed {
+		return false // already reported by read
+	}
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err := c.Conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeTimeout)); err != nil {
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return true // closed through Conn by the test
+		}
+		c.t.Errorf("Could not close websocket connection: %s", err)
+		return false
+	}
+
+	ok := true
+	_ = c.Conn.SetReadDeadline(time.Now().Add(closeTimeout))
+	for {
+		_, data, err := c.Conn.ReadMessage()
+		if err == nil {
+			c.t.Errorf("Unexpected websocket message before close:\n%s", data)
+			ok = false
+			continue
+		}
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			return ok
+		}
+		c.t.Errorf("Websocket connection did not close cleanly: %s", err)
+		return false
+	}
+}
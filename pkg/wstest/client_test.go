@@ -0,0 +1,156 @@
+/*
+Copyright 2026 Danielle Zephyr Malament
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/ocsw/go-testhelp/internal/testhelptest"
+)
+
+// newWSServer serves a websocket handler at /ws that calls handle with each message it reads, until the client closes
+// the connection (or handle returns false, in which case the server drops the connection without a close handshake).
+func newWSServer(t *testing.T, handle func(conn *websocket.Conn, msg []byte) bool) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil || !handle(conn, msg) {
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient(t *testing.T) {
+	echo := func(conn *websocket.Conn, msg []byte) bool {
+		return conn.WriteMessage(websocket.TextMessage, msg) == nil
+	}
+	tests := []struct {
+		name     string
+		handle   func(conn *websocket.Conn, msg []byte) bool
+		converse func(c *Client)
+		expectRE []string
+	}{
+		{
+			name:   "echo",
+			handle: echo,
+			converse: func(c *Client) {
+				c.SendJSON(map[string]interface{}{"op": "ping", "n": 1})
+				c.ExpectJSON(struct {
+					N  int    `json:"n"`
+					Op string `json:"op"`
+				}{1, "ping"}, time.Second)
+				c.SendJSON([]int{1, 2})
+				var got []int
+				if c.ReadJSON(&got, time.Second) && len(got) != 2 {
+					c.t.Errorf("wrong ReadJSON result %#+v", got)
+				}
+			},
+		},
+		{
+			name:     "wrong message",
+			handle:   echo,
+			converse: func(c *Client) { c.SendJSON("a"); c.ExpectJSON("b", time.Second) },
+			expectRE: []string{`^Incorrect websocket message: expected\n"b"\ngot\n"a"\n$`},
+		},
+		{
+			name:   "not JSON",
+			handle: func(conn *websocket.Conn, msg []byte) bool { return echo(conn, []byte("{oops")) },
+			converse: func(c *Client) {
+				c.SendJSON(1)
+				c.ExpectJSON(1, time.Second)
+				c.SendJSON(1)
+				var v interface{}
+				c.ReadJSON(&v, time.Second)
+			},
+			expectRE: []string{
+				`^Could not decode websocket message as JSON: .*\nmessage:\n\{oops$`,
+				`^Could not decode websocket message as JSON: .*\nmessage:\n\{oops$`,
+			},
+		},
+		{
+			name: "unread message",
+			handle: func(conn *websocket.Conn, msg []byte) bool {
+				return echo(conn, msg) && echo(conn, []byte(`"extra"`))
+			},
+			converse: func(c *Client) { c.SendJSON("a"); c.ExpectJSON("a", time.Second) },
+			expectRE: []string{`^Unexpected websocket message before close:\n"extra"$`},
+		},
+		{
+			name:     "timeout",
+			handle:   func(*websocket.Conn, []byte) bool { return true },
+			converse: func(c *Client) { c.SendJSON("a"); c.ExpectJSON("a", 10*time.Millisecond) },
+			expectRE: []string{`^No websocket message within 10ms$`},
+		},
+		{
+			name:     "dropped",
+			handle:   func(*websocket.Conn, []byte) bool { return false },
+			converse: func(c *Client) { c.SendJSON("a"); time.Sleep(10 * time.Millisecond) },
+			expectRE: []string{`^(Could not close websocket connection|Websocket connection did not close cleanly): `},
+		},
+	}
+	for _, test := range tests {
+		server := newWSServer(t, test.handle)
+		rt := &testhelptest.RecordingT{}
+		c := DialTestWS(rt, server, "/ws")
+		if c == nil {
+			t.Fatalf("DialTestWS(): Unexpected failure:\n%#+v\nin test '%s'", rt.Failures(), test.name)
+		}
+		test.converse(c)
+		rt.RunCleanups()
+		if !c.Close() {
+			t.Errorf("Client.Close(): Expected true for a second call in test '%s'", test.name)
+		}
+
+		ok := len(rt.Failures()) == len(test.expectRE)
+		for i := 0; ok && i < len(rt.Failures()); i++ {
+			ok = regexp.MustCompile(test.expectRE[i]).MatchString(rt.Failures()[i])
+		}
+		if !ok {
+			t.Errorf("Client: Incorrect failures: expected matches for\n%#+v\ngot\n%#+v\nin test '%s'", test.expectRE,
+				rt.Failures(), test.name)
+		}
+	}
+}
+
+func TestDialTestWSFailure(t *testing.T) {
+	server := newWSServer(t, nil)
+	rt := &testhelptest.RecordingT{}
+	if c := DialTestWS(rt, server, "/nope"); c != nil {
+		t.Errorf("DialTestWS(): Expected nil for a failed connection")
+	}
+	want := `^Could not open a websocket connection to ws://127\.0\.0\.1:\d+/nope: websocket: bad handshake ` +
+		`\(HTTP status 404 Not Found\)$`
+	if len(rt.Failures()) != 1 || !regexp.MustCompile(want).MatchString(rt.Failures()[0]) {
+		t.Errorf("DialTestWS(): Incorrect failures: expected a match for\n%s\ngot\n%#+v", want, rt.Failures())
+	}
+}
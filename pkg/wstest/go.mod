@@ -0,0 +1,10 @@
+module github.com/ocsw/go-testhelp/pkg/wstest
+
+go 1.19
+
+replace github.com/ocsw/go-testhelp => ../..
+
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/ocsw/go-testhelp v0.0.0-00010101000000-000000000000
+)